@@ -0,0 +1,140 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	domain "taskmanager/Domain"
+	usecases "taskmanager/Usecases"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// --- AUDIT CONTROLLER ---
+
+type AuditController struct {
+	auditUsecase usecases.AuditUsecase
+}
+
+// NewAuditController creates a new instance of the controller
+func NewAuditController(au usecases.AuditUsecase) *AuditController {
+	return &AuditController{
+		auditUsecase: au,
+	}
+}
+
+func (ac *AuditController) GetAuditLog(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	filter, err := parseAuditFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := ac.auditUsecase.QueryAuditLog(ctx, filter)
+	if err != nil {
+		writeAuditError(c, err)
+		return
+	}
+
+	response := gin.H{"entries": page.Entries, "total": page.Total, "limit": page.Limit, "offset": page.Offset}
+	if page.Offset+page.Limit < page.Total {
+		response["next"] = pageLink(c, page.Offset+page.Limit, page.Limit)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// ExportAuditLog streams the matching entries as JSON Lines, one entry per line, oldest first
+func (ac *AuditController) ExportAuditLog(c *gin.Context) {
+
+	// an export can be much larger than a page, give it more time
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 2*time.Minute)
+	defer cancel()
+
+	filter, err := parseAuditFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// the headers are only sent with the first entry, until then an error can still be reported
+	started := false
+	start := func() {
+		c.Header("Content-Type", "application/x-ndjson")
+		c.Header("Content-Disposition", `attachment; filename="audit.jsonl"`)
+		c.Status(http.StatusOK)
+		started = true
+	}
+
+	encoder := json.NewEncoder(c.Writer)
+	err = ac.auditUsecase.ExportAuditLog(ctx, filter, func(entry domain.AuditEntry) error {
+		if !started {
+			start()
+		}
+		return encoder.Encode(entry)
+	})
+	if err != nil {
+		if started {
+			// the status is already sent, all we can do is cut the stream short
+			_ = c.Error(err)
+			return
+		}
+		writeAuditError(c, err)
+		return
+	}
+
+	if !started {
+		start()
+	}
+}
+
+// parseAuditFilter reads the audit filtering and pagination query parameters
+func parseAuditFilter(c *gin.Context) (domain.AuditFilter, error) {
+
+	filter := domain.AuditFilter{
+		ActorID:    c.Query("actor_id"),
+		Action:     domain.AuditAction(c.Query("action")),
+		TargetType: c.Query("target_type"),
+		TargetID:   c.Query("target_id"),
+	}
+
+	if from := c.Query("from"); from != "" {
+		parsed, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			return domain.AuditFilter{}, fmt.Errorf("from must be an RFC3339 timestamp")
+		}
+		filter.From = parsed
+	}
+	if to := c.Query("to"); to != "" {
+		parsed, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			return domain.AuditFilter{}, fmt.Errorf("to must be an RFC3339 timestamp")
+		}
+		filter.To = parsed
+	}
+
+	limit, offset, err := parsePagination(c)
+	if err != nil {
+		return domain.AuditFilter{}, err
+	}
+	filter.Limit = limit
+	filter.Offset = offset
+
+	return filter, nil
+}
+
+// writeAuditError maps the audit usecase errors to responses
+func writeAuditError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrValidation):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
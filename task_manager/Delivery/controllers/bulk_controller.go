@@ -0,0 +1,134 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	domain "taskmanager/Domain"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// --- BULK TASK OPERATIONS ---
+
+// the criteria a bulk update can select tasks with, the same ones GET /tasks accepts
+type bulkTaskFilter struct {
+	Status      domain.TaskStatus `json:"status"`
+	TitlePrefix string            `json:"title_prefix"`
+	AssigneeID  string            `json:"assignee_id"`
+	CreatedBy   string            `json:"created_by"`
+	DueFrom     time.Time         `json:"due_from"`
+	DueTo       time.Time         `json:"due_to"`
+	LabelsAny   []string          `json:"labels_any"`
+	LabelsAll   []string          `json:"labels_all"`
+}
+
+func (t *TaskController) BulkCreateTasks(c *gin.Context) {
+
+	// a batch does many writes, give it more time than a single request
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	var body struct {
+		Tasks  []domain.Task `json:"tasks" binding:"required"`
+		Atomic bool          `json:"atomic"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := t.taskUsecase.BulkCreateTasks(ctx, actorFromContext(c), body.Tasks, body.Atomic)
+	if err != nil {
+		writeBulkError(c, err)
+		return
+	}
+
+	writeBulkResult(c, result, http.StatusCreated)
+}
+
+func (t *TaskController) BulkUpdateTasks(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	var body struct {
+		IDs     []string        `json:"ids"`
+		Filter  *bulkTaskFilter `json:"filter"`
+		Changes domain.Task     `json:"changes"`
+		Atomic  bool            `json:"atomic"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	update := domain.BulkTaskUpdate{
+		IDs:     body.IDs,
+		Changes: body.Changes,
+		Atomic:  body.Atomic,
+	}
+	if body.Filter != nil {
+		update.Filter = &domain.TaskFilter{
+			Status:      body.Filter.Status,
+			TitlePrefix: body.Filter.TitlePrefix,
+			AssigneeID:  body.Filter.AssigneeID,
+			CreatedBy:   body.Filter.CreatedBy,
+			DueFrom:     body.Filter.DueFrom,
+			DueTo:       body.Filter.DueTo,
+			LabelsAny:   body.Filter.LabelsAny,
+			LabelsAll:   body.Filter.LabelsAll,
+		}
+	}
+
+	result, err := t.taskUsecase.BulkUpdateTasks(ctx, actorFromContext(c), update)
+	if err != nil {
+		writeBulkError(c, err)
+		return
+	}
+
+	writeBulkResult(c, result, http.StatusOK)
+}
+
+func (t *TaskController) BulkDeleteTasks(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	var body struct {
+		IDs    []string `json:"ids" binding:"required"`
+		Atomic bool     `json:"atomic"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := t.taskUsecase.BulkDeleteTasks(ctx, actorFromContext(c), body.IDs, body.Atomic)
+	if err != nil {
+		writeBulkError(c, err)
+		return
+	}
+
+	writeBulkResult(c, result, http.StatusOK)
+}
+
+// writeBulkResult answers with the given status when every item succeeded, and with
+// 207 Multi-Status when some didn't so clients know to look at the per item results
+func writeBulkResult(c *gin.Context, result domain.BulkResult, status int) {
+	if result.Failed > 0 || result.Skipped > 0 {
+		status = http.StatusMultiStatus
+	}
+	c.JSON(status, result)
+}
+
+// writeBulkError maps the errors that reject a whole batch to responses
+func writeBulkError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrValidation):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
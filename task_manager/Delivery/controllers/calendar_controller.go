@@ -0,0 +1,260 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	domain "taskmanager/Domain"
+	usecases "taskmanager/Usecases"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+)
+
+// --- CALENDAR CONTROLLER ---
+
+// the path of a calendar feed, the secret of the feed takes the place of %s
+const calendarFeedPath = "/api/v1/calendar/%s/tasks.ics"
+
+// the domain part of the UIDs in a feed, a task keeps its UID across fetches and feeds
+const icalUIDDomain = "taskmanager"
+
+type CalendarController struct {
+	calendarUsecase usecases.CalendarUsecase
+	publicBaseURL   string // where clients reach the API, empty to use the host of the request
+}
+
+// NewCalendarController creates a new instance of the controller
+func NewCalendarController(cu usecases.CalendarUsecase, publicBaseURL string) *CalendarController {
+	return &CalendarController{
+		calendarUsecase: cu,
+		publicBaseURL:   strings.TrimSuffix(publicBaseURL, "/"),
+	}
+}
+
+func (cc *CalendarController) GetCalendarFeed(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	feed, err := cc.calendarUsecase.GetCalendarFeed(ctx, actorFromContext(c).UserID)
+	if err != nil {
+		writeCalendarError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"feed": feed})
+}
+
+// CreateCalendarFeed gives the user a new feed URL and revokes the previous one.
+// The URL holds the secret of the feed, it is only ever returned in this response.
+func (cc *CalendarController) CreateCalendarFeed(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	feed, token, err := cc.calendarUsecase.CreateCalendarFeed(ctx, actorFromContext(c).UserID)
+	if err != nil {
+		writeCalendarError(c, err)
+		return
+	}
+
+	// without a public base URL the feed points back at the connection it was created through,
+	// forwarded headers are left alone as any client can send them
+	baseURL := cc.publicBaseURL
+	if baseURL == "" {
+		scheme := "http"
+		if c.Request.TLS != nil {
+			scheme = "https"
+		}
+		baseURL = scheme + "://" + c.Request.Host
+	}
+	url := baseURL + fmt.Sprintf(calendarFeedPath, token)
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Calendar feed created successfully", "feed": feed, "url": url})
+}
+
+func (cc *CalendarController) RevokeCalendarFeed(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := cc.calendarUsecase.RevokeCalendarFeed(ctx, actorFromContext(c).UserID); err != nil {
+		writeCalendarError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Calendar feed revoked successfully"})
+}
+
+// GetCalendar renders the tasks of a feed as an iCalendar file, the secret in the path authenticates the request.
+// ?type=todo renders VTODOs instead of VEVENTs.
+func (cc *CalendarController) GetCalendar(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	component := domain.CalendarComponent(c.DefaultQuery("type", string(domain.CalendarEvent)))
+	if !component.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "type must be event or todo"})
+		return
+	}
+
+	tasks, err := cc.calendarUsecase.GetCalendarTasks(ctx, c.Param("token"))
+	if err != nil {
+		writeCalendarError(c, err)
+		return
+	}
+
+	// the URL is a secret, keep the response out of shared caches
+	c.Header("Cache-Control", "private, no-store")
+	c.Header("Content-Disposition", `inline; filename="tasks.ics"`)
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", renderCalendar(tasks, component, time.Now()))
+}
+
+func writeCalendarError(c *gin.Context, err error) {
+	switch {
+	// an unknown secret looks like a missing feed, it doesn't tell whether the URL ever existed
+	case errors.Is(err, domain.ErrNotFound), errors.Is(err, domain.ErrInvalidToken):
+		c.JSON(http.StatusNotFound, gin.H{"error": "calendar feed not found"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// renderCalendar writes the tasks as an RFC 5545 calendar.
+// The calendar is published, so every DTSTAMP is the time it was rendered at.
+func renderCalendar(tasks []domain.Task, component domain.CalendarComponent, now time.Time) []byte {
+
+	var w icalWriter
+	stamp := icalTime(now)
+
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", "-//taskmanager//Task due dates//EN")
+	w.line("CALSCALE", "GREGORIAN")
+	w.line("METHOD", "PUBLISH")
+	w.line("X-WR-CALNAME", "Task due dates")
+	w.line("REFRESH-INTERVAL;VALUE=DURATION", "PT1H")
+	w.line("X-PUBLISHED-TTL", "PT1H")
+
+	for _, task := range tasks {
+		status, _ := domain.NormalizeTaskStatus(string(task.Status))
+
+		name := "VEVENT"
+		if component == domain.CalendarTodo {
+			name = "VTODO"
+		}
+
+		w.line("BEGIN", name)
+		w.line("UID", task.ID+"@"+icalUIDDomain)
+		w.line("DTSTAMP", stamp)
+		if component == domain.CalendarTodo {
+			w.line("DUE", icalTime(task.DueDate))
+		} else {
+			// a deadline is a point in time, it shouldn't block time in the calendar
+			w.line("DTSTART", icalTime(task.DueDate))
+			w.line("TRANSP", "TRANSPARENT")
+		}
+		w.line("SUMMARY", icalText(task.Title))
+		if task.Description != "" {
+			w.line("DESCRIPTION", icalText(task.Description))
+		}
+		w.line("STATUS", icalStatus(component, status))
+		if component == domain.CalendarTodo && status == domain.StatusDone {
+			w.line("PERCENT-COMPLETE", "100")
+		}
+		if len(task.Labels) > 0 {
+			categories := make([]string, len(task.Labels))
+			for i, label := range task.Labels {
+				categories[i] = icalText(label)
+			}
+			w.line("CATEGORIES", strings.Join(categories, ","))
+		}
+		if task.ParentID != "" {
+			w.line("RELATED-TO;RELTYPE=PARENT", task.ParentID+"@"+icalUIDDomain)
+		}
+		// the version goes up on every write, so clients know which copy is newer
+		w.line("SEQUENCE", strconv.FormatInt(max(task.Version-1, 0), 10))
+		w.line("END", name)
+	}
+
+	w.line("END", "VCALENDAR")
+
+	return w.buf.Bytes()
+}
+
+// icalStatus maps a task status to the STATUS values RFC 5545 allows for the component
+func icalStatus(component domain.CalendarComponent, status domain.TaskStatus) string {
+
+	if status == domain.StatusCancelled {
+		return "CANCELLED"
+	}
+	if component == domain.CalendarEvent {
+		return "CONFIRMED"
+	}
+
+	switch status {
+	case domain.StatusInProgress:
+		return "IN-PROCESS"
+	case domain.StatusDone:
+		return "COMPLETED"
+	default:
+		return "NEEDS-ACTION"
+	}
+}
+
+// icalTime formats a time as a UTC DATE-TIME
+func icalTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// icalText escapes a TEXT value, control characters other than tabs aren't allowed in it
+func icalText(value string) string {
+
+	var b strings.Builder
+	for _, r := range strings.ReplaceAll(value, "\r\n", "\n") {
+		switch {
+		case r == '\\' || r == ';' || r == ',':
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\t' || r >= 0x20 && r != 0x7f:
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// icalWriter writes content lines ended by CRLF and folded at 75 octets, without splitting a UTF-8 character
+type icalWriter struct {
+	buf bytes.Buffer
+}
+
+func (w *icalWriter) line(name string, value string) {
+
+	line := name + ":" + value
+
+	// a continuation line starts with a space, which counts towards its 75 octets
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		w.buf.WriteString(line[:cut])
+		w.buf.WriteString("\r\n ")
+		line = line[cut:]
+		limit = 74
+	}
+
+	w.buf.WriteString(line)
+	w.buf.WriteString("\r\n")
+}
@@ -0,0 +1,123 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	domain "taskmanager/Domain"
+	usecases "taskmanager/Usecases"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// --- COMMENT CONTROLLER ---
+
+type CommentController struct {
+	commentUsecase usecases.CommentUsecase
+}
+
+// NewCommentController creates a new instance of the controller
+func NewCommentController(cu usecases.CommentUsecase) *CommentController {
+	return &CommentController{
+		commentUsecase: cu,
+	}
+}
+
+// commentRequest is the body accepted when creating or editing a comment
+type commentRequest struct {
+	Body string `json:"body" binding:"required"`
+}
+
+func (cc *CommentController) CreateComment(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	var request commentRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// the author is always the authenticated user
+	comment, err := cc.commentUsecase.AddComment(ctx, actorFromContext(c), c.Param("id"), request.Body)
+	if err != nil {
+		writeCommentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Comment created successfully", "comment": comment})
+}
+
+func (cc *CommentController) GetComments(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	limit, offset, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := cc.commentUsecase.ListComments(ctx, c.Param("id"), limit, offset)
+	if err != nil {
+		writeCommentError(c, err)
+		return
+	}
+
+	response := gin.H{"comments": page.Comments, "total": page.Total, "limit": page.Limit, "offset": page.Offset}
+	if page.Offset+page.Limit < page.Total {
+		response["next"] = pageLink(c, page.Offset+page.Limit, page.Limit)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (cc *CommentController) UpdateComment(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	var request commentRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	comment, err := cc.commentUsecase.EditComment(ctx, actorFromContext(c), c.Param("id"), c.Param("commentId"), request.Body)
+	if err != nil {
+		writeCommentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Comment updated successfully", "comment": comment})
+}
+
+func (cc *CommentController) DeleteComment(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	err := cc.commentUsecase.DeleteComment(ctx, actorFromContext(c), c.Param("id"), c.Param("commentId"))
+	if err != nil {
+		writeCommentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Comment deleted successfully"})
+}
+
+// writeCommentError maps the comment usecase errors to responses
+func writeCommentError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "task or comment not found"})
+	case errors.Is(err, domain.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrValidation):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
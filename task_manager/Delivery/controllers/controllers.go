@@ -3,8 +3,14 @@ package controllers
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
 	domain "taskmanager/Domain"
+	infrastructure "taskmanager/Infrastructure"
 	usecases "taskmanager/Usecases"
 	"time"
 
@@ -29,12 +35,174 @@ func (t *TaskController) GetTasks(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
 
-	allTasks, err := t.taskUsecase.RetrieveAllTasks(ctx)
+	filter, err := parseTaskFilter(c)
 	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := t.taskUsecase.RetrieveAllTasks(ctx, filter)
+	if err != nil {
+		if errors.Is(err, domain.ErrValidation) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := gin.H{"tasks": page.Tasks, "total": page.Total, "limit": page.Limit, "offset": page.Offset}
+	if page.Offset+page.Limit < page.Total {
+		response["next"] = pageLink(c, page.Offset+page.Limit, page.Limit)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (t *TaskController) GetMyTasks(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	filter, err := parseTaskFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// only the tasks assigned to the authenticated user
+	filter.AssigneeID = actorFromContext(c).UserID
+
+	page, err := t.taskUsecase.RetrieveAllTasks(ctx, filter)
+	if err != nil {
+		if errors.Is(err, domain.ErrValidation) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := gin.H{"tasks": page.Tasks, "total": page.Total, "limit": page.Limit, "offset": page.Offset}
+	if page.Offset+page.Limit < page.Total {
+		response["next"] = pageLink(c, page.Offset+page.Limit, page.Limit)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (t *TaskController) SearchTasks(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	limit, offset, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := t.taskUsecase.SearchTasks(ctx, c.Query("q"), limit, offset)
+	if err != nil {
+		if errors.Is(err, domain.ErrValidation) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"tasks": allTasks})
+
+	response := gin.H{"hits": result.Hits, "total": result.Total, "limit": result.Limit, "offset": result.Offset}
+	if result.Offset+result.Limit < result.Total {
+		response["next"] = pageLink(c, result.Offset+result.Limit, result.Limit)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// parseTaskFilter reads the filtering, sorting and pagination query parameters
+func parseTaskFilter(c *gin.Context) (domain.TaskFilter, error) {
+
+	filter := domain.TaskFilter{
+		Status:      domain.TaskStatus(c.Query("status")),
+		TitlePrefix: c.Query("title_prefix"),
+		AssigneeID:  c.Query("assignee_id"),
+		CreatedBy:   c.Query("created_by"),
+		LabelsAny:   splitQueryList(c.Query("labels_any")),
+		LabelsAll:   splitQueryList(c.Query("labels_all")),
+	}
+
+	if dueFrom := c.Query("due_from"); dueFrom != "" {
+		parsed, err := time.Parse(time.RFC3339, dueFrom)
+		if err != nil {
+			return domain.TaskFilter{}, fmt.Errorf("due_from must be an RFC3339 timestamp")
+		}
+		filter.DueFrom = parsed
+	}
+	if dueTo := c.Query("due_to"); dueTo != "" {
+		parsed, err := time.Parse(time.RFC3339, dueTo)
+		if err != nil {
+			return domain.TaskFilter{}, fmt.Errorf("due_to must be an RFC3339 timestamp")
+		}
+		filter.DueTo = parsed
+	}
+
+	// a leading "-" sorts in descending order, e.g. sort=-due_date
+	if sort := c.Query("sort"); sort != "" {
+		filter.SortDesc = strings.HasPrefix(sort, "-")
+		filter.SortBy = strings.TrimPrefix(sort, "-")
+	}
+
+	limit, offset, err := parsePagination(c)
+	if err != nil {
+		return domain.TaskFilter{}, err
+	}
+	filter.Limit = limit
+	filter.Offset = offset
+
+	return filter, nil
+}
+
+// splitQueryList splits a comma separated query parameter, empty items are dropped
+func splitQueryList(value string) []string {
+
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// parsePagination reads the optional limit and offset query parameters
+func parsePagination(c *gin.Context) (int64, int64, error) {
+
+	var limit, offset int64
+
+	if raw := c.Query("limit"); raw != "" {
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("limit must be an integer")
+		}
+		limit = parsed
+	}
+	if raw := c.Query("offset"); raw != "" {
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("offset must be an integer")
+		}
+		offset = parsed
+	}
+
+	return limit, offset, nil
+}
+
+// pageLink builds the URL of another page, keeping the rest of the query intact
+func pageLink(c *gin.Context, offset int64, limit int64) string {
+	query := c.Request.URL.Query()
+	query.Set("offset", strconv.FormatInt(offset, 10))
+	query.Set("limit", strconv.FormatInt(limit, 10))
+
+	return c.Request.URL.Path + "?" + query.Encode()
 }
 
 func (t *TaskController) GetTaskById(c *gin.Context) {
@@ -43,18 +211,46 @@ func (t *TaskController) GetTaskById(c *gin.Context) {
 	defer cancel()
 
 	id := c.Param("id")
-	task, err := t.taskUsecase.RetrieveTaskByID(ctx, id)
-	if err != nil {
-		if errors.Is(err, domain.ErrNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+
+	// ?subtree=true nests the task's subtasks in the response
+	subtree := false
+	if raw := c.Query("subtree"); raw != "" {
+		var err error
+		if subtree, err = strconv.ParseBool(raw); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "subtree must be true or false"})
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+	}
+
+	if subtree {
+		node, err := t.taskUsecase.RetrieveTaskTree(ctx, id)
+		if err != nil {
+			writeGetTaskError(c, err)
+			return
+		}
+		c.Header("ETag", taskETag(node.Task))
+		c.JSON(http.StatusOK, gin.H{"task": node})
+		return
+	}
+
+	task, err := t.taskUsecase.RetrieveTaskByID(ctx, id)
+	if err != nil {
+		writeGetTaskError(c, err)
 		return
 	}
+	c.Header("ETag", taskETag(task))
 	c.JSON(http.StatusOK, gin.H{"task": task})
 }
 
+// writeGetTaskError maps the errors of reading a single task to responses
+func writeGetTaskError(c *gin.Context, err error) {
+	if errors.Is(err, domain.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+}
+
 func (t *TaskController) CreatTask(c *gin.Context) {
 
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
@@ -66,6 +262,9 @@ func (t *TaskController) CreatTask(c *gin.Context) {
 		return
 	}
 
+	// the creator is always the authenticated user
+	newTask.CreatedBy = actorFromContext(c).UserID
+
 	createdTask, err := t.taskUsecase.CreateTask(ctx, newTask)
 	if err != nil {
 		if errors.Is(err, domain.ErrValidation) {
@@ -75,6 +274,7 @@ func (t *TaskController) CreatTask(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	c.Header("ETag", taskETag(createdTask))
 	c.JSON(http.StatusCreated, gin.H{"message": "Task created successfully", "Task": createdTask})
 }
 
@@ -89,15 +289,38 @@ func (t *TaskController) UpdateTask(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	task, err := t.taskUsecase.ModifyTask(ctx, id, updatedTask)
+
+	// If-Match takes precedence over a version sent in the body
+	if c.GetHeader("If-Match") != "" {
+		version, err := parseIfMatch(c.GetHeader("If-Match"))
+		if err != nil {
+			writeIfMatchError(c, err)
+			return
+		}
+		updatedTask.Version = version
+	}
+	task, err := t.taskUsecase.ModifyTask(ctx, actorFromContext(c), id, updatedTask)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
 			return
 		}
+		if errors.Is(err, domain.ErrForbidden) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, domain.ErrValidation) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, domain.ErrConflict) {
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	c.Header("ETag", taskETag(task))
 	c.JSON(http.StatusOK, gin.H{"message": "Task updated successfully", "Updated task": task})
 
 }
@@ -108,18 +331,240 @@ func (t *TaskController) DeleteTask(c *gin.Context) {
 	defer cancel()
 
 	id := c.Param("id")
-	err := t.taskUsecase.RemoveTask(ctx, id)
+	version, err := parseIfMatch(c.GetHeader("If-Match"))
+	if err != nil {
+		writeIfMatchError(c, err)
+		return
+	}
+
+	err = t.taskUsecase.RemoveTask(ctx, actorFromContext(c), id, version)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
 			return
 		}
+		if errors.Is(err, domain.ErrConflict) {
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "Task deleted successfully"})
 }
 
+func (t *TaskController) AssignTask(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	id := c.Param("id")
+	var body struct {
+		AssigneeID string `json:"assignee_id" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	task, err := t.taskUsecase.AssignTask(ctx, actorFromContext(c), id, body.AssigneeID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+			return
+		}
+		if errors.Is(err, domain.ErrValidation) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Task assigned successfully", "task": task})
+}
+
+func (t *TaskController) UnassignTask(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	id := c.Param("id")
+	task, err := t.taskUsecase.UnassignTask(ctx, actorFromContext(c), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Task unassigned successfully", "task": task})
+}
+
+func (t *TaskController) AddTaskLabels(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	var body struct {
+		Labels []string `json:"labels" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	task, err := t.taskUsecase.AddTaskLabels(ctx, actorFromContext(c), c.Param("id"), body.Labels)
+	if err != nil {
+		writeTaskChangeError(c, err)
+		return
+	}
+
+	c.Header("ETag", taskETag(task))
+	c.JSON(http.StatusOK, gin.H{"message": "Labels added successfully", "task": task})
+}
+
+func (t *TaskController) RemoveTaskLabel(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	task, err := t.taskUsecase.RemoveTaskLabel(ctx, actorFromContext(c), c.Param("id"), c.Param("label"))
+	if err != nil {
+		writeTaskChangeError(c, err)
+		return
+	}
+
+	c.Header("ETag", taskETag(task))
+	c.JSON(http.StatusOK, gin.H{"message": "Label removed successfully", "task": task})
+}
+
+// writeTaskChangeError maps the errors of changing the labels or links of a task to responses
+func writeTaskChangeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+	case errors.Is(err, domain.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrValidation):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrConflict):
+		// the task changed between reading and writing it
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// GetTrash lists the deleted tasks that haven't been purged yet, most recently deleted first
+func (t *TaskController) GetTrash(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	limit, offset, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := t.taskUsecase.ListDeletedTasks(ctx, limit, offset)
+	if err != nil {
+		if errors.Is(err, domain.ErrValidation) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := gin.H{"tasks": page.Tasks, "total": page.Total, "limit": page.Limit, "offset": page.Offset}
+	if page.Offset+page.Limit < page.Total {
+		response["next"] = pageLink(c, page.Offset+page.Limit, page.Limit)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (t *TaskController) RestoreTask(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	task, err := t.taskUsecase.RestoreTask(ctx, actorFromContext(c), c.Param("id"))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found in trash"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("ETag", taskETag(task))
+	c.JSON(http.StatusOK, gin.H{"message": "Task restored successfully", "task": task})
+}
+
+func (t *TaskController) PurgeTask(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	err := t.taskUsecase.PurgeTask(ctx, actorFromContext(c), c.Param("id"))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found in trash"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Task purged permanently"})
+}
+
+// writeIfMatchError answers a malformed If-Match with 400 and a weak one with 412
+func writeIfMatchError(c *gin.Context, err error) {
+	if errors.Is(err, errWeakIfMatch) {
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
+
+// taskETag formats the task version as a strong entity tag
+func taskETag(task domain.Task) string {
+	return strconv.Quote(strconv.FormatInt(task.Version, 10))
+}
+
+// errWeakIfMatch is returned for a weak ETag, If-Match uses the strong comparison (RFC 9110)
+var errWeakIfMatch = errors.New("If-Match requires a strong ETag, weak tags never match")
+
+// parseIfMatch returns the version an If-Match header expects.
+// An empty header or "*" means any version and returns 0.
+func parseIfMatch(header string) (int64, error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+	if strings.HasPrefix(header, "W/") {
+		return 0, errWeakIfMatch
+	}
+
+	tag := strings.Trim(header, `"`)
+	version, err := strconv.ParseInt(tag, 10, 64)
+	if err != nil || version < 0 {
+		return 0, fmt.Errorf("If-Match must hold a single task ETag")
+	}
+
+	return version, nil
+}
+
+// actorFromContext returns the authenticated user that AuthMiddleware stored in the context
+func actorFromContext(c *gin.Context) domain.Actor {
+	principal, _ := infrastructure.PrincipalFromContext(c)
+	return principal.Actor()
+}
+
 // --- USER CONTROLLER ---
 
 type UserController struct {
@@ -176,9 +621,24 @@ func (u *UserController) AuthenticateUser(c *gin.Context) {
 		return
 	}
 
-	// call the appropriate service function
-	token, err := u.userUsecase.AuthenticateUser(ctx, userCredential.UserName, userCredential.Password)
+	// call the appropriate service function, failed logins are counted per username and client address
+	tokens, err := u.userUsecase.AuthenticateUser(ctx, userCredential.UserName, userCredential.Password, userCredential.NewPassword, c.ClientIP())
 	if err != nil {
+		var throttled *domain.LoginThrottledError
+		if errors.As(err, &throttled) {
+			writeLoginThrottled(c, throttled)
+			return
+		}
+		// the new password of a user who had to change theirs was rejected
+		var weak *domain.PasswordPolicyError
+		if errors.As(err, &weak) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": weak.Error()})
+			return
+		}
+		if errors.Is(err, domain.ErrPasswordChangeRequired) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error(), "password_change_required": true})
+			return
+		}
 		if errors.Is(err, domain.ErrNotFound) || errors.Is(err, domain.ErrValidation) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid username or password"})
 			return
@@ -188,7 +648,69 @@ func (u *UserController) AuthenticateUser(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"token": token, "message": "Login successfully"})
+	c.JSON(http.StatusOK, gin.H{
+		"token":         tokens.AccessToken,
+		"refresh_token": tokens.RefreshToken,
+		"expires_in":    tokens.ExpiresIn,
+		"message":       "Login successfully",
+	})
+}
+
+// refreshTokenRequest is the body of the refresh and logout endpoints
+type refreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+func (u *UserController) RefreshToken(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	var body refreshTokenRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tokens, err := u.userUsecase.RefreshTokens(ctx, body.RefreshToken)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidToken) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Token refresh failed due to a server issue"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"token":         tokens.AccessToken,
+		"refresh_token": tokens.RefreshToken,
+		"expires_in":    tokens.ExpiresIn,
+		"message":       "Token refreshed successfully",
+	})
+}
+
+func (u *UserController) Logout(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	var body refreshTokenRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// the access token used for this request is revoked as well
+	principal, _ := infrastructure.PrincipalFromContext(c)
+
+	err := u.userUsecase.Logout(ctx, principal.UserID, body.RefreshToken, principal.TokenID, principal.ExpiresAt)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Logout failed due to a server issue"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out successfully"})
 }
 
 func (u *UserController) PromoteUser(c *gin.Context) {
@@ -197,7 +719,7 @@ func (u *UserController) PromoteUser(c *gin.Context) {
 	defer cancel()
 
 	id := c.Param("id")
-	user, err := u.userUsecase.PromoteUser(ctx, id)
+	user, err := u.userUsecase.PromoteUser(ctx, actorFromContext(c), id)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
@@ -210,3 +732,136 @@ func (u *UserController) PromoteUser(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "user status updated successfully", "updatedUser": user})
 
 }
+
+// changePasswordRequest is the body of the change password endpoint
+type changePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required"`
+}
+
+// ChangePassword sets a new password for the caller, the response carries the tokens of a new session
+func (u *UserController) ChangePassword(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	var body changePasswordRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tokens, err := u.userUsecase.ChangePassword(ctx, actorFromContext(c), body.CurrentPassword, body.NewPassword)
+	if err != nil {
+		var throttled *domain.LoginThrottledError
+		switch {
+		case errors.As(err, &throttled):
+			writeLoginThrottled(c, throttled)
+		case errors.Is(err, domain.ErrInvalidCredential):
+			c.JSON(http.StatusForbidden, gin.H{"error": "current password is incorrect"})
+		case errors.Is(err, domain.ErrValidation):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Password change failed due to a server issue"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"token":         tokens.AccessToken,
+		"refresh_token": tokens.RefreshToken,
+		"expires_in":    tokens.ExpiresIn,
+		"message":       "Password changed successfully",
+	})
+}
+
+// resetPasswordRequest is the optional body of the reset password endpoint
+type resetPasswordRequest struct {
+	TemporaryPassword string `json:"temporary_password"`
+}
+
+// ResetPassword makes a user choose a new password at their next login
+func (u *UserController) ResetPassword(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	// the body is optional, without one the user keeps logging in with their current password
+	var body resetPasswordRequest
+	if err := c.ShouldBindJSON(&body); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := u.userUsecase.ResetPassword(ctx, actorFromContext(c), c.Param("id"), body.TemporaryPassword)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrValidation):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "password reset successfully", "user": user})
+}
+
+// writeLoginThrottled answers an attempt made before the caller may try to log in again
+func writeLoginThrottled(c *gin.Context, throttled *domain.LoginThrottledError) {
+	// whole seconds, rounded up so the client doesn't come back too early
+	c.Header("Retry-After", strconv.FormatInt(int64(math.Ceil(max(throttled.RetryAfter.Seconds(), 1))), 10))
+	c.JSON(http.StatusTooManyRequests, gin.H{"error": throttled.Error(), "locked": throttled.Locked})
+}
+
+func (u *UserController) GetLoginLockouts(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	lockouts, err := u.userUsecase.ListLoginLockouts(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"lockouts": lockouts})
+}
+
+// UnlockLogin lifts the lockout of ?user_name= or ?ip=, exactly one of them is required
+func (u *UserController) UnlockLogin(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userName, byUser := c.GetQuery("user_name")
+	ip, byIP := c.GetQuery("ip")
+	if byUser == byIP {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "exactly one of user_name or ip is required"})
+		return
+	}
+
+	kind, subject := domain.LoginAttemptUser, userName
+	if byIP {
+		kind, subject = domain.LoginAttemptIP, ip
+	}
+
+	err := u.userUsecase.UnlockLogin(ctx, actorFromContext(c), kind, subject)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrValidation):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "no failed logins recorded"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "login unlocked successfully"})
+}
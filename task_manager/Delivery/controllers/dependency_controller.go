@@ -0,0 +1,86 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (t *TaskController) SetTaskParent(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	var body struct {
+		ParentID string `json:"parent_id" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	task, err := t.taskUsecase.SetTaskParent(ctx, actorFromContext(c), c.Param("id"), body.ParentID)
+	if err != nil {
+		writeTaskChangeError(c, err)
+		return
+	}
+
+	c.Header("ETag", taskETag(task))
+	c.JSON(http.StatusOK, gin.H{"message": "Parent task set successfully", "task": task})
+}
+
+func (t *TaskController) RemoveTaskParent(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	// an empty parent makes the task a top-level task again
+	task, err := t.taskUsecase.SetTaskParent(ctx, actorFromContext(c), c.Param("id"), "")
+	if err != nil {
+		writeTaskChangeError(c, err)
+		return
+	}
+
+	c.Header("ETag", taskETag(task))
+	c.JSON(http.StatusOK, gin.H{"message": "Parent task removed successfully", "task": task})
+}
+
+func (t *TaskController) AddTaskBlocker(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	var body struct {
+		TaskID string `json:"task_id" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	task, err := t.taskUsecase.AddTaskBlocker(ctx, actorFromContext(c), c.Param("id"), body.TaskID)
+	if err != nil {
+		writeTaskChangeError(c, err)
+		return
+	}
+
+	c.Header("ETag", taskETag(task))
+	c.JSON(http.StatusOK, gin.H{"message": "Blocker added successfully", "task": task})
+}
+
+func (t *TaskController) RemoveTaskBlocker(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	task, err := t.taskUsecase.RemoveTaskBlocker(ctx, actorFromContext(c), c.Param("id"), c.Param("blockerId"))
+	if err != nil {
+		writeTaskChangeError(c, err)
+		return
+	}
+
+	c.Header("ETag", taskETag(task))
+	c.JSON(http.StatusOK, gin.H{"message": "Blocker removed successfully", "task": task})
+}
@@ -0,0 +1,109 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	domain "taskmanager/Domain"
+	usecases "taskmanager/Usecases"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// --- LABEL CONTROLLER ---
+
+type LabelController struct {
+	labelUsecase usecases.LabelUsecase
+}
+
+// NewLabelController creates a new instance of the controller
+func NewLabelController(lu usecases.LabelUsecase) *LabelController {
+	return &LabelController{
+		labelUsecase: lu,
+	}
+}
+
+func (lc *LabelController) GetLabels(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	labels, err := lc.labelUsecase.ListLabels(ctx)
+	if err != nil {
+		writeLabelError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"labels": labels})
+}
+
+func (lc *LabelController) CreateLabel(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	var label domain.Label
+	if err := c.ShouldBindJSON(&label); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	label, err := lc.labelUsecase.CreateLabel(ctx, actorFromContext(c), label)
+	if err != nil {
+		writeLabelError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Label created successfully", "label": label})
+}
+
+// UpdateLabel changes a label, a new name is applied to every task carrying the label
+func (lc *LabelController) UpdateLabel(c *gin.Context) {
+
+	// a rename touches every task using the label
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	var changes domain.Label
+	if err := c.ShouldBindJSON(&changes); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	label, err := lc.labelUsecase.UpdateLabel(ctx, actorFromContext(c), c.Param("name"), changes)
+	if err != nil {
+		writeLabelError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Label updated successfully", "label": label})
+}
+
+func (lc *LabelController) DeleteLabel(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	untagged, err := lc.labelUsecase.DeleteLabel(ctx, actorFromContext(c), c.Param("name"))
+	if err != nil {
+		writeLabelError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Label deleted successfully", "tasks_updated": untagged})
+}
+
+// writeLabelError maps the label usecase errors to responses
+func writeLabelError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "label not found"})
+	case errors.Is(err, domain.ErrAleadyExists):
+		c.JSON(http.StatusConflict, gin.H{"error": "a label with this name already exists"})
+	case errors.Is(err, domain.ErrValidation):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,45 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	domain "taskmanager/Domain"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PreviewTaskOccurrences lists the due dates of the next occurrences of a recurring task, ?count= sets how many
+func (t *TaskController) PreviewTaskOccurrences(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	var count int
+	if raw := c.Query("count"); raw != "" {
+		var err error
+		if count, err = strconv.Atoi(raw); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "count must be an integer"})
+			return
+		}
+	}
+
+	id := c.Param("id")
+	occurrences, err := t.taskUsecase.PreviewTaskOccurrences(ctx, id, count)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+			return
+		}
+		if errors.Is(err, domain.ErrValidation) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"task_id": id, "occurrences": occurrences})
+}
@@ -0,0 +1,98 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	domain "taskmanager/Domain"
+	usecases "taskmanager/Usecases"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+)
+
+// how often an idle stream sends a comment, so proxies don't close the connection
+const taskEventHeartbeat = 25 * time.Second
+
+// --- TASK EVENT CONTROLLER ---
+
+type TaskEventController struct {
+	taskEventUsecase usecases.TaskEventUsecase
+}
+
+// NewTaskEventController creates a new instance of the controller
+func NewTaskEventController(teu usecases.TaskEventUsecase) *TaskEventController {
+	return &TaskEventController{
+		taskEventUsecase: teu,
+	}
+}
+
+// StreamTaskEvents sends the task changes as server-sent events until the client disconnects.
+// A client reconnecting with the Last-Event-ID header, or the last_event_id query parameter,
+// gets the events it missed first, or a "resync" event when they are no longer buffered.
+func (tc *TaskEventController) StreamTaskEvents(c *gin.Context) {
+
+	filter := domain.TaskEventFilter{
+		Status:     domain.TaskStatus(c.Query("status")),
+		AssigneeID: c.Query("assignee_id"),
+	}
+
+	lastEventId := c.GetHeader("Last-Event-ID")
+	if lastEventId == "" {
+		lastEventId = c.Query("last_event_id")
+	}
+
+	subscription, err := tc.taskEventUsecase.Subscribe(lastEventId, filter)
+	if err != nil {
+		if errors.Is(err, domain.ErrValidation) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer subscription.Close()
+
+	c.Header("Content-Type", "text/event-stream;charset=utf-8")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	if subscription.Resync {
+		c.Render(-1, sse.Event{Event: "resync", Data: gin.H{"message": "some events were missed, reload the tasks"}})
+	}
+	for _, event := range subscription.Missed {
+		renderTaskEvent(c, event)
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(taskEventHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+
+		case event, ok := <-subscription.Events:
+			if !ok {
+				// the server is shutting down or the client fell behind, it will reconnect
+				return
+			}
+			renderTaskEvent(c, event)
+			c.Writer.Flush()
+
+		case <-heartbeat.C:
+			if _, err := c.Writer.WriteString(": heartbeat\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		}
+	}
+}
+
+// renderTaskEvent writes one event, its id is what the client resumes from
+func renderTaskEvent(c *gin.Context, event domain.TaskEvent) {
+	c.Render(-1, sse.Event{Id: event.ID, Event: string(event.Event), Data: event})
+}
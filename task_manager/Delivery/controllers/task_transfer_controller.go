@@ -0,0 +1,448 @@
+package controllers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	domain "taskmanager/Domain"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// --- TASK IMPORT AND EXPORT ---
+
+// the columns of a CSV export, an import accepts them in any order.
+// Lists are separated by ";", created_by, occurrence and version are ignored on import.
+var taskCSVColumns = []string{
+	"id", "title", "description", "status", "due_date", "assignee_id", "labels",
+	"parent_id", "blocked_by", "recurrence", "occurrence", "created_by", "version",
+}
+
+// the content type and file extension of every task format
+var taskFormatTypes = map[domain.TaskFormat][2]string{
+	domain.TaskFormatCSV:    {"text/csv", "csv"},
+	domain.TaskFormatJSON:   {"application/json", "json"},
+	domain.TaskFormatNDJSON: {"application/x-ndjson", "ndjson"},
+}
+
+// ExportTasks streams every task matching the filters of GET /tasks, limit and offset are ignored
+func (t *TaskController) ExportTasks(c *gin.Context) {
+
+	// an export can be much larger than a page, give it more time
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 2*time.Minute)
+	defer cancel()
+
+	format := domain.TaskFormat(c.DefaultQuery("format", string(domain.TaskFormatJSON)))
+	if !format.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be one of csv, json and ndjson"})
+		return
+	}
+
+	filter, err := parseTaskFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// the headers are only sent with the first task, until then an error can still be reported
+	encoder := newTaskEncoder(c.Writer, format)
+	started := false
+	start := func() error {
+		c.Header("Content-Type", taskFormatTypes[format][0])
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="tasks.%s"`, taskFormatTypes[format][1]))
+		c.Status(http.StatusOK)
+		started = true
+		return encoder.begin()
+	}
+
+	err = t.taskUsecase.ExportTasks(ctx, filter, func(task domain.Task) error {
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		return encoder.encode(task)
+	})
+	if err == nil && !started {
+		err = start()
+	}
+	if err == nil {
+		err = encoder.end()
+	}
+	if err != nil {
+		if started {
+			// the status is already sent, all we can do is cut the stream short
+			_ = c.Error(err)
+			return
+		}
+		if errors.Is(err, domain.ErrValidation) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// ImportTasks reads tasks in any export format, given by ?format= or the Content-Type.
+// ?upsert=true updates the tasks whose id exists, ?dry_run=true only checks the rows.
+func (t *TaskController) ImportTasks(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 2*time.Minute)
+	defer cancel()
+
+	format := domain.TaskFormat(c.Query("format"))
+	if format == "" {
+		format = taskFormatOf(c.ContentType())
+		if format == "" {
+			c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "send text/csv, application/json or application/x-ndjson, or set the format parameter"})
+			return
+		}
+	}
+	if !format.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be one of csv, json and ndjson"})
+		return
+	}
+
+	var options domain.TaskImportOptions
+	for name, value := range map[string]*bool{"upsert": &options.Upsert, "dry_run": &options.DryRun} {
+		if raw := c.Query(name); raw != "" {
+			parsed, err := strconv.ParseBool(raw)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": name + " must be true or false"})
+				return
+			}
+			*value = parsed
+		}
+	}
+
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, domain.MaxImportBytes)
+	rows, err := decodeTaskImport(body, format)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("an import can be at most %d bytes", domain.MaxImportBytes)})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := t.taskUsecase.ImportTasks(ctx, actorFromContext(c), rows, options)
+	if err != nil {
+		writeBulkError(c, err)
+		return
+	}
+
+	// like a bulk operation, some rows failing is reported with 207 Multi-Status
+	status := http.StatusOK
+	if result.Failed > 0 {
+		status = http.StatusMultiStatus
+	}
+	c.JSON(status, result)
+}
+
+// taskFormatOf returns the task format sent with a content type, empty when it isn't one
+func taskFormatOf(contentType string) domain.TaskFormat {
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "text/csv":
+		return domain.TaskFormatCSV
+	case "application/json":
+		return domain.TaskFormatJSON
+	case "application/x-ndjson", "application/ndjson":
+		return domain.TaskFormatNDJSON
+	}
+
+	return ""
+}
+
+// taskEncoder writes the tasks of an export in one of the task formats
+type taskEncoder struct {
+	format domain.TaskFormat
+	w      io.Writer
+	csv    *csv.Writer
+	count  int
+}
+
+func newTaskEncoder(w io.Writer, format domain.TaskFormat) *taskEncoder {
+	return &taskEncoder{format: format, w: w, csv: csv.NewWriter(w)}
+}
+
+func (e *taskEncoder) begin() error {
+	switch e.format {
+	case domain.TaskFormatCSV:
+		return e.csv.Write(taskCSVColumns)
+	case domain.TaskFormatJSON:
+		_, err := io.WriteString(e.w, "[")
+		return err
+	}
+	return nil
+}
+
+func (e *taskEncoder) encode(task domain.Task) error {
+
+	e.count++
+
+	if e.format == domain.TaskFormatCSV {
+		return e.csv.Write(taskCSVRecord(task))
+	}
+
+	encoded, err := json.Marshal(task)
+	if err != nil {
+		return err
+	}
+	if e.format == domain.TaskFormatJSON && e.count > 1 {
+		encoded = append([]byte(","), encoded...)
+	}
+	if e.format == domain.TaskFormatNDJSON {
+		encoded = append(encoded, '\n')
+	}
+	_, err = e.w.Write(encoded)
+	return err
+}
+
+func (e *taskEncoder) end() error {
+	switch e.format {
+	case domain.TaskFormatCSV:
+		e.csv.Flush()
+		return e.csv.Error()
+	case domain.TaskFormatJSON:
+		_, err := io.WriteString(e.w, "]\n")
+		return err
+	}
+	return nil
+}
+
+// taskCSVRecord lays a task out in the order of taskCSVColumns
+func taskCSVRecord(task domain.Task) []string {
+
+	dueDate := ""
+	if !task.DueDate.IsZero() {
+		dueDate = task.DueDate.UTC().Format(time.RFC3339Nano)
+	}
+
+	return []string{
+		task.ID,
+		escapeCSVCell(task.Title),
+		escapeCSVCell(task.Description),
+		string(task.Status),
+		dueDate,
+		task.AssigneeID,
+		escapeCSVCell(strings.Join(task.Labels, ";")),
+		task.ParentID,
+		strings.Join(task.BlockedBy, ";"),
+		task.Recurrence,
+		strconv.Itoa(task.Occurrence),
+		task.CreatedBy,
+		strconv.FormatInt(task.Version, 10),
+	}
+}
+
+// csvFormulaPrefixes start a cell a spreadsheet would evaluate as a formula, a leading ' is escaped as well
+// so that the cell reads back as it was written
+const csvFormulaPrefixes = "=+-@'"
+
+// escapeCSVCell quotes a cell with ' when a spreadsheet opening the export would evaluate it
+func escapeCSVCell(value string) string {
+	if value != "" && strings.ContainsRune(csvFormulaPrefixes, rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// unescapeCSVCell drops the ' escapeCSVCell added, any other cell is kept as is
+func unescapeCSVCell(value string) string {
+	if len(value) > 1 && value[0] == '\'' && strings.ContainsRune(csvFormulaPrefixes, rune(value[1])) {
+		return value[1:]
+	}
+	return value
+}
+
+// decodeTaskImport reads the rows of an import. A row that can't be read is returned with its error,
+// an error is only returned when the file as a whole can't be read.
+func decodeTaskImport(r io.Reader, format domain.TaskFormat) ([]domain.TaskImportRow, error) {
+
+	var rows []domain.TaskImportRow
+	add := func(row domain.TaskImportRow) error {
+		if len(rows) == domain.MaxImportTasks {
+			return fmt.Errorf("%w: at most %d tasks can be imported at once", domain.ErrValidation, domain.MaxImportTasks)
+		}
+		row.Row = len(rows) + 1
+		rows = append(rows, row)
+		return nil
+	}
+
+	switch format {
+	case domain.TaskFormatCSV:
+		return rows, decodeTaskCSV(r, add)
+
+	case domain.TaskFormatJSON:
+		decoder := json.NewDecoder(r)
+		if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+			return nil, readImportError(err, "a JSON import must be an array of tasks")
+		}
+		for decoder.More() {
+			var raw json.RawMessage
+			if err := decoder.Decode(&raw); err != nil {
+				return nil, readImportError(err, "invalid JSON")
+			}
+			if err := add(decodeTaskJSON(raw)); err != nil {
+				return nil, err
+			}
+		}
+		if _, err := decoder.Token(); err != nil {
+			return nil, readImportError(err, "invalid JSON")
+		}
+		if _, err := decoder.Token(); err != io.EOF {
+			return nil, readImportError(err, "a JSON import must be a single array of tasks")
+		}
+		return rows, nil
+
+	default:
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 64*1024), domain.MaxImportBytes)
+		for scanner.Scan() {
+			line := bytes.TrimSpace(scanner.Bytes())
+			if len(line) == 0 {
+				continue
+			}
+			if err := add(decodeTaskJSON(line)); err != nil {
+				return nil, err
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, readImportError(err, "invalid NDJSON")
+		}
+		return rows, nil
+	}
+}
+
+// decodeTaskCSV reads the header of a CSV import, then one task per record
+func decodeTaskCSV(r io.Reader, add func(row domain.TaskImportRow) error) error {
+
+	reader := csv.NewReader(r)
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return readImportError(err, "a CSV import needs a header row")
+	}
+	known := make(map[string]bool, len(taskCSVColumns))
+	for _, column := range taskCSVColumns {
+		known[column] = true
+	}
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))
+		if !known[column] {
+			return fmt.Errorf("unknown CSV column %q", column)
+		}
+		if _, repeated := columns[column]; repeated {
+			return fmt.Errorf("CSV column %q appears more than once", column)
+		}
+		columns[column] = i
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		var row domain.TaskImportRow
+		if err != nil {
+			// a record with the wrong number of fields only fails its own row
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) || !errors.Is(parseErr.Err, csv.ErrFieldCount) {
+				return readImportError(err, "invalid CSV")
+			}
+			row.Err = fmt.Errorf("the record has %d fields, the header %d", len(record), len(header))
+		} else {
+			row.Task, row.Err = taskFromCSV(record, columns)
+		}
+		if err := add(row); err != nil {
+			return err
+		}
+	}
+}
+
+// taskFromCSV reads a task from a record, columns maps the column names to their position
+func taskFromCSV(record []string, columns map[string]int) (domain.Task, error) {
+
+	field := func(name string) string {
+		if i, ok := columns[name]; ok {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	text := func(name string) string {
+		return unescapeCSVCell(field(name))
+	}
+	list := func(name string) []string {
+		var items []string
+		for _, item := range strings.Split(text(name), ";") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		return items
+	}
+
+	task := domain.Task{
+		ID:          field("id"),
+		Title:       text("title"),
+		Description: text("description"),
+		Status:      domain.TaskStatus(field("status")),
+		AssigneeID:  field("assignee_id"),
+		Labels:      list("labels"),
+		ParentID:    field("parent_id"),
+		BlockedBy:   list("blocked_by"),
+		Recurrence:  field("recurrence"),
+	}
+
+	if dueDate := field("due_date"); dueDate != "" {
+		parsed, err := time.Parse(time.RFC3339, dueDate)
+		if err != nil {
+			return domain.Task{}, fmt.Errorf("due_date must be an RFC3339 timestamp")
+		}
+		task.DueDate = parsed
+	}
+
+	return task, nil
+}
+
+// decodeTaskJSON reads one task of a JSON or NDJSON import
+func decodeTaskJSON(raw []byte) domain.TaskImportRow {
+
+	var row domain.TaskImportRow
+	if err := json.Unmarshal(raw, &row.Task); err != nil {
+		row.Task = domain.Task{}
+		row.Err = fmt.Errorf("invalid task: %v", err)
+	}
+
+	return row
+}
+
+// readImportError describes why an import couldn't be read, a body over the size limit is passed on as is
+func readImportError(err error, message string) error {
+
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return err
+	}
+	if err == nil || err == io.EOF {
+		return errors.New(message)
+	}
+
+	return fmt.Errorf("%s: %v", message, err)
+}
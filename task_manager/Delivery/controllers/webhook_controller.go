@@ -0,0 +1,173 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	domain "taskmanager/Domain"
+	usecases "taskmanager/Usecases"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// --- WEBHOOK CONTROLLER ---
+
+type WebhookController struct {
+	webhookUsecase usecases.WebhookUsecase
+}
+
+// NewWebhookController creates a new instance of the controller
+func NewWebhookController(wu usecases.WebhookUsecase) *WebhookController {
+	return &WebhookController{
+		webhookUsecase: wu,
+	}
+}
+
+func (wc *WebhookController) GetWebhooks(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	webhooks, err := wc.webhookUsecase.ListWebhooks(ctx)
+	if err != nil {
+		writeWebhookError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"webhooks": webhooks})
+}
+
+func (wc *WebhookController) GetWebhook(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	webhook, err := wc.webhookUsecase.GetWebhook(ctx, c.Param("id"))
+	if err != nil {
+		writeWebhookError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"webhook": webhook})
+}
+
+// CreateWebhook subscribes a URL to events, the secret is only ever returned in this response
+func (wc *WebhookController) CreateWebhook(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	var body struct {
+		URL    string                `json:"url" binding:"required"`
+		Events []domain.WebhookEvent `json:"events" binding:"required"`
+		Secret string                `json:"secret"`
+		Active *bool                 `json:"active"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	webhook := domain.Webhook{
+		URL:    body.URL,
+		Events: body.Events,
+		Secret: body.Secret,
+		Active: body.Active == nil || *body.Active,
+	}
+
+	webhook, err := wc.webhookUsecase.CreateWebhook(ctx, actorFromContext(c), webhook)
+	if err != nil {
+		writeWebhookError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Webhook created successfully", "webhook": webhook, "secret": webhook.Secret})
+}
+
+func (wc *WebhookController) UpdateWebhook(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	var changes domain.WebhookChanges
+	if err := c.ShouldBindJSON(&changes); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	webhook, err := wc.webhookUsecase.UpdateWebhook(ctx, actorFromContext(c), c.Param("id"), changes)
+	if err != nil {
+		writeWebhookError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Webhook updated successfully", "webhook": webhook})
+}
+
+func (wc *WebhookController) DeleteWebhook(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	if err := wc.webhookUsecase.DeleteWebhook(ctx, actorFromContext(c), c.Param("id")); err != nil {
+		writeWebhookError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Webhook deleted successfully"})
+}
+
+// GetDeliveries lists the delivery log of a webhook, newest first
+func (wc *WebhookController) GetDeliveries(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	limit, offset, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := wc.webhookUsecase.ListDeliveries(ctx, c.Param("id"), limit, offset)
+	if err != nil {
+		writeWebhookError(c, err)
+		return
+	}
+
+	response := gin.H{"deliveries": page.Deliveries, "total": page.Total, "limit": page.Limit, "offset": page.Offset}
+	if page.Offset+page.Limit < page.Total {
+		response["next"] = pageLink(c, page.Offset+page.Limit, page.Limit)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// Redeliver queues the payload of an earlier delivery again, the outcome shows up in the delivery log
+func (wc *WebhookController) Redeliver(c *gin.Context) {
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	delivery, err := wc.webhookUsecase.Redeliver(ctx, c.Param("id"), c.Param("deliveryId"))
+	if err != nil {
+		writeWebhookError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "Redelivery queued", "delivery": delivery})
+}
+
+// writeWebhookError maps the webhook usecase errors to responses
+func writeWebhookError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "webhook or delivery not found"})
+	case errors.Is(err, domain.ErrConflict):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrValidation):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
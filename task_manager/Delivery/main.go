@@ -2,9 +2,19 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log"
+	"math"
+	"net/http"
 	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
 	"taskmanager/Delivery/router"
+	domain "taskmanager/Domain"
+	infrastructure "taskmanager/Infrastructure"
 	repositories "taskmanager/Repositories"
 	usecases "taskmanager/Usecases"
 	"time"
@@ -21,32 +31,463 @@ func main() {
 	}
 
 	// Retrieve all necessary configuration from environment variables
-	mongoURI := os.Getenv("MONGO_URI")
-	dbName := os.Getenv("MONGO_DB_NAME")
-	taskCollectionName := os.Getenv("MONGO_TASK_COLLECTION")
-	userCollectionName := os.Getenv("MONGO_USER_COLLECTION")
+	storageBackend := os.Getenv("STORAGE_BACKEND")
 
-	// Critical Validation: Ensure the URI is set
-	if mongoURI == "" {
-		log.Fatal("FATAL: MONGO_URI environment variable is not set. Cannot connect to database.")
+	if storageBackend == "" {
+		storageBackend = "mongo"
+		log.Println("Using default storage backend: mongo")
 	}
 
-	// 3. Fallback/Validation for DB/Collection
-	if dbName == "" {
-		dbName = "task_db"
-		log.Println("Using default database name: task_db")
+	var (
+		taskRepo     repositories.TaskRepository
+		userRepo     repositories.UserRepository
+		tokenRepo    repositories.TokenRepository
+		commentRepo  repositories.CommentRepository
+		auditRepo    repositories.AuditRepository
+		labelRepo    repositories.LabelRepository
+		reminderRepo repositories.ReminderRepository
+		webhookRepo  repositories.WebhookRepository
+		calendarRepo repositories.CalendarFeedRepository
+	)
+
+	switch storageBackend {
+	case "memory":
+		// keep everything in-process, nothing survives a restart
+		taskRepo = repositories.NewInMemoryTaskRepository()
+		userRepo = repositories.NewInMemoryUserRepository()
+		tokenRepo = repositories.NewInMemoryTokenRepository()
+		commentRepo = repositories.NewInMemoryCommentRepository()
+		auditRepo = repositories.NewInMemoryAuditRepository()
+		labelRepo = repositories.NewInMemoryLabelRepository()
+		reminderRepo = repositories.NewInMemoryReminderRepository()
+		webhookRepo = repositories.NewInMemoryWebhookRepository()
+		calendarRepo = repositories.NewInMemoryCalendarFeedRepository()
+
+		log.Println("Using in-memory storage, data will be lost when the server stops.")
+
+	case "file":
+		storageDir := os.Getenv("STORAGE_DIR")
+		if storageDir == "" {
+			storageDir = "./data"
+			log.Println("Using default storage directory: ./data")
+		}
+
+		store, err := repositories.OpenFileStore(storageDir)
+		if err != nil {
+			log.Fatalf("FATAL: Failed to open file storage: %v", err)
+		}
+
+		// snapshot the data when main() exits so the next start doesn't replay the journal
+		defer func() {
+			if err := store.Close(); err != nil {
+				log.Printf("Error closing file storage: %v", err)
+			}
+		}()
+
+		taskRepo = repositories.NewFileTaskRepository(store, "tasks")
+		userRepo = repositories.NewFileUserRepository(store, "users")
+		tokenRepo = repositories.NewFileTokenRepository(store, "refresh_tokens", "revoked_tokens")
+		commentRepo = repositories.NewFileCommentRepository(store, "comments")
+		auditRepo = repositories.NewFileAuditRepository(store, "audit_log")
+		labelRepo = repositories.NewFileLabelRepository(store, "labels")
+		reminderRepo = repositories.NewFileReminderRepository(store, "reminders")
+		webhookRepo = repositories.NewFileWebhookRepository(store, "webhooks", "webhook_deliveries")
+		calendarRepo = repositories.NewFileCalendarFeedRepository(store, "calendar_feeds")
+
+		log.Printf("Using file storage in %s.", storageDir)
+
+	case "mongo":
+		client := connectMongo()
+
+		// Ensure the client is closed when main() exits or panics
+		defer func() {
+			if err := client.Disconnect(context.Background()); err != nil {
+				log.Fatalf("FATAL: Error disconnecting MongoDB client: %v", err)
+			}
+		}()
+
+		dbName := os.Getenv("MONGO_DB_NAME")
+		taskCollectionName := os.Getenv("MONGO_TASK_COLLECTION")
+		userCollectionName := os.Getenv("MONGO_USER_COLLECTION")
+		refreshTokenCollectionName := os.Getenv("MONGO_REFRESH_TOKEN_COLLECTION")
+		revokedTokenCollectionName := os.Getenv("MONGO_REVOKED_TOKEN_COLLECTION")
+		commentCollectionName := os.Getenv("MONGO_COMMENT_COLLECTION")
+		auditCollectionName := os.Getenv("MONGO_AUDIT_COLLECTION")
+		labelCollectionName := os.Getenv("MONGO_LABEL_COLLECTION")
+		reminderCollectionName := os.Getenv("MONGO_REMINDER_COLLECTION")
+		webhookCollectionName := os.Getenv("MONGO_WEBHOOK_COLLECTION")
+		webhookDeliveryCollectionName := os.Getenv("MONGO_WEBHOOK_DELIVERY_COLLECTION")
+		calendarFeedCollectionName := os.Getenv("MONGO_CALENDAR_FEED_COLLECTION")
+
+		// Fallback/Validation for DB/Collection
+		if dbName == "" {
+			dbName = "task_db"
+			log.Println("Using default database name: task_db")
+		}
+		if taskCollectionName == "" {
+			taskCollectionName = "tasks"
+			log.Println("Using default task collection name: tasks")
+		}
+
+		if userCollectionName == "" {
+			userCollectionName = "users"
+			log.Println("Using default user collection name: users")
+		}
+
+		if refreshTokenCollectionName == "" {
+			refreshTokenCollectionName = "refresh_tokens"
+			log.Println("Using default refresh token collection name: refresh_tokens")
+		}
+
+		if revokedTokenCollectionName == "" {
+			revokedTokenCollectionName = "revoked_tokens"
+			log.Println("Using default revoked token collection name: revoked_tokens")
+		}
+
+		if commentCollectionName == "" {
+			commentCollectionName = "comments"
+			log.Println("Using default comment collection name: comments")
+		}
+
+		if auditCollectionName == "" {
+			auditCollectionName = "audit_log"
+			log.Println("Using default audit collection name: audit_log")
+		}
+
+		if labelCollectionName == "" {
+			labelCollectionName = "labels"
+			log.Println("Using default label collection name: labels")
+		}
+
+		if reminderCollectionName == "" {
+			reminderCollectionName = "reminders"
+			log.Println("Using default reminder collection name: reminders")
+		}
+
+		if webhookCollectionName == "" {
+			webhookCollectionName = "webhooks"
+			log.Println("Using default webhook collection name: webhooks")
+		}
+
+		if webhookDeliveryCollectionName == "" {
+			webhookDeliveryCollectionName = "webhook_deliveries"
+			log.Println("Using default webhook delivery collection name: webhook_deliveries")
+		}
+
+		if calendarFeedCollectionName == "" {
+			calendarFeedCollectionName = "calendar_feeds"
+			log.Println("Using default calendar feed collection name: calendar_feeds")
+		}
+
+		// intialize mongo repositories, each creates the unique indexes it relies on
+		var errs [9]error
+		taskRepo, errs[0] = repositories.NewMongoTaskRepository(client, dbName, taskCollectionName)
+
+		userRepo, errs[1] = repositories.NewMongoUserRepository(client, dbName, userCollectionName)
+
+		tokenRepo, errs[2] = repositories.NewMongoTokenRepository(client, dbName, refreshTokenCollectionName, revokedTokenCollectionName)
+
+		commentRepo, errs[3] = repositories.NewMongoCommentRepository(client, dbName, commentCollectionName)
+
+		auditRepo, errs[4] = repositories.NewMongoAuditRepository(client, dbName, auditCollectionName)
+
+		labelRepo, errs[5] = repositories.NewMongoLabelRepository(client, dbName, labelCollectionName)
+
+		reminderRepo, errs[6] = repositories.NewMongoReminderRepository(client, dbName, reminderCollectionName)
+
+		webhookRepo, errs[7] = repositories.NewMongoWebhookRepository(client, dbName, webhookCollectionName, webhookDeliveryCollectionName)
+
+		calendarRepo, errs[8] = repositories.NewMongoCalendarFeedRepository(client, dbName, calendarFeedCollectionName)
+
+		if err := errors.Join(errs[:]...); err != nil {
+			log.Fatalf("FATAL: Failed to set up MongoDB collections: %v", err)
+		}
+
+	default:
+		log.Fatalf("FATAL: unknown STORAGE_BACKEND %q, expected one of: mongo, file, memory", storageBackend)
+	}
+
+	// the webhooks are told about task and user changes, their deliveries are retried with exponential backoff
+	webhookAttempts := intFromEnv("WEBHOOK_MAX_ATTEMPTS", domain.DefaultWebhookMaxAttempts)
+	if webhookAttempts <= 0 {
+		log.Fatal("FATAL: WEBHOOK_MAX_ATTEMPTS must be positive")
+	}
+	webhookBackoff := durationFromEnv("WEBHOOK_RETRY_BACKOFF", domain.DefaultWebhookRetryBackoff)
+	if webhookBackoff <= 0 {
+		log.Fatal("FATAL: WEBHOOK_RETRY_BACKOFF must be positive")
+	}
+	webhookTimeout := durationFromEnv("WEBHOOK_TIMEOUT", domain.DefaultWebhookTimeout)
+	if webhookTimeout <= 0 {
+		log.Fatal("FATAL: WEBHOOK_TIMEOUT must be positive")
 	}
-	if taskCollectionName == "" {
-		taskCollectionName = "tasks"
-		log.Println("Using default task collection name: tasks")
+
+	webhookSender := infrastructure.NewHTTPWebhookSender(webhookTimeout)
+	webhookDispatcher := usecases.NewWebhookDispatcher(webhookRepo, webhookSender, webhookAttempts, webhookBackoff)
+
+	// the latest task changes are kept in memory for clients resuming their event stream
+	taskEventBuffer := intFromEnv("TASK_EVENT_BUFFER", domain.DefaultTaskEventBuffer)
+	if taskEventBuffer <= 0 {
+		log.Fatal("FATAL: TASK_EVENT_BUFFER must be positive")
 	}
 
-	if userCollectionName == "" {
-		userCollectionName = "users"
-		log.Println("Using default user collection name: users")
+	// failed logins are slowed down and then locked out, per username and per client address
+	loginLimits := domain.DefaultLoginLimits()
+	loginLimits.Window = durationFromEnv("LOGIN_FAILURE_WINDOW", loginLimits.Window)
+	loginLimits.UserDelayAfter = intFromEnv("LOGIN_USER_DELAY_AFTER", loginLimits.UserDelayAfter)
+	loginLimits.UserLockoutAfter = intFromEnv("LOGIN_USER_LOCKOUT_AFTER", loginLimits.UserLockoutAfter)
+	loginLimits.IPDelayAfter = intFromEnv("LOGIN_IP_DELAY_AFTER", loginLimits.IPDelayAfter)
+	loginLimits.IPLockoutAfter = intFromEnv("LOGIN_IP_LOCKOUT_AFTER", loginLimits.IPLockoutAfter)
+	loginLimits.BaseDelay = durationFromEnv("LOGIN_BASE_DELAY", loginLimits.BaseDelay)
+	loginLimits.MaxDelay = durationFromEnv("LOGIN_MAX_DELAY", loginLimits.MaxDelay)
+	loginLimits.LockoutDuration = durationFromEnv("LOGIN_LOCKOUT_DURATION", loginLimits.LockoutDuration)
+	if err := loginLimits.Validate(); err != nil {
+		log.Fatalf("FATAL: invalid LOGIN_* settings: %v", err)
 	}
 
-	/// ---CREAT MONGODB CONNECTION ---
+	// new passwords are checked against the policy
+	passwordPolicy := domain.DefaultPasswordPolicy()
+	passwordPolicy.MinLength = intFromEnv("PASSWORD_MIN_LENGTH", passwordPolicy.MinLength)
+	passwordPolicy.MinClasses = intFromEnv("PASSWORD_MIN_CLASSES", passwordPolicy.MinClasses)
+	passwordPolicy.RejectBreached = os.Getenv("PASSWORD_REJECT_BREACHED") != "false"
+	if err := passwordPolicy.Validate(); err != nil {
+		log.Fatalf("FATAL: invalid PASSWORD_* settings: %v", err)
+	}
+
+	// new passwords are hashed with PASSWORD_HASH_ALGORITHM, older hashes are upgraded when their users log in
+	argon2Params := infrastructure.DefaultArgon2Params
+	argon2Memory := intFromEnv("ARGON2_MEMORY_KIB", int(argon2Params.Memory))
+	argon2Iterations := intFromEnv("ARGON2_ITERATIONS", int(argon2Params.Iterations))
+	argon2Parallelism := intFromEnv("ARGON2_PARALLELISM", int(argon2Params.Parallelism))
+	if argon2Memory <= 0 || int64(argon2Memory) > math.MaxUint32 || argon2Iterations <= 0 || int64(argon2Iterations) > math.MaxUint32 {
+		log.Fatal("FATAL: ARGON2_MEMORY_KIB and ARGON2_ITERATIONS must be positive")
+	}
+	if argon2Parallelism <= 0 || argon2Parallelism > math.MaxUint8 {
+		log.Fatal("FATAL: ARGON2_PARALLELISM must be between 1 and 255")
+	}
+	argon2Params.Memory = uint32(argon2Memory)
+	argon2Params.Iterations = uint32(argon2Iterations)
+	argon2Params.Parallelism = uint8(argon2Parallelism)
+	hashAlgorithm := os.Getenv("PASSWORD_HASH_ALGORITHM")
+	if hashAlgorithm == "" {
+		hashAlgorithm = infrastructure.PasswordHashArgon2id
+	}
+	passwordHasher, err := infrastructure.NewPasswordHasher(hashAlgorithm, intFromEnv("BCRYPT_COST", infrastructure.DefaultBcryptCost), argon2Params)
+	if err != nil {
+		log.Fatalf("FATAL: invalid password hashing settings: %v", err)
+	}
+
+	// access tokens are signed with JWT_PRIVATE_KEY_FILE, or JWT_SECRET without one
+	jwtKeys, err := infrastructure.JWTKeysFromEnv()
+	if err != nil {
+		log.Fatalf("FATAL: invalid JWT signing settings: %v", err)
+	}
+
+	// tokens name who issued them and who they are for, other services check both
+	jwtSettings := infrastructure.DefaultJWTSettings()
+	if issuer := os.Getenv("JWT_ISSUER"); issuer != "" {
+		jwtSettings.Issuer = issuer
+	}
+	if audience := os.Getenv("JWT_AUDIENCE"); audience != "" {
+		jwtSettings.Audience = audience
+	}
+	jwtSettings.Leeway = durationFromEnv("JWT_LEEWAY", jwtSettings.Leeway)
+	if err := jwtSettings.Validate(); err != nil {
+		log.Fatalf("FATAL: invalid JWT settings: %v", err)
+	}
+
+	// the counters are kept by this instance, whatever the storage backend
+	loginAttemptRepo := repositories.NewInMemoryLoginAttemptRepository()
+
+	// intialize usecases
+	webhookUsecase := usecases.NewWebhookUsecase(webhookRepo, auditRepo, webhookDispatcher)
+
+	taskEventUsecase := usecases.NewTaskEventUsecase(taskEventBuffer)
+
+	taskUsecase := usecases.NewTaskUsecase(taskRepo, userRepo, commentRepo, auditRepo, labelRepo, usecases.EventPublishers{webhookUsecase, taskEventUsecase})
+
+	userUsecase := usecases.NewUserUsecase(userRepo, tokenRepo, auditRepo, webhookUsecase, loginAttemptRepo, loginLimits, passwordPolicy, passwordHasher, jwtKeys, jwtSettings)
+
+	commentUsecase := usecases.NewCommentUsecase(commentRepo, taskRepo)
+
+	auditUsecase := usecases.NewAuditUsecase(auditRepo)
+
+	labelUsecase := usecases.NewLabelUsecase(labelRepo, taskRepo, auditRepo)
+
+	calendarUsecase := usecases.NewCalendarUsecase(calendarRepo, taskRepo)
+
+	// optionally rewrite legacy task statuses before serving requests
+	if os.Getenv("MIGRATE_TASK_STATUSES") == "true" {
+		migrationCtx, cancelMigration := context.WithTimeout(context.Background(), time.Minute)
+		migrated, err := taskUsecase.MigrateTaskStatuses(migrationCtx)
+		cancelMigration()
+		if err != nil {
+			log.Fatalf("FATAL: Failed to migrate task statuses: %v", err)
+		}
+		log.Printf("Migrated %d tasks to the new status set.", migrated)
+	}
+
+	// the server and the background jobs stop on SIGINT or SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var background sync.WaitGroup
+
+	// purge the task trash in the background until shutdown
+	trashRetention := durationFromEnv("TASK_TRASH_RETENTION", domain.DefaultTrashRetention)
+	if trashRetention > 0 {
+		purgeInterval := durationFromEnv("TASK_TRASH_PURGE_INTERVAL", time.Hour)
+		if purgeInterval <= 0 {
+			log.Fatal("FATAL: TASK_TRASH_PURGE_INTERVAL must be positive")
+		}
+
+		purger := usecases.NewTrashPurger(taskUsecase, trashRetention, purgeInterval)
+		background.Go(func() { purger.Run(ctx) })
+		log.Printf("Deleted tasks are purged after %s.", trashRetention)
+	} else {
+		log.Println("Automatic trash purge is disabled.")
+	}
+
+	// remind of tasks that are due soon or overdue until shutdown
+	if notifier := reminderNotifier(); notifier != nil {
+		reminderInterval := durationFromEnv("REMINDER_INTERVAL", domain.DefaultReminderInterval)
+		if reminderInterval <= 0 {
+			log.Fatal("FATAL: REMINDER_INTERVAL must be positive")
+		}
+		leadTime := durationFromEnv("REMINDER_LEAD_TIME", domain.DefaultReminderLeadTime)
+
+		reminderUsecase := usecases.NewReminderUsecase(taskRepo, reminderRepo, notifier, leadTime)
+		scheduler := usecases.NewReminderScheduler(reminderUsecase, reminderInterval)
+		background.Go(func() { scheduler.Run(ctx) })
+		log.Printf("Reminders are sent %s before tasks are due.", leadTime)
+	} else {
+		log.Println("Due-date reminders are disabled.")
+	}
+
+	// deliver the webhooks until the server has stopped, so the last requests can still publish their changes,
+	// deliveries still pending then are resumed on the next start
+	webhookCtx, stopWebhooks := context.WithCancel(context.Background())
+	defer stopWebhooks()
+
+	background.Go(func() { webhookDispatcher.Run(webhookCtx) })
+
+	// intialize the router
+	r := router.SetupRouter(taskUsecase, userUsecase, commentUsecase, auditUsecase, labelUsecase, webhookUsecase, taskEventUsecase, calendarUsecase, jwtKeys, jwtSettings)
+
+	server := &http.Server{Addr: ":8080", Handler: r}
+
+	// event streams never finish on their own, end them so the shutdown doesn't wait for them
+	server.RegisterOnShutdown(taskEventUsecase.Close)
+
+	log.Println("Server starting on port 8080...")
+
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Server failed to run: %v", err)
+		}
+	}()
+
+	<-ctx.Done()
+	log.Println("Shutting down...")
+
+	// let the requests in flight finish, then wait for the background jobs before the storage is closed
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelShutdown()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Error shutting down the server: %v", err)
+	}
+	stopWebhooks()
+	background.Wait()
+}
+
+// reminderNotifier builds the notifier selected by REMINDER_NOTIFIER, nil when reminders are turned off
+func reminderNotifier() infrastructure.Notifier {
+	kind := os.Getenv("REMINDER_NOTIFIER")
+	if kind == "" {
+		kind = "log"
+		log.Println("Using default reminder notifier: log")
+	}
+
+	switch kind {
+	case "off":
+		return nil
+
+	case "log":
+		return infrastructure.NewLogNotifier(nil)
+
+	case "file":
+		reminderFile := os.Getenv("REMINDER_FILE")
+		if reminderFile == "" {
+			reminderFile = "./reminders.jsonl"
+			log.Println("Using default reminder file: ./reminders.jsonl")
+		}
+		return infrastructure.NewFileNotifier(reminderFile)
+
+	case "smtp":
+		var recipients []string
+		for _, to := range strings.Split(os.Getenv("SMTP_TO"), ",") {
+			if to = strings.TrimSpace(to); to != "" {
+				recipients = append(recipients, to)
+			}
+		}
+
+		notifier, err := infrastructure.NewSMTPNotifier(infrastructure.SMTPConfig{
+			Addr:     os.Getenv("SMTP_ADDR"),
+			From:     os.Getenv("SMTP_FROM"),
+			To:       recipients,
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			Timeout:  durationFromEnv("SMTP_TIMEOUT", infrastructure.DefaultSMTPTimeout),
+		})
+		if err != nil {
+			log.Fatalf("FATAL: invalid SMTP configuration: %v", err)
+		}
+		return notifier
+
+	default:
+		log.Fatalf("FATAL: unknown REMINDER_NOTIFIER %q, expected one of: log, file, smtp, off", kind)
+		return nil
+	}
+}
+
+// durationFromEnv reads a duration such as "720h" from the environment, an empty value means fallback
+func durationFromEnv(name string, fallback time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		log.Fatalf("FATAL: %s must be a duration such as 720h: %v", name, err)
+	}
+
+	return duration
+}
+
+// intFromEnv reads an integer from the environment, an empty value means fallback
+func intFromEnv(name string, fallback int) int {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		log.Fatalf("FATAL: %s must be an integer: %v", name, err)
+	}
+
+	return parsed
+}
+
+// connectMongo opens and verifies the MongoDB connection described by MONGO_URI
+func connectMongo() *mongo.Client {
+	mongoURI := os.Getenv("MONGO_URI")
+
+	// Critical Validation: Ensure the URI is set
+	if mongoURI == "" {
+		log.Fatal("FATAL: MONGO_URI environment variable is not set. Cannot connect to database.")
+	}
 
 	// set up a context for connection timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -61,13 +502,6 @@ func main() {
 		log.Fatal("FATAL: unable to connect to database")
 	}
 
-	// Ensure the client is closed when main() exits or panics
-	defer func() {
-		if err := client.Disconnect(context.Background()); err != nil {
-			log.Fatalf("FATAL: Error disconnecting MongoDB client: %v", err)
-		}
-	}()
-
 	// Ping the primary database to verify connection and credentials
 	err = client.Ping(ctx, nil)
 	if err != nil {
@@ -78,22 +512,5 @@ func main() {
 
 	log.Println("Successfully connected to MongoDB Atlas.")
 
-	// intialize mongo repositories
-	mongoTaskRepo := repositories.NewMongoTaskRepository(client, dbName, taskCollectionName)
-
-	mongoUserRepo := repositories.NewMongoUserRepository(client, dbName, userCollectionName)
-
-	// intialize usecases
-	taskUsecase := usecases.NewTaskUsecase(mongoTaskRepo)
-
-	userUsecase := usecases.NewUserUsecase(mongoUserRepo)
-
-	// intialize the router
-	r := router.SetupRouter(taskUsecase, userUsecase)
-
-	log.Println("Server starting on port 8080...")
-
-	if err := r.Run(":8080"); err != nil {
-		log.Fatalf("Server failed to run: %v", err)
-	}
+	return client
 }
@@ -1,7 +1,10 @@
 package router
 
 import (
+	"log"
+	"net/url"
 	"os"
+	"strings"
 	"taskmanager/Delivery/controllers"
 	domain "taskmanager/Domain"
 	middleware "taskmanager/Infrastructure"
@@ -10,15 +13,31 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func SetupRouter(tu usecases.TaskUsecase, uu usecases.UserUsecase) *gin.Engine {
+func SetupRouter(tu usecases.TaskUsecase, uu usecases.UserUsecase, cu usecases.CommentUsecase, au usecases.AuditUsecase, lu usecases.LabelUsecase, wu usecases.WebhookUsecase, teu usecases.TaskEventUsecase, cau usecases.CalendarUsecase, jwtKeys *middleware.JWTKeys, jwtSettings middleware.JWTSettings) *gin.Engine {
 
-	// itialize task and user controller
+	// itialize task, task event, user, comment, audit, label, webhook and calendar controller
 	taskController := controllers.NewTaskController(tu)
+	taskEventController := controllers.NewTaskEventController(teu)
 	userController := controllers.NewUserController(uu)
+	commentController := controllers.NewCommentController(cu)
+	auditController := controllers.NewAuditController(au)
+	labelController := controllers.NewLabelController(lu)
+	webhookController := controllers.NewWebhookController(wu)
+	calendarController := controllers.NewCalendarController(cau, publicBaseURL())
 
 	// intialize the router
 	router := gin.Default()
 
+	// failed logins are counted per client address, so X-Forwarded-For is only believed
+	// when it comes from one of the comma separated TRUSTED_PROXIES
+	var trustedProxies []string
+	if proxies := os.Getenv("TRUSTED_PROXIES"); proxies != "" {
+		trustedProxies = strings.Split(proxies, ",")
+	}
+	if err := router.SetTrustedProxies(trustedProxies); err != nil {
+		log.Fatalf("FATAL: TRUSTED_PROXIES must be addresses or CIDR ranges: %v", err)
+	}
+
 	// group routes under /api/v1
 	api := router.Group("/api/v1")
 
@@ -28,26 +47,143 @@ func SetupRouter(tu usecases.TaskUsecase, uu usecases.UserUsecase) *gin.Engine {
 	// user routes
 	userRoutes := api.Group("/user")
 
-	// read jwt secret from env varaiable
-	jwtSecret := os.Getenv("JWT_SECRET")
+	// the user usecase knows which access tokens were revoked on logout or by a password change
+	authMiddleware := middleware.AuthMiddleware(jwtKeys, jwtSettings, uu)
+	// a user who has to change their password can only do that
+	passwordChangeMiddleware := middleware.PasswordChangeAuthMiddleware(jwtKeys, jwtSettings, uu)
+
+	// public keys other services verify access tokens with
+	router.GET("/.well-known/jwks.json", middleware.JWKSHandler(jwtKeys))
 
 	// routes only need authentication
-	taskRoutes.GET("", middleware.AuthMiddleware(jwtSecret), taskController.GetTasks)
-	taskRoutes.GET("/:id", middleware.AuthMiddleware(jwtSecret), taskController.GetTaskById)
+	taskRoutes.GET("", authMiddleware, taskController.GetTasks)
+	taskRoutes.GET("/mine", authMiddleware, taskController.GetMyTasks)
+	taskRoutes.GET("/search", authMiddleware, taskController.SearchTasks)
+
+	// task changes as server-sent events, resumable with Last-Event-ID
+	taskRoutes.GET("/events", authMiddleware, taskEventController.StreamTaskEvents)
+
+	// every task matching the filters as csv, json or ndjson
+	taskRoutes.GET("/export", authMiddleware, taskController.ExportTasks)
+
+	taskRoutes.GET("/:id", authMiddleware, taskController.GetTaskById)
+
+	// admins can update any task, users only the ones assigned to them
+	taskRoutes.PUT("/:id", authMiddleware, taskController.UpdateTask)
+
+	// any authenticated user can discuss a task, only the author or an admin may change a comment
+	taskRoutes.GET("/:id/comments", authMiddleware, commentController.GetComments)
+	taskRoutes.POST("/:id/comments", authMiddleware, commentController.CreateComment)
+	taskRoutes.PUT("/:id/comments/:commentId", authMiddleware, commentController.UpdateComment)
+	taskRoutes.DELETE("/:id/comments/:commentId", authMiddleware, commentController.DeleteComment)
+
+	// labels follow the update rule, admins can label any task and users the ones assigned to them
+	taskRoutes.POST("/:id/labels", authMiddleware, taskController.AddTaskLabels)
+	taskRoutes.DELETE("/:id/labels/:label", authMiddleware, taskController.RemoveTaskLabel)
+
+	// subtasks and blockers, links are checked for cycles
+	taskRoutes.PUT("/:id/parent", authMiddleware, taskController.SetTaskParent)
+	taskRoutes.DELETE("/:id/parent", authMiddleware, taskController.RemoveTaskParent)
+	taskRoutes.POST("/:id/blockers", authMiddleware, taskController.AddTaskBlocker)
+	taskRoutes.DELETE("/:id/blockers/:blockerId", authMiddleware, taskController.RemoveTaskBlocker)
+
+	// the due dates a recurring task will come back on
+	taskRoutes.GET("/:id/occurrences", authMiddleware, taskController.PreviewTaskOccurrences)
 
 	// admin-only routes
 	adminTaskRoutes := taskRoutes.Group("")
 
-	adminTaskRoutes.Use(middleware.AuthMiddleware(jwtSecret))
+	adminTaskRoutes.Use(authMiddleware)
 	adminTaskRoutes.Use(middleware.AuthorizationMiddleware(domain.RoleAdmin))
 
 	adminTaskRoutes.POST("", taskController.CreatTask)
-	adminTaskRoutes.PUT("/:id", taskController.UpdateTask)
 	adminTaskRoutes.DELETE("/:id", taskController.DeleteTask)
+	adminTaskRoutes.PUT("/:id/assignee", taskController.AssignTask)
+	adminTaskRoutes.DELETE("/:id/assignee", taskController.UnassignTask)
+
+	// bulk operations report the outcome of every item, atomic batches are all-or-nothing
+	adminTaskRoutes.POST("/bulk", taskController.BulkCreateTasks)
+	adminTaskRoutes.PATCH("/bulk", taskController.BulkUpdateTasks)
+	adminTaskRoutes.POST("/bulk/delete", taskController.BulkDeleteTasks)
+
+	// imports take the export formats, rows are checked like created tasks and can be dry-run
+	adminTaskRoutes.POST("/import", taskController.ImportTasks)
+
+	// deleted tasks wait in the trash until they are restored or purged
+	adminTaskRoutes.GET("/trash", taskController.GetTrash)
+	adminTaskRoutes.POST("/trash/:id/restore", taskController.RestoreTask)
+	adminTaskRoutes.DELETE("/trash/:id", taskController.PurgeTask)
 
 	userRoutes.POST("/register", userController.RegisterUser)
 	userRoutes.POST("/login", userController.AuthenticateUser)
-	userRoutes.PATCH("/:id/promote", middleware.AuthMiddleware(jwtSecret), middleware.AuthorizationMiddleware(domain.RoleAdmin), userController.PromoteUser)
+	userRoutes.POST("/refresh", userController.RefreshToken)
+	userRoutes.POST("/logout", authMiddleware, userController.Logout)
+	// users change their own password, admins can make anyone choose a new one at the next login
+	userRoutes.POST("/password", passwordChangeMiddleware, userController.ChangePassword)
+	userRoutes.POST("/:id/password/reset", authMiddleware, middleware.AuthorizationMiddleware(domain.RoleAdmin), userController.ResetPassword)
+	// every user manages the calendar feed of their own tasks
+	userRoutes.GET("/calendar", authMiddleware, calendarController.GetCalendarFeed)
+	userRoutes.POST("/calendar", authMiddleware, calendarController.CreateCalendarFeed)
+	userRoutes.DELETE("/calendar", authMiddleware, calendarController.RevokeCalendarFeed)
+
+	userRoutes.PATCH("/:id/promote", authMiddleware, middleware.AuthorizationMiddleware(domain.RoleAdmin), userController.PromoteUser)
+
+	// admins see who is locked out after failing to log in, and can lift the lockout early
+	userRoutes.GET("/lockouts", authMiddleware, middleware.AuthorizationMiddleware(domain.RoleAdmin), userController.GetLoginLockouts)
+	userRoutes.DELETE("/lockouts", authMiddleware, middleware.AuthorizationMiddleware(domain.RoleAdmin), userController.UnlockLogin)
+
+	// the audit log is only readable by admins
+	auditRoutes := api.Group("/audit")
+
+	auditRoutes.Use(authMiddleware)
+	auditRoutes.Use(middleware.AuthorizationMiddleware(domain.RoleAdmin))
+
+	auditRoutes.GET("", auditController.GetAuditLog)
+	auditRoutes.GET("/export", auditController.ExportAuditLog)
+
+	// every user can see the label definitions, only admins manage them
+	labelRoutes := api.Group("/labels")
+
+	labelRoutes.Use(authMiddleware)
+
+	labelRoutes.GET("", labelController.GetLabels)
+	labelRoutes.POST("", middleware.AuthorizationMiddleware(domain.RoleAdmin), labelController.CreateLabel)
+	labelRoutes.PATCH("/:name", middleware.AuthorizationMiddleware(domain.RoleAdmin), labelController.UpdateLabel)
+	labelRoutes.DELETE("/:name", middleware.AuthorizationMiddleware(domain.RoleAdmin), labelController.DeleteLabel)
+
+	// webhooks and their delivery log are managed by admins
+	webhookRoutes := api.Group("/webhooks")
+
+	webhookRoutes.Use(authMiddleware)
+	webhookRoutes.Use(middleware.AuthorizationMiddleware(domain.RoleAdmin))
+
+	webhookRoutes.GET("", webhookController.GetWebhooks)
+	webhookRoutes.POST("", webhookController.CreateWebhook)
+	webhookRoutes.GET("/:id", webhookController.GetWebhook)
+	webhookRoutes.PATCH("/:id", webhookController.UpdateWebhook)
+	webhookRoutes.DELETE("/:id", webhookController.DeleteWebhook)
+	webhookRoutes.GET("/:id/deliveries", webhookController.GetDeliveries)
+	webhookRoutes.POST("/:id/deliveries/:deliveryId/redeliver", webhookController.Redeliver)
+
+	// calendar apps can't send a token, the secret in the feed URL authenticates them
+	api.GET("/calendar/:token/tasks.ics", calendarController.GetCalendar)
 
 	return router
 }
+
+// publicBaseURL reads PUBLIC_BASE_URL, the address clients reach the API through, e.g. https://tasks.example.com.
+// Links handed out to clients, like calendar feeds, start with it. Empty when it isn't set.
+func publicBaseURL() string {
+
+	baseURL := os.Getenv("PUBLIC_BASE_URL")
+	if baseURL == "" {
+		return ""
+	}
+
+	parsed, err := url.Parse(baseURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" || parsed.RawQuery != "" || parsed.Fragment != "" {
+		log.Fatalf("FATAL: PUBLIC_BASE_URL must be an http or https URL without a query, got %q", baseURL)
+	}
+
+	return baseURL
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditAction names a mutation recorded in the audit log
+type AuditAction string
+
+const (
+	AuditTaskCreated    AuditAction = "task.create"
+	AuditTaskUpdated    AuditAction = "task.update"
+	AuditTaskDeleted    AuditAction = "task.delete"
+	AuditTaskAssigned   AuditAction = "task.assign"
+	AuditTaskUnassigned AuditAction = "task.unassign"
+	AuditTaskRestored   AuditAction = "task.restore"
+	AuditTaskPurged     AuditAction = "task.purge"
+	AuditUserPromoted   AuditAction = "user.promote"
+	AuditLabelCreated   AuditAction = "label.create"
+	AuditLabelUpdated   AuditAction = "label.update"
+	AuditLabelDeleted   AuditAction = "label.delete"
+	AuditWebhookCreated AuditAction = "webhook.create"
+	AuditWebhookUpdated AuditAction = "webhook.update"
+	AuditWebhookDeleted AuditAction = "webhook.delete"
+	AuditLoginUnlocked  AuditAction = "login.unlock"
+
+	AuditUserPasswordChanged AuditAction = "user.password_change"
+	AuditUserPasswordReset   AuditAction = "user.password_reset"
+)
+
+// actor recorded for changes made by the server itself, e.g. the trash purge
+const AuditSystemActor = "system"
+
+// kinds of resources an audit entry can point at
+const (
+	AuditTargetTask    = "task"
+	AuditTargetUser    = "user"
+	AuditTargetLabel   = "label"
+	AuditTargetWebhook = "webhook"
+	AuditTargetLogin   = "login" // the failed logins of a username or client address, e.g. "user:alice"
+)
+
+// IsValid reports whether the action is one the audit log records
+func (a AuditAction) IsValid() bool {
+	switch a {
+	case AuditTaskCreated, AuditTaskUpdated, AuditTaskDeleted, AuditTaskAssigned, AuditTaskUnassigned,
+		AuditTaskRestored, AuditTaskPurged, AuditUserPromoted, AuditLabelCreated, AuditLabelUpdated, AuditLabelDeleted,
+		AuditWebhookCreated, AuditWebhookUpdated, AuditWebhookDeleted, AuditLoginUnlocked,
+		AuditUserPasswordChanged, AuditUserPasswordReset:
+		return true
+	}
+	return false
+}
+
+// AuditEntry is one immutable record of the audit log.
+// Before and After hold the JSON representation of the target around the change,
+// Before is empty for creations and After for deletions.
+type AuditEntry struct {
+	ID         string          `json:"id" bson:"entry_id"`
+	ActorID    string          `json:"actor_id" bson:"actor_id"`
+	Action     AuditAction     `json:"action" bson:"action"`
+	TargetType string          `json:"target_type" bson:"target_type"`
+	TargetID   string          `json:"target_id" bson:"target_id"`
+	Before     json.RawMessage `json:"before,omitempty" bson:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty" bson:"after,omitempty"`
+	Timestamp  time.Time       `json:"timestamp" bson:"timestamp"`
+}
+
+// pagination limits applied when querying the audit log
+const (
+	DefaultAuditPageLimit int64 = 50
+	MaxAuditPageLimit     int64 = 500
+)
+
+// AuditFilter holds the optional criteria used to query the audit log(zero values mean "not set")
+type AuditFilter struct {
+	ActorID    string
+	Action     AuditAction
+	TargetType string
+	TargetID   string
+	From       time.Time // inclusive
+	To         time.Time // exclusive
+	Limit      int64
+	Offset     int64
+}
+
+// AuditPage is one page of audit entries, newest first
+type AuditPage struct {
+	Entries []AuditEntry `json:"entries"`
+	Total   int64        `json:"total"`
+	Limit   int64        `json:"limit"`
+	Offset  int64        `json:"offset"`
+}
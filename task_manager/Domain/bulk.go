@@ -0,0 +1,42 @@
+package domain
+
+// most items a single bulk request may carry, a filter may not select more tasks either
+const MaxBulkTasks = 500
+
+// BulkItemStatus is the outcome of one item of a bulk operation
+type BulkItemStatus string
+
+const (
+	BulkItemSucceeded BulkItemStatus = "succeeded"
+	BulkItemFailed    BulkItemStatus = "failed"
+	// the item was valid but not applied because another item of an all-or-nothing batch failed
+	BulkItemSkipped BulkItemStatus = "skipped"
+)
+
+// BulkItemResult reports what happened to one item of a bulk operation.
+// Index is the item's position in the request, Task is the task as stored after the change.
+type BulkItemResult struct {
+	Index  int            `json:"index"`
+	ID     string         `json:"id,omitempty"`
+	Status BulkItemStatus `json:"status"`
+	Error  string         `json:"error,omitempty"`
+	Task   *Task          `json:"task,omitempty"`
+}
+
+// BulkResult is the outcome of a bulk operation, with one result per item in request order
+type BulkResult struct {
+	Atomic    bool             `json:"atomic"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Skipped   int              `json:"skipped"`
+	Results   []BulkItemResult `json:"results"`
+}
+
+// BulkTaskUpdate applies the same changes to a set of tasks, selected either by id or by a filter.
+// Only title, description, due_date, status and recurrence are taken from Changes, like a single update.
+type BulkTaskUpdate struct {
+	IDs     []string
+	Filter  *TaskFilter
+	Changes Task
+	Atomic  bool
+}
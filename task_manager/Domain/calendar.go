@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// CalendarFeed lets a user subscribe to the due dates of their tasks from a calendar app.
+// Calendar apps can't send an Authorization header, so the feed URL carries a secret of its own.
+// Only its hash is stored, a user has at most one feed and replacing it revokes the old URL.
+type CalendarFeed struct {
+	UserID    string    `json:"-" bson:"user_id"`
+	TokenHash string    `json:"-" bson:"token_hash"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+}
+
+// CalendarComponent is how tasks are rendered in a feed
+type CalendarComponent string
+
+const (
+	CalendarEvent CalendarComponent = "event" // a VEVENT at the due date, shown by every calendar app
+	CalendarTodo  CalendarComponent = "todo"  // a VTODO due at the due date, for apps with task lists
+)
+
+// IsValid reports whether tasks can be rendered as the component
+func (c CalendarComponent) IsValid() bool {
+	return c == CalendarEvent || c == CalendarTodo
+}
@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// Comment is a message posted on a task's discussion thread
+type Comment struct {
+	ID        string    `json:"id" bson:"comment_id"`
+	TaskID    string    `json:"task_id" bson:"task_id"`
+	AuthorID  string    `json:"author_id" bson:"author_id"`
+	Body      string    `json:"body" bson:"body"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+// longest comment body accepted, in characters
+const MaxCommentLength = 5000
+
+// CommentPage is one page of a task's comments, oldest first
+type CommentPage struct {
+	Comments []Comment `json:"comments"`
+	Total    int64     `json:"total"`
+	Limit    int64     `json:"limit"`
+	Offset   int64     `json:"offset"`
+}
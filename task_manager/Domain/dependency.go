@@ -0,0 +1,15 @@
+package domain
+
+// limits on how tasks are linked together
+const (
+	// most tasks a single task can be blocked by
+	MaxTaskBlockers = 50
+	// how many levels of subtasks a subtree returns below the requested task
+	MaxSubtreeDepth = 10
+)
+
+// TaskNode is a task together with its subtasks, the subtasks are nested the same way
+type TaskNode struct {
+	Task
+	Subtasks []TaskNode `json:"subtasks"`
+}
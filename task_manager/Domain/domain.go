@@ -11,11 +11,26 @@ type Task struct {
 	// MongoDB's navtive _id(internal, private field)
 	id primitive.ObjectID `bson:"_id,omitempty" json:"-"` // igonre it in the json
 	// custom id for task identification
-	ID          string    `json:"id" bson:"task_id"`
-	Title       string    `json:"title" bson:"title"`
-	Description string    `json:"description" bson:"description"`
-	DueDate     time.Time `json:"due_date" bson:"due_date"`
-	Status      string    `json:"status" bson:"status"`
+	ID          string     `json:"id" bson:"task_id"`
+	Title       string     `json:"title" bson:"title"`
+	Description string     `json:"description" bson:"description"`
+	DueDate     time.Time  `json:"due_date" bson:"due_date"`
+	Status      TaskStatus `json:"status" bson:"status"`
+	// user ids of the task's creator and of the user responsible for it
+	CreatedBy  string `json:"created_by" bson:"created_by"`
+	AssigneeID string `json:"assignee_id" bson:"assignee_id"`
+	// names of the labels the task is tagged with, sorted
+	Labels []string `json:"labels,omitempty" bson:"labels,omitempty"`
+	// the task this one is a subtask of, and the tasks that must be done before this one
+	ParentID  string   `json:"parent_id,omitempty" bson:"parent_id,omitempty"`
+	BlockedBy []string `json:"blocked_by,omitempty" bson:"blocked_by,omitempty"`
+	// RRULE the task repeats by, and the number of this task in its series starting at 1
+	Recurrence string `json:"recurrence,omitempty" bson:"recurrence,omitempty"`
+	Occurrence int    `json:"occurrence,omitempty" bson:"occurrence,omitempty"`
+	// incremented on every write, used for optimistic concurrency control
+	Version int64 `json:"version" bson:"version"`
+	// set while the task is in the trash
+	DeletedAt *time.Time `json:"deleted_at,omitempty" bson:"deleted_at,omitempty"`
 }
 
 // define user role enum
@@ -33,10 +48,63 @@ type User struct {
 	UserName       string             `bson:"user_name" json:"user_name"`
 	HashedPassword string             `bson:"hashed_password" json:"-"`
 	Role           UserRole           `bson:"role" json:"role"`
+
+	// an admin asked the user to choose a new password, they can't log in without one
+	MustChangePassword bool `bson:"must_change_password" json:"must_change_password"`
+	// sessions started before the password last changed or was reset are ended
+	PasswordChangedAt time.Time `bson:"password_changed_at" json:"password_changed_at"`
+}
+
+// Actor is the authenticated user performing an operation
+type Actor struct {
+	UserID string
+	Role   UserRole
 }
 
 // Used only for binding credentials from the client's request body
 type Credentials struct {
 	UserName string `json:"user_name" binding:"required"`
 	Password string `json:"password" binding:"required"`
+	// only read on login, it sets the password of a user who has to change it
+	NewPassword string `json:"new_password,omitempty"`
+}
+
+// task fields a task list can be sorted by
+const (
+	TaskSortByDueDate = "due_date"
+	TaskSortByTitle   = "title"
+	TaskSortByStatus  = "status"
+)
+
+// pagination limits applied when listing tasks
+const (
+	DefaultTaskPageLimit int64 = 20
+	MaxTaskPageLimit     int64 = 100
+)
+
+// TaskFilter holds the optional criteria used to list tasks(zero values mean "not set")
+type TaskFilter struct {
+	Status      TaskStatus
+	DueFrom     time.Time
+	DueTo       time.Time
+	TitlePrefix string
+	AssigneeID  string
+	CreatedBy   string
+	LabelsAny   []string // tasks with at least one of these labels
+	LabelsAll   []string // tasks with every one of these labels
+	SortBy      string
+	SortDesc    bool
+	Limit       int64
+	Offset      int64
+}
+
+// how long deleted tasks stay in the trash before they are purged, unless configured otherwise
+const DefaultTrashRetention = 30 * 24 * time.Hour
+
+// TaskPage is one page of a filtered task list
+type TaskPage struct {
+	Tasks  []Task `json:"tasks"`
+	Total  int64  `json:"total"`
+	Limit  int64  `json:"limit"`
+	Offset int64  `json:"offset"`
 }
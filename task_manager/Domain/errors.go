@@ -7,3 +7,8 @@ var ErrNotFound = errors.New("resource not found")
 var ErrAleadyExists = errors.New("resource already exists")
 var ErrValidation = errors.New("input validation failed")
 var ErrInvalidCredential = errors.New("invalid username or password")
+var ErrForbidden = errors.New("operation not permitted")
+var ErrConflict = errors.New("resource has been modified by another request")
+var ErrInvalidToken = errors.New("invalid or expired token")
+var ErrTooManyAttempts = errors.New("too many failed login attempts")
+var ErrPasswordChangeRequired = errors.New("password change required")
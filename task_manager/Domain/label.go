@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Label is an admin-defined category tasks can be tagged with, e.g. backend, infra or docs
+type Label struct {
+	Name        string    `json:"name" bson:"name"`
+	Color       string    `json:"color" bson:"color"` // "#rrggbb"
+	Description string    `json:"description" bson:"description"`
+	CreatedAt   time.Time `json:"created_at" bson:"created_at"`
+}
+
+// limits on labels and on how many a task can carry
+const (
+	MaxLabelNameLength        = 32
+	MaxLabelDescriptionLength = 200
+	MaxTaskLabels             = 20
+)
+
+// colour given to labels created without one
+const DefaultLabelColor = "#9e9e9e"
+
+var (
+	labelNamePattern  = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+	labelColorPattern = regexp.MustCompile(`^#[0-9a-f]{6}$`)
+)
+
+// NormalizeLabelName lowercases a label name and checks it only holds letters, digits, '-' and '_'
+func NormalizeLabelName(name string) (string, error) {
+
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	if name == "" {
+		return "", fmt.Errorf("%w: label name is required", ErrValidation)
+	}
+	if len(name) > MaxLabelNameLength {
+		return "", fmt.Errorf("%w: label name must be at most %d characters", ErrValidation, MaxLabelNameLength)
+	}
+	if !labelNamePattern.MatchString(name) {
+		return "", fmt.Errorf("%w: label %q may only contain letters, digits, '-' and '_'", ErrValidation, name)
+	}
+
+	return name, nil
+}
+
+// NormalizeLabelNames normalizes a set of label names, repeats are dropped and the names sorted
+func NormalizeLabelNames(names []string) ([]string, error) {
+
+	seen := make(map[string]bool, len(names))
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		name, err := NormalizeLabelName(name)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[name] {
+			seen[name] = true
+			normalized = append(normalized, name)
+		}
+	}
+
+	sort.Strings(normalized)
+
+	return normalized, nil
+}
+
+// NormalizeLabelColor lowercases a "#rrggbb" colour, an empty colour gets the default one
+func NormalizeLabelColor(color string) (string, error) {
+
+	color = strings.ToLower(strings.TrimSpace(color))
+
+	if color == "" {
+		return DefaultLabelColor, nil
+	}
+	if !labelColorPattern.MatchString(color) {
+		return "", fmt.Errorf("%w: color must be a hex colour such as #1f77b4", ErrValidation)
+	}
+
+	return color, nil
+}
+
+// ValidateLabelDescription checks the length of a label description
+func ValidateLabelDescription(description string) error {
+
+	if utf8.RuneCountInString(description) > MaxLabelDescriptionLength {
+		return fmt.Errorf("%w: description must be at most %d characters", ErrValidation, MaxLabelDescriptionLength)
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// LoginAttemptKind is what failed logins are counted by
+type LoginAttemptKind string
+
+const (
+	LoginAttemptUser LoginAttemptKind = "user" // the username logged in with, whether or not it exists
+	LoginAttemptIP   LoginAttemptKind = "ip"   // the address of the client
+)
+
+// IsValid reports whether failed logins are counted by the kind
+func (k LoginAttemptKind) IsValid() bool {
+	return k == LoginAttemptUser || k == LoginAttemptIP
+}
+
+// LoginAttempts counts the recent failed logins of a username or a client address.
+// The failures are forgotten once the last one is older than the window of the limits.
+type LoginAttempts struct {
+	Kind        LoginAttemptKind `json:"kind" bson:"kind"`
+	Subject     string           `json:"subject" bson:"subject"`
+	Failures    int              `json:"failures" bson:"failures"`
+	LastFailure time.Time        `json:"last_failure" bson:"last_failure"`
+	LockedUntil time.Time        `json:"locked_until" bson:"locked_until"`
+}
+
+// LoginLimits say when failed logins slow down and then lock out a username or a client address.
+// After DelayAfter failures every attempt has to wait BaseDelay since the last failure, doubling with
+// every further failure up to MaxDelay. After LockoutAfter failures no attempt is accepted for
+// LockoutDuration. A client address gets more room, many users can share one. Zero turns a limit off.
+type LoginLimits struct {
+	Window           time.Duration
+	UserDelayAfter   int
+	UserLockoutAfter int
+	IPDelayAfter     int
+	IPLockoutAfter   int
+	BaseDelay        time.Duration
+	MaxDelay         time.Duration
+	LockoutDuration  time.Duration
+}
+
+// DefaultLoginLimits are the limits used unless configured otherwise
+func DefaultLoginLimits() LoginLimits {
+	return LoginLimits{
+		Window:           15 * time.Minute,
+		UserDelayAfter:   3,
+		UserLockoutAfter: 10,
+		IPDelayAfter:     10,
+		IPLockoutAfter:   50,
+		BaseDelay:        time.Second,
+		MaxDelay:         30 * time.Second,
+		LockoutDuration:  15 * time.Minute,
+	}
+}
+
+// Validate reports limits that can't work together
+func (l LoginLimits) Validate() error {
+
+	if l.Window <= 0 {
+		return fmt.Errorf("%w: the window of failed logins must be positive", ErrValidation)
+	}
+	if l.UserDelayAfter < 0 || l.UserLockoutAfter < 0 || l.IPDelayAfter < 0 || l.IPLockoutAfter < 0 {
+		return fmt.Errorf("%w: failed login thresholds can't be negative", ErrValidation)
+	}
+	if (l.UserDelayAfter > 0 || l.IPDelayAfter > 0) && (l.BaseDelay <= 0 || l.MaxDelay < l.BaseDelay) {
+		return fmt.Errorf("%w: the login delay must be positive and at most the maximum delay", ErrValidation)
+	}
+	if (l.UserLockoutAfter > 0 || l.IPLockoutAfter > 0) && l.LockoutDuration <= 0 {
+		return fmt.Errorf("%w: the lockout duration must be positive", ErrValidation)
+	}
+
+	return nil
+}
+
+// Thresholds returns the failures after which logins of the kind are delayed and locked out
+func (l LoginLimits) Thresholds(kind LoginAttemptKind) (delayAfter int, lockoutAfter int) {
+	if kind == LoginAttemptIP {
+		return l.IPDelayAfter, l.IPLockoutAfter
+	}
+	return l.UserDelayAfter, l.UserLockoutAfter
+}
+
+// Wait is how long the counter has to wait before its next attempt at the given time,
+// and whether it is locked out rather than asked to slow down
+func (l LoginLimits) Wait(attempts LoginAttempts, now time.Time) (time.Duration, bool) {
+
+	if attempts.LockedUntil.After(now) {
+		return attempts.LockedUntil.Sub(now), true
+	}
+
+	// the failures have been forgotten
+	if now.Sub(attempts.LastFailure) > l.Window {
+		return 0, false
+	}
+
+	delayAfter, _ := l.Thresholds(attempts.Kind)
+	if delayAfter == 0 || attempts.Failures < delayAfter {
+		return 0, false
+	}
+
+	// the delay doubles with every failure past the threshold
+	delay := l.BaseDelay
+	for i := delayAfter; i < attempts.Failures && delay < l.MaxDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, l.MaxDelay)
+
+	return attempts.LastFailure.Add(delay).Sub(now), false
+}
+
+// LoginThrottledError is returned for a login attempted before the username or client address may try again
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+	Locked     bool // locked out, rather than asked to slow down
+}
+
+func (e *LoginThrottledError) Error() string {
+	if e.Locked {
+		return fmt.Sprintf("%s: locked out, try again in %s", ErrTooManyAttempts, e.RetryAfter.Round(time.Second))
+	}
+	return fmt.Sprintf("%s: try again in %s", ErrTooManyAttempts, e.RetryAfter.Round(time.Second))
+}
+
+func (e *LoginThrottledError) Unwrap() error {
+	return ErrTooManyAttempts
+}
@@ -0,0 +1,50 @@
+package domain
+
+import "fmt"
+
+// bcrypt only looks at the first 72 bytes, a longer password would be truncated silently
+const MaxPasswordBytes = 72
+
+// PasswordPolicy is what a new password has to satisfy. MinClasses counts the classes among
+// lowercase letters, uppercase letters, digits and everything else that the password has to mix.
+// A password is never allowed to be the username.
+type PasswordPolicy struct {
+	MinLength      int
+	MinClasses     int
+	RejectBreached bool // reject the passwords found in known breaches
+}
+
+// DefaultPasswordPolicy is the policy used unless configured otherwise
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:      8,
+		MinClasses:     1,
+		RejectBreached: true,
+	}
+}
+
+// Validate reports a policy no password could satisfy
+func (p PasswordPolicy) Validate() error {
+
+	if p.MinLength < 1 || p.MinLength > MaxPasswordBytes {
+		return fmt.Errorf("%w: the minimum password length must be between 1 and %d", ErrValidation, MaxPasswordBytes)
+	}
+	if p.MinClasses < 0 || p.MinClasses > 4 {
+		return fmt.Errorf("%w: the number of character classes must be between 0 and 4", ErrValidation)
+	}
+
+	return nil
+}
+
+// PasswordPolicyError is returned for a new password the policy rejects
+type PasswordPolicyError struct {
+	Reason string
+}
+
+func (e *PasswordPolicyError) Error() string {
+	return "password " + e.Reason
+}
+
+func (e *PasswordPolicyError) Unwrap() error {
+	return ErrValidation
+}
@@ -0,0 +1,296 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RecurrenceFrequency is how often a recurring task comes back
+type RecurrenceFrequency string
+
+const (
+	RecurDaily   RecurrenceFrequency = "DAILY"
+	RecurWeekly  RecurrenceFrequency = "WEEKLY"
+	RecurMonthly RecurrenceFrequency = "MONTHLY"
+)
+
+// limits on recurrence rules and their previews
+const (
+	MaxRecurrenceInterval     = 1000
+	DefaultRecurrencePreview  = 5
+	MaxRecurrencePreview      = 100
+	maxRecurrencePeriodsAhead = 1000 // periods searched for the next occurrence before giving up
+)
+
+// iCalendar weekday codes, indexed by weekdayOffset
+var recurrenceWeekdays = []string{"MO", "TU", "WE", "TH", "FR", "SA", "SU"}
+
+// RecurrenceRule is the subset of the iCalendar RRULE (RFC 5545) a task can repeat by:
+// FREQ=DAILY, WEEKLY (optionally BYDAY) or MONTHLY (optionally BYMONTHDAY), with an optional
+// INTERVAL and at most one of COUNT and UNTIL. The first occurrence is the due date of the
+// task the rule was set on, every later one keeps its time of day.
+type RecurrenceRule struct {
+	Freq       RecurrenceFrequency
+	Interval   int
+	ByDay      []time.Weekday // weekly only, empty means the weekday of the current occurrence
+	ByMonthDay []int          // monthly only, negative days count from the end of the month
+	Count      int            // number of occurrences in the series, 0 means no limit
+	Until      time.Time      // no occurrence is due after this time, zero means no limit
+}
+
+// ParseRecurrenceRule parses an RRULE value such as "FREQ=WEEKLY;BYDAY=MO,TH;COUNT=10",
+// an optional "RRULE:" prefix is accepted
+func ParseRecurrenceRule(raw string) (RecurrenceRule, error) {
+
+	raw = strings.TrimSpace(raw)
+	if len(raw) >= 6 && strings.EqualFold(raw[:6], "RRULE:") {
+		raw = raw[6:]
+	}
+	if raw == "" {
+		return RecurrenceRule{}, fmt.Errorf("%w: recurrence rule must not be empty", ErrValidation)
+	}
+
+	rule := RecurrenceRule{Interval: 1}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(raw, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		key = strings.ToUpper(strings.TrimSpace(key))
+		value = strings.ToUpper(strings.TrimSpace(value))
+		if !ok || key == "" || value == "" {
+			return RecurrenceRule{}, fmt.Errorf("%w: malformed recurrence rule part %q", ErrValidation, part)
+		}
+		if seen[key] {
+			return RecurrenceRule{}, fmt.Errorf("%w: %s is set more than once", ErrValidation, key)
+		}
+		seen[key] = true
+
+		var err error
+		switch key {
+		case "FREQ":
+			rule.Freq = RecurrenceFrequency(value)
+			if rule.Freq != RecurDaily && rule.Freq != RecurWeekly && rule.Freq != RecurMonthly {
+				return RecurrenceRule{}, fmt.Errorf("%w: FREQ must be DAILY, WEEKLY or MONTHLY", ErrValidation)
+			}
+		case "INTERVAL":
+			rule.Interval, err = strconv.Atoi(value)
+			if err != nil || rule.Interval < 1 || rule.Interval > MaxRecurrenceInterval {
+				return RecurrenceRule{}, fmt.Errorf("%w: INTERVAL must be between 1 and %d", ErrValidation, MaxRecurrenceInterval)
+			}
+		case "COUNT":
+			rule.Count, err = strconv.Atoi(value)
+			if err != nil || rule.Count < 1 {
+				return RecurrenceRule{}, fmt.Errorf("%w: COUNT must be a positive number", ErrValidation)
+			}
+		case "UNTIL":
+			if rule.Until, err = parseRecurrenceUntil(value); err != nil {
+				return RecurrenceRule{}, err
+			}
+		case "BYDAY":
+			if rule.ByDay, err = parseRecurrenceWeekdays(value); err != nil {
+				return RecurrenceRule{}, err
+			}
+		case "BYMONTHDAY":
+			if rule.ByMonthDay, err = parseRecurrenceMonthDays(value); err != nil {
+				return RecurrenceRule{}, err
+			}
+		default:
+			return RecurrenceRule{}, fmt.Errorf("%w: unsupported recurrence rule part %s", ErrValidation, key)
+		}
+	}
+
+	if rule.Freq == "" {
+		return RecurrenceRule{}, fmt.Errorf("%w: FREQ is required", ErrValidation)
+	}
+	if len(rule.ByDay) > 0 && rule.Freq != RecurWeekly {
+		return RecurrenceRule{}, fmt.Errorf("%w: BYDAY is only supported with FREQ=WEEKLY", ErrValidation)
+	}
+	if len(rule.ByMonthDay) > 0 && rule.Freq != RecurMonthly {
+		return RecurrenceRule{}, fmt.Errorf("%w: BYMONTHDAY is only supported with FREQ=MONTHLY", ErrValidation)
+	}
+	if rule.Count > 0 && !rule.Until.IsZero() {
+		return RecurrenceRule{}, fmt.Errorf("%w: COUNT and UNTIL can't be combined", ErrValidation)
+	}
+
+	return rule, nil
+}
+
+// parseRecurrenceUntil accepts a UTC date-time (20251231T170000Z) or a date, which lasts until the end of the day
+func parseRecurrenceUntil(value string) (time.Time, error) {
+
+	if until, err := time.Parse("20060102T150405Z", value); err == nil {
+		return until, nil
+	}
+	if until, err := time.Parse("20060102", value); err == nil {
+		return until.Add(24*time.Hour - time.Millisecond), nil
+	}
+
+	return time.Time{}, fmt.Errorf("%w: UNTIL must look like 20251231 or 20251231T170000Z", ErrValidation)
+}
+
+func parseRecurrenceWeekdays(value string) ([]time.Weekday, error) {
+
+	var days []time.Weekday
+	for _, code := range strings.Split(value, ",") {
+		offset := slices.Index(recurrenceWeekdays, code)
+		if offset < 0 {
+			return nil, fmt.Errorf("%w: BYDAY only accepts MO, TU, WE, TH, FR, SA and SU, got %q", ErrValidation, code)
+		}
+		if day := time.Weekday((offset + 1) % 7); !slices.Contains(days, day) {
+			days = append(days, day)
+		}
+	}
+
+	// keep the days in week order, starting on Monday
+	slices.SortFunc(days, func(a, b time.Weekday) int { return weekdayOffset(a) - weekdayOffset(b) })
+
+	return days, nil
+}
+
+func parseRecurrenceMonthDays(value string) ([]int, error) {
+
+	var days []int
+	for _, item := range strings.Split(value, ",") {
+		day, err := strconv.Atoi(item)
+		if err != nil || day == 0 || day < -31 || day > 31 {
+			return nil, fmt.Errorf("%w: BYMONTHDAY days must be between 1 and 31 or -31 and -1, got %q", ErrValidation, item)
+		}
+		if !slices.Contains(days, day) {
+			days = append(days, day)
+		}
+	}
+
+	return days, nil
+}
+
+// String writes the rule back in its canonical form, which is how it is stored
+func (r RecurrenceRule) String() string {
+
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		codes := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			codes[i] = recurrenceWeekdays[weekdayOffset(day)]
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, len(r.ByMonthDay))
+		for i, day := range r.ByMonthDay {
+			days[i] = strconv.Itoa(day)
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// Occurrences returns up to n due dates following current, which is occurrence number occurrence of the series.
+// The list is shorter when COUNT or UNTIL end the series first.
+func (r RecurrenceRule) Occurrences(current time.Time, occurrence int, n int) []time.Time {
+
+	dates := []time.Time{}
+	for len(dates) < n {
+		if r.Count > 0 && occurrence >= r.Count {
+			break
+		}
+
+		next, ok := r.next(current)
+		if !ok || (!r.Until.IsZero() && next.After(r.Until)) {
+			break
+		}
+
+		dates = append(dates, next)
+		current = next
+		occurrence++
+	}
+
+	return dates
+}
+
+// Next returns the due date of the occurrence after current, which is occurrence number occurrence
+// of the series. The second return value is false when the series has ended.
+func (r RecurrenceRule) Next(current time.Time, occurrence int) (time.Time, bool) {
+
+	dates := r.Occurrences(current, occurrence, 1)
+	if len(dates) == 0 {
+		return time.Time{}, false
+	}
+
+	return dates[0], true
+}
+
+// next finds the first date of the rule after current, periods are counted from the one current falls in
+func (r RecurrenceRule) next(current time.Time) (time.Time, bool) {
+
+	current = current.UTC()
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, current.Hour(), current.Minute(), current.Second(), current.Nanosecond(), time.UTC)
+	}
+
+	switch r.Freq {
+	case RecurDaily:
+		return current.AddDate(0, 0, r.Interval), true
+
+	case RecurWeekly:
+		days := r.ByDay
+		if len(days) == 0 {
+			days = []time.Weekday{current.Weekday()}
+		}
+		monday := at(current.Year(), current.Month(), current.Day()-weekdayOffset(current.Weekday()))
+		for period := 0; period <= 1; period++ {
+			week := monday.AddDate(0, 0, 7*r.Interval*period)
+			for _, day := range days {
+				if candidate := week.AddDate(0, 0, weekdayOffset(day)); candidate.After(current) {
+					return candidate, true
+				}
+			}
+		}
+
+	case RecurMonthly:
+		days := r.ByMonthDay
+		if len(days) == 0 {
+			days = []int{current.Day()}
+		}
+		for period := 0; period < maxRecurrencePeriodsAhead; period++ {
+			first := time.Date(current.Year(), current.Month()+time.Month(r.Interval*period), 1, 0, 0, 0, 0, time.UTC)
+			length := time.Date(first.Year(), first.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+
+			// months too short for a day are skipped, like RFC 5545 does
+			var candidates []time.Time
+			for _, day := range days {
+				if day < 0 {
+					day = length + day + 1
+				}
+				if day >= 1 && day <= length {
+					candidates = append(candidates, at(first.Year(), first.Month(), day))
+				}
+			}
+			slices.SortFunc(candidates, func(a, b time.Time) int { return a.Compare(b) })
+
+			for _, candidate := range candidates {
+				if candidate.After(current) {
+					return candidate, true
+				}
+			}
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// weekdayOffset is the position of a weekday in a week starting on Monday
+func weekdayOffset(day time.Weekday) int {
+	return (int(day) + 6) % 7
+}
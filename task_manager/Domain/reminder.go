@@ -0,0 +1,43 @@
+package domain
+
+import "time"
+
+// ReminderKind tells whether a reminder is sent before or after a task's due date
+type ReminderKind string
+
+const (
+	ReminderDueSoon ReminderKind = "due_soon"
+	ReminderOverdue ReminderKind = "overdue"
+)
+
+// defaults of the reminder scheduler, unless configured otherwise
+const (
+	DefaultReminderLeadTime = 24 * time.Hour  // how long before the due date a task is reminded of
+	DefaultReminderInterval = 5 * time.Minute // how often due dates are checked
+	// tasks overdue for longer than this are no longer reminded of, e.g. after a long downtime
+	ReminderLookback = 7 * 24 * time.Hour
+)
+
+// Reminder is a notice that a task is due soon or overdue.
+// A reminder is sent once per task, kind and due date, moving the due date makes the task due again.
+type Reminder struct {
+	Key        string       `json:"key" bson:"reminder_key"`
+	Kind       ReminderKind `json:"kind" bson:"kind"`
+	TaskID     string       `json:"task_id" bson:"task_id"`
+	Title      string       `json:"title" bson:"title"`
+	AssigneeID string       `json:"assignee_id,omitempty" bson:"assignee_id,omitempty"`
+	DueDate    time.Time    `json:"due_date" bson:"due_date"`
+	SentAt     time.Time    `json:"sent_at" bson:"sent_at"`
+}
+
+// NewReminder creates the reminder of the given kind for a task, it is not sent yet
+func NewReminder(kind ReminderKind, task Task) Reminder {
+	return Reminder{
+		Key:        task.ID + "/" + string(kind) + "/" + task.DueDate.UTC().Format(time.RFC3339Nano),
+		Kind:       kind,
+		TaskID:     task.ID,
+		Title:      task.Title,
+		AssigneeID: task.AssigneeID,
+		DueDate:    task.DueDate,
+	}
+}
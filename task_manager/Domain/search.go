@@ -0,0 +1,297 @@
+package domain
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// limits applied to full-text search queries
+const (
+	MaxSearchQueryWords = 32
+	SearchSnippetLength = 160 // approximate length of a description snippet, in runes
+)
+
+// markers wrapped around the matched words of a snippet
+const (
+	SearchHighlightStart = "<mark>"
+	SearchHighlightEnd   = "</mark>"
+)
+
+// TaskSearchQuery is a parsed full-text search query.
+// A task matches when it contains at least one of the Terms(if any), every phrase and
+// a word starting with every prefix.
+type TaskSearchQuery struct {
+	Terms    []string   // plain words
+	Phrases  [][]string // "quoted phrases", as their words
+	Prefixes []string   // words written with a trailing *, e.g. deploy*
+	Limit    int64
+	Offset   int64
+}
+
+// TaskSearchHighlights are HTML-safe fragments with the matched words wrapped in <mark>
+type TaskSearchHighlights struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+// TaskSearchHit is a task matching a search query along with its relevance
+type TaskSearchHit struct {
+	Task       Task                 `json:"task"`
+	Score      float64              `json:"score"`
+	Highlights TaskSearchHighlights `json:"highlights"`
+}
+
+// TaskSearchResult is one page of search hits, best matches first
+type TaskSearchResult struct {
+	Hits   []TaskSearchHit `json:"hits"`
+	Total  int64           `json:"total"`
+	Limit  int64           `json:"limit"`
+	Offset int64           `json:"offset"`
+}
+
+// ParseTaskSearchQuery reads words, "quoted phrases" and prefix* words from a raw query
+func ParseTaskSearchQuery(raw string) (TaskSearchQuery, error) {
+
+	var query TaskSearchQuery
+	seen := make(map[string]bool)
+
+	addTerm := func(word string) {
+		if !seen[word] {
+			seen[word] = true
+			query.Terms = append(query.Terms, word)
+		}
+	}
+
+	// every odd part was inside quotes, an unterminated quote runs to the end
+	for i, part := range strings.Split(raw, `"`) {
+		if i%2 == 1 {
+			if words := TokenizeText(part); len(words) > 0 {
+				query.Phrases = append(query.Phrases, words)
+			}
+			continue
+		}
+
+		for _, field := range strings.Fields(part) {
+			words := TokenizeText(field)
+			if len(words) == 0 {
+				continue
+			}
+			// only the last word of e.g. "front-end*" is a prefix
+			if strings.HasSuffix(field, "*") {
+				query.Prefixes = append(query.Prefixes, words[len(words)-1])
+				words = words[:len(words)-1]
+			}
+			for _, word := range words {
+				addTerm(word)
+			}
+		}
+	}
+
+	words := len(query.Terms) + len(query.Prefixes)
+	for _, phrase := range query.Phrases {
+		words += len(phrase)
+	}
+	if words == 0 {
+		return TaskSearchQuery{}, fmt.Errorf("%w: search query must contain at least one word", ErrValidation)
+	}
+	if words > MaxSearchQueryWords {
+		return TaskSearchQuery{}, fmt.Errorf("%w: search query must not contain more than %d words", ErrValidation, MaxSearchQueryWords)
+	}
+
+	return query, nil
+}
+
+// TokenizeText splits text into lowercase words made of letters and digits
+func TokenizeText(text string) []string {
+	tokens := textTokens(text)
+
+	words := make([]string, len(tokens))
+	for i, token := range tokens {
+		words[i] = token.word
+	}
+
+	return words
+}
+
+// MatchesPrefix reports whether word starts with one of the query's prefixes
+func (q TaskSearchQuery) MatchesPrefix(word string) bool {
+	for _, prefix := range q.Prefixes {
+		if strings.HasPrefix(word, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Highlights builds the title and description fragments shown for a matching task
+func (q TaskSearchQuery) Highlights(task Task) TaskSearchHighlights {
+	return TaskSearchHighlights{
+		Title:       q.highlight(task.Title, 0),
+		Description: q.highlight(task.Description, SearchSnippetLength),
+	}
+}
+
+// textToken is a word of a text and its byte offsets
+type textToken struct {
+	word       string
+	start, end int
+}
+
+func textTokens(text string) []textToken {
+
+	var tokens []textToken
+
+	start := -1
+	for i, r := range text {
+		isWordRune := unicode.IsLetter(r) || unicode.IsDigit(r)
+		if isWordRune && start < 0 {
+			start = i
+		}
+		if !isWordRune && start >= 0 {
+			tokens = append(tokens, textToken{word: strings.ToLower(text[start:i]), start: start, end: i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, textToken{word: strings.ToLower(text[start:]), start: start, end: len(text)})
+	}
+
+	return tokens
+}
+
+// highlight marks the matches in text, a positive length cuts the text down to a
+// snippet of about that many runes around the first match
+func (q TaskSearchQuery) highlight(text string, length int) string {
+
+	tokens := textTokens(text)
+	spans := q.matchSpans(tokens)
+
+	from, to := 0, len(text)
+	if length > 0 && utf8.RuneCountInString(text) > length {
+		from, to = snippetWindow(text, tokens, spans, length)
+	}
+
+	var builder strings.Builder
+	if from > 0 {
+		builder.WriteString("…")
+	}
+
+	cursor := from
+	for _, span := range spans {
+		if span[0] < from || span[1] > to {
+			continue
+		}
+		builder.WriteString(html.EscapeString(text[cursor:span[0]]))
+		builder.WriteString(SearchHighlightStart)
+		builder.WriteString(html.EscapeString(text[span[0]:span[1]]))
+		builder.WriteString(SearchHighlightEnd)
+		cursor = span[1]
+	}
+	builder.WriteString(html.EscapeString(text[cursor:to]))
+
+	if to < len(text) {
+		builder.WriteString("…")
+	}
+
+	return builder.String()
+}
+
+// matchSpans returns the sorted, non-overlapping byte ranges of text matched by the query
+func (q TaskSearchQuery) matchSpans(tokens []textToken) [][2]int {
+
+	terms := make(map[string]bool, len(q.Terms))
+	for _, term := range q.Terms {
+		terms[term] = true
+	}
+
+	var spans [][2]int
+	for i, token := range tokens {
+		if terms[token.word] || q.MatchesPrefix(token.word) {
+			spans = append(spans, [2]int{token.start, token.end})
+		}
+
+		// a phrase is marked as a whole
+		for _, phrase := range q.Phrases {
+			if i+len(phrase) <= len(tokens) && phraseAt(tokens, i, phrase) {
+				spans = append(spans, [2]int{token.start, tokens[i+len(phrase)-1].end})
+			}
+		}
+	}
+
+	sort.Slice(spans, func(i, j int) bool { return spans[i][0] < spans[j][0] })
+
+	var merged [][2]int
+	for _, span := range spans {
+		if n := len(merged); n > 0 && span[0] < merged[n-1][1] {
+			merged[n-1][1] = max(merged[n-1][1], span[1])
+			continue
+		}
+		merged = append(merged, span)
+	}
+
+	return merged
+}
+
+func phraseAt(tokens []textToken, i int, phrase []string) bool {
+	for j, word := range phrase {
+		if tokens[i+j].word != word {
+			return false
+		}
+	}
+	return true
+}
+
+// snippetWindow picks the byte range of a snippet, starting a little before the first
+// match and cut at word boundaries
+func snippetWindow(text string, tokens []textToken, spans [][2]int, length int) (int, int) {
+
+	from := 0
+	if len(spans) > 0 {
+		// keep roughly a third of the snippet as context before the match
+		from = spans[0][0]
+		for context := length / 3; context > 0 && from > 0; context-- {
+			_, size := utf8.DecodeLastRuneInString(text[:from])
+			from -= size
+		}
+	}
+
+	to := from
+	for runes := 0; runes < length && to < len(text); runes++ {
+		_, size := utf8.DecodeRuneInString(text[to:])
+		to += size
+	}
+
+	// don't cut words in half
+	for _, token := range tokens {
+		if token.start < from && from < token.end {
+			from = token.start
+		}
+		if token.start < to && to < token.end {
+			to = token.start
+		}
+	}
+
+	// a single word longer than the snippet is kept whole
+	if to <= from {
+		to = len(text)
+		for _, token := range tokens {
+			if token.start >= from {
+				to = token.end
+				break
+			}
+		}
+	}
+
+	// never cut a match in half either
+	for _, span := range spans {
+		if span[0] < to && to < span[1] {
+			to = span[1]
+		}
+	}
+
+	return from, to
+}
@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+// how many of the latest task events are kept for clients resuming their stream, unless configured otherwise
+const DefaultTaskEventBuffer = 1000
+
+// TaskEvent is one change on the task event stream, it uses the same event names as the webhooks.
+// Task is the task after the change, or before it for deletions.
+type TaskEvent struct {
+	ID         string       `json:"id"`
+	Event      WebhookEvent `json:"event"`
+	OccurredAt time.Time    `json:"occurred_at"`
+	ActorID    string       `json:"actor_id"`
+	Task       Task         `json:"task"`
+}
+
+// TaskEventFilter narrows a task event stream down, empty fields match every task
+type TaskEventFilter struct {
+	Status     TaskStatus
+	AssigneeID string
+}
+
+// Matches reports whether the task is one the stream was asked for
+func (f TaskEventFilter) Matches(task Task) bool {
+	if f.Status != "" && task.Status != f.Status {
+		return false
+	}
+	if f.AssigneeID != "" && task.AssigneeID != f.AssigneeID {
+		return false
+	}
+	return true
+}
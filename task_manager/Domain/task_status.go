@@ -0,0 +1,93 @@
+package domain
+
+import "strings"
+
+// TaskStatus is the closed set of states a task can be in
+type TaskStatus string
+
+const (
+	StatusTodo       TaskStatus = "todo"
+	StatusInProgress TaskStatus = "in_progress"
+	StatusBlocked    TaskStatus = "blocked"
+	StatusDone       TaskStatus = "done"
+	StatusCancelled  TaskStatus = "cancelled"
+)
+
+// taskStatusTransitions lists the states each status can move to
+var taskStatusTransitions = map[TaskStatus][]TaskStatus{
+	StatusTodo:       {StatusInProgress, StatusBlocked, StatusDone, StatusCancelled},
+	StatusInProgress: {StatusTodo, StatusBlocked, StatusDone, StatusCancelled},
+	StatusBlocked:    {StatusTodo, StatusInProgress, StatusCancelled},
+	StatusDone:       {StatusTodo}, // reopen
+	StatusCancelled:  {StatusTodo}, // reopen
+}
+
+// legacyTaskStatuses maps the free-form statuses stored before the closed set existed
+var legacyTaskStatuses = map[string]TaskStatus{
+	"pending":     StatusTodo,
+	"open":        StatusTodo,
+	"new":         StatusTodo,
+	"to_do":       StatusTodo,
+	"inprogress":  StatusInProgress,
+	"in_progress": StatusInProgress,
+	"started":     StatusInProgress,
+	"doing":       StatusInProgress,
+	"active":      StatusInProgress,
+	"on_hold":     StatusBlocked,
+	"waiting":     StatusBlocked,
+	"completed":   StatusDone,
+	"complete":    StatusDone,
+	"finished":    StatusDone,
+	"closed":      StatusDone,
+	"resolved":    StatusDone,
+	"canceled":    StatusCancelled,
+	"abandoned":   StatusCancelled,
+}
+
+// IsValid reports whether the status belongs to the closed status set
+func (s TaskStatus) IsValid() bool {
+	_, ok := taskStatusTransitions[s]
+	return ok
+}
+
+// NextStatuses returns the states a task in this status may move to
+func (s TaskStatus) NextStatuses() []TaskStatus {
+	return append([]TaskStatus(nil), taskStatusTransitions[s]...)
+}
+
+// CanTransitionTo reports whether a task may move from this status to next
+func (s TaskStatus) CanTransitionTo(next TaskStatus) bool {
+	for _, allowed := range taskStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsFinished reports whether no more work is expected on a task in this status
+func (s TaskStatus) IsFinished() bool {
+	return s == StatusDone || s == StatusCancelled
+}
+
+// NormalizeTaskStatus maps a stored, possibly legacy, status onto the closed status set.
+// The second return value is false when the status cannot be recognized.
+func NormalizeTaskStatus(raw string) (TaskStatus, bool) {
+	key := strings.ToLower(strings.TrimSpace(raw))
+	key = strings.NewReplacer("-", "_", " ", "_").Replace(key)
+
+	if status := TaskStatus(key); status.IsValid() {
+		return status, true
+	}
+	status, ok := legacyTaskStatuses[key]
+	return status, ok
+}
+
+// JoinTaskStatuses formats statuses as a comma separated list for error messages
+func JoinTaskStatuses(statuses []TaskStatus) string {
+	names := make([]string, len(statuses))
+	for i, status := range statuses {
+		names[i] = string(status)
+	}
+	return strings.Join(names, ", ")
+}
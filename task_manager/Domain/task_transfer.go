@@ -0,0 +1,67 @@
+package domain
+
+// TaskFormat is a file format tasks can be exported to and imported from
+type TaskFormat string
+
+const (
+	TaskFormatCSV    TaskFormat = "csv"
+	TaskFormatJSON   TaskFormat = "json"   // a single array of tasks
+	TaskFormatNDJSON TaskFormat = "ndjson" // one task per line
+)
+
+// IsValid reports whether tasks can be exported to and imported from the format
+func (f TaskFormat) IsValid() bool {
+	switch f {
+	case TaskFormatCSV, TaskFormatJSON, TaskFormatNDJSON:
+		return true
+	}
+	return false
+}
+
+// limits on a single import
+const (
+	MaxImportTasks = 5000
+	MaxImportBytes = 10 << 20
+)
+
+// TaskImportRow is one task read from an import file, Row counts the tasks from 1.
+// Err is set when the row couldn't be read, the task is empty then.
+type TaskImportRow struct {
+	Row  int
+	Task Task
+	Err  error
+}
+
+// TaskImportAction is what an import did, or would do on a dry run, with a row
+type TaskImportAction string
+
+const (
+	TaskImportCreated TaskImportAction = "created"
+	TaskImportUpdated TaskImportAction = "updated"
+)
+
+// TaskImportOptions control an import. Upsert updates the tasks whose id already exists instead of
+// rejecting those rows, DryRun checks every row without writing anything.
+type TaskImportOptions struct {
+	Upsert bool
+	DryRun bool
+}
+
+// TaskImportRowResult reports what happened to one row of an import
+type TaskImportRowResult struct {
+	Row    int              `json:"row"`
+	ID     string           `json:"id,omitempty"`
+	Status BulkItemStatus   `json:"status"`
+	Action TaskImportAction `json:"action,omitempty"`
+	Error  string           `json:"error,omitempty"`
+}
+
+// TaskImportResult is the outcome of an import, with one result per row in file order
+type TaskImportResult struct {
+	DryRun  bool                  `json:"dry_run"`
+	Upsert  bool                  `json:"upsert"`
+	Created int                   `json:"created"`
+	Updated int                   `json:"updated"`
+	Failed  int                   `json:"failed"`
+	Results []TaskImportRowResult `json:"results"`
+}
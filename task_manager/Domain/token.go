@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// RefreshToken is a single-use token that can be exchanged for a new token pair.
+// Every login starts a new family, each refresh rotates the token within that family.
+type RefreshToken struct {
+	ID         string    `bson:"token_id" json:"-"`
+	FamilyID   string    `bson:"family_id" json:"-"`
+	UserID     string    `bson:"user_id" json:"-"`
+	TokenHash  string    `bson:"token_hash" json:"-"`
+	ExpiresAt  time.Time `bson:"expires_at" json:"-"`
+	CreatedAt  time.Time `bson:"created_at" json:"-"`
+	ReplacedBy string    `bson:"replaced_by" json:"-"` // id of the token issued when this one was rotated
+	Revoked    bool      `bson:"revoked" json:"-"`
+}
+
+// RevokedToken records an access token(by its jti) that must no longer be accepted
+type RevokedToken struct {
+	ID        string    `bson:"token_id"`
+	ExpiresAt time.Time `bson:"expires_at"`
+}
+
+// TokenPair is handed to the client on login and on every refresh
+type TokenPair struct {
+	AccessToken  string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"` // access token lifetime in seconds
+}
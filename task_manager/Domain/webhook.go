@@ -0,0 +1,198 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+)
+
+// WebhookEvent names a change webhooks can subscribe to
+type WebhookEvent string
+
+const (
+	WebhookTaskCreated  WebhookEvent = "task.created"
+	WebhookTaskUpdated  WebhookEvent = "task.updated"
+	WebhookTaskDeleted  WebhookEvent = "task.deleted"
+	WebhookUserPromoted WebhookEvent = "user.promoted"
+)
+
+// IsValid reports whether webhooks can subscribe to the event
+func (e WebhookEvent) IsValid() bool {
+	switch e {
+	case WebhookTaskCreated, WebhookTaskUpdated, WebhookTaskDeleted, WebhookUserPromoted:
+		return true
+	}
+	return false
+}
+
+// WebhookEventForAudit maps an audited change to the event published for it,
+// false means the change isn't published
+func WebhookEventForAudit(action AuditAction) (WebhookEvent, bool) {
+	switch action {
+	case AuditTaskCreated:
+		return WebhookTaskCreated, true
+	case AuditTaskUpdated, AuditTaskAssigned, AuditTaskUnassigned, AuditTaskRestored:
+		return WebhookTaskUpdated, true
+	case AuditTaskDeleted:
+		return WebhookTaskDeleted, true
+	case AuditUserPromoted:
+		return WebhookUserPromoted, true
+	}
+	return "", false
+}
+
+// Webhook is an admin-managed subscription, every subscribed event is POSTed to URL
+// with a signature computed from Secret. The secret is never sent back to clients.
+type Webhook struct {
+	ID        string         `json:"id" bson:"webhook_id"`
+	URL       string         `json:"url" bson:"url"`
+	Events    []WebhookEvent `json:"events" bson:"events"`
+	Secret    string         `json:"-" bson:"secret"`
+	Active    bool           `json:"active" bson:"active"`
+	CreatedBy string         `json:"created_by" bson:"created_by"`
+	CreatedAt time.Time      `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at" bson:"updated_at"`
+}
+
+// Subscribes reports whether the webhook wants to receive the event
+func (w Webhook) Subscribes(event WebhookEvent) bool {
+	if !w.Active {
+		return false
+	}
+	for _, subscribed := range w.Events {
+		if subscribed == event {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookChanges holds the fields of a webhook update, nil fields are left as they are
+type WebhookChanges struct {
+	URL    *string        `json:"url"`
+	Events []WebhookEvent `json:"events"`
+	Secret *string        `json:"secret"`
+	Active *bool          `json:"active"`
+}
+
+// limits on webhook subscriptions
+const (
+	MaxWebhookURLLength    = 2048
+	MinWebhookSecretLength = 16
+	MaxWebhookSecretLength = 256
+)
+
+// ValidateWebhookURL checks that deliveries can be POSTed to the URL
+func ValidateWebhookURL(rawURL string) error {
+
+	if rawURL == "" {
+		return fmt.Errorf("%w: url is required", ErrValidation)
+	}
+	if len(rawURL) > MaxWebhookURLLength {
+		return fmt.Errorf("%w: url must be at most %d characters", ErrValidation, MaxWebhookURLLength)
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http or https URL", ErrValidation)
+	}
+
+	return nil
+}
+
+// ValidateWebhookSecret checks the length of a signing secret
+func ValidateWebhookSecret(secret string) error {
+
+	if len(secret) < MinWebhookSecretLength || len(secret) > MaxWebhookSecretLength {
+		return fmt.Errorf("%w: secret must be between %d and %d characters", ErrValidation, MinWebhookSecretLength, MaxWebhookSecretLength)
+	}
+
+	return nil
+}
+
+// NormalizeWebhookEvents checks a set of subscribed events, repeats are dropped and the events sorted
+func NormalizeWebhookEvents(events []WebhookEvent) ([]WebhookEvent, error) {
+
+	if len(events) == 0 {
+		return nil, fmt.Errorf("%w: at least one event is required", ErrValidation)
+	}
+
+	seen := make(map[WebhookEvent]bool, len(events))
+	normalized := make([]WebhookEvent, 0, len(events))
+	for _, event := range events {
+		if !event.IsValid() {
+			return nil, fmt.Errorf("%w: unknown event %q, expected one of: %s, %s, %s, %s", ErrValidation,
+				event, WebhookTaskCreated, WebhookTaskUpdated, WebhookTaskDeleted, WebhookUserPromoted)
+		}
+		if !seen[event] {
+			seen[event] = true
+			normalized = append(normalized, event)
+		}
+	}
+
+	sort.Slice(normalized, func(i, j int) bool {
+		return normalized[i] < normalized[j]
+	})
+
+	return normalized, nil
+}
+
+// WebhookPayload is the JSON body POSTed for an event. ID identifies the event,
+// it stays the same when a delivery is retried or redelivered.
+type WebhookPayload struct {
+	ID         string          `json:"id"`
+	Event      WebhookEvent    `json:"event"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	ActorID    string          `json:"actor_id"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// DeliveryStatus is the state of a webhook delivery
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	DeliveryFailed    DeliveryStatus = "failed"
+)
+
+// WebhookDelivery is one entry of the delivery log, it tracks the attempts
+// to POST one event to one webhook
+type WebhookDelivery struct {
+	ID             string          `json:"id" bson:"delivery_id"`
+	WebhookID      string          `json:"webhook_id" bson:"webhook_id"`
+	EventID        string          `json:"event_id" bson:"event_id"`
+	Event          WebhookEvent    `json:"event" bson:"event"`
+	Payload        json.RawMessage `json:"payload" bson:"payload"`
+	Status         DeliveryStatus  `json:"status" bson:"status"`
+	Attempts       int             `json:"attempts" bson:"attempts"`
+	ResponseStatus int             `json:"response_status,omitempty" bson:"response_status,omitempty"`
+	LastError      string          `json:"last_error,omitempty" bson:"last_error,omitempty"`
+	RedeliveryOf   string          `json:"redelivery_of,omitempty" bson:"redelivery_of,omitempty"`
+	CreatedAt      time.Time       `json:"created_at" bson:"created_at"`
+	LastAttemptAt  *time.Time      `json:"last_attempt_at,omitempty" bson:"last_attempt_at,omitempty"`
+}
+
+// defaults of the webhook delivery, see WebhookDispatcher
+const (
+	DefaultWebhookMaxAttempts  = 6
+	DefaultWebhookRetryBackoff = 30 * time.Second
+	MaxWebhookRetryBackoff     = time.Hour
+	DefaultWebhookTimeout      = 10 * time.Second
+)
+
+// pagination limits applied when listing deliveries
+const (
+	DefaultDeliveryPageLimit int64 = 20
+	MaxDeliveryPageLimit     int64 = 100
+)
+
+// DeliveryPage is one page of a webhook's delivery log, newest first
+type DeliveryPage struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+	Total      int64             `json:"total"`
+	Limit      int64             `json:"limit"`
+	Offset     int64             `json:"offset"`
+}
@@ -1,16 +1,69 @@
 package infrastructure
 
 import (
-	"fmt"
+	"context"
+	"errors"
 	"net/http"
 	"strings"
 	domain "taskmanager/Domain"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
 )
 
-func AuthMiddleware(jwtSecret string) gin.HandlerFunc {
+// TokenRevocationChecker reports whether an access token has been revoked(e.g. on logout),
+// or its user changed their password since it was issued
+type TokenRevocationChecker interface {
+	IsAccessTokenRevoked(ctx context.Context, tokenId string) (bool, error)
+	// CheckAccessTokenUser returns domain.ErrInvalidToken for a token issued before the last password change,
+	// and domain.ErrPasswordChangeRequired while the user has to choose a new password
+	CheckAccessTokenUser(ctx context.Context, userId string, issuedAt time.Time) error
+}
+
+// principalKey is the context key AuthMiddleware stores the Principal under
+const principalKey = "principal"
+
+// Principal is the authenticated caller of a request, as named by its access token
+type Principal struct {
+	UserID   string
+	UserName string
+	Role     domain.UserRole
+	// the token id and expiry are needed to revoke the token on logout
+	TokenID   string
+	ExpiresAt time.Time
+}
+
+// Actor is the principal as the usecases know it
+func (p Principal) Actor() domain.Actor {
+	return domain.Actor{UserID: p.UserID, Role: p.Role}
+}
+
+// SetPrincipal stores the authenticated caller for subsequent handlers
+func SetPrincipal(ctx *gin.Context, principal Principal) {
+	ctx.Set(principalKey, principal)
+}
+
+// PrincipalFromContext returns the caller AuthMiddleware authenticated, false on unauthenticated routes
+func PrincipalFromContext(ctx *gin.Context) (Principal, bool) {
+	value, exists := ctx.Get(principalKey)
+	if !exists {
+		return Principal{}, false
+	}
+	principal, ok := value.(Principal)
+	return principal, ok
+}
+
+func AuthMiddleware(jwtKeys *JWTKeys, settings JWTSettings, revocations TokenRevocationChecker) gin.HandlerFunc {
+	return authMiddleware(jwtKeys, settings, revocations, false)
+}
+
+// PasswordChangeAuthMiddleware authenticates like AuthMiddleware, but also lets in the users
+// who have to change their password, for the route they change it on
+func PasswordChangeAuthMiddleware(jwtKeys *JWTKeys, settings JWTSettings, revocations TokenRevocationChecker) gin.HandlerFunc {
+	return authMiddleware(jwtKeys, settings, revocations, true)
+}
+
+func authMiddleware(jwtKeys *JWTKeys, settings JWTSettings, revocations TokenRevocationChecker, allowPasswordChange bool) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		authHeader := ctx.GetHeader("Authorization")
 		if authHeader == "" {
@@ -24,37 +77,48 @@ func AuthMiddleware(jwtSecret string) gin.HandlerFunc {
 			return
 		}
 
-		token, err := jwt.Parse(authParts[1], func(token *jwt.Token) (interface{}, error) {
-			// Check the algorithm (safety measure against "none" attacks)
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-			}
-
-			return []byte(jwtSecret), nil
-		})
-
-		if err != nil || !token.Valid {
+		// checks the signature, the issuer, the audience and the token times
+		claims, err := ParseJWT(jwtKeys, settings, authParts[1])
+		if err != nil {
 			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid JWT"})
 			return
 		}
 
-		// check if token is valid and cast the claims
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims format"})
+		// reject tokens that were revoked on logout
+		revoked, err := revocations.IsAccessTokenRevoked(ctx.Request.Context(), claims.ID)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to check token revocation"})
+			return
+		}
+		if revoked {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Token has been revoked"})
 			return
 		}
 
-		// extract role
-		roleFloat, ok := claims["role"].(float64)
-		if !ok {
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Role claim missing or invalid"})
+		// a password change or reset ends the sessions started before, not only their refresh tokens
+		err = revocations.CheckAccessTokenUser(ctx.Request.Context(), claims.UserID, claims.IssuedAt.Time)
+		switch {
+		case err == nil:
+		case errors.Is(err, domain.ErrInvalidToken):
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Token has been revoked"})
+			return
+		case errors.Is(err, domain.ErrPasswordChangeRequired):
+			if !allowPasswordChange {
+				ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": err.Error(), "password_change_required": true})
+				return
+			}
+		default:
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to check token revocation"})
 			return
 		}
 
-		// set role and userID for subsequent handlers
-		ctx.Set("role", domain.UserRole(roleFloat))
-		ctx.Set("user_id", claims["user_id"])
+		SetPrincipal(ctx, Principal{
+			UserID:    claims.UserID,
+			UserName:  claims.UserName,
+			Role:      *claims.Role,
+			TokenID:   claims.ID,
+			ExpiresAt: claims.ExpiresAt.Time,
+		})
 
 		ctx.Next()
 	}
@@ -63,19 +127,13 @@ func AuthMiddleware(jwtSecret string) gin.HandlerFunc {
 
 func AuthorizationMiddleware(requiredRole domain.UserRole) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		userRoleVal, exists := ctx.Get("role")
+		principal, exists := PrincipalFromContext(ctx)
 		if !exists {
 			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
 			return
 		}
 
-		userRole, ok := userRoleVal.(domain.UserRole)
-		if !ok {
-			ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Invalid role format in context"})
-			return
-		}
-
-		if userRole < requiredRole {
+		if principal.Role < requiredRole {
 			ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
 			return
 		}
@@ -0,0 +1,346 @@
+package infrastructure
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// minRSAKeyBits is the smallest RSA modulus accepted for signing or verifying tokens
+const minRSAKeyBits = 2048
+
+// JSONWebKey is the public half of a token signing key (RFC 7517)
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	Modulus   string `json:"n,omitempty"`
+	Exponent  string `json:"e,omitempty"`
+}
+
+// JSONWebKeySet is the document served at /.well-known/jwks.json
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// jwtVerificationKey is a public key access tokens may be signed with
+type jwtVerificationKey struct {
+	id     string
+	method jwt.SigningMethod
+	key    crypto.PublicKey
+}
+
+// JWTKeys signs access tokens with one key and verifies them against every key that is still trusted.
+// HS256 signs with a shared secret, RS256 and EdDSA with a private key whose public half is published
+// in the JWKS so other services can verify tokens without holding the secret.
+type JWTKeys struct {
+	method     jwt.SigningMethod
+	signingKey interface{}
+	keyID      string
+
+	// secret is only set for HS256, tokens signed with it carry no kid
+	secret []byte
+
+	// the signing key comes first, then the keys kept around for rotation
+	verificationKeys []jwtVerificationKey
+}
+
+// NewHMACJWTKeys signs and verifies access tokens with a shared HS256 secret
+func NewHMACJWTKeys(secret string) (*JWTKeys, error) {
+	if secret == "" {
+		return nil, errors.New("the JWT secret is empty")
+	}
+
+	return &JWTKeys{
+		method:     jwt.SigningMethodHS256,
+		signingKey: []byte(secret),
+		secret:     []byte(secret),
+	}, nil
+}
+
+// NewJWTKeys signs access tokens with an RSA (RS256) or Ed25519 (EdDSA) key.
+// Tokens signed with any of the verification keys are accepted too, which lets a retired
+// signing key keep verifying until the tokens it signed have expired.
+// Every key is identified by its RFC 7638 thumbprint, used as the kid header.
+func NewJWTKeys(signingKey crypto.Signer, verificationKeys ...crypto.PublicKey) (*JWTKeys, error) {
+	method, err := signingMethodFor(signingKey.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	keys := &JWTKeys{
+		method:     method,
+		signingKey: signingKey,
+		keyID:      jwkThumbprint(signingKey.Public()),
+	}
+	keys.verificationKeys = append(keys.verificationKeys, jwtVerificationKey{id: keys.keyID, method: method, key: signingKey.Public()})
+
+	for _, key := range verificationKeys {
+		method, err := signingMethodFor(key)
+		if err != nil {
+			return nil, err
+		}
+
+		id := jwkThumbprint(key)
+		if keys.hasKey(id) {
+			continue
+		}
+		keys.verificationKeys = append(keys.verificationKeys, jwtVerificationKey{id: id, method: method, key: key})
+	}
+
+	return keys, nil
+}
+
+// LoadJWTKeys reads the signing key and the verification keys from PEM files.
+// Private keys may be PKCS#8 or PKCS#1 (RSA), public keys PKIX or PKCS#1 (RSA).
+func LoadJWTKeys(privateKeyFile string, verificationKeyFiles []string) (*JWTKeys, error) {
+	data, err := os.ReadFile(privateKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading JWT private key: %w", err)
+	}
+	signingKey, err := parsePrivateKeyPEM(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", privateKeyFile, err)
+	}
+
+	var verificationKeys []crypto.PublicKey
+	for _, file := range verificationKeyFiles {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("error reading JWT verification key: %w", err)
+		}
+		key, err := parsePublicKeyPEM(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		verificationKeys = append(verificationKeys, key)
+	}
+
+	keys, err := NewJWTKeys(signingKey, verificationKeys...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", privateKeyFile, err)
+	}
+
+	return keys, nil
+}
+
+// JWTKeysFromEnv signs with the key in JWT_PRIVATE_KEY_FILE, verifying with the comma separated
+// JWT_VERIFICATION_KEY_FILES as well. Without a private key, tokens are signed with JWT_SECRET.
+func JWTKeysFromEnv() (*JWTKeys, error) {
+	var verificationKeyFiles []string
+	for _, file := range strings.Split(os.Getenv("JWT_VERIFICATION_KEY_FILES"), ",") {
+		if file = strings.TrimSpace(file); file != "" {
+			verificationKeyFiles = append(verificationKeyFiles, file)
+		}
+	}
+
+	if privateKeyFile := os.Getenv("JWT_PRIVATE_KEY_FILE"); privateKeyFile != "" {
+		return LoadJWTKeys(privateKeyFile, verificationKeyFiles)
+	}
+	if len(verificationKeyFiles) > 0 {
+		return nil, errors.New("JWT_VERIFICATION_KEY_FILES requires JWT_PRIVATE_KEY_FILE to be set")
+	}
+
+	jwtSecret := os.Getenv("JWT_SECRET")
+	if jwtSecret == "" {
+		return nil, errors.New("JWT_SECRET environment variable is not set or empty")
+	}
+
+	return NewHMACJWTKeys(jwtSecret)
+}
+
+// Algorithm is the alg new tokens are signed with
+func (k *JWTKeys) Algorithm() string {
+	return k.method.Alg()
+}
+
+// Algorithms lists every alg a token may be signed with
+func (k *JWTKeys) Algorithms() []string {
+	var algorithms []string
+	if k.secret != nil {
+		algorithms = append(algorithms, jwt.SigningMethodHS256.Alg())
+	}
+	for _, key := range k.verificationKeys {
+		if !slices.Contains(algorithms, key.method.Alg()) {
+			algorithms = append(algorithms, key.method.Alg())
+		}
+	}
+	return algorithms
+}
+
+// Keyfunc picks the key a token is verified with, by its kid header
+func (k *JWTKeys) Keyfunc(token *jwt.Token) (interface{}, error) {
+	keyID, _ := token.Header["kid"].(string)
+
+	// only HS256 tokens are signed without a kid
+	if keyID == "" {
+		if k.secret == nil || token.Method.Alg() != jwt.SigningMethodHS256.Alg() {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return k.secret, nil
+	}
+
+	for _, key := range k.verificationKeys {
+		if key.id != keyID {
+			continue
+		}
+		// the algorithm must be the one of the key (safety measure against algorithm confusion)
+		if token.Method.Alg() != key.method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return key.key, nil
+	}
+
+	return nil, fmt.Errorf("unknown key id %q", keyID)
+}
+
+// JWKS returns the public verification keys. It is empty for HS256, the secret is never published.
+func (k *JWTKeys) JWKS() JSONWebKeySet {
+	set := JSONWebKeySet{Keys: []JSONWebKey{}}
+	for _, key := range k.verificationKeys {
+		jwk := JSONWebKey{KeyID: key.id, Use: "sig", Algorithm: key.method.Alg()}
+		switch public := key.key.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.Modulus, jwk.Exponent = rsaJWKParams(public)
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
+
+// JWKSHandler serves the public verification keys, so other services can verify access tokens
+func JWKSHandler(keys *JWTKeys) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		// verifiers may cache the keys for a while, a new key is published before it signs anything
+		ctx.Header("Cache-Control", "public, max-age=300")
+		ctx.JSON(http.StatusOK, keys.JWKS())
+	}
+}
+
+// sign signs the claims, naming the signing key in the kid header
+func (k *JWTKeys) sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(k.method, claims)
+	if k.keyID != "" {
+		token.Header["kid"] = k.keyID
+	}
+
+	return token.SignedString(k.signingKey)
+}
+
+func (k *JWTKeys) hasKey(keyID string) bool {
+	for _, key := range k.verificationKeys {
+		if key.id == keyID {
+			return true
+		}
+	}
+	return false
+}
+
+// signingMethodFor returns the algorithm tokens signed with the key use
+func signingMethodFor(key crypto.PublicKey) (jwt.SigningMethod, error) {
+	switch public := key.(type) {
+	case *rsa.PublicKey:
+		if public.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("RSA keys must have at least %d bits", minRSAKeyBits)
+		}
+		return jwt.SigningMethodRS256, nil
+	case ed25519.PublicKey:
+		return jwt.SigningMethodEdDSA, nil
+	default:
+		return nil, fmt.Errorf("unsupported JWT key type %T, use an RSA or Ed25519 key", key)
+	}
+}
+
+// jwkThumbprint is the RFC 7638 thumbprint of the key: the SHA-256 of its required JWK members
+func jwkThumbprint(key crypto.PublicKey) string {
+	var members string
+	switch public := key.(type) {
+	case *rsa.PublicKey:
+		n, e := rsaJWKParams(public)
+		members = fmt.Sprintf(`{"e":"%s","kty":"RSA","n":"%s"}`, e, n)
+	case ed25519.PublicKey:
+		members = fmt.Sprintf(`{"crv":"Ed25519","kty":"OKP","x":"%s"}`, base64.RawURLEncoding.EncodeToString(public))
+	}
+
+	sum := sha256.Sum256([]byte(members))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// rsaJWKParams encodes the modulus and the exponent as unsigned big-endian base64url
+func rsaJWKParams(key *rsa.PublicKey) (string, string) {
+	n := base64.RawURLEncoding.EncodeToString(key.N.Bytes())
+	e := base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes())
+	return n, e
+}
+
+func parsePrivateKeyPEM(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q, expected a private key", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parsing private key: %w", err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported JWT key type %T, use an RSA or Ed25519 key", key)
+	}
+	return signer, nil
+}
+
+func parsePublicKeyPEM(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	var key crypto.PublicKey
+	var err error
+	switch block.Type {
+	case "PUBLIC KEY":
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q, expected a public key", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parsing public key: %w", err)
+	}
+
+	return key, nil
+}
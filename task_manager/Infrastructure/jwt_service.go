@@ -3,35 +3,123 @@ package infrastructure
 import (
 	"errors"
 	"fmt"
-	"os"
 	domain "taskmanager/Domain"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
-func GenerateJWT(userId string, userName string, role domain.UserRole) (string, error) {
+// lifetimes of the issued tokens
+const (
+	AccessTokenTTL  = 15 * time.Minute
+	RefreshTokenTTL = 7 * 24 * time.Hour
+)
+
+// DefaultJWTLeeway is the clock skew tolerated between the services issuing and verifying tokens
+const DefaultJWTLeeway = 30 * time.Second
 
-	// get jwt secret from env varaiable
-	jwtSecret := os.Getenv("JWT_SECRET")
+// JWTSettings name who issues access tokens and who they are meant for.
+// Tokens are only accepted with the same issuer and audience, and when their exp, nbf and iat
+// are no further off than the leeway.
+type JWTSettings struct {
+	Issuer   string
+	Audience string
+	Leeway   time.Duration
+}
 
-	// 2. VALIDATION: Check if the secret is missing or empty
-	if jwtSecret == "" {
-		return "", errors.New("JWT_SECRET environment variable is not set or empty")
+// DefaultJWTSettings returns the settings used when nothing else is configured
+func DefaultJWTSettings() JWTSettings {
+	return JWTSettings{
+		Issuer:   "taskmanager",
+		Audience: "taskmanager",
+		Leeway:   DefaultJWTLeeway,
 	}
+}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"user_id":   userId,
-		"user_name": userName,
-		"role":      role,
-		"exp":       time.Now().Add(time.Hour * 24).Unix(),
-	})
+// Validate reports settings tokens can't be issued or verified with
+func (s JWTSettings) Validate() error {
+	if s.Issuer == "" {
+		return fmt.Errorf("%w: the JWT issuer can't be empty", domain.ErrValidation)
+	}
+	if s.Audience == "" {
+		return fmt.Errorf("%w: the JWT audience can't be empty", domain.ErrValidation)
+	}
+	if s.Leeway < 0 || s.Leeway >= AccessTokenTTL {
+		return fmt.Errorf("%w: the JWT leeway must be between 0 and %s", domain.ErrValidation, AccessTokenTTL)
+	}
+	return nil
+}
+
+// AccessTokenClaims are the claims of an access token, signed by GenerateJWT and verified by AuthMiddleware
+type AccessTokenClaims struct {
+	UserID   string `json:"user_id"`
+	UserName string `json:"user_name"`
+	// a pointer, so a token without a role is told apart from a user token
+	Role *domain.UserRole `json:"role"`
+	jwt.RegisteredClaims
+}
 
-	// sign the token with the secret key
-	jwtToken, err := token.SignedString([]byte(jwtSecret))
+// Validate rejects tokens missing a claim, it runs after the standard claims were checked
+func (c AccessTokenClaims) Validate() error {
+	if c.ID == "" {
+		return errors.New("token id claim missing")
+	}
+	if c.UserID == "" {
+		return errors.New("user id claim missing")
+	}
+	if c.Role == nil || (*c.Role != domain.RoleUser && *c.Role != domain.RoleAdmin) {
+		return errors.New("role claim missing or invalid")
+	}
+	if c.ExpiresAt == nil || c.IssuedAt == nil || c.NotBefore == nil {
+		return errors.New("exp, iat and nbf claims are required")
+	}
+	return nil
+}
+
+// GenerateJWT signs an access token for the user with the current signing key
+func GenerateJWT(keys *JWTKeys, settings JWTSettings, userId string, userName string, role domain.UserRole) (string, error) {
+
+	now := time.Now()
+	claims := AccessTokenClaims{
+		UserID:   userId,
+		UserName: userName,
+		Role:     &role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(), // unique token id, used to revoke the token
+			Issuer:    settings.Issuer,
+			Audience:  jwt.ClaimStrings{settings.Audience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(AccessTokenTTL)),
+		},
+	}
+
+	// sign the token with the signing key, named in the kid header
+	jwtToken, err := keys.sign(claims)
 	if err != nil {
 		return "", fmt.Errorf("error signing token: %w", err)
 	}
 
 	return jwtToken, nil
 }
+
+// ParseJWT verifies the signature and the claims of an access token
+func ParseJWT(keys *JWTKeys, settings JWTSettings, tokenString string) (*AccessTokenClaims, error) {
+	claims := &AccessTokenClaims{}
+
+	// the key is picked by the kid header, and its algorithm must match the one of the token
+	_, err := jwt.ParseWithClaims(tokenString, claims, keys.Keyfunc,
+		jwt.WithValidMethods(keys.Algorithms()),
+		jwt.WithIssuer(settings.Issuer),
+		jwt.WithAudience(settings.Audience),
+		jwt.WithIssuedAt(),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(settings.Leeway),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,82 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	domain "taskmanager/Domain"
+)
+
+// Notifier delivers due-date reminders
+type Notifier interface {
+	Notify(ctx context.Context, reminder domain.Reminder) error
+}
+
+// LogNotifier writes reminders to a logger
+type LogNotifier struct {
+	logger *log.Logger
+}
+
+// NewLogNotifier creates a notifier writing to logger, a nil logger means the standard logger
+func NewLogNotifier(logger *log.Logger) *LogNotifier {
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	return &LogNotifier{logger: logger}
+}
+
+func (n *LogNotifier) Notify(ctx context.Context, reminder domain.Reminder) error {
+	n.logger.Printf("Reminder: %s", reminderSubject(reminder))
+	return nil
+}
+
+// FileNotifier appends every reminder as one JSON line to a file, for other tools to pick up
+type FileNotifier struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileNotifier creates a notifier appending to the file at path, the file is created on the first reminder
+func NewFileNotifier(path string) *FileNotifier {
+	return &FileNotifier{path: path}
+}
+
+func (n *FileNotifier) Notify(ctx context.Context, reminder domain.Reminder) error {
+
+	line, err := json.Marshal(reminder)
+	if err != nil {
+		return fmt.Errorf("failed to encode reminder: %w", err)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	file, err := os.OpenFile(n.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open reminder file: %w", err)
+	}
+
+	_, err = file.Write(append(line, '\n'))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write reminder: %w", err)
+	}
+
+	return nil
+}
+
+// reminderSubject is the one line summary of a reminder
+func reminderSubject(reminder domain.Reminder) string {
+
+	if reminder.Kind == domain.ReminderOverdue {
+		return fmt.Sprintf("task %q (%s) was due %s", reminder.Title, reminder.TaskID, reminder.DueDate.UTC().Format("2006-01-02 15:04 MST"))
+	}
+
+	return fmt.Sprintf("task %q (%s) is due %s", reminder.Title, reminder.TaskID, reminder.DueDate.UTC().Format("2006-01-02 15:04 MST"))
+}
@@ -0,0 +1,85 @@
+package infrastructure
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+	domain "taskmanager/Domain"
+	"unicode"
+	"unicode/utf8"
+)
+
+//go:embed breached_passwords.txt
+var breachedPasswordList string
+
+// breachedPasswords holds the embedded list, lowercased
+var breachedPasswords = func() map[string]struct{} {
+	passwords := make(map[string]struct{})
+	for _, line := range strings.Split(breachedPasswordList, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		passwords[strings.ToLower(line)] = struct{}{}
+	}
+	return passwords
+}()
+
+// IsBreachedPassword reports whether the password is on the list of commonly breached passwords, ignoring case
+func IsBreachedPassword(password string) bool {
+	_, breached := breachedPasswords[strings.ToLower(password)]
+	return breached
+}
+
+// CheckPasswordPolicy returns a *domain.PasswordPolicyError when the policy rejects the new password of a user
+func CheckPasswordPolicy(policy domain.PasswordPolicy, userName string, password string) error {
+
+	// the length is counted in characters, the limit of bcrypt in bytes
+	if utf8.RuneCountInString(password) < policy.MinLength {
+		return &domain.PasswordPolicyError{Reason: fmt.Sprintf("must be at least %d characters", policy.MinLength)}
+	}
+	if len(password) > domain.MaxPasswordBytes {
+		return &domain.PasswordPolicyError{Reason: fmt.Sprintf("must be at most %d bytes", domain.MaxPasswordBytes)}
+	}
+
+	if classes := characterClasses(password); classes < policy.MinClasses {
+		return &domain.PasswordPolicyError{Reason: fmt.Sprintf("must mix at least %d of lowercase letters, uppercase letters, digits and symbols", policy.MinClasses)}
+	}
+
+	if strings.EqualFold(password, userName) {
+		return &domain.PasswordPolicyError{Reason: "must not be the username"}
+	}
+
+	if policy.RejectBreached && IsBreachedPassword(password) {
+		return &domain.PasswordPolicyError{Reason: "is too common, it appears in known data breaches"}
+	}
+
+	return nil
+}
+
+// characterClasses counts which of lowercase letters, uppercase letters, digits and other characters the password has
+func characterClasses(password string) int {
+
+	var lower, upper, digit, other bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			other = true
+		}
+	}
+
+	classes := 0
+	for _, has := range []bool{lower, upper, digit, other} {
+		if has {
+			classes++
+		}
+	}
+
+	return classes
+}
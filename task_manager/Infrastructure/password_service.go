@@ -1,17 +1,136 @@
 package infrastructure
 
 import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"strings"
 	domain "taskmanager/Domain"
 
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// PasswordHasher hashes new passwords with one algorithm, but verifies the hashes of every supported one.
+// The algorithm and its parameters are encoded in the hash, so they can change without breaking old hashes.
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	// Compare returns domain.ErrValidation when the password doesn't match the hash
+	Compare(encodedHash string, password string) error
+	// NeedsRehash reports whether the hash was made with another algorithm or other parameters
+	NeedsRehash(encodedHash string) bool
+}
+
+// the password hashing algorithms
+const (
+	PasswordHashArgon2id = "argon2id"
+	PasswordHashBcrypt   = "bcrypt"
+)
+
+// DefaultBcryptCost is the cost of bcrypt hashes unless configured otherwise
+const DefaultBcryptCost = bcrypt.DefaultCost
+
+// Argon2Params are the cost parameters of argon2id, Memory is in KiB
+type Argon2Params struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// DefaultArgon2Params follow the OWASP recommendation of 19 MiB, 2 iterations and one thread
+var DefaultArgon2Params = Argon2Params{
+	Memory:      19 * 1024,
+	Iterations:  2,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+// Validate reports parameters argon2id can't work with
+func (p Argon2Params) Validate() error {
+	if p.Iterations < 1 || p.Parallelism < 1 || p.Memory < 8*uint32(p.Parallelism) {
+		return fmt.Errorf("%w: argon2id needs an iteration, a thread and at least 8 KiB of memory per thread", domain.ErrValidation)
+	}
+	if p.SaltLength < 8 || p.KeyLength < 16 {
+		return fmt.Errorf("%w: argon2id needs a salt of at least 8 bytes and a key of at least 16", domain.ErrValidation)
+	}
+	return nil
+}
+
+// NewPasswordHasher returns a hasher for the algorithm, bcryptCost only applies to bcrypt and params to argon2id
+func NewPasswordHasher(algorithm string, bcryptCost int, params Argon2Params) (PasswordHasher, error) {
+
+	switch algorithm {
+	case PasswordHashArgon2id:
+		if err := params.Validate(); err != nil {
+			return nil, err
+		}
+		return &argon2idHasher{params: params}, nil
+
+	case PasswordHashBcrypt:
+		if bcryptCost < bcrypt.MinCost || bcryptCost > bcrypt.MaxCost {
+			return nil, fmt.Errorf("%w: the bcrypt cost must be between %d and %d", domain.ErrValidation, bcrypt.MinCost, bcrypt.MaxCost)
+		}
+		return &bcryptHasher{cost: bcryptCost}, nil
+	}
+
+	return nil, fmt.Errorf("%w: unknown password hash algorithm %q, expected argon2id or bcrypt", domain.ErrValidation, algorithm)
+}
+
+// defaultPasswordHasher backs HashPassword
+var defaultPasswordHasher = &bcryptHasher{cost: DefaultBcryptCost}
+
+// HashPassword hashes a password with bcrypt at the default cost
 func HashPassword(password string) (string, error) {
+	return defaultPasswordHasher.Hash(password)
+}
 
-	// hash the password with bcrypt package
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+// ComparePassword checks a password against a hash of any supported algorithm
+func ComparePassword(storedPassword string, insertedPassword string) error {
+	return comparePassword(storedPassword, insertedPassword)
+}
+
+// comparePassword picks the algorithm from the prefix of the hash
+func comparePassword(encodedHash string, password string) error {
+
+	if strings.HasPrefix(encodedHash, "$"+PasswordHashArgon2id+"$") {
+		params, salt, key, err := decodeArgon2idHash(encodedHash)
+		if err != nil {
+			return err
+		}
+
+		// compare in constant time so the time taken doesn't tell how much matched
+		candidate := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+		if subtle.ConstantTimeCompare(candidate, key) != 1 {
+			return domain.ErrValidation
+		}
+		return nil
+	}
+
+	// compare user insereted password with the stored one
+	err := bcrypt.CompareHashAndPassword([]byte(encodedHash), []byte(password))
+	if err != nil {
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return domain.ErrValidation
+		}
+		return fmt.Errorf("failed to verify password: %w", err)
+	}
+
+	return nil
+}
+
+type bcryptHasher struct {
+	cost int
+}
+
+func (h *bcryptHasher) Hash(password string) (string, error) {
 
+	// hash the password with bcrypt package
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
 	if err != nil {
 		return "", fmt.Errorf("failed to hash password: %w", err)
 	}
@@ -19,13 +138,83 @@ func HashPassword(password string) (string, error) {
 	return string(hashedPassword), nil
 }
 
-func ComparePassword(storedPassword string, insertedPassword string) error {
+func (h *bcryptHasher) Compare(encodedHash string, password string) error {
+	return comparePassword(encodedHash, password)
+}
 
-	// compare user insereted password with the stored one
-	err := bcrypt.CompareHashAndPassword([]byte(storedPassword), []byte(insertedPassword))
+func (h *bcryptHasher) NeedsRehash(encodedHash string) bool {
+	cost, err := bcrypt.Cost([]byte(encodedHash))
+	return err != nil || cost != h.cost
+}
+
+// argon2idHasher encodes hashes in the PHC string format, e.g.
+// $argon2id$v=19$m=19456,t=2,p=1$<salt>$<key> with unpadded base64
+type argon2idHasher struct {
+	params Argon2Params
+}
+
+func (h *argon2idHasher) Hash(password string) (string, error) {
+
+	salt := make([]byte, h.params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.params.Iterations, h.params.Memory, h.params.Parallelism, h.params.KeyLength)
+
+	return fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s", PasswordHashArgon2id, argon2.Version,
+		h.params.Memory, h.params.Iterations, h.params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+func (h *argon2idHasher) Compare(encodedHash string, password string) error {
+	return comparePassword(encodedHash, password)
+}
+
+func (h *argon2idHasher) NeedsRehash(encodedHash string) bool {
+
+	params, salt, key, err := decodeArgon2idHash(encodedHash)
 	if err != nil {
-		return domain.ErrValidation
+		return true
 	}
 
-	return nil
+	return params.Memory != h.params.Memory || params.Iterations != h.params.Iterations ||
+		params.Parallelism != h.params.Parallelism || uint32(len(salt)) != h.params.SaltLength || uint32(len(key)) != h.params.KeyLength
+}
+
+// decodeArgon2idHash reads the parameters, the salt and the key back from an argon2id hash
+func decodeArgon2idHash(encodedHash string) (Argon2Params, []byte, []byte, error) {
+
+	// the leading $ gives an empty first part
+	parts := strings.Split(encodedHash, "$")
+	if len(parts) != 6 || parts[1] != PasswordHashArgon2id {
+		return Argon2Params{}, nil, nil, errors.New("failed to verify password: malformed argon2id hash")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return Argon2Params{}, nil, nil, errors.New("failed to verify password: unsupported argon2id version")
+	}
+
+	var params Argon2Params
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return Argon2Params{}, nil, nil, fmt.Errorf("failed to verify password: malformed argon2id parameters: %w", err)
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return Argon2Params{}, nil, nil, fmt.Errorf("failed to verify password: malformed argon2id salt: %w", err)
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return Argon2Params{}, nil, nil, fmt.Errorf("failed to verify password: malformed argon2id key: %w", err)
+	}
+
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+	if params.Validate() != nil {
+		return Argon2Params{}, nil, nil, errors.New("failed to verify password: invalid argon2id parameters")
+	}
+
+	return params, salt, key, nil
 }
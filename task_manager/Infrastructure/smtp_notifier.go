@@ -0,0 +1,146 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	domain "taskmanager/Domain"
+	"time"
+)
+
+// DefaultSMTPTimeout bounds the conversation with the mail server when the caller sets no deadline
+const DefaultSMTPTimeout = 30 * time.Second
+
+// SMTPConfig describes the mail server reminders are sent through and who receives them
+type SMTPConfig struct {
+	Addr     string // host:port of the server
+	From     string
+	To       []string
+	Username string // no authentication when empty
+	Password string
+	Timeout  time.Duration // DefaultSMTPTimeout when zero
+}
+
+// SMTPNotifier mails every reminder to the configured recipients
+type SMTPNotifier struct {
+	config SMTPConfig
+	host   string
+}
+
+func NewSMTPNotifier(config SMTPConfig) (*SMTPNotifier, error) {
+
+	host, _, err := net.SplitHostPort(config.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("smtp address must look like host:port: %w", err)
+	}
+	if config.From == "" || len(config.To) == 0 {
+		return nil, fmt.Errorf("smtp sender and recipients are required")
+	}
+	if config.Timeout < 0 {
+		return nil, fmt.Errorf("smtp timeout can't be negative")
+	}
+	if config.Timeout == 0 {
+		config.Timeout = DefaultSMTPTimeout
+	}
+
+	return &SMTPNotifier{config: config, host: host}, nil
+}
+
+// Notify sends the reminder as a plain text mail, ctx bounds the whole conversation with the server.
+// Without a deadline on ctx the configured timeout applies, so a stalling server can't hold up a shutdown.
+func (n *SMTPNotifier) Notify(ctx context.Context, reminder domain.Reminder) error {
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, n.config.Timeout)
+		defer cancel()
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", n.config.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to smtp server: %w", err)
+	}
+	deadline, _ := ctx.Deadline()
+	conn.SetDeadline(deadline)
+
+	// the deadline doesn't notice a cancelled ctx, closing the connection ends the conversation
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	client, err := smtp.NewClient(conn, n.host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to start smtp session: %w", err)
+	}
+	defer client.Close()
+
+	if err := n.send(client, reminder); err != nil {
+		return fmt.Errorf("failed to send reminder mail: %w", err)
+	}
+
+	return nil
+}
+
+// send runs the same exchange as smtp.SendMail on an open session
+func (n *SMTPNotifier) send(client *smtp.Client, reminder domain.Reminder) error {
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: n.host}); err != nil {
+			return err
+		}
+	}
+	if n.config.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", n.config.Username, n.config.Password, n.host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(n.config.From); err != nil {
+		return err
+	}
+	for _, to := range n.config.To {
+		if err := client.Rcpt(to); err != nil {
+			return err
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(n.message(reminder)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// message formats the reminder as a mail, the subject is encoded so a title can't inject headers
+func (n *SMTPNotifier) message(reminder domain.Reminder) []byte {
+
+	subject := reminderSubject(reminder)
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", n.config.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(n.config.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", "Reminder: "+subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("\r\n")
+	fmt.Fprintf(&msg, "Reminder: %s.\r\n", subject)
+	if reminder.AssigneeID != "" {
+		fmt.Fprintf(&msg, "Assignee: %s\r\n", reminder.AssigneeID)
+	}
+
+	return msg.Bytes()
+}
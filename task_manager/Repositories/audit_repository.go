@@ -0,0 +1,135 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	domain "taskmanager/Domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AuditRepository is append-only, entries are never changed or removed once written
+type AuditRepository interface {
+	Append(ctx context.Context, entry domain.AuditEntry) error
+	Query(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, int64, error)
+	Stream(ctx context.Context, filter domain.AuditFilter, fn func(entry domain.AuditEntry) error) error
+}
+
+type MongoAuditRepository struct {
+	auditCollection *mongo.Collection
+}
+
+func NewMongoAuditRepository(client *mongo.Client, dbName string, collectionName string) (AuditRepository, error) {
+	collection := client.Database(dbName).Collection(collectionName)
+
+	if err := createUniqueIndexes(collection, "entry_id"); err != nil {
+		return nil, err
+	}
+
+	return &MongoAuditRepository{
+		auditCollection: collection,
+	}, nil
+}
+
+func (m *MongoAuditRepository) Append(ctx context.Context, entry domain.AuditEntry) error {
+
+	_, err := m.auditCollection.InsertOne(ctx, entry)
+	if err != nil {
+		return fmt.Errorf("failed to append audit entry: %w", duplicateKeyError(err))
+	}
+
+	return nil
+}
+
+func (m *MongoAuditRepository) Query(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, int64, error) {
+
+	query := auditFilterQuery(filter)
+
+	total, err := m.auditCollection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit entries: %w", err)
+	}
+
+	// newest first, entry_id keeps pages stable
+	opts := options.Find().
+		SetSort(bson.D{{Key: "timestamp", Value: -1}, {Key: "entry_id", Value: -1}}).
+		SetSkip(filter.Offset)
+	if filter.Limit > 0 {
+		opts.SetLimit(filter.Limit)
+	}
+
+	cursor, err := m.auditCollection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find audit entries: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var entries []domain.AuditEntry
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode audit entries: %w", err)
+	}
+
+	return entries, total, nil
+}
+
+func (m *MongoAuditRepository) Stream(ctx context.Context, filter domain.AuditFilter, fn func(entry domain.AuditEntry) error) error {
+
+	// oldest first so consumers can read the log in the order it was written,
+	// the limit and offset of the filter are ignored
+	opts := options.Find().SetSort(bson.D{{Key: "timestamp", Value: 1}, {Key: "entry_id", Value: 1}})
+
+	cursor, err := m.auditCollection.Find(ctx, auditFilterQuery(filter), opts)
+	if err != nil {
+		return fmt.Errorf("failed to find audit entries: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var entry domain.AuditEntry
+		if err := cursor.Decode(&entry); err != nil {
+			return fmt.Errorf("failed to decode audit entry: %w", err)
+		}
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return fmt.Errorf("failed to read audit entries: %w", err)
+	}
+
+	return nil
+}
+
+// auditFilterQuery translates an AuditFilter into a MongoDB query
+func auditFilterQuery(filter domain.AuditFilter) bson.M {
+
+	query := bson.M{}
+
+	if filter.ActorID != "" {
+		query["actor_id"] = filter.ActorID
+	}
+	if filter.Action != "" {
+		query["action"] = filter.Action
+	}
+	if filter.TargetType != "" {
+		query["target_type"] = filter.TargetType
+	}
+	if filter.TargetID != "" {
+		query["target_id"] = filter.TargetID
+	}
+
+	timestamp := bson.M{}
+	if !filter.From.IsZero() {
+		timestamp["$gte"] = filter.From
+	}
+	if !filter.To.IsZero() {
+		timestamp["$lt"] = filter.To
+	}
+	if len(timestamp) > 0 {
+		query["timestamp"] = timestamp
+	}
+
+	return query
+}
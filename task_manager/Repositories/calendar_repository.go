@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	domain "taskmanager/Domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CalendarFeedRepository stores the calendar feed of each user, looked up by the hash of its secret
+type CalendarFeedRepository interface {
+	SaveFeed(ctx context.Context, feed domain.CalendarFeed) error
+	GetFeedByUser(ctx context.Context, userId string) (domain.CalendarFeed, error)
+	GetFeedByHash(ctx context.Context, tokenHash string) (domain.CalendarFeed, error)
+	DeleteFeed(ctx context.Context, userId string) error
+}
+
+type MongoCalendarFeedRepository struct {
+	feedCollection *mongo.Collection
+}
+
+func NewMongoCalendarFeedRepository(client *mongo.Client, dbName string, collectionName string) (CalendarFeedRepository, error) {
+	collection := client.Database(dbName).Collection(collectionName)
+
+	if err := createUniqueIndexes(collection, "user_id", "token_hash"); err != nil {
+		return nil, err
+	}
+
+	return &MongoCalendarFeedRepository{
+		feedCollection: collection,
+	}, nil
+}
+
+// SaveFeed stores the feed of a user, replacing the one they had
+func (m *MongoCalendarFeedRepository) SaveFeed(ctx context.Context, feed domain.CalendarFeed) error {
+
+	opts := options.Replace().SetUpsert(true)
+	_, err := m.feedCollection.ReplaceOne(ctx, bson.M{"user_id": feed.UserID}, feed, opts)
+	if err != nil {
+		return fmt.Errorf("failed to save calendar feed: %w", err)
+	}
+
+	return nil
+}
+
+func (m *MongoCalendarFeedRepository) GetFeedByUser(ctx context.Context, userId string) (domain.CalendarFeed, error) {
+	return m.findFeed(ctx, bson.M{"user_id": userId})
+}
+
+func (m *MongoCalendarFeedRepository) GetFeedByHash(ctx context.Context, tokenHash string) (domain.CalendarFeed, error) {
+	return m.findFeed(ctx, bson.M{"token_hash": tokenHash})
+}
+
+func (m *MongoCalendarFeedRepository) findFeed(ctx context.Context, filter bson.M) (domain.CalendarFeed, error) {
+
+	var feed domain.CalendarFeed
+
+	err := m.feedCollection.FindOne(ctx, filter).Decode(&feed)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.CalendarFeed{}, domain.ErrNotFound
+		}
+		return domain.CalendarFeed{}, fmt.Errorf("failed to retrieve calendar feed: %w", err)
+	}
+
+	return feed, nil
+}
+
+func (m *MongoCalendarFeedRepository) DeleteFeed(ctx context.Context, userId string) error {
+
+	result, err := m.feedCollection.DeleteOne(ctx, bson.M{"user_id": userId})
+	if err != nil {
+		return fmt.Errorf("failed to delete calendar feed: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
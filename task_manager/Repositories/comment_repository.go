@@ -0,0 +1,139 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	domain "taskmanager/Domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type CommentRepository interface {
+	Create(ctx context.Context, comment domain.Comment) (domain.Comment, error)
+	GetByID(ctx context.Context, taskId string, commentId string) (domain.Comment, error)
+	ListByTask(ctx context.Context, taskId string, limit int64, offset int64) ([]domain.Comment, int64, error)
+	Update(ctx context.Context, taskId string, commentId string, body string, updatedAt time.Time) (domain.Comment, error)
+	Delete(ctx context.Context, taskId string, commentId string) error
+	DeleteByTask(ctx context.Context, taskId string) error
+}
+
+type MongoCommentRepository struct {
+	commentCollection *mongo.Collection
+}
+
+func NewMongoCommentRepository(client *mongo.Client, dbName string, collectionName string) (CommentRepository, error) {
+	collection := client.Database(dbName).Collection(collectionName)
+
+	if err := createUniqueIndexes(collection, "comment_id"); err != nil {
+		return nil, err
+	}
+
+	return &MongoCommentRepository{
+		commentCollection: collection,
+	}, nil
+}
+
+func (m *MongoCommentRepository) Create(ctx context.Context, comment domain.Comment) (domain.Comment, error) {
+
+	_, err := m.commentCollection.InsertOne(ctx, comment)
+	if err != nil {
+		return domain.Comment{}, fmt.Errorf("failed to create comment: %w", duplicateKeyError(err))
+	}
+
+	return comment, nil
+}
+
+func (m *MongoCommentRepository) GetByID(ctx context.Context, taskId string, commentId string) (domain.Comment, error) {
+
+	var comment domain.Comment
+
+	// comments are only found through the task they belong to
+	filter := bson.M{"task_id": taskId, "comment_id": commentId}
+	err := m.commentCollection.FindOne(ctx, filter).Decode(&comment)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.Comment{}, domain.ErrNotFound
+		}
+		return domain.Comment{}, fmt.Errorf("failed to retrieve comment: %w", err)
+	}
+
+	return comment, nil
+}
+
+func (m *MongoCommentRepository) ListByTask(ctx context.Context, taskId string, limit int64, offset int64) ([]domain.Comment, int64, error) {
+
+	filter := bson.M{"task_id": taskId}
+
+	total, err := m.commentCollection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count comments: %w", err)
+	}
+
+	// oldest first, comment_id keeps pages stable
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "comment_id", Value: 1}}).
+		SetSkip(offset)
+	if limit > 0 {
+		opts.SetLimit(limit)
+	}
+
+	cursor, err := m.commentCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find comments: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var comments []domain.Comment
+	if err := cursor.All(ctx, &comments); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode comments: %w", err)
+	}
+
+	return comments, total, nil
+}
+
+func (m *MongoCommentRepository) Update(ctx context.Context, taskId string, commentId string, body string, updatedAt time.Time) (domain.Comment, error) {
+
+	filter := bson.M{"task_id": taskId, "comment_id": commentId}
+	updateQuery := bson.M{"$set": bson.M{"body": body, "updated_at": updatedAt}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var comment domain.Comment
+	err := m.commentCollection.FindOneAndUpdate(ctx, filter, updateQuery, opts).Decode(&comment)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.Comment{}, domain.ErrNotFound
+		}
+		return domain.Comment{}, fmt.Errorf("failed to update comment: %w", err)
+	}
+
+	return comment, nil
+}
+
+func (m *MongoCommentRepository) Delete(ctx context.Context, taskId string, commentId string) error {
+
+	filter := bson.M{"task_id": taskId, "comment_id": commentId}
+
+	result, err := m.commentCollection.DeleteOne(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteByTask removes every comment of a task, a task without comments is fine
+func (m *MongoCommentRepository) DeleteByTask(ctx context.Context, taskId string) error {
+
+	if _, err := m.commentCollection.DeleteMany(ctx, bson.M{"task_id": taskId}); err != nil {
+		return fmt.Errorf("failed to delete comments: %w", err)
+	}
+
+	return nil
+}
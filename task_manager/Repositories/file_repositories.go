@@ -0,0 +1,109 @@
+package repositories
+
+// The file-backed repositories share their query logic with the in-memory ones,
+// only their collections are persisted by a FileStore.
+
+// FileTaskRepository is a TaskRepository whose tasks are persisted by a FileStore
+type FileTaskRepository struct {
+	InMemoryTaskRepository
+}
+
+func NewFileTaskRepository(store *FileStore, collectionName string) TaskRepository {
+	return &FileTaskRepository{
+		*newInMemoryTaskRepository(store.collection(collectionName)),
+	}
+}
+
+// FileUserRepository is a UserRepository whose users are persisted by a FileStore
+type FileUserRepository struct {
+	InMemoryUserRepository
+}
+
+func NewFileUserRepository(store *FileStore, collectionName string) UserRepository {
+	return &FileUserRepository{
+		InMemoryUserRepository{users: store.collection(collectionName)},
+	}
+}
+
+// FileTokenRepository is a TokenRepository whose tokens are persisted by a FileStore
+type FileTokenRepository struct {
+	InMemoryTokenRepository
+}
+
+func NewFileTokenRepository(store *FileStore, refreshCollectionName string, revokedCollectionName string) TokenRepository {
+	return &FileTokenRepository{
+		InMemoryTokenRepository{
+			refreshTokens: store.collection(refreshCollectionName),
+			revokedTokens: store.collection(revokedCollectionName),
+		},
+	}
+}
+
+// FileCommentRepository is a CommentRepository whose comments are persisted by a FileStore
+type FileCommentRepository struct {
+	InMemoryCommentRepository
+}
+
+func NewFileCommentRepository(store *FileStore, collectionName string) CommentRepository {
+	return &FileCommentRepository{
+		InMemoryCommentRepository{comments: store.collection(collectionName)},
+	}
+}
+
+// FileAuditRepository is an AuditRepository whose entries are persisted by a FileStore
+type FileAuditRepository struct {
+	InMemoryAuditRepository
+}
+
+func NewFileAuditRepository(store *FileStore, collectionName string) AuditRepository {
+	return &FileAuditRepository{
+		InMemoryAuditRepository{entries: store.collection(collectionName)},
+	}
+}
+
+// FileLabelRepository is a LabelRepository whose labels are persisted by a FileStore
+type FileLabelRepository struct {
+	InMemoryLabelRepository
+}
+
+func NewFileLabelRepository(store *FileStore, collectionName string) LabelRepository {
+	return &FileLabelRepository{
+		InMemoryLabelRepository{labels: store.collection(collectionName)},
+	}
+}
+
+// FileReminderRepository is a ReminderRepository whose sent reminders are persisted by a FileStore
+type FileReminderRepository struct {
+	InMemoryReminderRepository
+}
+
+func NewFileReminderRepository(store *FileStore, collectionName string) ReminderRepository {
+	return &FileReminderRepository{
+		InMemoryReminderRepository{reminders: store.collection(collectionName)},
+	}
+}
+
+// FileWebhookRepository is a WebhookRepository whose webhooks and deliveries are persisted by a FileStore
+type FileWebhookRepository struct {
+	InMemoryWebhookRepository
+}
+
+func NewFileWebhookRepository(store *FileStore, webhookCollectionName string, deliveryCollectionName string) WebhookRepository {
+	return &FileWebhookRepository{
+		InMemoryWebhookRepository{
+			webhooks:   store.collection(webhookCollectionName),
+			deliveries: store.collection(deliveryCollectionName),
+		},
+	}
+}
+
+// FileCalendarFeedRepository is a CalendarFeedRepository whose feeds are persisted by a FileStore
+type FileCalendarFeedRepository struct {
+	InMemoryCalendarFeedRepository
+}
+
+func NewFileCalendarFeedRepository(store *FileStore, collectionName string) CalendarFeedRepository {
+	return &FileCalendarFeedRepository{
+		InMemoryCalendarFeedRepository{feeds: store.collection(collectionName)},
+	}
+}
@@ -0,0 +1,546 @@
+package repositories
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// FileStore is an embedded, file-backed storage engine for single-binary deployments.
+//
+// Every change is appended to a write-ahead journal and fsynced before it becomes visible.
+// When the journal grows past a threshold(and when the store is closed) the whole state is
+// written to a new snapshot that atomically replaces the old one, and the journal starts over.
+// Both files are made of length-prefixed, CRC32-checked records, an incomplete record at the
+// end of the journal(a crash in the middle of a write) is discarded when the store is opened.
+// Changes that must not be applied partially are framed together as a single batch record.
+//
+// A store directory must only be used by one process at a time.
+type FileStore struct {
+	mu           sync.Mutex
+	dir          string
+	journal      *os.File
+	journalSize  int64
+	snapshotSize int64
+	generation   uint64 // bumped on every snapshot, ties the journal to the snapshot it extends
+	collections  map[string]*memoryCollection
+	closed       bool
+	staleJournal bool // the journal predates the snapshot and must be reset before the next write
+}
+
+const (
+	snapshotFileName = "snapshot.db"
+	journalFileName  = "journal.wal"
+
+	// compact once the journal holds this many bytes and outgrows the snapshot,
+	// so large stores aren't rewritten over and over
+	journalCompactThreshold = 4 << 20
+
+	recordHeaderSize = 8        // uint32 length + uint32 crc32
+	maxRecordSize    = 32 << 20 // anything larger is treated as corruption
+)
+
+// journal record operations
+const (
+	opHeader byte = iota + 1
+	opPut
+	opRemove
+	opBatch // puts and removes of one collection that are only applied together
+)
+
+var errCorruptRecord = errors.New("corrupt storage record")
+
+// journalRecord is a single entry of the journal or the snapshot
+type journalRecord struct {
+	op         byte
+	generation uint64 // header records only
+	collection string
+	key        string
+	doc        []byte
+}
+
+// OpenFileStore loads the snapshot and replays the journal found in dir, creating them if needed
+func OpenFileStore(dir string) (*FileStore, error) {
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+
+	store := &FileStore{
+		dir:         dir,
+		collections: make(map[string]*memoryCollection),
+	}
+
+	if err := store.loadSnapshot(); err != nil {
+		return nil, err
+	}
+	if err := store.openJournal(); err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+// Close writes a fresh snapshot and releases the journal, the store can't be used afterwards
+func (s *FileStore) Close() error {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+
+	compactErr := s.compact()
+	closeErr := s.journal.Close()
+
+	return errors.Join(compactErr, closeErr)
+}
+
+// collection returns the named collection, loaded with whatever the files held for it
+func (s *FileStore) collection(name string) *memoryCollection {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.collectionLocked(name)
+}
+
+func (s *FileStore) collectionLocked(name string) *memoryCollection {
+
+	collection, ok := s.collections[name]
+	if !ok {
+		collection = newMemoryCollection()
+		collection.name = name
+		collection.store = s
+		s.collections[name] = collection
+	}
+
+	return collection
+}
+
+// commit appends the record to the journal and applies it once it is on disk.
+// The caller holds the collection's lock, the store lock serializes every change
+// so a snapshot always sees a consistent state.
+func (s *FileStore) commit(collection *memoryCollection, record journalRecord) error {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return errors.New("storage is closed")
+	}
+
+	if s.staleJournal {
+		if err := s.resetJournal(); err != nil {
+			return err
+		}
+	}
+
+	record.collection = collection.name
+
+	written, err := s.journal.Write(encodeRecord(record))
+	if err == nil {
+		err = s.journal.Sync()
+	}
+	if err != nil {
+		// drop whatever part of the record made it to the file so later records stay readable
+		if truncErr := s.journal.Truncate(s.journalSize); truncErr == nil {
+			s.journal.Seek(s.journalSize, io.SeekStart)
+		}
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+
+	s.journalSize += int64(written)
+	collection.apply(record)
+
+	if s.journalSize > journalCompactThreshold && s.journalSize > s.snapshotSize {
+		// the change is already durable, a failed compaction is retried on the next write
+		if err := s.compact(); err != nil {
+			log.Printf("storage: failed to compact journal: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// compact writes the current state to a new snapshot and starts an empty journal, the caller holds mu
+func (s *FileStore) compact() error {
+
+	generation := s.generation + 1
+
+	// 1. write the snapshot next to the old one and atomically swap it in
+	err := writeFileAtomic(filepath.Join(s.dir, snapshotFileName), func(w io.Writer) error {
+		if _, err := w.Write(encodeRecord(journalRecord{op: opHeader, generation: generation})); err != nil {
+			return err
+		}
+
+		names := make([]string, 0, len(s.collections))
+		for name := range s.collections {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			collection := s.collections[name]
+			for _, key := range collection.order {
+				record := journalRecord{op: opPut, collection: name, key: key, doc: collection.docs[key]}
+				if _, err := w.Write(encodeRecord(record)); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	if info, err := os.Stat(filepath.Join(s.dir, snapshotFileName)); err == nil {
+		s.snapshotSize = info.Size()
+	}
+
+	// 2. the old journal is now part of the snapshot, its generation no longer matches so
+	// it is ignored on load even if we crash before it is replaced
+	s.generation = generation
+	s.staleJournal = true
+
+	return s.resetJournal()
+}
+
+// loadSnapshot reads the snapshot, if any, into the collections
+func (s *FileStore) loadSnapshot() error {
+
+	file, err := os.Open(filepath.Join(s.dir, snapshotFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open snapshot: %w", err)
+	}
+	defer file.Close()
+
+	if info, err := file.Stat(); err == nil {
+		s.snapshotSize = info.Size()
+	}
+
+	reader := bufio.NewReader(file)
+
+	// snapshots are replaced atomically, so unlike the journal any damage is fatal
+	header, _, err := readRecord(reader)
+	if err != nil || header.op != opHeader {
+		return fmt.Errorf("failed to read snapshot: %w", errCorruptRecord)
+	}
+	s.generation = header.generation
+
+	for {
+		record, _, err := readRecord(reader)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read snapshot: %w", err)
+		}
+		if record.op != opPut {
+			return fmt.Errorf("failed to read snapshot: %w", errCorruptRecord)
+		}
+		s.collectionLocked(record.collection).apply(record)
+	}
+}
+
+// openJournal replays the journal on top of the snapshot and keeps it open for appends
+func (s *FileStore) openJournal() error {
+
+	path := filepath.Join(s.dir, journalFileName)
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open journal: %w", err)
+	}
+	s.journal = file
+
+	reader := bufio.NewReader(file)
+
+	header, valid, err := readRecord(reader)
+	if err != nil || header.op != opHeader || header.generation != s.generation {
+		// an empty journal, one left over from before the last snapshot or one
+		// that didn't get its header written: nothing in it needs replaying
+		return s.resetJournal()
+	}
+
+	var records []journalRecord
+	for {
+		record, size, err := readRecord(reader)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			// a torn write at the end of the journal, keep everything before it
+			log.Printf("storage: discarding incomplete journal tail after %d bytes", valid)
+			break
+		}
+		if !isJournalOp(record) {
+			log.Printf("storage: discarding unexpected journal record after %d bytes", valid)
+			break
+		}
+		records = append(records, record)
+		valid += size
+	}
+
+	for _, record := range records {
+		s.collectionLocked(record.collection).apply(record)
+	}
+
+	if err := file.Truncate(valid); err != nil {
+		return fmt.Errorf("failed to truncate journal: %w", err)
+	}
+	if _, err := file.Seek(valid, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to open journal: %w", err)
+	}
+	s.journalSize = valid
+
+	return nil
+}
+
+// resetJournal atomically replaces the journal with an empty one for the current generation
+func (s *FileStore) resetJournal() error {
+
+	path := filepath.Join(s.dir, journalFileName)
+	header := encodeRecord(journalRecord{op: opHeader, generation: s.generation})
+
+	err := writeFileAtomic(path, func(w io.Writer) error {
+		_, err := w.Write(header)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reset journal: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_RDWR, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to reset journal: %w", err)
+	}
+	if _, err := file.Seek(0, io.SeekEnd); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to reset journal: %w", err)
+	}
+
+	if s.journal != nil {
+		s.journal.Close()
+	}
+	s.journal = file
+	s.journalSize = int64(len(header))
+	s.staleJournal = false
+
+	return nil
+}
+
+// writeFileAtomic writes a temporary file, syncs it and renames it over path
+func writeFileAtomic(path string, write func(w io.Writer) error) error {
+
+	tmpPath := path + ".tmp"
+
+	file, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+
+	writer := bufio.NewWriter(file)
+	err = write(writer)
+	if err == nil {
+		err = writer.Flush()
+	}
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return err
+	}
+
+	return syncDir(filepath.Dir(path))
+}
+
+// syncDir makes a rename inside dir durable
+func syncDir(dir string) error {
+
+	file, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// some platforms can't sync directories, the rename is still atomic there
+	if err := file.Sync(); err != nil && !errors.Is(err, os.ErrInvalid) {
+		return err
+	}
+
+	return nil
+}
+
+// encodeRecord frames a record as: length | crc32 | op | generation | collection | key | doc
+func encodeRecord(record journalRecord) []byte {
+
+	payload := []byte{record.op}
+	payload = binary.AppendUvarint(payload, record.generation)
+	payload = appendBytes(payload, []byte(record.collection))
+	payload = appendBytes(payload, []byte(record.key))
+	payload = appendBytes(payload, record.doc)
+
+	frame := make([]byte, recordHeaderSize, recordHeaderSize+len(payload))
+	binary.LittleEndian.PutUint32(frame[0:4], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(frame[4:8], crc32.ChecksumIEEE(payload))
+
+	return append(frame, payload...)
+}
+
+// readRecord reads the next framed record and reports its size on disk.
+// It returns io.EOF at a clean end of input and errCorruptRecord for a torn or damaged record.
+func readRecord(reader *bufio.Reader) (journalRecord, int64, error) {
+
+	var header [recordHeaderSize]byte
+	if _, err := io.ReadFull(reader, header[:]); err != nil {
+		if errors.Is(err, io.EOF) {
+			return journalRecord{}, 0, io.EOF
+		}
+		return journalRecord{}, 0, errCorruptRecord
+	}
+
+	length := binary.LittleEndian.Uint32(header[0:4])
+	if length == 0 || length > maxRecordSize {
+		return journalRecord{}, 0, errCorruptRecord
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(reader, payload); err != nil {
+		return journalRecord{}, 0, errCorruptRecord
+	}
+	if crc32.ChecksumIEEE(payload) != binary.LittleEndian.Uint32(header[4:8]) {
+		return journalRecord{}, 0, errCorruptRecord
+	}
+
+	record, err := decodePayload(payload)
+	if err != nil {
+		return journalRecord{}, 0, err
+	}
+
+	return record, int64(recordHeaderSize + len(payload)), nil
+}
+
+func decodePayload(payload []byte) (journalRecord, error) {
+
+	buf := bytes.NewReader(payload)
+
+	op, err := buf.ReadByte()
+	if err != nil {
+		return journalRecord{}, errCorruptRecord
+	}
+	generation, err := binary.ReadUvarint(buf)
+	if err != nil {
+		return journalRecord{}, errCorruptRecord
+	}
+	collection, err := readBytes(buf)
+	if err != nil {
+		return journalRecord{}, err
+	}
+	key, err := readBytes(buf)
+	if err != nil {
+		return journalRecord{}, err
+	}
+	doc, err := readBytes(buf)
+	if err != nil {
+		return journalRecord{}, err
+	}
+	if buf.Len() != 0 {
+		return journalRecord{}, errCorruptRecord
+	}
+
+	return journalRecord{op: op, generation: generation, collection: string(collection), key: string(key), doc: doc}, nil
+}
+
+// encodeBatch frames the records as one batch record, they are written and replayed together
+func encodeBatch(records []journalRecord) (journalRecord, error) {
+
+	var doc []byte
+	for _, record := range records {
+		doc = append(doc, encodeRecord(record)...)
+	}
+	if len(doc) > maxRecordSize-recordHeaderSize {
+		return journalRecord{}, fmt.Errorf("batch of %d changes is too large to store at once", len(records))
+	}
+
+	return journalRecord{op: opBatch, doc: doc}, nil
+}
+
+// decodeBatch returns the records framed by encodeBatch
+func decodeBatch(doc []byte) ([]journalRecord, error) {
+
+	reader := bufio.NewReader(bytes.NewReader(doc))
+
+	var records []journalRecord
+	for {
+		record, _, err := readRecord(reader)
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if record.op != opPut && record.op != opRemove {
+			return nil, errCorruptRecord
+		}
+		records = append(records, record)
+	}
+}
+
+// isJournalOp reports whether the record may appear in the journal after its header
+func isJournalOp(record journalRecord) bool {
+	switch record.op {
+	case opPut, opRemove:
+		return true
+	case opBatch:
+		_, err := decodeBatch(record.doc)
+		return err == nil
+	default:
+		return false
+	}
+}
+
+// appendBytes appends a uvarint length followed by b
+func appendBytes(dst []byte, b []byte) []byte {
+	dst = binary.AppendUvarint(dst, uint64(len(b)))
+	return append(dst, b...)
+}
+
+// readBytes reads a value written by appendBytes
+func readBytes(buf *bytes.Reader) ([]byte, error) {
+
+	length, err := binary.ReadUvarint(buf)
+	if err != nil || length > uint64(buf.Len()) {
+		return nil, errCorruptRecord
+	}
+
+	b := make([]byte, length)
+	if _, err := io.ReadFull(buf, b); err != nil {
+		return nil, errCorruptRecord
+	}
+
+	return b, nil
+}
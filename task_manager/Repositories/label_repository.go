@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	domain "taskmanager/Domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// LabelRepository stores the label definitions, labels are identified by their name
+type LabelRepository interface {
+	GetAll(ctx context.Context) ([]domain.Label, error)
+	GetByName(ctx context.Context, name string) (domain.Label, error)
+	Create(ctx context.Context, label domain.Label) (domain.Label, error)
+	Update(ctx context.Context, name string, label domain.Label) (domain.Label, error)
+	Delete(ctx context.Context, name string) error
+}
+
+type MongoLabelRepository struct {
+	labelCollection *mongo.Collection
+}
+
+func NewMongoLabelRepository(client *mongo.Client, dbName string, collectionName string) (LabelRepository, error) {
+	collection := client.Database(dbName).Collection(collectionName)
+
+	if err := createUniqueIndexes(collection, "name"); err != nil {
+		return nil, err
+	}
+
+	return &MongoLabelRepository{
+		labelCollection: collection,
+	}, nil
+}
+
+// GetAll returns every label sorted by name
+func (m *MongoLabelRepository) GetAll(ctx context.Context) ([]domain.Label, error) {
+
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+
+	cursor, err := m.labelCollection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find labels: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	labels := []domain.Label{}
+	if err := cursor.All(ctx, &labels); err != nil {
+		return nil, fmt.Errorf("failed to decode labels: %w", err)
+	}
+
+	return labels, nil
+}
+
+func (m *MongoLabelRepository) GetByName(ctx context.Context, name string) (domain.Label, error) {
+
+	var label domain.Label
+
+	err := m.labelCollection.FindOne(ctx, bson.M{"name": name}).Decode(&label)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.Label{}, domain.ErrNotFound
+		}
+		return domain.Label{}, fmt.Errorf("failed to retrieve label: %w", err)
+	}
+
+	return label, nil
+}
+
+func (m *MongoLabelRepository) Create(ctx context.Context, label domain.Label) (domain.Label, error) {
+
+	// an upsert that only sets fields on insert creates the label unless the name is taken
+	opts := options.Update().SetUpsert(true)
+	result, err := m.labelCollection.UpdateOne(ctx, bson.M{"name": label.Name}, bson.M{"$setOnInsert": label}, opts)
+	if err != nil {
+		return domain.Label{}, fmt.Errorf("failed to create label: %w", duplicateKeyError(err))
+	}
+	if result.MatchedCount > 0 {
+		return domain.Label{}, fmt.Errorf("failed to create label: %w", domain.ErrAleadyExists)
+	}
+
+	return label, nil
+}
+
+// Update replaces the label stored under name, label.Name may differ to rename it
+func (m *MongoLabelRepository) Update(ctx context.Context, name string, label domain.Label) (domain.Label, error) {
+
+	if label.Name != name {
+		count, err := m.labelCollection.CountDocuments(ctx, bson.M{"name": label.Name})
+		if err != nil {
+			return domain.Label{}, fmt.Errorf("failed to check label existence: %w", err)
+		}
+		if count > 0 {
+			return domain.Label{}, fmt.Errorf("failed to rename label: %w", domain.ErrAleadyExists)
+		}
+	}
+
+	result, err := m.labelCollection.ReplaceOne(ctx, bson.M{"name": name}, label)
+	if err != nil {
+		return domain.Label{}, fmt.Errorf("failed to update label: %w", duplicateKeyError(err))
+	}
+	if result.MatchedCount == 0 {
+		return domain.Label{}, domain.ErrNotFound
+	}
+
+	return label, nil
+}
+
+func (m *MongoLabelRepository) Delete(ctx context.Context, name string) error {
+
+	result, err := m.labelCollection.DeleteOne(ctx, bson.M{"name": name})
+	if err != nil {
+		return fmt.Errorf("failed to delete label: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package repositories
+
+import (
+	"context"
+	"sort"
+	"sync"
+	domain "taskmanager/Domain"
+	"time"
+)
+
+// LoginAttemptRepository counts failed logins by username and by client address.
+// ReserveAttempt must check and count in one atomic step, so parallel guesses can't all get through
+// once a delay expires, and instances sharing a store can't lose each other's failures.
+type LoginAttemptRepository interface {
+	GetAttempts(ctx context.Context, kind domain.LoginAttemptKind, subject string) (domain.LoginAttempts, error)
+	ReserveAttempt(ctx context.Context, kind domain.LoginAttemptKind, subject string, at time.Time, limits domain.LoginLimits) (domain.LoginAttempts, error)
+	ReleaseAttempt(ctx context.Context, kind domain.LoginAttemptKind, subject string) error
+	Lock(ctx context.Context, kind domain.LoginAttemptKind, subject string, until time.Time) error
+	Reset(ctx context.Context, kind domain.LoginAttemptKind, subject string) error
+	ListLocked(ctx context.Context, now time.Time) ([]domain.LoginAttempts, error)
+}
+
+type loginAttemptKey struct {
+	kind    domain.LoginAttemptKind
+	subject string
+}
+
+// InMemoryLoginAttemptRepository keeps the counters of a single instance, they are lost when the process exits.
+// Failures that have left their window are dropped as new ones come in, so guessing from many
+// addresses or at many usernames doesn't grow it without bound.
+type InMemoryLoginAttemptRepository struct {
+	mu        sync.Mutex
+	attempts  map[loginAttemptKey]domain.LoginAttempts
+	lastPrune time.Time
+}
+
+func NewInMemoryLoginAttemptRepository() LoginAttemptRepository {
+	return &InMemoryLoginAttemptRepository{
+		attempts: make(map[loginAttemptKey]domain.LoginAttempts),
+	}
+}
+
+func (r *InMemoryLoginAttemptRepository) GetAttempts(ctx context.Context, kind domain.LoginAttemptKind, subject string) (domain.LoginAttempts, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attempts, found := r.attempts[loginAttemptKey{kind, subject}]
+	if !found {
+		return domain.LoginAttempts{}, domain.ErrNotFound
+	}
+
+	return attempts, nil
+}
+
+// ReserveAttempt counts a login attempted at the given time as failed until it is released.
+// It returns a *domain.LoginThrottledError, without counting anything, when the limits say the
+// username or address has to wait. The count starts over when the previous failure is older than the window.
+func (r *InMemoryLoginAttemptRepository) ReserveAttempt(ctx context.Context, kind domain.LoginAttemptKind, subject string, at time.Time, limits domain.LoginLimits) (domain.LoginAttempts, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if at.Sub(r.lastPrune) >= limits.Window {
+		r.prune(at, limits.Window)
+		r.lastPrune = at
+	}
+
+	key := loginAttemptKey{kind, subject}
+	attempts, found := r.attempts[key]
+	if !found {
+		attempts = domain.LoginAttempts{Kind: kind, Subject: subject}
+	}
+
+	if wait, locked := limits.Wait(attempts, at); wait > 0 {
+		return domain.LoginAttempts{}, &domain.LoginThrottledError{RetryAfter: wait, Locked: locked}
+	}
+
+	if at.Sub(attempts.LastFailure) > limits.Window {
+		attempts = domain.LoginAttempts{Kind: kind, Subject: subject, LockedUntil: attempts.LockedUntil}
+	}
+
+	attempts.Failures++
+	attempts.LastFailure = at
+	r.attempts[key] = attempts
+
+	return attempts, nil
+}
+
+// ReleaseAttempt takes back a reserved attempt that didn't fail. It still counts as the latest
+// attempt, so the next one is spaced out from it like from a failure.
+func (r *InMemoryLoginAttemptRepository) ReleaseAttempt(ctx context.Context, kind domain.LoginAttemptKind, subject string) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := loginAttemptKey{kind, subject}
+	attempts, found := r.attempts[key]
+	if !found {
+		return domain.ErrNotFound
+	}
+
+	attempts.Failures = max(attempts.Failures-1, 0)
+	r.attempts[key] = attempts
+
+	return nil
+}
+
+func (r *InMemoryLoginAttemptRepository) Lock(ctx context.Context, kind domain.LoginAttemptKind, subject string, until time.Time) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := loginAttemptKey{kind, subject}
+	attempts, found := r.attempts[key]
+	if !found {
+		attempts = domain.LoginAttempts{Kind: kind, Subject: subject}
+	}
+
+	attempts.LockedUntil = until
+	r.attempts[key] = attempts
+
+	return nil
+}
+
+// Reset forgets the failures and any lockout of a username or client address
+func (r *InMemoryLoginAttemptRepository) Reset(ctx context.Context, kind domain.LoginAttemptKind, subject string) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := loginAttemptKey{kind, subject}
+	if _, found := r.attempts[key]; !found {
+		return domain.ErrNotFound
+	}
+
+	delete(r.attempts, key)
+
+	return nil
+}
+
+// ListLocked returns the usernames and client addresses locked out at the given time, the longest locked first
+func (r *InMemoryLoginAttemptRepository) ListLocked(ctx context.Context, now time.Time) ([]domain.LoginAttempts, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	locked := []domain.LoginAttempts{}
+	for _, attempts := range r.attempts {
+		if attempts.LockedUntil.After(now) {
+			locked = append(locked, attempts)
+		}
+	}
+
+	sort.Slice(locked, func(i, j int) bool {
+		if !locked[i].LockedUntil.Equal(locked[j].LockedUntil) {
+			return locked[i].LockedUntil.After(locked[j].LockedUntil)
+		}
+		if locked[i].Kind != locked[j].Kind {
+			return locked[i].Kind > locked[j].Kind
+		}
+		return locked[i].Subject < locked[j].Subject
+	})
+
+	return locked, nil
+}
+
+// prune drops the counters whose failures have left the window and that aren't locked out
+func (r *InMemoryLoginAttemptRepository) prune(now time.Time, window time.Duration) {
+	for key, attempts := range r.attempts {
+		if now.Sub(attempts.LastFailure) > window && !attempts.LockedUntil.After(now) {
+			delete(r.attempts, key)
+		}
+	}
+}
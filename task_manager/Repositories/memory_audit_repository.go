@@ -0,0 +1,141 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	domain "taskmanager/Domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// InMemoryAuditRepository is the concurrency-safe in-memory AuditRepository
+type InMemoryAuditRepository struct {
+	entries *memoryCollection
+}
+
+func NewInMemoryAuditRepository() AuditRepository {
+	return &InMemoryAuditRepository{
+		entries: newMemoryCollection(),
+	}
+}
+
+func (r *InMemoryAuditRepository) Append(ctx context.Context, entry domain.AuditEntry) error {
+
+	r.entries.mu.Lock()
+	defer r.entries.mu.Unlock()
+
+	if _, exists := r.entries.docs[entry.ID]; exists {
+		return fmt.Errorf("failed to append audit entry: %w", domain.ErrAleadyExists)
+	}
+
+	if err := r.entries.put(entry.ID, entry); err != nil {
+		return fmt.Errorf("failed to append audit entry: %w", err)
+	}
+
+	return nil
+}
+
+func (r *InMemoryAuditRepository) Query(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, int64, error) {
+
+	entries, err := r.matching(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// newest first, entry_id keeps pages stable
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+
+	total := int64(len(entries))
+
+	if filter.Offset >= total {
+		return []domain.AuditEntry{}, total, nil
+	}
+	entries = entries[filter.Offset:]
+	if filter.Limit > 0 && filter.Limit < int64(len(entries)) {
+		entries = entries[:filter.Limit]
+	}
+
+	return entries, total, nil
+}
+
+func (r *InMemoryAuditRepository) Stream(ctx context.Context, filter domain.AuditFilter, fn func(entry domain.AuditEntry) error) error {
+
+	// the matching entries are copied first so fn runs without holding the lock
+	entries, err := r.matching(filter)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// matching returns the entries selected by the filter, oldest first
+func (r *InMemoryAuditRepository) matching(filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+
+	r.entries.mu.RLock()
+	defer r.entries.mu.RUnlock()
+
+	entries := []domain.AuditEntry{}
+
+	var decodeErr error
+	r.entries.each(func(key string, doc bson.Raw) bool {
+		var entry domain.AuditEntry
+		if err := bson.Unmarshal(doc, &entry); err != nil {
+			decodeErr = fmt.Errorf("failed to decode audit entries: %w", err)
+			return false
+		}
+		if matchesAuditFilter(entry, filter) {
+			entries = append(entries, entry)
+		}
+		return true
+	})
+	if decodeErr != nil {
+		return nil, decodeErr
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].Timestamp.Equal(entries[j].Timestamp) {
+			return entries[i].Timestamp.Before(entries[j].Timestamp)
+		}
+		return entries[i].ID < entries[j].ID
+	})
+
+	return entries, nil
+}
+
+// matchesAuditFilter mirrors the query built by auditFilterQuery
+func matchesAuditFilter(entry domain.AuditEntry, filter domain.AuditFilter) bool {
+
+	if filter.ActorID != "" && entry.ActorID != filter.ActorID {
+		return false
+	}
+	if filter.Action != "" && entry.Action != filter.Action {
+		return false
+	}
+	if filter.TargetType != "" && entry.TargetType != filter.TargetType {
+		return false
+	}
+	if filter.TargetID != "" && entry.TargetID != filter.TargetID {
+		return false
+	}
+	if !filter.From.IsZero() && entry.Timestamp.Before(filter.From) {
+		return false
+	}
+	if !filter.To.IsZero() && !entry.Timestamp.Before(filter.To) {
+		return false
+	}
+
+	return true
+}
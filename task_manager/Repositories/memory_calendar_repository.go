@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	domain "taskmanager/Domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// InMemoryCalendarFeedRepository is the concurrency-safe in-memory CalendarFeedRepository
+type InMemoryCalendarFeedRepository struct {
+	feeds *memoryCollection // keyed by user id
+}
+
+func NewInMemoryCalendarFeedRepository() CalendarFeedRepository {
+	return &InMemoryCalendarFeedRepository{
+		feeds: newMemoryCollection(),
+	}
+}
+
+// SaveFeed stores the feed of a user, replacing the one they had
+func (r *InMemoryCalendarFeedRepository) SaveFeed(ctx context.Context, feed domain.CalendarFeed) error {
+
+	r.feeds.mu.Lock()
+	defer r.feeds.mu.Unlock()
+
+	if err := r.feeds.put(feed.UserID, feed); err != nil {
+		return fmt.Errorf("failed to save calendar feed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *InMemoryCalendarFeedRepository) GetFeedByUser(ctx context.Context, userId string) (domain.CalendarFeed, error) {
+
+	r.feeds.mu.RLock()
+	defer r.feeds.mu.RUnlock()
+
+	var feed domain.CalendarFeed
+	found, err := r.feeds.get(userId, &feed)
+	if err != nil {
+		return domain.CalendarFeed{}, fmt.Errorf("failed to retrieve calendar feed: %w", err)
+	}
+	if !found {
+		return domain.CalendarFeed{}, domain.ErrNotFound
+	}
+
+	return feed, nil
+}
+
+func (r *InMemoryCalendarFeedRepository) GetFeedByHash(ctx context.Context, tokenHash string) (domain.CalendarFeed, error) {
+
+	r.feeds.mu.RLock()
+	defer r.feeds.mu.RUnlock()
+
+	var (
+		feed      domain.CalendarFeed
+		found     bool
+		decodeErr error
+	)
+
+	r.feeds.each(func(key string, doc bson.Raw) bool {
+		hash, _ := doc.Lookup("token_hash").StringValueOK()
+		if hash != tokenHash {
+			return true
+		}
+		if err := bson.Unmarshal(doc, &feed); err != nil {
+			decodeErr = fmt.Errorf("failed to retrieve calendar feed: %w", err)
+		}
+		found = true
+		return false
+	})
+
+	if decodeErr != nil {
+		return domain.CalendarFeed{}, decodeErr
+	}
+	if !found {
+		return domain.CalendarFeed{}, domain.ErrNotFound
+	}
+
+	return feed, nil
+}
+
+func (r *InMemoryCalendarFeedRepository) DeleteFeed(ctx context.Context, userId string) error {
+
+	r.feeds.mu.Lock()
+	defer r.feeds.mu.Unlock()
+
+	if _, exists := r.feeds.docs[userId]; !exists {
+		return domain.ErrNotFound
+	}
+
+	if err := r.feeds.remove(userId); err != nil {
+		return fmt.Errorf("failed to delete calendar feed: %w", err)
+	}
+
+	return nil
+}
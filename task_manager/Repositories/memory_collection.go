@@ -0,0 +1,140 @@
+package repositories
+
+import (
+	"fmt"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// memoryCollection keeps documents BSON-encoded in memory, keyed by their custom id.
+// Storing the encoded form gives the in-memory repositories the same field mapping,
+// time precision and copy-on-read behaviour as documents coming back from MongoDB.
+// The in-memory repositories behave like their MongoDB counterparts without needing a database,
+// the data is lost when the process exits unless a FileStore persists it.
+// Callers must hold mu while calling the helper methods.
+type memoryCollection struct {
+	mu    sync.RWMutex
+	docs  map[string][]byte
+	order []string // insertion order, mirrors MongoDB's natural order
+
+	// set when the collection is persisted by a FileStore
+	name  string
+	store *FileStore
+}
+
+func newMemoryCollection() *memoryCollection {
+	return &memoryCollection{
+		docs: make(map[string][]byte),
+	}
+}
+
+// get decodes the document stored under key into out
+func (m *memoryCollection) get(key string, out interface{}) (bool, error) {
+	doc, ok := m.docs[key]
+	if !ok {
+		return false, nil
+	}
+
+	if err := bson.Unmarshal(doc, out); err != nil {
+		return true, fmt.Errorf("failed to decode document: %w", err)
+	}
+
+	return true, nil
+}
+
+// put encodes value and stores it under key, replacing any previous document
+func (m *memoryCollection) put(key string, value interface{}) error {
+	doc, err := bson.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode document: %w", err)
+	}
+
+	return m.commit(journalRecord{op: opPut, key: key, doc: doc})
+}
+
+// putAll encodes the values and stores each under the key at the same index as one change,
+// a persisted collection journals them together so a crash can't leave only some of them stored
+func (m *memoryCollection) putAll(keys []string, values []interface{}) error {
+	records := make([]journalRecord, 0, len(values))
+	for i, value := range values {
+		doc, err := bson.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("failed to encode document: %w", err)
+		}
+		records = append(records, journalRecord{op: opPut, key: keys[i], doc: doc})
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	batch, err := encodeBatch(records)
+	if err != nil {
+		return err
+	}
+
+	return m.commit(batch)
+}
+
+// remove deletes the document stored under key
+func (m *memoryCollection) remove(key string) error {
+	if _, exists := m.docs[key]; !exists {
+		return nil
+	}
+
+	return m.commit(journalRecord{op: opRemove, key: key})
+}
+
+// each calls fn with every document in insertion order until fn returns false
+func (m *memoryCollection) each(fn func(key string, doc bson.Raw) bool) {
+	for _, key := range m.order {
+		if !fn(key, m.docs[key]) {
+			return
+		}
+	}
+}
+
+// count returns the number of stored documents
+func (m *memoryCollection) count() int {
+	return len(m.docs)
+}
+
+// commit makes a change durable when the collection is persisted, then applies it
+func (m *memoryCollection) commit(record journalRecord) error {
+	if m.store != nil {
+		return m.store.commit(m, record)
+	}
+
+	m.apply(record)
+	return nil
+}
+
+// apply changes the in-memory state without persisting anything
+func (m *memoryCollection) apply(record journalRecord) {
+	switch record.op {
+	case opPut:
+		if _, exists := m.docs[record.key]; !exists {
+			m.order = append(m.order, record.key)
+		}
+		m.docs[record.key] = record.doc
+
+	case opBatch:
+		// batches are checked before they are committed or replayed
+		records, _ := decodeBatch(record.doc)
+		for _, r := range records {
+			m.apply(r)
+		}
+
+	case opRemove:
+		if _, exists := m.docs[record.key]; !exists {
+			return
+		}
+		delete(m.docs, record.key)
+		for i, k := range m.order {
+			if k == record.key {
+				m.order = append(m.order[:i], m.order[i+1:]...)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	domain "taskmanager/Domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// InMemoryCommentRepository is the concurrency-safe in-memory CommentRepository
+type InMemoryCommentRepository struct {
+	comments *memoryCollection
+}
+
+func NewInMemoryCommentRepository() CommentRepository {
+	return &InMemoryCommentRepository{
+		comments: newMemoryCollection(),
+	}
+}
+
+func (r *InMemoryCommentRepository) Create(ctx context.Context, comment domain.Comment) (domain.Comment, error) {
+
+	r.comments.mu.Lock()
+	defer r.comments.mu.Unlock()
+
+	if _, exists := r.comments.docs[comment.ID]; exists {
+		return domain.Comment{}, fmt.Errorf("failed to create comment: %w", domain.ErrAleadyExists)
+	}
+
+	if err := r.comments.put(comment.ID, comment); err != nil {
+		return domain.Comment{}, fmt.Errorf("failed to create comment: %w", err)
+	}
+
+	return comment, nil
+}
+
+func (r *InMemoryCommentRepository) GetByID(ctx context.Context, taskId string, commentId string) (domain.Comment, error) {
+
+	r.comments.mu.RLock()
+	defer r.comments.mu.RUnlock()
+
+	return r.find(taskId, commentId)
+}
+
+func (r *InMemoryCommentRepository) ListByTask(ctx context.Context, taskId string, limit int64, offset int64) ([]domain.Comment, int64, error) {
+
+	r.comments.mu.RLock()
+	defer r.comments.mu.RUnlock()
+
+	comments := []domain.Comment{}
+
+	var decodeErr error
+	r.comments.each(func(key string, doc bson.Raw) bool {
+		if id, _ := doc.Lookup("task_id").StringValueOK(); id != taskId {
+			return true
+		}
+		var comment domain.Comment
+		if err := bson.Unmarshal(doc, &comment); err != nil {
+			decodeErr = fmt.Errorf("failed to decode comments: %w", err)
+			return false
+		}
+		comments = append(comments, comment)
+		return true
+	})
+	if decodeErr != nil {
+		return nil, 0, decodeErr
+	}
+
+	// oldest first, comment_id keeps pages stable
+	sort.SliceStable(comments, func(i, j int) bool {
+		if !comments[i].CreatedAt.Equal(comments[j].CreatedAt) {
+			return comments[i].CreatedAt.Before(comments[j].CreatedAt)
+		}
+		return comments[i].ID < comments[j].ID
+	})
+
+	total := int64(len(comments))
+
+	if offset >= total {
+		return []domain.Comment{}, total, nil
+	}
+	comments = comments[offset:]
+	if limit > 0 && limit < int64(len(comments)) {
+		comments = comments[:limit]
+	}
+
+	return comments, total, nil
+}
+
+func (r *InMemoryCommentRepository) Update(ctx context.Context, taskId string, commentId string, body string, updatedAt time.Time) (domain.Comment, error) {
+
+	r.comments.mu.Lock()
+	defer r.comments.mu.Unlock()
+
+	comment, err := r.find(taskId, commentId)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+
+	comment.Body = body
+	comment.UpdatedAt = updatedAt
+	if err := r.comments.put(comment.ID, comment); err != nil {
+		return domain.Comment{}, fmt.Errorf("failed to update comment: %w", err)
+	}
+
+	// read it back so the returned comment has the stored precision
+	return r.find(taskId, commentId)
+}
+
+func (r *InMemoryCommentRepository) Delete(ctx context.Context, taskId string, commentId string) error {
+
+	r.comments.mu.Lock()
+	defer r.comments.mu.Unlock()
+
+	if _, err := r.find(taskId, commentId); err != nil {
+		return err
+	}
+
+	if err := r.comments.remove(commentId); err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteByTask removes every comment of a task, a task without comments is fine
+func (r *InMemoryCommentRepository) DeleteByTask(ctx context.Context, taskId string) error {
+
+	r.comments.mu.Lock()
+	defer r.comments.mu.Unlock()
+
+	var orphaned []string
+	var decodeErr error
+	r.comments.each(func(key string, doc bson.Raw) bool {
+		owner, ok := doc.Lookup("task_id").StringValueOK()
+		if !ok {
+			decodeErr = fmt.Errorf("failed to decode comments: comment %s has no task_id", key)
+			return false
+		}
+		if owner == taskId {
+			orphaned = append(orphaned, key)
+		}
+		return true
+	})
+	if decodeErr != nil {
+		return decodeErr
+	}
+
+	for _, key := range orphaned {
+		if err := r.comments.remove(key); err != nil {
+			return fmt.Errorf("failed to delete comments: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// find returns a comment of the given task, the caller must hold the lock
+func (r *InMemoryCommentRepository) find(taskId string, commentId string) (domain.Comment, error) {
+
+	var comment domain.Comment
+	found, err := r.comments.get(commentId, &comment)
+	if err != nil {
+		return domain.Comment{}, fmt.Errorf("failed to retrieve comment: %w", err)
+	}
+	if !found || comment.TaskID != taskId {
+		return domain.Comment{}, domain.ErrNotFound
+	}
+
+	return comment, nil
+}
@@ -0,0 +1,130 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	domain "taskmanager/Domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// InMemoryLabelRepository is the concurrency-safe in-memory LabelRepository
+type InMemoryLabelRepository struct {
+	labels *memoryCollection
+}
+
+func NewInMemoryLabelRepository() LabelRepository {
+	return &InMemoryLabelRepository{
+		labels: newMemoryCollection(),
+	}
+}
+
+func (r *InMemoryLabelRepository) GetAll(ctx context.Context) ([]domain.Label, error) {
+
+	r.labels.mu.RLock()
+	defer r.labels.mu.RUnlock()
+
+	labels := []domain.Label{}
+
+	var decodeErr error
+	r.labels.each(func(key string, doc bson.Raw) bool {
+		var label domain.Label
+		if err := bson.Unmarshal(doc, &label); err != nil {
+			decodeErr = fmt.Errorf("failed to decode labels: %w", err)
+			return false
+		}
+		labels = append(labels, label)
+		return true
+	})
+	if decodeErr != nil {
+		return nil, decodeErr
+	}
+
+	sort.Slice(labels, func(i, j int) bool {
+		return labels[i].Name < labels[j].Name
+	})
+
+	return labels, nil
+}
+
+func (r *InMemoryLabelRepository) GetByName(ctx context.Context, name string) (domain.Label, error) {
+
+	r.labels.mu.RLock()
+	defer r.labels.mu.RUnlock()
+
+	return r.find(name)
+}
+
+func (r *InMemoryLabelRepository) Create(ctx context.Context, label domain.Label) (domain.Label, error) {
+
+	r.labels.mu.Lock()
+	defer r.labels.mu.Unlock()
+
+	if _, exists := r.labels.docs[label.Name]; exists {
+		return domain.Label{}, fmt.Errorf("failed to create label: %w", domain.ErrAleadyExists)
+	}
+
+	if err := r.labels.put(label.Name, label); err != nil {
+		return domain.Label{}, fmt.Errorf("failed to create label: %w", err)
+	}
+
+	return label, nil
+}
+
+func (r *InMemoryLabelRepository) Update(ctx context.Context, name string, label domain.Label) (domain.Label, error) {
+
+	r.labels.mu.Lock()
+	defer r.labels.mu.Unlock()
+
+	if _, err := r.find(name); err != nil {
+		return domain.Label{}, err
+	}
+
+	// a rename moves the label to its new key
+	if label.Name != name {
+		if _, exists := r.labels.docs[label.Name]; exists {
+			return domain.Label{}, fmt.Errorf("failed to rename label: %w", domain.ErrAleadyExists)
+		}
+		if err := r.labels.remove(name); err != nil {
+			return domain.Label{}, fmt.Errorf("failed to rename label: %w", err)
+		}
+	}
+
+	if err := r.labels.put(label.Name, label); err != nil {
+		return domain.Label{}, fmt.Errorf("failed to update label: %w", err)
+	}
+
+	return label, nil
+}
+
+func (r *InMemoryLabelRepository) Delete(ctx context.Context, name string) error {
+
+	r.labels.mu.Lock()
+	defer r.labels.mu.Unlock()
+
+	if _, exists := r.labels.docs[name]; !exists {
+		return domain.ErrNotFound
+	}
+
+	if err := r.labels.remove(name); err != nil {
+		return fmt.Errorf("failed to delete label: %w", err)
+	}
+
+	return nil
+}
+
+// find decodes the label stored under name, the caller must hold the lock
+func (r *InMemoryLabelRepository) find(name string) (domain.Label, error) {
+
+	var label domain.Label
+	found, err := r.labels.get(name, &label)
+	if err != nil {
+		return domain.Label{}, fmt.Errorf("failed to retrieve label: %w", err)
+	}
+	if !found {
+		return domain.Label{}, domain.ErrNotFound
+	}
+
+	return label, nil
+}
@@ -0,0 +1,44 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	domain "taskmanager/Domain"
+)
+
+// InMemoryReminderRepository is the concurrency-safe in-memory ReminderRepository
+type InMemoryReminderRepository struct {
+	reminders *memoryCollection
+}
+
+func NewInMemoryReminderRepository() ReminderRepository {
+	return &InMemoryReminderRepository{
+		reminders: newMemoryCollection(),
+	}
+}
+
+func (r *InMemoryReminderRepository) WasSent(ctx context.Context, key string) (bool, error) {
+
+	r.reminders.mu.RLock()
+	defer r.reminders.mu.RUnlock()
+
+	_, exists := r.reminders.docs[key]
+	return exists, nil
+}
+
+// MarkSent records a sent reminder, recording it again keeps the first record
+func (r *InMemoryReminderRepository) MarkSent(ctx context.Context, reminder domain.Reminder) error {
+
+	r.reminders.mu.Lock()
+	defer r.reminders.mu.Unlock()
+
+	if _, exists := r.reminders.docs[reminder.Key]; exists {
+		return nil
+	}
+
+	if err := r.reminders.put(reminder.Key, reminder); err != nil {
+		return fmt.Errorf("failed to record sent reminder: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,689 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	domain "taskmanager/Domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// InMemoryTaskRepository is the concurrency-safe in-memory TaskRepository
+type InMemoryTaskRepository struct {
+	tasks *memoryCollection
+	index *searchIndex // full-text index, guarded by the collection's lock
+}
+
+func NewInMemoryTaskRepository() TaskRepository {
+	return newInMemoryTaskRepository(newMemoryCollection())
+}
+
+// newInMemoryTaskRepository wraps a collection that may already hold tasks
+func newInMemoryTaskRepository(tasks *memoryCollection) *InMemoryTaskRepository {
+
+	repo := &InMemoryTaskRepository{
+		tasks: tasks,
+		index: newSearchIndex(),
+	}
+
+	tasks.mu.Lock()
+	defer tasks.mu.Unlock()
+
+	// tasks that can't be decoded simply aren't searchable, GetAll reports the error.
+	// Deleted tasks are left out, they are indexed again when restored.
+	tasks.each(func(key string, doc bson.Raw) bool {
+		var task domain.Task
+		if err := bson.Unmarshal(doc, &task); err == nil && task.DeletedAt == nil {
+			repo.index.add(task)
+		}
+		return true
+	})
+
+	return repo
+}
+
+func (r *InMemoryTaskRepository) GetAll(ctx context.Context, filter domain.TaskFilter) ([]domain.Task, int64, error) {
+
+	r.tasks.mu.RLock()
+	defer r.tasks.mu.RUnlock()
+
+	matches, err := r.findTasks(func(task domain.Task) bool {
+		return matchesTaskFilter(task, filter)
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	sortTasks(matches, filter.SortBy, filter.SortDesc)
+
+	total := int64(len(matches))
+
+	// apply offset and limit the same way skip and limit work in MongoDB
+	if filter.Offset >= total {
+		return []domain.Task{}, total, nil
+	}
+	matches = matches[filter.Offset:]
+	if filter.Limit > 0 && filter.Limit < int64(len(matches)) {
+		matches = matches[:filter.Limit]
+	}
+
+	return matches, total, nil
+}
+
+func (r *InMemoryTaskRepository) Stream(ctx context.Context, filter domain.TaskFilter, fn func(task domain.Task) error) error {
+
+	// the matching tasks are copied first so fn runs without holding the lock
+	r.tasks.mu.RLock()
+	matches, err := r.findTasks(func(task domain.Task) bool {
+		return matchesTaskFilter(task, filter)
+	})
+	r.tasks.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	sortTasks(matches, filter.SortBy, filter.SortDesc)
+
+	for _, task := range matches {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(task); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *InMemoryTaskRepository) GetByID(ctx context.Context, id string) (domain.Task, error) {
+
+	r.tasks.mu.RLock()
+	defer r.tasks.mu.RUnlock()
+
+	return r.find(id, false)
+}
+
+func (r *InMemoryTaskRepository) Create(ctx context.Context, task domain.Task) (domain.Task, error) {
+
+	r.tasks.mu.Lock()
+	defer r.tasks.mu.Unlock()
+
+	if _, exists := r.tasks.docs[task.ID]; exists {
+		return domain.Task{}, fmt.Errorf("failed to create task: %w", domain.ErrAleadyExists)
+	}
+
+	if err := r.tasks.put(task.ID, task); err != nil {
+		return domain.Task{}, fmt.Errorf("failed to create task: %w", err)
+	}
+	r.index.add(task)
+
+	return task, nil
+}
+
+func (r *InMemoryTaskRepository) Update(ctx context.Context, id string, updates bson.M, expectedVersion int64) (domain.Task, error) {
+
+	r.tasks.mu.Lock()
+	defer r.tasks.mu.Unlock()
+
+	doc, err := r.updatedDocument(id, updates, expectedVersion)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	return r.storeDocument(id, doc)
+}
+
+// updatedDocument returns the stored live task with the updates applied and its version bumped,
+// without storing it. The caller must hold the lock.
+func (r *InMemoryTaskRepository) updatedDocument(id string, updates bson.M, expectedVersion int64) (bson.M, error) {
+
+	stored, ok := r.tasks.docs[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+
+	// work on the raw document so updates use the bson field names, like $set does
+	var doc bson.M
+	if err := bson.Unmarshal(stored, &doc); err != nil {
+		return nil, fmt.Errorf("failed to update task: %w", err)
+	}
+
+	// tasks in the trash can't be changed
+	if doc["deleted_at"] != nil {
+		return nil, domain.ErrNotFound
+	}
+
+	version := documentVersion(doc)
+	if expectedVersion > 0 && version != expectedVersion {
+		return nil, domain.ErrConflict
+	}
+
+	for field, value := range updates {
+		doc[field] = value
+	}
+	doc["version"] = version + 1
+
+	return doc, nil
+}
+
+// storeDocument stores an updated task document and keeps the search index in step, the caller must hold the lock
+func (r *InMemoryTaskRepository) storeDocument(id string, doc bson.M) (domain.Task, error) {
+
+	if err := r.tasks.put(id, doc); err != nil {
+		return domain.Task{}, fmt.Errorf("failed to update task: %w", err)
+	}
+
+	return r.reindex(id)
+}
+
+// reindex brings the search index in line with the stored task, the caller must hold the lock
+func (r *InMemoryTaskRepository) reindex(id string) (domain.Task, error) {
+
+	var task domain.Task
+	if _, err := r.tasks.get(id, &task); err != nil {
+		return domain.Task{}, fmt.Errorf("failed to update task: %w", err)
+	}
+
+	// an update setting deleted_at moves the task to the trash
+	if task.DeletedAt != nil {
+		r.index.remove(id)
+	} else {
+		r.index.add(task)
+	}
+
+	return task, nil
+}
+
+func (r *InMemoryTaskRepository) Delete(ctx context.Context, id string, expectedVersion int64, deletedAt time.Time) error {
+
+	r.tasks.mu.Lock()
+	defer r.tasks.mu.Unlock()
+
+	task, err := r.find(id, false)
+	if err != nil {
+		return err
+	}
+	if expectedVersion > 0 && task.Version != expectedVersion {
+		return domain.ErrConflict
+	}
+
+	task.DeletedAt = &deletedAt
+	task.Version++
+	if err := r.tasks.put(id, task); err != nil {
+		return fmt.Errorf("failed to delete task: %w", err)
+	}
+	r.index.remove(id)
+
+	return nil
+}
+
+func (r *InMemoryTaskRepository) ListDeleted(ctx context.Context, deletedBefore time.Time, limit int64, offset int64) ([]domain.Task, int64, error) {
+
+	r.tasks.mu.RLock()
+	defer r.tasks.mu.RUnlock()
+
+	// MongoDB compares dates with millisecond precision
+	deletedBefore = deletedBefore.Truncate(time.Millisecond)
+
+	matches, err := r.findTasks(func(task domain.Task) bool {
+		return task.DeletedAt != nil && (deletedBefore.IsZero() || task.DeletedAt.Before(deletedBefore))
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// most recently deleted first, then by id
+	sort.SliceStable(matches, func(i, j int) bool {
+		if c := matches[i].DeletedAt.Compare(*matches[j].DeletedAt); c != 0 {
+			return c > 0
+		}
+		return matches[i].ID < matches[j].ID
+	})
+
+	total := int64(len(matches))
+
+	if offset >= total {
+		return []domain.Task{}, total, nil
+	}
+	matches = matches[offset:]
+	if limit > 0 && limit < int64(len(matches)) {
+		matches = matches[:limit]
+	}
+
+	return matches, total, nil
+}
+
+func (r *InMemoryTaskRepository) GetDeletedByID(ctx context.Context, id string) (domain.Task, error) {
+
+	r.tasks.mu.RLock()
+	defer r.tasks.mu.RUnlock()
+
+	return r.find(id, true)
+}
+
+func (r *InMemoryTaskRepository) Restore(ctx context.Context, id string) (domain.Task, error) {
+
+	r.tasks.mu.Lock()
+	defer r.tasks.mu.Unlock()
+
+	task, err := r.find(id, true)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	task.DeletedAt = nil
+	task.Version++
+	if err := r.tasks.put(id, task); err != nil {
+		return domain.Task{}, fmt.Errorf("failed to restore task: %w", err)
+	}
+	r.index.add(task)
+
+	return task, nil
+}
+
+func (r *InMemoryTaskRepository) Purge(ctx context.Context, id string) error {
+
+	r.tasks.mu.Lock()
+	defer r.tasks.mu.Unlock()
+
+	if _, err := r.find(id, true); err != nil {
+		return err
+	}
+
+	if err := r.tasks.remove(id); err != nil {
+		return fmt.Errorf("failed to purge task: %w", err)
+	}
+
+	return nil
+}
+
+func (r *InMemoryTaskRepository) GetByIDs(ctx context.Context, ids []string) ([]domain.Task, error) {
+
+	r.tasks.mu.RLock()
+	defer r.tasks.mu.RUnlock()
+
+	tasks := []domain.Task{}
+	for _, id := range ids {
+		task, err := r.find(id, false)
+		if errors.Is(err, domain.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
+
+func (r *InMemoryTaskRepository) GetSubtasks(ctx context.Context, parentIDs []string) ([]domain.Task, error) {
+
+	r.tasks.mu.RLock()
+	defer r.tasks.mu.RUnlock()
+
+	subtasks, err := r.findTasks(func(task domain.Task) bool {
+		return task.DeletedAt == nil && task.ParentID != "" && slices.Contains(parentIDs, task.ParentID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sortTasks(subtasks, domain.TaskSortByDueDate, false)
+
+	return subtasks, nil
+}
+
+// BulkCreate stores the tasks under a single lock as one change, an atomic batch stores nothing when any task fails
+func (r *InMemoryTaskRepository) BulkCreate(ctx context.Context, tasks []domain.Task, atomic bool) ([]error, error) {
+
+	r.tasks.mu.Lock()
+	defer r.tasks.mu.Unlock()
+
+	errs := make([]error, len(tasks))
+	failed := false
+	seen := make(map[string]bool, len(tasks))
+	for i, task := range tasks {
+		if _, exists := r.tasks.docs[task.ID]; exists || seen[task.ID] {
+			errs[i] = domain.ErrAleadyExists
+			failed = true
+			continue
+		}
+		seen[task.ID] = true
+	}
+
+	if atomic && failed {
+		return errs, nil
+	}
+
+	// the tasks are stored as one change, on failure none of them is
+	var keys []string
+	var values []interface{}
+	for i, task := range tasks {
+		if errs[i] == nil {
+			keys = append(keys, task.ID)
+			values = append(values, task)
+		}
+	}
+	if err := r.tasks.putAll(keys, values); err != nil {
+		return nil, fmt.Errorf("failed to create tasks: %w", err)
+	}
+	for i, task := range tasks {
+		if errs[i] == nil {
+			r.index.add(task)
+		}
+	}
+
+	return errs, nil
+}
+
+// BulkUpdate applies the updates under a single lock as one change, an atomic batch changes nothing when any update fails
+func (r *InMemoryTaskRepository) BulkUpdate(ctx context.Context, updates []TaskUpdate, atomic bool) ([]error, error) {
+
+	r.tasks.mu.Lock()
+	defer r.tasks.mu.Unlock()
+
+	// every update is checked before any is applied
+	errs := make([]error, len(updates))
+	docs := make([]bson.M, len(updates))
+	failed := false
+	seen := make(map[string]bool, len(updates))
+	for i, update := range updates {
+		// like in MongoDB, a second update of the same task finds its version already bumped
+		if seen[update.ID] {
+			errs[i] = domain.ErrConflict
+			failed = true
+			continue
+		}
+		seen[update.ID] = true
+
+		doc, err := r.updatedDocument(update.ID, update.Updates, 0)
+		if errors.Is(err, domain.ErrNotFound) {
+			errs[i] = err
+			failed = true
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		// the version was bumped by one, unlike Update a zero expected version is checked too
+		if documentVersion(doc) != update.ExpectedVersion+1 {
+			errs[i] = domain.ErrConflict
+			failed = true
+			continue
+		}
+		docs[i] = doc
+	}
+
+	if atomic && failed {
+		return errs, nil
+	}
+
+	// the updates are stored as one change, on failure none of them is
+	var keys []string
+	var values []interface{}
+	for i, update := range updates {
+		if errs[i] == nil {
+			keys = append(keys, update.ID)
+			values = append(values, docs[i])
+		}
+	}
+	if err := r.tasks.putAll(keys, values); err != nil {
+		return nil, fmt.Errorf("failed to update tasks: %w", err)
+	}
+	for _, id := range keys {
+		if _, err := r.reindex(id); err != nil {
+			return nil, err
+		}
+	}
+
+	return errs, nil
+}
+
+// find returns a live task, or one in the trash when deleted is set, the caller must hold the lock
+func (r *InMemoryTaskRepository) find(id string, deleted bool) (domain.Task, error) {
+
+	var task domain.Task
+	found, err := r.tasks.get(id, &task)
+	if err != nil {
+		return domain.Task{}, fmt.Errorf("failed to retrieve task: %w", err)
+	}
+	if !found || (task.DeletedAt != nil) != deleted {
+		return domain.Task{}, domain.ErrNotFound
+	}
+
+	return task, nil
+}
+
+func (r *InMemoryTaskRepository) DistinctStatuses(ctx context.Context) ([]string, error) {
+
+	r.tasks.mu.RLock()
+	defer r.tasks.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	statuses := []string{}
+
+	// like MongoDB, only string values are reported
+	r.tasks.each(func(key string, doc bson.Raw) bool {
+		status, ok := doc.Lookup("status").StringValueOK()
+		if ok && !seen[status] {
+			seen[status] = true
+			statuses = append(statuses, status)
+		}
+		return true
+	})
+
+	sort.Strings(statuses)
+
+	return statuses, nil
+}
+
+func (r *InMemoryTaskRepository) ReplaceStatus(ctx context.Context, from string, to domain.TaskStatus) (int64, error) {
+
+	r.tasks.mu.Lock()
+	defer r.tasks.mu.Unlock()
+
+	matches, err := r.findTasks(func(task domain.Task) bool {
+		return string(task.Status) == from
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to replace task status: %w", err)
+	}
+
+	// UpdateMany only counts documents whose value actually changed
+	var modified int64
+	for _, task := range matches {
+		if task.Status == to {
+			continue
+		}
+		task.Status = to
+		task.Version++
+		if err := r.tasks.put(task.ID, task); err != nil {
+			return modified, fmt.Errorf("failed to replace task status: %w", err)
+		}
+		modified++
+	}
+
+	return modified, nil
+}
+
+func (r *InMemoryTaskRepository) RenameLabel(ctx context.Context, from string, to string) (int64, error) {
+
+	r.tasks.mu.Lock()
+	defer r.tasks.mu.Unlock()
+
+	return r.relabel(from, func(labels []string) []string {
+		if !slices.Contains(labels, to) {
+			labels = append(labels, to)
+			sort.Strings(labels)
+		}
+		return labels
+	})
+}
+
+func (r *InMemoryTaskRepository) DropLabel(ctx context.Context, name string) (int64, error) {
+
+	r.tasks.mu.Lock()
+	defer r.tasks.mu.Unlock()
+
+	return r.relabel(name, func(labels []string) []string { return labels })
+}
+
+// relabel takes the label off every task carrying it, the trash included, and lets
+// replace adjust the remaining labels. The caller must hold the lock.
+func (r *InMemoryTaskRepository) relabel(label string, replace func(labels []string) []string) (int64, error) {
+
+	matches, err := r.findTasks(func(task domain.Task) bool {
+		return slices.Contains(task.Labels, label)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to update labels: %w", err)
+	}
+
+	var modified int64
+	for _, task := range matches {
+		labels := slices.DeleteFunc(slices.Clone(task.Labels), func(l string) bool { return l == label })
+		task.Labels = replace(labels)
+		task.Version++
+		if err := r.tasks.put(task.ID, task); err != nil {
+			return modified, fmt.Errorf("failed to update labels: %w", err)
+		}
+		modified++
+	}
+
+	return modified, nil
+}
+
+func (r *InMemoryTaskRepository) Search(ctx context.Context, query domain.TaskSearchQuery) ([]domain.TaskSearchHit, int64, error) {
+
+	r.tasks.mu.RLock()
+	defer r.tasks.mu.RUnlock()
+
+	scores := r.index.search(query)
+	ranked := rankSearchScores(scores)
+
+	total := int64(len(ranked))
+
+	if query.Offset >= total {
+		return []domain.TaskSearchHit{}, total, nil
+	}
+	ranked = ranked[query.Offset:]
+	if query.Limit > 0 && query.Limit < int64(len(ranked)) {
+		ranked = ranked[:query.Limit]
+	}
+
+	hits := make([]domain.TaskSearchHit, 0, len(ranked))
+	for _, taskId := range ranked {
+		var task domain.Task
+		if _, err := r.tasks.get(taskId, &task); err != nil {
+			return nil, 0, fmt.Errorf("failed to search tasks: %w", err)
+		}
+		hits = append(hits, domain.TaskSearchHit{Task: task, Score: scores[taskId]})
+	}
+
+	return hits, total, nil
+}
+
+// findTasks decodes every stored task accepted by match, the caller must hold the lock
+func (r *InMemoryTaskRepository) findTasks(match func(task domain.Task) bool) ([]domain.Task, error) {
+
+	tasks := []domain.Task{}
+
+	var decodeErr error
+	r.tasks.each(func(key string, doc bson.Raw) bool {
+		var task domain.Task
+		if err := bson.Unmarshal(doc, &task); err != nil {
+			decodeErr = fmt.Errorf("failed to decode tasks: %w", err)
+			return false
+		}
+		if match(task) {
+			tasks = append(tasks, task)
+		}
+		return true
+	})
+
+	return tasks, decodeErr
+}
+
+// matchesTaskFilter mirrors the query built by buildTaskQuery
+func matchesTaskFilter(task domain.Task, filter domain.TaskFilter) bool {
+
+	if task.DeletedAt != nil {
+		return false
+	}
+	if filter.Status != "" && task.Status != filter.Status {
+		return false
+	}
+	if filter.AssigneeID != "" && task.AssigneeID != filter.AssigneeID {
+		return false
+	}
+	if filter.CreatedBy != "" && task.CreatedBy != filter.CreatedBy {
+		return false
+	}
+	if filter.TitlePrefix != "" && !strings.HasPrefix(strings.ToLower(task.Title), strings.ToLower(filter.TitlePrefix)) {
+		return false
+	}
+	if len(filter.LabelsAny) > 0 && !slices.ContainsFunc(filter.LabelsAny, func(label string) bool { return slices.Contains(task.Labels, label) }) {
+		return false
+	}
+	for _, label := range filter.LabelsAll {
+		if !slices.Contains(task.Labels, label) {
+			return false
+		}
+	}
+
+	// MongoDB compares dates with millisecond precision
+	if !filter.DueFrom.IsZero() && task.DueDate.Before(filter.DueFrom.Truncate(time.Millisecond)) {
+		return false
+	}
+	if !filter.DueTo.IsZero() && task.DueDate.After(filter.DueTo.Truncate(time.Millisecond)) {
+		return false
+	}
+
+	return true
+}
+
+// sortTasks orders tasks by the given field and then by id, like the MongoDB sort document
+func sortTasks(tasks []domain.Task, sortBy string, desc bool) {
+
+	compare := func(a, b domain.Task) int {
+		switch sortBy {
+		case domain.TaskSortByDueDate:
+			return a.DueDate.Compare(b.DueDate)
+		case domain.TaskSortByTitle:
+			return strings.Compare(a.Title, b.Title)
+		case domain.TaskSortByStatus:
+			return strings.Compare(string(a.Status), string(b.Status))
+		}
+		return 0
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if c := compare(tasks[i], tasks[j]); c != 0 {
+			if desc {
+				return c > 0
+			}
+			return c < 0
+		}
+		return tasks[i].ID < tasks[j].ID
+	})
+}
+
+// documentVersion reads the version field of a decoded document, missing versions count as 0
+func documentVersion(doc bson.M) int64 {
+	switch version := doc["version"].(type) {
+	case int64:
+		return version
+	case int32:
+		return int64(version)
+	case int:
+		return int64(version)
+	}
+	return 0
+}
@@ -0,0 +1,153 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	domain "taskmanager/Domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// InMemoryTokenRepository is the concurrency-safe in-memory TokenRepository
+type InMemoryTokenRepository struct {
+	refreshTokens *memoryCollection
+	revokedTokens *memoryCollection
+}
+
+func NewInMemoryTokenRepository() TokenRepository {
+	return &InMemoryTokenRepository{
+		refreshTokens: newMemoryCollection(),
+		revokedTokens: newMemoryCollection(),
+	}
+}
+
+func (r *InMemoryTokenRepository) SaveRefreshToken(ctx context.Context, token domain.RefreshToken) error {
+
+	r.refreshTokens.mu.Lock()
+	defer r.refreshTokens.mu.Unlock()
+
+	if _, exists := r.refreshTokens.docs[token.ID]; exists {
+		return fmt.Errorf("failed to save refresh token: %w", domain.ErrAleadyExists)
+	}
+
+	if err := r.refreshTokens.put(token.ID, token); err != nil {
+		return fmt.Errorf("failed to save refresh token: %w", err)
+	}
+
+	return nil
+}
+
+func (r *InMemoryTokenRepository) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (domain.RefreshToken, error) {
+
+	r.refreshTokens.mu.RLock()
+	defer r.refreshTokens.mu.RUnlock()
+
+	var (
+		token     domain.RefreshToken
+		found     bool
+		decodeErr error
+	)
+
+	r.refreshTokens.each(func(key string, doc bson.Raw) bool {
+		hash, _ := doc.Lookup("token_hash").StringValueOK()
+		if hash != tokenHash {
+			return true
+		}
+		if err := bson.Unmarshal(doc, &token); err != nil {
+			decodeErr = fmt.Errorf("failed to retrieve refresh token: %w", err)
+		}
+		found = true
+		return false
+	})
+
+	if decodeErr != nil {
+		return domain.RefreshToken{}, decodeErr
+	}
+	if !found {
+		return domain.RefreshToken{}, domain.ErrNotFound
+	}
+
+	return token, nil
+}
+
+// RotateRefreshToken marks the token as used, it fails with domain.ErrConflict
+// when the token was already rotated or revoked
+func (r *InMemoryTokenRepository) RotateRefreshToken(ctx context.Context, tokenId string, replacedBy string) error {
+
+	r.refreshTokens.mu.Lock()
+	defer r.refreshTokens.mu.Unlock()
+
+	var token domain.RefreshToken
+	found, err := r.refreshTokens.get(tokenId, &token)
+	if err != nil {
+		return fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+	if !found || token.ReplacedBy != "" || token.Revoked {
+		return domain.ErrConflict
+	}
+
+	token.ReplacedBy = replacedBy
+	if err := r.refreshTokens.put(tokenId, token); err != nil {
+		return fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+
+	return nil
+}
+
+func (r *InMemoryTokenRepository) RevokeTokenFamily(ctx context.Context, familyId string) error {
+
+	r.refreshTokens.mu.Lock()
+	defer r.refreshTokens.mu.Unlock()
+
+	var family []domain.RefreshToken
+	var decodeErr error
+	r.refreshTokens.each(func(key string, doc bson.Raw) bool {
+		id, _ := doc.Lookup("family_id").StringValueOK()
+		if id != familyId {
+			return true
+		}
+		var token domain.RefreshToken
+		if err := bson.Unmarshal(doc, &token); err != nil {
+			decodeErr = err
+			return false
+		}
+		family = append(family, token)
+		return true
+	})
+	if decodeErr != nil {
+		return fmt.Errorf("failed to revoke token family: %w", decodeErr)
+	}
+
+	for _, token := range family {
+		token.Revoked = true
+		if err := r.refreshTokens.put(token.ID, token); err != nil {
+			return fmt.Errorf("failed to revoke token family: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *InMemoryTokenRepository) RevokeAccessToken(ctx context.Context, tokenId string, expiresAt time.Time) error {
+
+	r.revokedTokens.mu.Lock()
+	defer r.revokedTokens.mu.Unlock()
+
+	// revoking the same token twice is harmless, the record is simply replaced
+	if err := r.revokedTokens.put(tokenId, domain.RevokedToken{ID: tokenId, ExpiresAt: expiresAt}); err != nil {
+		return fmt.Errorf("failed to revoke access token: %w", err)
+	}
+
+	return nil
+}
+
+func (r *InMemoryTokenRepository) IsAccessTokenRevoked(ctx context.Context, tokenId string) (bool, error) {
+
+	r.revokedTokens.mu.RLock()
+	defer r.revokedTokens.mu.RUnlock()
+
+	_, revoked := r.revokedTokens.docs[tokenId]
+
+	return revoked, nil
+}
@@ -0,0 +1,225 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	domain "taskmanager/Domain"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// InMemoryUserRepository is the concurrency-safe in-memory UserRepository
+type InMemoryUserRepository struct {
+	users *memoryCollection
+}
+
+func NewInMemoryUserRepository() UserRepository {
+	return &InMemoryUserRepository{
+		users: newMemoryCollection(),
+	}
+}
+
+func (r *InMemoryUserRepository) IsUsernameAvailable(ctx context.Context, userName string) error {
+
+	r.users.mu.RLock()
+	defer r.users.mu.RUnlock()
+
+	_, found, err := r.findByUserName(userName)
+	if err != nil {
+		return fmt.Errorf("error checking username uniqueness: %w", err)
+	}
+	if found {
+		return domain.ErrAleadyExists
+	}
+
+	return nil
+}
+
+func (r *InMemoryUserRepository) IsDatabaseEmpty(ctx context.Context) (bool, error) {
+
+	r.users.mu.RLock()
+	defer r.users.mu.RUnlock()
+
+	return r.users.count() == 0, nil
+}
+
+func (r *InMemoryUserRepository) SaveUser(ctx context.Context, user domain.User) (domain.User, error) {
+
+	r.users.mu.Lock()
+	defer r.users.mu.Unlock()
+
+	// the id and the username are unique, like the indexes of the MongoDB collection
+	key := user.ID.String()
+	if _, exists := r.users.docs[key]; exists {
+		return domain.User{}, fmt.Errorf("error registering user: %w", domain.ErrAleadyExists)
+	}
+	_, taken, err := r.findByUserName(user.UserName)
+	if err != nil {
+		return domain.User{}, fmt.Errorf("error registering user: %w", err)
+	}
+	if taken {
+		return domain.User{}, fmt.Errorf("error registering user: %w", domain.ErrAleadyExists)
+	}
+
+	if err := r.users.put(key, user); err != nil {
+		return domain.User{}, fmt.Errorf("error registering user: %w", err)
+	}
+
+	return user, nil
+}
+
+func (r *InMemoryUserRepository) DoesUserExist(ctx context.Context, userName string) (string, string, domain.UserRole, error) {
+
+	r.users.mu.RLock()
+	defer r.users.mu.RUnlock()
+
+	user, found, err := r.findByUserName(userName)
+	if err != nil {
+		return "", "", domain.RoleUser, fmt.Errorf("error checking user name: %w", err)
+	}
+	if !found {
+		return "", "", domain.RoleUser, domain.ErrNotFound
+	}
+
+	return user.ID.String(), user.HashedPassword, user.Role, nil
+}
+
+func (r *InMemoryUserRepository) PromoteUser(ctx context.Context, userId string) (domain.User, error) {
+
+	parsedUUID, err := uuid.Parse(userId)
+	if err != nil {
+		return domain.User{}, fmt.Errorf("failed to parse string id to uuid type")
+	}
+
+	r.users.mu.Lock()
+	defer r.users.mu.Unlock()
+
+	key := parsedUUID.String()
+
+	var user domain.User
+	found, err := r.users.get(key, &user)
+	if err != nil {
+		return domain.User{}, fmt.Errorf("failed to update user status: %w", err)
+	}
+	if !found {
+		return domain.User{}, domain.ErrNotFound
+	}
+
+	user.Role = domain.RoleAdmin
+	if err := r.users.put(key, user); err != nil {
+		return domain.User{}, fmt.Errorf("failed to update user status: %w", err)
+	}
+
+	return user, nil
+}
+
+func (r *InMemoryUserRepository) GetUserByID(ctx context.Context, userId string) (domain.User, error) {
+
+	parsedUUID, err := uuid.Parse(userId)
+	if err != nil {
+		return domain.User{}, domain.ErrNotFound
+	}
+
+	r.users.mu.RLock()
+	defer r.users.mu.RUnlock()
+
+	var user domain.User
+	found, err := r.users.get(parsedUUID.String(), &user)
+	if err != nil {
+		return domain.User{}, fmt.Errorf("failed to retrieve user: %w", err)
+	}
+	if !found {
+		return domain.User{}, domain.ErrNotFound
+	}
+
+	return user, nil
+}
+
+// UpdatePassword replaces the password hash of a user and returns the updated user
+func (r *InMemoryUserRepository) UpdatePassword(ctx context.Context, userId string, hashedPassword string, mustChange bool, changedAt time.Time) (domain.User, error) {
+
+	parsedUUID, err := uuid.Parse(userId)
+	if err != nil {
+		return domain.User{}, domain.ErrNotFound
+	}
+
+	r.users.mu.Lock()
+	defer r.users.mu.Unlock()
+
+	key := parsedUUID.String()
+
+	var user domain.User
+	found, err := r.users.get(key, &user)
+	if err != nil {
+		return domain.User{}, fmt.Errorf("failed to update password: %w", err)
+	}
+	if !found {
+		return domain.User{}, domain.ErrNotFound
+	}
+
+	user.HashedPassword = hashedPassword
+	user.MustChangePassword = mustChange
+	user.PasswordChangedAt = changedAt
+	if err := r.users.put(key, user); err != nil {
+		return domain.User{}, fmt.Errorf("failed to update password: %w", err)
+	}
+
+	return user, nil
+}
+
+// ReplacePasswordHash swaps the hash of an unchanged password for a stronger hash of the same password.
+// It fails with domain.ErrConflict when the hash is no longer currentHash, e.g. the password was changed meanwhile.
+func (r *InMemoryUserRepository) ReplacePasswordHash(ctx context.Context, userId string, currentHash string, newHash string) error {
+
+	parsedUUID, err := uuid.Parse(userId)
+	if err != nil {
+		return domain.ErrNotFound
+	}
+
+	r.users.mu.Lock()
+	defer r.users.mu.Unlock()
+
+	key := parsedUUID.String()
+
+	var user domain.User
+	found, err := r.users.get(key, &user)
+	if err != nil {
+		return fmt.Errorf("failed to replace password hash: %w", err)
+	}
+	if !found || user.HashedPassword != currentHash {
+		return domain.ErrConflict
+	}
+
+	user.HashedPassword = newHash
+	if err := r.users.put(key, user); err != nil {
+		return fmt.Errorf("failed to replace password hash: %w", err)
+	}
+
+	return nil
+}
+
+// findByUserName returns the first stored user with the given user name, the caller must hold the lock
+func (r *InMemoryUserRepository) findByUserName(userName string) (domain.User, bool, error) {
+
+	var (
+		user      domain.User
+		found     bool
+		decodeErr error
+	)
+
+	r.users.each(func(key string, doc bson.Raw) bool {
+		name, _ := doc.Lookup("user_name").StringValueOK()
+		if name != userName {
+			return true
+		}
+		if err := bson.Unmarshal(doc, &user); err != nil {
+			decodeErr = fmt.Errorf("failed to decode user: %w", err)
+		}
+		found = true
+		return false
+	})
+
+	return user, found, decodeErr
+}
@@ -0,0 +1,242 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	domain "taskmanager/Domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// InMemoryWebhookRepository is the concurrency-safe in-memory WebhookRepository
+type InMemoryWebhookRepository struct {
+	webhooks   *memoryCollection
+	deliveries *memoryCollection
+}
+
+func NewInMemoryWebhookRepository() WebhookRepository {
+	return &InMemoryWebhookRepository{
+		webhooks:   newMemoryCollection(),
+		deliveries: newMemoryCollection(),
+	}
+}
+
+func (r *InMemoryWebhookRepository) GetAll(ctx context.Context) ([]domain.Webhook, error) {
+
+	r.webhooks.mu.RLock()
+	defer r.webhooks.mu.RUnlock()
+
+	webhooks := []domain.Webhook{}
+
+	var decodeErr error
+	r.webhooks.each(func(key string, doc bson.Raw) bool {
+		var webhook domain.Webhook
+		if err := bson.Unmarshal(doc, &webhook); err != nil {
+			decodeErr = fmt.Errorf("failed to decode webhooks: %w", err)
+			return false
+		}
+		webhooks = append(webhooks, webhook)
+		return true
+	})
+	if decodeErr != nil {
+		return nil, decodeErr
+	}
+
+	sort.SliceStable(webhooks, func(i, j int) bool {
+		if !webhooks[i].CreatedAt.Equal(webhooks[j].CreatedAt) {
+			return webhooks[i].CreatedAt.Before(webhooks[j].CreatedAt)
+		}
+		return webhooks[i].ID < webhooks[j].ID
+	})
+
+	return webhooks, nil
+}
+
+func (r *InMemoryWebhookRepository) GetByID(ctx context.Context, id string) (domain.Webhook, error) {
+
+	r.webhooks.mu.RLock()
+	defer r.webhooks.mu.RUnlock()
+
+	var webhook domain.Webhook
+	found, err := r.webhooks.get(id, &webhook)
+	if err != nil {
+		return domain.Webhook{}, fmt.Errorf("failed to retrieve webhook: %w", err)
+	}
+	if !found {
+		return domain.Webhook{}, domain.ErrNotFound
+	}
+
+	return webhook, nil
+}
+
+func (r *InMemoryWebhookRepository) Create(ctx context.Context, webhook domain.Webhook) (domain.Webhook, error) {
+
+	r.webhooks.mu.Lock()
+	defer r.webhooks.mu.Unlock()
+
+	if _, exists := r.webhooks.docs[webhook.ID]; exists {
+		return domain.Webhook{}, fmt.Errorf("failed to create webhook: %w", domain.ErrAleadyExists)
+	}
+
+	if err := r.webhooks.put(webhook.ID, webhook); err != nil {
+		return domain.Webhook{}, fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+func (r *InMemoryWebhookRepository) Update(ctx context.Context, webhook domain.Webhook) (domain.Webhook, error) {
+
+	r.webhooks.mu.Lock()
+	defer r.webhooks.mu.Unlock()
+
+	if _, exists := r.webhooks.docs[webhook.ID]; !exists {
+		return domain.Webhook{}, domain.ErrNotFound
+	}
+
+	if err := r.webhooks.put(webhook.ID, webhook); err != nil {
+		return domain.Webhook{}, fmt.Errorf("failed to update webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+func (r *InMemoryWebhookRepository) Delete(ctx context.Context, id string) error {
+
+	r.webhooks.mu.Lock()
+	defer r.webhooks.mu.Unlock()
+
+	if _, exists := r.webhooks.docs[id]; !exists {
+		return domain.ErrNotFound
+	}
+
+	if err := r.webhooks.remove(id); err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	// the delivery log goes with the webhook
+	r.deliveries.mu.Lock()
+	defer r.deliveries.mu.Unlock()
+
+	var orphaned []string
+	var decodeErr error
+	r.deliveries.each(func(key string, doc bson.Raw) bool {
+		webhookId, ok := doc.Lookup("webhook_id").StringValueOK()
+		if !ok {
+			decodeErr = fmt.Errorf("failed to decode webhook deliveries: delivery %s has no webhook_id", key)
+			return false
+		}
+		if webhookId == id {
+			orphaned = append(orphaned, key)
+		}
+		return true
+	})
+	if decodeErr != nil {
+		return decodeErr
+	}
+
+	for _, key := range orphaned {
+		if err := r.deliveries.remove(key); err != nil {
+			return fmt.Errorf("failed to delete webhook deliveries: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *InMemoryWebhookRepository) SaveDelivery(ctx context.Context, delivery domain.WebhookDelivery) error {
+
+	r.deliveries.mu.Lock()
+	defer r.deliveries.mu.Unlock()
+
+	if err := r.deliveries.put(delivery.ID, delivery); err != nil {
+		return fmt.Errorf("failed to save webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+func (r *InMemoryWebhookRepository) GetDelivery(ctx context.Context, id string) (domain.WebhookDelivery, error) {
+
+	r.deliveries.mu.RLock()
+	defer r.deliveries.mu.RUnlock()
+
+	var delivery domain.WebhookDelivery
+	found, err := r.deliveries.get(id, &delivery)
+	if err != nil {
+		return domain.WebhookDelivery{}, fmt.Errorf("failed to retrieve webhook delivery: %w", err)
+	}
+	if !found {
+		return domain.WebhookDelivery{}, domain.ErrNotFound
+	}
+
+	return delivery, nil
+}
+
+func (r *InMemoryWebhookRepository) ListDeliveries(ctx context.Context, webhookId string, limit int64, offset int64) ([]domain.WebhookDelivery, int64, error) {
+
+	deliveries, err := r.matching(func(delivery domain.WebhookDelivery) bool {
+		return delivery.WebhookID == webhookId
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// newest first, delivery_id keeps pages stable
+	for i, j := 0, len(deliveries)-1; i < j; i, j = i+1, j-1 {
+		deliveries[i], deliveries[j] = deliveries[j], deliveries[i]
+	}
+
+	total := int64(len(deliveries))
+
+	if offset >= total {
+		return []domain.WebhookDelivery{}, total, nil
+	}
+	deliveries = deliveries[offset:]
+	if limit > 0 && limit < int64(len(deliveries)) {
+		deliveries = deliveries[:limit]
+	}
+
+	return deliveries, total, nil
+}
+
+func (r *InMemoryWebhookRepository) GetPendingDeliveries(ctx context.Context) ([]domain.WebhookDelivery, error) {
+	return r.matching(func(delivery domain.WebhookDelivery) bool {
+		return delivery.Status == domain.DeliveryPending
+	})
+}
+
+// matching returns the deliveries selected by keep, oldest first
+func (r *InMemoryWebhookRepository) matching(keep func(delivery domain.WebhookDelivery) bool) ([]domain.WebhookDelivery, error) {
+
+	r.deliveries.mu.RLock()
+	defer r.deliveries.mu.RUnlock()
+
+	deliveries := []domain.WebhookDelivery{}
+
+	var decodeErr error
+	r.deliveries.each(func(key string, doc bson.Raw) bool {
+		var delivery domain.WebhookDelivery
+		if err := bson.Unmarshal(doc, &delivery); err != nil {
+			decodeErr = fmt.Errorf("failed to decode webhook deliveries: %w", err)
+			return false
+		}
+		if keep(delivery) {
+			deliveries = append(deliveries, delivery)
+		}
+		return true
+	})
+	if decodeErr != nil {
+		return nil, decodeErr
+	}
+
+	sort.SliceStable(deliveries, func(i, j int) bool {
+		if !deliveries[i].CreatedAt.Equal(deliveries[j].CreatedAt) {
+			return deliveries[i].CreatedAt.Before(deliveries[j].CreatedAt)
+		}
+		return deliveries[i].ID < deliveries[j].ID
+	})
+
+	return deliveries, nil
+}
@@ -0,0 +1,45 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	domain "taskmanager/Domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// indexTimeout bounds creating the indexes of a collection when its repository is constructed
+const indexTimeout = 10 * time.Second
+
+// createUniqueIndexes makes each field unique in the collection, so MongoDB rejects a duplicate
+// the way the in-memory repositories do. Creating an index that exists is a no-op.
+func createUniqueIndexes(collection *mongo.Collection, fields ...string) error {
+
+	ctx, cancel := context.WithTimeout(context.Background(), indexTimeout)
+	defer cancel()
+
+	models := make([]mongo.IndexModel, 0, len(fields))
+	for _, field := range fields {
+		models = append(models, mongo.IndexModel{
+			Keys:    bson.D{{Key: field, Value: 1}},
+			Options: options.Index().SetName(field + "_unique").SetUnique(true),
+		})
+	}
+
+	if _, err := collection.Indexes().CreateMany(ctx, models); err != nil {
+		return fmt.Errorf("failed to create unique indexes on %s: %w", collection.Name(), err)
+	}
+
+	return nil
+}
+
+// duplicateKeyError turns a write that broke a unique index into domain.ErrAleadyExists
+func duplicateKeyError(err error) error {
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrAleadyExists
+	}
+	return err
+}
@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	domain "taskmanager/Domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ReminderRepository remembers which reminders were sent, so a restart doesn't send them again
+type ReminderRepository interface {
+	WasSent(ctx context.Context, key string) (bool, error)
+	MarkSent(ctx context.Context, reminder domain.Reminder) error
+}
+
+type MongoReminderRepository struct {
+	reminderCollection *mongo.Collection
+}
+
+func NewMongoReminderRepository(client *mongo.Client, dbName string, collectionName string) (ReminderRepository, error) {
+	collection := client.Database(dbName).Collection(collectionName)
+
+	if err := createUniqueIndexes(collection, "reminder_key"); err != nil {
+		return nil, err
+	}
+
+	return &MongoReminderRepository{
+		reminderCollection: collection,
+	}, nil
+}
+
+func (m *MongoReminderRepository) WasSent(ctx context.Context, key string) (bool, error) {
+
+	count, err := m.reminderCollection.CountDocuments(ctx, bson.M{"reminder_key": key}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, fmt.Errorf("failed to check sent reminder: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// MarkSent records a sent reminder, recording it again keeps the first record
+func (m *MongoReminderRepository) MarkSent(ctx context.Context, reminder domain.Reminder) error {
+
+	opts := options.Update().SetUpsert(true)
+	_, err := m.reminderCollection.UpdateOne(ctx, bson.M{"reminder_key": reminder.Key}, bson.M{"$setOnInsert": reminder}, opts)
+	if err != nil {
+		return fmt.Errorf("failed to record sent reminder: %w", err)
+	}
+
+	return nil
+}
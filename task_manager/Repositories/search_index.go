@@ -0,0 +1,228 @@
+package repositories
+
+import (
+	"math"
+	"sort"
+	"strings"
+	domain "taskmanager/Domain"
+)
+
+// relevance weight of a word found in each field, the MongoDB text index uses the same weights
+const (
+	titleSearchWeight       = 3
+	descriptionSearchWeight = 1
+)
+
+// searchIndex is an inverted index over task titles and descriptions,
+// used by the repositories that can't rely on a MongoDB text index.
+// It isn't safe for concurrent use, the owning repository guards it with its own lock.
+type searchIndex struct {
+	postings map[string]map[string]*searchPosting // word -> task id -> where the word occurs
+	words    map[string][]string                  // task id -> its distinct words, for removals
+}
+
+// searchPosting holds the positions of a word in each field of a task
+type searchPosting struct {
+	title       []int
+	description []int
+}
+
+func newSearchIndex() *searchIndex {
+	return &searchIndex{
+		postings: make(map[string]map[string]*searchPosting),
+		words:    make(map[string][]string),
+	}
+}
+
+// add indexes a task, replacing whatever was indexed for it before
+func (idx *searchIndex) add(task domain.Task) {
+
+	idx.remove(task.ID)
+
+	postings := make(map[string]*searchPosting)
+	posting := func(word string) *searchPosting {
+		if postings[word] == nil {
+			postings[word] = &searchPosting{}
+		}
+		return postings[word]
+	}
+
+	for i, word := range domain.TokenizeText(task.Title) {
+		p := posting(word)
+		p.title = append(p.title, i)
+	}
+	for i, word := range domain.TokenizeText(task.Description) {
+		p := posting(word)
+		p.description = append(p.description, i)
+	}
+
+	words := make([]string, 0, len(postings))
+	for word, p := range postings {
+		if idx.postings[word] == nil {
+			idx.postings[word] = make(map[string]*searchPosting)
+		}
+		idx.postings[word][task.ID] = p
+		words = append(words, word)
+	}
+	idx.words[task.ID] = words
+}
+
+// remove drops a task from the index
+func (idx *searchIndex) remove(taskId string) {
+
+	for _, word := range idx.words[taskId] {
+		delete(idx.postings[word], taskId)
+		if len(idx.postings[word]) == 0 {
+			delete(idx.postings, word)
+		}
+	}
+	delete(idx.words, taskId)
+}
+
+// search scores every task matching the query, unsorted
+func (idx *searchIndex) search(query domain.TaskSearchQuery) map[string]float64 {
+
+	scores := make(map[string]float64)
+	total := float64(len(idx.words))
+
+	// score adds the weighted frequency of a word to every task containing it,
+	// rarer words count more
+	score := func(word string, into map[string]float64) {
+		postings := idx.postings[word]
+		if len(postings) == 0 {
+			return
+		}
+		idf := math.Log(1 + total/float64(len(postings)))
+		for taskId, p := range postings {
+			into[taskId] += idf * float64(titleSearchWeight*len(p.title)+descriptionSearchWeight*len(p.description))
+		}
+	}
+
+	// 1. plain words: a task must contain at least one of them
+	for _, term := range query.Terms {
+		score(term, scores)
+	}
+
+	// 2. phrases and prefixes: a task must match every one of them
+	var required []map[string]float64
+
+	for _, phrase := range query.Phrases {
+		required = append(required, idx.phraseMatches(phrase))
+	}
+
+	for _, prefix := range query.Prefixes {
+		matches := make(map[string]float64)
+		for word := range idx.postings {
+			if strings.HasPrefix(word, prefix) {
+				score(word, matches)
+			}
+		}
+		required = append(required, matches)
+	}
+
+	if len(required) == 0 {
+		return scores
+	}
+
+	// start from the plain word matches, or from the first requirement when there are none
+	result := scores
+	if len(query.Terms) == 0 {
+		result = required[0]
+		required = required[1:]
+	}
+
+	for taskId := range result {
+		for _, matches := range required {
+			extra, ok := matches[taskId]
+			if !ok {
+				delete(result, taskId)
+				break
+			}
+			result[taskId] += extra
+		}
+	}
+
+	return result
+}
+
+// phraseMatches scores the tasks where the words of the phrase appear next to each other in one field
+func (idx *searchIndex) phraseMatches(phrase []string) map[string]float64 {
+
+	matches := make(map[string]float64)
+	total := float64(len(idx.words))
+
+	for taskId := range idx.postings[phrase[0]] {
+		postings := make([]*searchPosting, len(phrase))
+		for i, word := range phrase {
+			postings[i] = idx.postings[word][taskId]
+			if postings[i] == nil {
+				postings = nil
+				break
+			}
+		}
+		if postings == nil {
+			continue
+		}
+
+		titleHits := countPhrase(postings, func(p *searchPosting) []int { return p.title })
+		descriptionHits := countPhrase(postings, func(p *searchPosting) []int { return p.description })
+		if titleHits+descriptionHits == 0 {
+			continue
+		}
+
+		// a phrase is worth as much as its words together
+		var idf float64
+		for _, word := range phrase {
+			idf += math.Log(1 + total/float64(len(idx.postings[word])))
+		}
+		matches[taskId] = idf * float64(titleSearchWeight*titleHits+descriptionSearchWeight*descriptionHits)
+	}
+
+	return matches
+}
+
+// countPhrase counts the positions where the phrase words follow each other within one field
+func countPhrase(postings []*searchPosting, field func(p *searchPosting) []int) int {
+
+	positions := make([]map[int]bool, len(postings))
+	for i, p := range postings {
+		positions[i] = make(map[int]bool)
+		for _, position := range field(p) {
+			positions[i][position] = true
+		}
+	}
+
+	hits := 0
+	for _, start := range field(postings[0]) {
+		found := true
+		for i := 1; i < len(postings); i++ {
+			if !positions[i][start+i] {
+				found = false
+				break
+			}
+		}
+		if found {
+			hits++
+		}
+	}
+
+	return hits
+}
+
+// rankSearchScores orders task ids by score, best first, ties broken by id
+func rankSearchScores(scores map[string]float64) []string {
+
+	ids := make([]string, 0, len(scores))
+	for taskId := range scores {
+		ids = append(ids, taskId)
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		if scores[ids[i]] != scores[ids[j]] {
+			return scores[ids[i]] > scores[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+
+	return ids
+}
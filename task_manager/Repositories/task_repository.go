@@ -4,38 +4,99 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
 	domain "taskmanager/Domain"
+	"time"
 
+	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type TaskRepository interface {
-	GetAll(ctx context.Context) ([]domain.Task, error)
+	GetAll(ctx context.Context, filter domain.TaskFilter) ([]domain.Task, int64, error)
+	Stream(ctx context.Context, filter domain.TaskFilter, fn func(task domain.Task) error) error
 	GetByID(ctx context.Context, id string) (domain.Task, error)
 	Create(ctx context.Context, task domain.Task) (domain.Task, error)
-	Update(ctx context.Context, id string, updates bson.M) (domain.Task, error)
-	Delete(ctx context.Context, id string) error
+	Update(ctx context.Context, id string, updates bson.M, expectedVersion int64) (domain.Task, error)
+	Delete(ctx context.Context, id string, expectedVersion int64, deletedAt time.Time) error
+	ListDeleted(ctx context.Context, deletedBefore time.Time, limit int64, offset int64) ([]domain.Task, int64, error)
+	GetDeletedByID(ctx context.Context, id string) (domain.Task, error)
+	Restore(ctx context.Context, id string) (domain.Task, error)
+	Purge(ctx context.Context, id string) error
+	GetByIDs(ctx context.Context, ids []string) ([]domain.Task, error)
+	GetSubtasks(ctx context.Context, parentIDs []string) ([]domain.Task, error)
+	BulkCreate(ctx context.Context, tasks []domain.Task, atomic bool) ([]error, error)
+	BulkUpdate(ctx context.Context, updates []TaskUpdate, atomic bool) ([]error, error)
+	RenameLabel(ctx context.Context, from string, to string) (int64, error)
+	DropLabel(ctx context.Context, name string) (int64, error)
+	DistinctStatuses(ctx context.Context) ([]string, error)
+	ReplaceStatus(ctx context.Context, from string, to domain.TaskStatus) (int64, error)
+	Search(ctx context.Context, query domain.TaskSearchQuery) ([]domain.TaskSearchHit, int64, error)
+}
+
+// TaskUpdate is one write of a bulk update, it has the same meaning as the arguments of Update
+// except that the expected version is always checked, tasks stored without a version are at version 0.
+// Setting deleted_at in Updates moves the task to the trash, like Delete.
+type TaskUpdate struct {
+	ID              string
+	Updates         bson.M
+	ExpectedVersion int64
 }
 
 type MongoTaskRepository struct {
 	taskCollection *mongo.Collection
+
+	// the text index is created on the first search
+	textIndexMu    sync.Mutex
+	textIndexReady bool
 }
 
-func NewMongoTaskRepository(client *mongo.Client, dbName string, collectionName string) TaskRepository {
+func NewMongoTaskRepository(client *mongo.Client, dbName string, collectionName string) (TaskRepository, error) {
 	collection := client.Database(dbName).Collection(collectionName)
 
+	if err := createUniqueIndexes(collection, "task_id"); err != nil {
+		return nil, err
+	}
+
 	return &MongoTaskRepository{
 		taskCollection: collection,
-	}
+	}, nil
 }
 
-func (m *MongoTaskRepository) GetAll(ctx context.Context) ([]domain.Task, error) {
+func (m *MongoTaskRepository) GetAll(ctx context.Context, filter domain.TaskFilter) ([]domain.Task, int64, error) {
+
+	query := buildTaskQuery(filter)
 
-	cursor, err := m.taskCollection.Find(ctx, bson.D{})
+	// count every match so the caller can paginate
+	total, err := m.taskCollection.CountDocuments(ctx, query)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find tasks: %w", err)
+		return nil, 0, fmt.Errorf("failed to count tasks: %w", err)
+	}
+
+	// sort by the requested field, then by task_id so pages are stable
+	order := 1
+	if filter.SortDesc {
+		order = -1
+	}
+	sortBy := bson.D{}
+	if filter.SortBy != "" {
+		sortBy = append(sortBy, bson.E{Key: filter.SortBy, Value: order})
+	}
+	sortBy = append(sortBy, bson.E{Key: "task_id", Value: 1})
+
+	opts := options.Find().SetSort(sortBy).SetSkip(filter.Offset)
+	if filter.Limit > 0 {
+		opts.SetLimit(filter.Limit)
+	}
+
+	cursor, err := m.taskCollection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find tasks: %w", err)
 	}
 	defer cursor.Close(ctx) // close the cursor
 
@@ -43,17 +104,98 @@ func (m *MongoTaskRepository) GetAll(ctx context.Context) ([]domain.Task, error)
 
 	// decode all the documents to the tasks slice
 	if err := cursor.All(ctx, &tasks); err != nil {
-		return nil, fmt.Errorf("failed to decode tasks: %w", err)
+		return nil, 0, fmt.Errorf("failed to decode tasks: %w", err)
 	}
 
-	return tasks, nil
+	return tasks, total, nil
+}
+
+// Stream calls fn with every task matching the filter in the order it asks for,
+// the limit and offset of the filter are ignored
+func (m *MongoTaskRepository) Stream(ctx context.Context, filter domain.TaskFilter, fn func(task domain.Task) error) error {
+
+	order := 1
+	if filter.SortDesc {
+		order = -1
+	}
+	sortBy := bson.D{}
+	if filter.SortBy != "" {
+		sortBy = append(sortBy, bson.E{Key: filter.SortBy, Value: order})
+	}
+	sortBy = append(sortBy, bson.E{Key: "task_id", Value: 1})
+
+	cursor, err := m.taskCollection.Find(ctx, buildTaskQuery(filter), options.Find().SetSort(sortBy))
+	if err != nil {
+		return fmt.Errorf("failed to find tasks: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var task domain.Task
+		if err := cursor.Decode(&task); err != nil {
+			return fmt.Errorf("failed to decode task: %w", err)
+		}
+		if err := fn(task); err != nil {
+			return err
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return fmt.Errorf("failed to read tasks: %w", err)
+	}
+
+	return nil
+}
+
+// buildTaskQuery translates a task filter into a MongoDB query document
+func buildTaskQuery(filter domain.TaskFilter) bson.M {
+
+	// deleted tasks stay in the collection until purged, matching a null deleted_at
+	// also covers the tasks stored before soft deletes existed
+	query := bson.M{"deleted_at": nil}
+
+	if filter.Status != "" {
+		query["status"] = filter.Status
+	}
+	if filter.AssigneeID != "" {
+		query["assignee_id"] = filter.AssigneeID
+	}
+	if filter.CreatedBy != "" {
+		query["created_by"] = filter.CreatedBy
+	}
+	if filter.TitlePrefix != "" {
+		query["title"] = bson.M{"$regex": "^" + regexp.QuoteMeta(filter.TitlePrefix), "$options": "i"}
+	}
+
+	labels := bson.M{}
+	if len(filter.LabelsAny) > 0 {
+		labels["$in"] = filter.LabelsAny
+	}
+	if len(filter.LabelsAll) > 0 {
+		labels["$all"] = filter.LabelsAll
+	}
+	if len(labels) > 0 {
+		query["labels"] = labels
+	}
+
+	dueDate := bson.M{}
+	if !filter.DueFrom.IsZero() {
+		dueDate["$gte"] = filter.DueFrom
+	}
+	if !filter.DueTo.IsZero() {
+		dueDate["$lte"] = filter.DueTo
+	}
+	if len(dueDate) > 0 {
+		query["due_date"] = dueDate
+	}
+
+	return query
 }
 
 func (m *MongoTaskRepository) GetByID(ctx context.Context, id string) (domain.Task, error) {
 
 	var task domain.Task
 
-	filter := bson.M{"task_id": id}
+	filter := bson.M{"task_id": id, "deleted_at": nil}
 	err := m.taskCollection.FindOne(ctx, filter).Decode(&task)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
@@ -69,18 +211,26 @@ func (m *MongoTaskRepository) Create(ctx context.Context, task domain.Task) (dom
 
 	_, err := m.taskCollection.InsertOne(ctx, task)
 	if err != nil {
-		return domain.Task{}, fmt.Errorf("failed to create task: %w", err)
+		return domain.Task{}, fmt.Errorf("failed to create task: %w", duplicateKeyError(err))
 	}
 
 	return task, nil
 }
 
-func (m *MongoTaskRepository) Update(ctx context.Context, id string, updates bson.M) (domain.Task, error) {
+// Update applies the updates and bumps the task version.
+// A non-zero expectedVersion makes the write conditional on the stored version.
+func (m *MongoTaskRepository) Update(ctx context.Context, id string, updates bson.M, expectedVersion int64) (domain.Task, error) {
 
-	filter := bson.M{"task_id": id}
+	filter := bson.M{"task_id": id, "deleted_at": nil}
+	if expectedVersion > 0 {
+		filter["version"] = expectedVersion
+	}
 
 	// MongoDB query to update all fields inside updates map
-	updateQuery := bson.M{"$set": updates}
+	updateQuery := bson.M{"$inc": bson.M{"version": 1}}
+	if len(updates) > 0 {
+		updateQuery["$set"] = updates
+	}
 
 	opts := options.FindOneAndUpdate().SetReturnDocument(options.After) // return the updated document
 
@@ -89,7 +239,7 @@ func (m *MongoTaskRepository) Update(ctx context.Context, id string, updates bso
 	err := m.taskCollection.FindOneAndUpdate(ctx, filter, updateQuery, opts).Decode(&task)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
-			return domain.Task{}, domain.ErrNotFound
+			return domain.Task{}, m.missingTaskError(ctx, id, expectedVersion)
 		}
 		return domain.Task{}, fmt.Errorf("failed to update task: %w", err)
 	}
@@ -97,17 +247,576 @@ func (m *MongoTaskRepository) Update(ctx context.Context, id string, updates bso
 	return task, nil
 }
 
-func (m *MongoTaskRepository) Delete(ctx context.Context, id string) error {
+// Delete moves the task to the trash, a non-zero expectedVersion makes it conditional on the stored version
+func (m *MongoTaskRepository) Delete(ctx context.Context, id string, expectedVersion int64, deletedAt time.Time) error {
 
-	filter := bson.M{"task_id": id}
-	result, err := m.taskCollection.DeleteOne(ctx, filter)
+	filter := bson.M{"task_id": id, "deleted_at": nil}
+	if expectedVersion > 0 {
+		filter["version"] = expectedVersion
+	}
+
+	updateQuery := bson.M{"$set": bson.M{"deleted_at": deletedAt}, "$inc": bson.M{"version": 1}}
+
+	result, err := m.taskCollection.UpdateOne(ctx, filter, updateQuery)
 	if err != nil {
 		return fmt.Errorf("failed to delete task: %w", err)
 	}
 
+	if result.MatchedCount == 0 {
+		return m.missingTaskError(ctx, id, expectedVersion)
+	}
+
+	return nil
+}
+
+// ListDeleted returns the tasks in the trash, most recently deleted first.
+// A non-zero deletedBefore only lists the tasks deleted before that time.
+func (m *MongoTaskRepository) ListDeleted(ctx context.Context, deletedBefore time.Time, limit int64, offset int64) ([]domain.Task, int64, error) {
+
+	deletedAt := bson.M{"$ne": nil}
+	if !deletedBefore.IsZero() {
+		deletedAt["$lt"] = deletedBefore
+	}
+	query := bson.M{"deleted_at": deletedAt}
+
+	total, err := m.taskCollection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count deleted tasks: %w", err)
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "deleted_at", Value: -1}, {Key: "task_id", Value: 1}}).
+		SetSkip(offset)
+	if limit > 0 {
+		opts.SetLimit(limit)
+	}
+
+	cursor, err := m.taskCollection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find deleted tasks: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var tasks []domain.Task
+	if err := cursor.All(ctx, &tasks); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode deleted tasks: %w", err)
+	}
+
+	return tasks, total, nil
+}
+
+func (m *MongoTaskRepository) GetDeletedByID(ctx context.Context, id string) (domain.Task, error) {
+
+	var task domain.Task
+
+	filter := bson.M{"task_id": id, "deleted_at": bson.M{"$ne": nil}}
+	err := m.taskCollection.FindOne(ctx, filter).Decode(&task)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.Task{}, domain.ErrNotFound
+		}
+		return domain.Task{}, fmt.Errorf("failed to retrieve deleted task: %w", err)
+	}
+
+	return task, nil
+}
+
+// Restore takes a task out of the trash and bumps its version
+func (m *MongoTaskRepository) Restore(ctx context.Context, id string) (domain.Task, error) {
+
+	filter := bson.M{"task_id": id, "deleted_at": bson.M{"$ne": nil}}
+	updateQuery := bson.M{"$unset": bson.M{"deleted_at": ""}, "$inc": bson.M{"version": 1}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var task domain.Task
+	err := m.taskCollection.FindOneAndUpdate(ctx, filter, updateQuery, opts).Decode(&task)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.Task{}, domain.ErrNotFound
+		}
+		return domain.Task{}, fmt.Errorf("failed to restore task: %w", err)
+	}
+
+	return task, nil
+}
+
+// Purge permanently removes a task, only tasks in the trash can be purged
+func (m *MongoTaskRepository) Purge(ctx context.Context, id string) error {
+
+	filter := bson.M{"task_id": id, "deleted_at": bson.M{"$ne": nil}}
+
+	result, err := m.taskCollection.DeleteOne(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to purge task: %w", err)
+	}
 	if result.DeletedCount == 0 {
 		return domain.ErrNotFound
 	}
 
 	return nil
 }
+
+// GetByIDs returns the live tasks with the given ids, ids that don't match a task are left out
+func (m *MongoTaskRepository) GetByIDs(ctx context.Context, ids []string) ([]domain.Task, error) {
+
+	filter := bson.M{"task_id": bson.M{"$in": ids}, "deleted_at": nil}
+
+	cursor, err := m.taskCollection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find tasks: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	tasks := []domain.Task{}
+	if err := cursor.All(ctx, &tasks); err != nil {
+		return nil, fmt.Errorf("failed to decode tasks: %w", err)
+	}
+
+	return tasks, nil
+}
+
+// GetSubtasks returns the tasks whose parent is one of parentIDs, tasks in the trash are left out
+func (m *MongoTaskRepository) GetSubtasks(ctx context.Context, parentIDs []string) ([]domain.Task, error) {
+
+	filter := bson.M{"parent_id": bson.M{"$in": parentIDs}, "deleted_at": nil}
+	opts := options.Find().SetSort(bson.D{{Key: "due_date", Value: 1}, {Key: "task_id", Value: 1}})
+
+	cursor, err := m.taskCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find subtasks: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	tasks := []domain.Task{}
+	if err := cursor.All(ctx, &tasks); err != nil {
+		return nil, fmt.Errorf("failed to decode subtasks: %w", err)
+	}
+
+	return tasks, nil
+}
+
+// BulkCreate inserts the tasks with a single BulkWrite and returns one error per task, nil for the stored ones.
+// An atomic batch runs in a transaction, which needs a replica set, and stores nothing when any task fails.
+func (m *MongoTaskRepository) BulkCreate(ctx context.Context, tasks []domain.Task, atomic bool) ([]error, error) {
+
+	models := make([]mongo.WriteModel, 0, len(tasks))
+	for _, task := range tasks {
+		models = append(models, mongo.NewInsertOneModel().SetDocument(task))
+	}
+
+	return m.bulkWrite(ctx, models, atomic, nil)
+}
+
+// BulkUpdate applies the updates with a single BulkWrite and returns one error per update, nil for the applied ones.
+// An atomic batch runs in a transaction, which needs a replica set, and changes nothing when any update fails.
+// Like in the in-memory repository, a second update of the same task is a conflict.
+func (m *MongoTaskRepository) BulkUpdate(ctx context.Context, updates []TaskUpdate, atomic bool) ([]error, error) {
+
+	errs := make([]error, len(updates))
+	failed := false
+	seen := make(map[string]bool, len(updates))
+	for i, update := range updates {
+		if seen[update.ID] {
+			errs[i] = domain.ErrConflict
+			failed = true
+		}
+		seen[update.ID] = true
+	}
+	if atomic && failed {
+		return errs, nil
+	}
+
+	// every applied update tags its task with the id of the batch, a BulkWrite only
+	// reports how many updates matched and the tags tell which ones did
+	writeId := uuid.New().String()
+
+	models := make([]mongo.WriteModel, 0, len(updates))
+	modelUpdates := make([]int, 0, len(updates)) // index of the update each model runs
+	for i, update := range updates {
+		if errs[i] != nil {
+			continue
+		}
+		filter := bson.M{"task_id": update.ID, "deleted_at": nil, "version": update.ExpectedVersion}
+		if update.ExpectedVersion == 0 {
+			// a null also matches the tasks stored before versions existed
+			filter["version"] = bson.M{"$in": bson.A{0, nil}}
+		}
+		set := bson.M{bulkWriteIdField: writeId}
+		for field, value := range update.Updates {
+			set[field] = value
+		}
+		updateQuery := bson.M{"$inc": bson.M{"version": 1}, "$set": set}
+		models = append(models, mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(updateQuery))
+		modelUpdates = append(modelUpdates, i)
+	}
+
+	unmatched := func(ctx context.Context, result *mongo.BulkWriteResult, modelErrs []error) error {
+		if result.MatchedCount == int64(len(models)) {
+			return nil
+		}
+
+		ids := make([]string, 0, len(models))
+		for _, i := range modelUpdates {
+			ids = append(ids, updates[i].ID)
+		}
+		cursor, err := m.taskCollection.Find(ctx, bson.M{"task_id": bson.M{"$in": ids}})
+		if err != nil {
+			return fmt.Errorf("failed to check applied updates: %w", err)
+		}
+		defer cursor.Close(ctx)
+
+		var stored []bson.M
+		if err := cursor.All(ctx, &stored); err != nil {
+			return fmt.Errorf("failed to decode tasks: %w", err)
+		}
+		byId := make(map[string]bson.M, len(stored))
+		for _, doc := range stored {
+			if id, ok := doc["task_id"].(string); ok {
+				byId[id] = doc
+			}
+		}
+
+		for model, i := range modelUpdates {
+			if modelErrs[model] != nil {
+				continue
+			}
+			doc, ok := byId[updates[i].ID]
+			switch {
+			case ok && doc[bulkWriteIdField] == writeId:
+			case !ok || doc["deleted_at"] != nil:
+				modelErrs[model] = domain.ErrNotFound
+			default:
+				modelErrs[model] = domain.ErrConflict
+			}
+		}
+		return nil
+	}
+
+	modelErrs, err := m.bulkWrite(ctx, models, atomic, unmatched)
+	if err != nil {
+		return nil, err
+	}
+	for model, i := range modelUpdates {
+		if model < len(modelErrs) {
+			errs[i] = modelErrs[model]
+		}
+	}
+
+	return errs, nil
+}
+
+// bulkWriteIdField holds the id of the last bulk update applied to a task
+const bulkWriteIdField = "bulk_write_id"
+
+// returned inside a transaction to roll back an atomic batch in which some write failed
+var errBulkRolledBack = errors.New("bulk write rolled back")
+
+// bulkWrite runs the models with a single BulkWrite and returns one error per model.
+// unmatched, when set, fills in the errors of the models that were accepted but changed nothing.
+// Atomic batches are ordered and run in a transaction that is aborted when any model fails.
+func (m *MongoTaskRepository) bulkWrite(ctx context.Context, models []mongo.WriteModel, atomic bool,
+	unmatched func(ctx context.Context, result *mongo.BulkWriteResult, errs []error) error) ([]error, error) {
+
+	if len(models) == 0 {
+		return []error{}, nil
+	}
+
+	write := func(ctx context.Context) ([]error, error) {
+		errs := make([]error, len(models))
+
+		result, err := m.taskCollection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(atomic))
+
+		var bulkErr mongo.BulkWriteException
+		switch {
+		case errors.As(err, &bulkErr) && bulkErr.WriteConcernError == nil:
+			for _, writeErr := range bulkErr.WriteErrors {
+				errs[writeErr.Index] = bulkWriteItemError(writeErr)
+			}
+			// a failed write aborts the transaction, nothing more can be read in it
+			if atomic {
+				return errs, nil
+			}
+		case err != nil:
+			return nil, fmt.Errorf("failed to write tasks: %w", err)
+		}
+
+		if unmatched != nil {
+			if err := unmatched(ctx, result, errs); err != nil {
+				return nil, err
+			}
+		}
+		return errs, nil
+	}
+
+	if !atomic {
+		return write(ctx)
+	}
+
+	session, err := m.taskCollection.Database().Client().StartSession()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start a session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	var errs []error
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		var err error
+		errs, err = write(sc)
+		if err != nil {
+			return nil, err
+		}
+		for _, itemErr := range errs {
+			if itemErr != nil {
+				return nil, errBulkRolledBack
+			}
+		}
+		return nil, nil
+	})
+	if err != nil && !errors.Is(err, errBulkRolledBack) {
+		return nil, fmt.Errorf("failed to write tasks: %w", err)
+	}
+
+	return errs, nil
+}
+
+// bulkWriteItemError maps the error of one write of a BulkWrite to a domain error
+func bulkWriteItemError(writeErr mongo.BulkWriteError) error {
+
+	// 11000 is MongoDB's duplicate key error
+	if writeErr.Code == 11000 {
+		return domain.ErrAleadyExists
+	}
+
+	return fmt.Errorf("failed to write task: %s", writeErr.Message)
+}
+
+// RenameLabel replaces a label on every task carrying it, the tasks in the trash included.
+// It returns the number of tasks changed.
+func (m *MongoTaskRepository) RenameLabel(ctx context.Context, from string, to string) (int64, error) {
+
+	// add the new name where it's missing, keeping the labels sorted
+	addFilter := bson.M{"labels": bson.M{"$eq": from, "$ne": to}}
+	addUpdate := bson.M{"$push": bson.M{"labels": bson.M{"$each": bson.A{to}, "$sort": 1}}}
+	if _, err := m.taskCollection.UpdateMany(ctx, addFilter, addUpdate); err != nil {
+		return 0, fmt.Errorf("failed to rename label: %w", err)
+	}
+
+	// then drop the old one, running both steps again after a failure is harmless
+	return m.DropLabel(ctx, from)
+}
+
+// DropLabel removes a label from every task carrying it, the tasks in the trash included.
+// It returns the number of tasks changed.
+func (m *MongoTaskRepository) DropLabel(ctx context.Context, name string) (int64, error) {
+
+	filter := bson.M{"labels": name}
+	updateQuery := bson.M{"$pull": bson.M{"labels": name}, "$inc": bson.M{"version": 1}}
+
+	result, err := m.taskCollection.UpdateMany(ctx, filter, updateQuery)
+	if err != nil {
+		return 0, fmt.Errorf("failed to remove label: %w", err)
+	}
+
+	return result.ModifiedCount, nil
+}
+
+// missingTaskError tells apart a task that doesn't exist from one whose version has moved on
+func (m *MongoTaskRepository) missingTaskError(ctx context.Context, id string, expectedVersion int64) error {
+
+	if expectedVersion == 0 {
+		return domain.ErrNotFound
+	}
+
+	count, err := m.taskCollection.CountDocuments(ctx, bson.M{"task_id": id, "deleted_at": nil})
+	if err != nil {
+		return fmt.Errorf("failed to check task existence: %w", err)
+	}
+	if count == 0 {
+		return domain.ErrNotFound
+	}
+
+	return domain.ErrConflict
+}
+
+func (m *MongoTaskRepository) DistinctStatuses(ctx context.Context) ([]string, error) {
+
+	values, err := m.taskCollection.Distinct(ctx, "status", bson.D{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list task statuses: %w", err)
+	}
+
+	// documents without a status come back as nil, skip anything that isn't a string
+	statuses := make([]string, 0, len(values))
+	for _, value := range values {
+		if status, ok := value.(string); ok {
+			statuses = append(statuses, status)
+		}
+	}
+
+	return statuses, nil
+}
+
+func (m *MongoTaskRepository) ReplaceStatus(ctx context.Context, from string, to domain.TaskStatus) (int64, error) {
+
+	// the version only moves when the status does
+	if from == string(to) {
+		return 0, nil
+	}
+
+	filter := bson.M{"status": from}
+	updateQuery := bson.M{"$set": bson.M{"status": to}, "$inc": bson.M{"version": 1}}
+
+	result, err := m.taskCollection.UpdateMany(ctx, filter, updateQuery)
+	if err != nil {
+		return 0, fmt.Errorf("failed to replace task status: %w", err)
+	}
+
+	return result.ModifiedCount, nil
+}
+
+// Search runs a full-text query, the words and phrases go through the text index while
+// prefixes, which a text index can't match, become regular expressions
+func (m *MongoTaskRepository) Search(ctx context.Context, query domain.TaskSearchQuery) ([]domain.TaskSearchHit, int64, error) {
+
+	if err := m.ensureTextIndex(ctx); err != nil {
+		return nil, 0, err
+	}
+
+	filter := bson.M{"deleted_at": nil}
+
+	var search []string
+	search = append(search, query.Terms...)
+	for _, phrase := range query.Phrases {
+		search = append(search, `"`+strings.Join(phrase, " ")+`"`)
+	}
+	if len(search) > 0 {
+		filter["$text"] = bson.M{"$search": strings.Join(search, " ")}
+	}
+
+	if len(query.Prefixes) > 0 {
+		prefixes := bson.A{}
+		for _, prefix := range query.Prefixes {
+			// the prefix must start a word, words are made of letters and digits
+			pattern := bson.M{"$regex": `(^|[^\p{L}\p{N}])` + regexp.QuoteMeta(prefix), "$options": "i"}
+			prefixes = append(prefixes, bson.M{"$or": bson.A{bson.M{"title": pattern}, bson.M{"description": pattern}}})
+		}
+		filter["$and"] = prefixes
+	}
+
+	// without words or phrases there is no text score, rank the prefix matches ourselves
+	if len(search) == 0 {
+		return m.searchByPrefixes(ctx, filter, query)
+	}
+
+	total, err := m.taskCollection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count search results: %w", err)
+	}
+
+	score := bson.M{"$meta": "textScore"}
+	opts := options.Find().
+		SetProjection(bson.M{"score": score}).
+		SetSort(bson.D{{Key: "score", Value: score}, {Key: "task_id", Value: 1}}).
+		SetSkip(query.Offset)
+	if query.Limit > 0 {
+		opts.SetLimit(query.Limit)
+	}
+
+	cursor, err := m.taskCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to search tasks: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var results []scoredTask
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode search results: %w", err)
+	}
+
+	hits := make([]domain.TaskSearchHit, 0, len(results))
+	for _, result := range results {
+		hits = append(hits, domain.TaskSearchHit{Task: result.Task, Score: result.Score})
+	}
+
+	return hits, total, nil
+}
+
+// scoredTask is a task decoded along with its text score
+type scoredTask struct {
+	domain.Task `bson:",inline"`
+	Score       float64 `bson:"score"`
+}
+
+// searchByPrefixes loads every task matching the prefixes and ranks them like the text index would
+func (m *MongoTaskRepository) searchByPrefixes(ctx context.Context, filter bson.M, query domain.TaskSearchQuery) ([]domain.TaskSearchHit, int64, error) {
+
+	cursor, err := m.taskCollection.Find(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to search tasks: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var tasks []domain.Task
+	if err := cursor.All(ctx, &tasks); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode search results: %w", err)
+	}
+
+	hits := make([]domain.TaskSearchHit, 0, len(tasks))
+	for _, task := range tasks {
+		var score float64
+		for _, word := range domain.TokenizeText(task.Title) {
+			if query.MatchesPrefix(word) {
+				score += titleSearchWeight
+			}
+		}
+		for _, word := range domain.TokenizeText(task.Description) {
+			if query.MatchesPrefix(word) {
+				score += descriptionSearchWeight
+			}
+		}
+		hits = append(hits, domain.TaskSearchHit{Task: task, Score: score})
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].Task.ID < hits[j].Task.ID
+	})
+
+	total := int64(len(hits))
+	if query.Offset >= total {
+		return []domain.TaskSearchHit{}, total, nil
+	}
+	hits = hits[query.Offset:]
+	if query.Limit > 0 && query.Limit < int64(len(hits)) {
+		hits = hits[:query.Limit]
+	}
+
+	return hits, total, nil
+}
+
+// ensureTextIndex creates the text index searches rely on, creating an existing index is a no-op
+func (m *MongoTaskRepository) ensureTextIndex(ctx context.Context) error {
+
+	m.textIndexMu.Lock()
+	defer m.textIndexMu.Unlock()
+
+	if m.textIndexReady {
+		return nil
+	}
+
+	index := mongo.IndexModel{
+		Keys: bson.D{{Key: "title", Value: "text"}, {Key: "description", Value: "text"}},
+		Options: options.Index().
+			SetName("task_text_search").
+			SetWeights(bson.M{"title": titleSearchWeight, "description": descriptionSearchWeight}),
+	}
+
+	if _, err := m.taskCollection.Indexes().CreateOne(ctx, index); err != nil {
+		return fmt.Errorf("failed to create text index: %w", err)
+	}
+	m.textIndexReady = true
+
+	return nil
+}
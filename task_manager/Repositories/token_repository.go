@@ -0,0 +1,124 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	domain "taskmanager/Domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type TokenRepository interface {
+	SaveRefreshToken(ctx context.Context, token domain.RefreshToken) error
+	GetRefreshTokenByHash(ctx context.Context, tokenHash string) (domain.RefreshToken, error)
+	RotateRefreshToken(ctx context.Context, tokenId string, replacedBy string) error
+	RevokeTokenFamily(ctx context.Context, familyId string) error
+	RevokeAccessToken(ctx context.Context, tokenId string, expiresAt time.Time) error
+	IsAccessTokenRevoked(ctx context.Context, tokenId string) (bool, error)
+}
+
+type MongoTokenRepository struct {
+	refreshTokenCollection *mongo.Collection
+	revokedTokenCollection *mongo.Collection
+}
+
+func NewMongoTokenRepository(client *mongo.Client, dbName string, refreshCollectionName string, revokedCollectionName string) (TokenRepository, error) {
+	database := client.Database(dbName)
+
+	repository := &MongoTokenRepository{
+		refreshTokenCollection: database.Collection(refreshCollectionName),
+		revokedTokenCollection: database.Collection(revokedCollectionName),
+	}
+	if err := createUniqueIndexes(repository.refreshTokenCollection, "token_id", "token_hash"); err != nil {
+		return nil, err
+	}
+	if err := createUniqueIndexes(repository.revokedTokenCollection, "token_id"); err != nil {
+		return nil, err
+	}
+
+	return repository, nil
+}
+
+func (m *MongoTokenRepository) SaveRefreshToken(ctx context.Context, token domain.RefreshToken) error {
+
+	_, err := m.refreshTokenCollection.InsertOne(ctx, token)
+	if err != nil {
+		return fmt.Errorf("failed to save refresh token: %w", duplicateKeyError(err))
+	}
+
+	return nil
+}
+
+func (m *MongoTokenRepository) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (domain.RefreshToken, error) {
+
+	var token domain.RefreshToken
+
+	filter := bson.M{"token_hash": tokenHash}
+	err := m.refreshTokenCollection.FindOne(ctx, filter).Decode(&token)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.RefreshToken{}, domain.ErrNotFound
+		}
+		return domain.RefreshToken{}, fmt.Errorf("failed to retrieve refresh token: %w", err)
+	}
+
+	return token, nil
+}
+
+// RotateRefreshToken marks the token as used, it fails with domain.ErrConflict
+// when the token was already rotated or revoked
+func (m *MongoTokenRepository) RotateRefreshToken(ctx context.Context, tokenId string, replacedBy string) error {
+
+	filter := bson.M{"token_id": tokenId, "replaced_by": "", "revoked": false}
+	updateQuery := bson.M{"$set": bson.M{"replaced_by": replacedBy}}
+
+	result, err := m.refreshTokenCollection.UpdateOne(ctx, filter, updateQuery)
+	if err != nil {
+		return fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrConflict
+	}
+
+	return nil
+}
+
+func (m *MongoTokenRepository) RevokeTokenFamily(ctx context.Context, familyId string) error {
+
+	filter := bson.M{"family_id": familyId}
+	updateQuery := bson.M{"$set": bson.M{"revoked": true}}
+
+	_, err := m.refreshTokenCollection.UpdateMany(ctx, filter, updateQuery)
+	if err != nil {
+		return fmt.Errorf("failed to revoke token family: %w", err)
+	}
+
+	return nil
+}
+
+func (m *MongoTokenRepository) RevokeAccessToken(ctx context.Context, tokenId string, expiresAt time.Time) error {
+
+	// revoking the same token twice is harmless, the record is simply replaced
+	opts := options.Replace().SetUpsert(true)
+	_, err := m.revokedTokenCollection.ReplaceOne(ctx, bson.M{"token_id": tokenId}, domain.RevokedToken{ID: tokenId, ExpiresAt: expiresAt}, opts)
+	if err != nil {
+		return fmt.Errorf("failed to revoke access token: %w", err)
+	}
+
+	return nil
+}
+
+func (m *MongoTokenRepository) IsAccessTokenRevoked(ctx context.Context, tokenId string) (bool, error) {
+
+	count, err := m.revokedTokenCollection.CountDocuments(ctx, bson.M{"token_id": tokenId})
+	if err != nil {
+		return false, fmt.Errorf("failed to check token revocation: %w", err)
+	}
+
+	return count > 0, nil
+}
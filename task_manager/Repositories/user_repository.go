@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	domain "taskmanager/Domain"
+	"time"
 
 	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson"
@@ -18,18 +19,25 @@ type UserRepository interface {
 	SaveUser(ctx context.Context, user domain.User) (domain.User, error)
 	DoesUserExist(ctx context.Context, userName string) (string, string, domain.UserRole, error)
 	PromoteUser(ctx context.Context, userId string) (domain.User, error)
+	GetUserByID(ctx context.Context, userId string) (domain.User, error)
+	UpdatePassword(ctx context.Context, userId string, hashedPassword string, mustChange bool, changedAt time.Time) (domain.User, error)
+	ReplacePasswordHash(ctx context.Context, userId string, currentHash string, newHash string) error
 }
 
 type MongoUserRepository struct {
 	userCollection *mongo.Collection
 }
 
-func NewMongoUserRepository(client *mongo.Client, dbName string, collectionName string) UserRepository {
+func NewMongoUserRepository(client *mongo.Client, dbName string, collectionName string) (UserRepository, error) {
 	collection := client.Database(dbName).Collection(collectionName)
 
+	if err := createUniqueIndexes(collection, "user_id", "user_name"); err != nil {
+		return nil, err
+	}
+
 	return &MongoUserRepository{
 		userCollection: collection,
-	}
+	}, nil
 }
 
 func (m *MongoUserRepository) IsUsernameAvailable(ctx context.Context, userName string) error {
@@ -72,7 +80,7 @@ func (m *MongoUserRepository) SaveUser(ctx context.Context, user domain.User) (d
 	// save user to the database
 	_, err := m.userCollection.InsertOne(ctx, user)
 	if err != nil {
-		return domain.User{}, fmt.Errorf("error registering user: %w", err)
+		return domain.User{}, fmt.Errorf("error registering user: %w", duplicateKeyError(err))
 	}
 
 	return user, nil
@@ -129,3 +137,72 @@ func (m *MongoUserRepository) PromoteUser(ctx context.Context, userId string) (d
 
 	return user, nil
 }
+
+func (m *MongoUserRepository) GetUserByID(ctx context.Context, userId string) (domain.User, error) {
+
+	parsedUUID, err := uuid.Parse(userId)
+	if err != nil {
+		return domain.User{}, domain.ErrNotFound
+	}
+	filter := bson.M{"user_id": parsedUUID}
+
+	var user domain.User
+	err = m.userCollection.FindOne(ctx, filter).Decode(&user)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.User{}, domain.ErrNotFound
+		}
+		return domain.User{}, fmt.Errorf("failed to retrieve user: %w", err)
+	}
+
+	return user, nil
+}
+
+// UpdatePassword replaces the password hash of a user and returns the updated user
+func (m *MongoUserRepository) UpdatePassword(ctx context.Context, userId string, hashedPassword string, mustChange bool, changedAt time.Time) (domain.User, error) {
+
+	parsedUUID, err := uuid.Parse(userId)
+	if err != nil {
+		return domain.User{}, domain.ErrNotFound
+	}
+	filter := bson.M{"user_id": parsedUUID}
+
+	updateQuery := bson.M{"$set": bson.M{
+		"hashed_password":      hashedPassword,
+		"must_change_password": mustChange,
+		"password_changed_at":  changedAt,
+	}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var user domain.User
+	err = m.userCollection.FindOneAndUpdate(ctx, filter, updateQuery, opts).Decode(&user)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.User{}, domain.ErrNotFound
+		}
+		return domain.User{}, fmt.Errorf("failed to update password: %w", err)
+	}
+
+	return user, nil
+}
+
+// ReplacePasswordHash swaps the hash of an unchanged password for a stronger hash of the same password.
+// It fails with domain.ErrConflict when the hash is no longer currentHash, e.g. the password was changed meanwhile.
+func (m *MongoUserRepository) ReplacePasswordHash(ctx context.Context, userId string, currentHash string, newHash string) error {
+
+	parsedUUID, err := uuid.Parse(userId)
+	if err != nil {
+		return domain.ErrNotFound
+	}
+	filter := bson.M{"user_id": parsedUUID, "hashed_password": currentHash}
+
+	result, err := m.userCollection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"hashed_password": newHash}})
+	if err != nil {
+		return fmt.Errorf("failed to replace password hash: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrConflict
+	}
+
+	return nil
+}
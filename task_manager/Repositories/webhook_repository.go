@@ -0,0 +1,199 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	domain "taskmanager/Domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// WebhookRepository stores the webhook subscriptions and their delivery log
+type WebhookRepository interface {
+	GetAll(ctx context.Context) ([]domain.Webhook, error)
+	GetByID(ctx context.Context, id string) (domain.Webhook, error)
+	Create(ctx context.Context, webhook domain.Webhook) (domain.Webhook, error)
+	Update(ctx context.Context, webhook domain.Webhook) (domain.Webhook, error)
+	Delete(ctx context.Context, id string) error
+	SaveDelivery(ctx context.Context, delivery domain.WebhookDelivery) error
+	GetDelivery(ctx context.Context, id string) (domain.WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, webhookId string, limit int64, offset int64) ([]domain.WebhookDelivery, int64, error)
+	GetPendingDeliveries(ctx context.Context) ([]domain.WebhookDelivery, error)
+}
+
+type MongoWebhookRepository struct {
+	webhookCollection  *mongo.Collection
+	deliveryCollection *mongo.Collection
+}
+
+func NewMongoWebhookRepository(client *mongo.Client, dbName string, webhookCollectionName string, deliveryCollectionName string) (WebhookRepository, error) {
+	db := client.Database(dbName)
+
+	repository := &MongoWebhookRepository{
+		webhookCollection:  db.Collection(webhookCollectionName),
+		deliveryCollection: db.Collection(deliveryCollectionName),
+	}
+	if err := createUniqueIndexes(repository.webhookCollection, "webhook_id"); err != nil {
+		return nil, err
+	}
+	if err := createUniqueIndexes(repository.deliveryCollection, "delivery_id"); err != nil {
+		return nil, err
+	}
+
+	return repository, nil
+}
+
+// GetAll returns every webhook, oldest first
+func (m *MongoWebhookRepository) GetAll(ctx context.Context) ([]domain.Webhook, error) {
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "webhook_id", Value: 1}})
+
+	cursor, err := m.webhookCollection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find webhooks: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	webhooks := []domain.Webhook{}
+	if err := cursor.All(ctx, &webhooks); err != nil {
+		return nil, fmt.Errorf("failed to decode webhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+func (m *MongoWebhookRepository) GetByID(ctx context.Context, id string) (domain.Webhook, error) {
+
+	var webhook domain.Webhook
+
+	err := m.webhookCollection.FindOne(ctx, bson.M{"webhook_id": id}).Decode(&webhook)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.Webhook{}, domain.ErrNotFound
+		}
+		return domain.Webhook{}, fmt.Errorf("failed to retrieve webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+func (m *MongoWebhookRepository) Create(ctx context.Context, webhook domain.Webhook) (domain.Webhook, error) {
+
+	_, err := m.webhookCollection.InsertOne(ctx, webhook)
+	if err != nil {
+		return domain.Webhook{}, fmt.Errorf("failed to create webhook: %w", duplicateKeyError(err))
+	}
+
+	return webhook, nil
+}
+
+func (m *MongoWebhookRepository) Update(ctx context.Context, webhook domain.Webhook) (domain.Webhook, error) {
+
+	result, err := m.webhookCollection.ReplaceOne(ctx, bson.M{"webhook_id": webhook.ID}, webhook)
+	if err != nil {
+		return domain.Webhook{}, fmt.Errorf("failed to update webhook: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return domain.Webhook{}, domain.ErrNotFound
+	}
+
+	return webhook, nil
+}
+
+// Delete removes the webhook together with its delivery log
+func (m *MongoWebhookRepository) Delete(ctx context.Context, id string) error {
+
+	result, err := m.webhookCollection.DeleteOne(ctx, bson.M{"webhook_id": id})
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return domain.ErrNotFound
+	}
+
+	if _, err := m.deliveryCollection.DeleteMany(ctx, bson.M{"webhook_id": id}); err != nil {
+		return fmt.Errorf("failed to delete webhook deliveries: %w", err)
+	}
+
+	return nil
+}
+
+// SaveDelivery inserts the delivery or replaces the stored copy of it
+func (m *MongoWebhookRepository) SaveDelivery(ctx context.Context, delivery domain.WebhookDelivery) error {
+
+	opts := options.Replace().SetUpsert(true)
+	_, err := m.deliveryCollection.ReplaceOne(ctx, bson.M{"delivery_id": delivery.ID}, delivery, opts)
+	if err != nil {
+		return fmt.Errorf("failed to save webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+func (m *MongoWebhookRepository) GetDelivery(ctx context.Context, id string) (domain.WebhookDelivery, error) {
+
+	var delivery domain.WebhookDelivery
+
+	err := m.deliveryCollection.FindOne(ctx, bson.M{"delivery_id": id}).Decode(&delivery)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.WebhookDelivery{}, domain.ErrNotFound
+		}
+		return domain.WebhookDelivery{}, fmt.Errorf("failed to retrieve webhook delivery: %w", err)
+	}
+
+	return delivery, nil
+}
+
+func (m *MongoWebhookRepository) ListDeliveries(ctx context.Context, webhookId string, limit int64, offset int64) ([]domain.WebhookDelivery, int64, error) {
+
+	query := bson.M{"webhook_id": webhookId}
+
+	total, err := m.deliveryCollection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count webhook deliveries: %w", err)
+	}
+
+	// newest first, delivery_id keeps pages stable
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "delivery_id", Value: -1}}).
+		SetSkip(offset)
+	if limit > 0 {
+		opts.SetLimit(limit)
+	}
+
+	cursor, err := m.deliveryCollection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find webhook deliveries: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	deliveries := []domain.WebhookDelivery{}
+	if err := cursor.All(ctx, &deliveries); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode webhook deliveries: %w", err)
+	}
+
+	return deliveries, total, nil
+}
+
+// GetPendingDeliveries returns the deliveries that are still being attempted, oldest first
+func (m *MongoWebhookRepository) GetPendingDeliveries(ctx context.Context) ([]domain.WebhookDelivery, error) {
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "delivery_id", Value: 1}})
+
+	cursor, err := m.deliveryCollection.Find(ctx, bson.M{"status": domain.DeliveryPending}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pending webhook deliveries: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	deliveries := []domain.WebhookDelivery{}
+	if err := cursor.All(ctx, &deliveries); err != nil {
+		return nil, fmt.Errorf("failed to decode webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
@@ -7,11 +7,16 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
+	"unicode/utf8"
 
 	"taskmanager/Delivery/controllers"
 	domain "taskmanager/Domain"
+	infrastructure "taskmanager/Infrastructure"
 	"taskmanager/Tests/mocks"
+	usecases "taskmanager/Usecases"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -50,18 +55,77 @@ func setupTestContext(method, url string, body interface{}, params gin.Params) (
 func TestTaskController_GetTasks_Success(t *testing.T) {
 	mockUsecase := new(mocks.MockTaskUsecase)
 	controller := controllers.NewTaskController(mockUsecase)
-	c, w := setupTestContext(http.MethodGet, "/tasks", nil, nil)
+	c, w := setupTestContext(http.MethodGet, "/tasks?status=todo&sort=-title&limit=1", nil, nil)
 
-	expectedTasks := []domain.Task{{ID: "1", Title: "Test"}}
-	mockUsecase.EXPECT().RetrieveAllTasks(mock.Anything).Return(expectedTasks, nil)
+	expectedFilter := domain.TaskFilter{Status: "todo", SortBy: "title", SortDesc: true, Limit: 1}
+	expectedPage := domain.TaskPage{Tasks: []domain.Task{{ID: "1", Title: "Test"}}, Total: 3, Limit: 1}
+	mockUsecase.EXPECT().RetrieveAllTasks(mock.Anything, expectedFilter).Return(expectedPage, nil)
 
 	controller.GetTasks(c)
 
 	assert.Equal(t, http.StatusOK, w.Code)
-	var response map[string][]domain.Task
+	var response struct {
+		Tasks []domain.Task `json:"tasks"`
+		Total int64         `json:"total"`
+		Next  string        `json:"next"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Len(t, response.Tasks, 1)
+	assert.Equal(t, int64(3), response.Total)
+	assert.Equal(t, "/tasks?limit=1&offset=1&sort=-title&status=todo", response.Next)
+
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestTaskController_GetTasks_Fail_InvalidQuery(t *testing.T) {
+	mockUsecase := new(mocks.MockTaskUsecase)
+	controller := controllers.NewTaskController(mockUsecase)
+	c, w := setupTestContext(http.MethodGet, "/tasks?due_from=yesterday", nil, nil)
+
+	controller.GetTasks(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUsecase.AssertNotCalled(t, "RetrieveAllTasks", mock.Anything, mock.Anything)
+}
+
+func TestTaskController_SearchTasks_Success(t *testing.T) {
+	mockUsecase := new(mocks.MockTaskUsecase)
+	controller := controllers.NewTaskController(mockUsecase)
+	c, w := setupTestContext(http.MethodGet, "/tasks/search?q=deploy&limit=1", nil, nil)
+
+	expectedResult := domain.TaskSearchResult{
+		Hits:  []domain.TaskSearchHit{{Task: domain.Task{ID: "1"}, Score: 2, Highlights: domain.TaskSearchHighlights{Title: "<mark>Deploy</mark>"}}},
+		Total: 2,
+		Limit: 1,
+	}
+	mockUsecase.EXPECT().SearchTasks(mock.Anything, "deploy", int64(1), int64(0)).Return(expectedResult, nil)
+
+	controller.SearchTasks(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Hits []domain.TaskSearchHit `json:"hits"`
+		Next string                 `json:"next"`
+	}
 	json.Unmarshal(w.Body.Bytes(), &response)
-	assert.Len(t, response["tasks"], 1)
+	assert.Equal(t, expectedResult.Hits, response.Hits)
+	assert.Equal(t, "/tasks/search?limit=1&offset=1&q=deploy", response.Next)
+
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestTaskController_SearchTasks_Fail_EmptyQuery(t *testing.T) {
+	mockUsecase := new(mocks.MockTaskUsecase)
+	controller := controllers.NewTaskController(mockUsecase)
+	c, w := setupTestContext(http.MethodGet, "/tasks/search?q=", nil, nil)
 
+	mockUsecase.EXPECT().
+		SearchTasks(mock.Anything, "", int64(0), int64(0)).
+		Return(domain.TaskSearchResult{}, fmt.Errorf("%w: search query must contain at least one word", domain.ErrValidation))
+
+	controller.SearchTasks(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
 	mockUsecase.AssertExpectations(t)
 }
 
@@ -106,14 +170,54 @@ func TestTaskController_CreatTask_Fail_Validation(t *testing.T) {
 	mockUsecase.AssertExpectations(t)
 }
 
+func TestTaskController_UpdateTask_Fail_Forbidden(t *testing.T) {
+	mockUsecase := new(mocks.MockTaskUsecase)
+	controller := controllers.NewTaskController(mockUsecase)
+
+	params := gin.Params{{Key: "id", Value: "123"}}
+	c, w := setupTestContext(http.MethodPut, "/tasks/123", domain.Task{Title: "New"}, params)
+
+	// Simulate AuthMiddleware having authenticated a regular user
+	infrastructure.SetPrincipal(c, infrastructure.Principal{UserID: "user-1", Role: domain.RoleUser})
+
+	actor := domain.Actor{UserID: "user-1", Role: domain.RoleUser}
+	mockUsecase.EXPECT().
+		ModifyTask(mock.Anything, actor, "123", domain.Task{Title: "New"}).
+		Return(domain.Task{}, fmt.Errorf("%w: task is not assigned to you", domain.ErrForbidden))
+
+	controller.UpdateTask(c)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestTaskController_GetMyTasks_FiltersByAssignee(t *testing.T) {
+	mockUsecase := new(mocks.MockTaskUsecase)
+	controller := controllers.NewTaskController(mockUsecase)
+	c, w := setupTestContext(http.MethodGet, "/tasks/mine", nil, nil)
+	infrastructure.SetPrincipal(c, infrastructure.Principal{UserID: "user-1"})
+
+	mockUsecase.EXPECT().
+		RetrieveAllTasks(mock.Anything, domain.TaskFilter{AssigneeID: "user-1"}).
+		Return(domain.TaskPage{Tasks: []domain.Task{{ID: "1", AssigneeID: "user-1"}}, Total: 1, Limit: 20}, nil)
+
+	controller.GetMyTasks(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
 func TestTaskController_DeleteTask_Success(t *testing.T) {
 	mockUsecase := new(mocks.MockTaskUsecase)
 	controller := controllers.NewTaskController(mockUsecase)
 
 	params := gin.Params{{Key: "id", Value: "123"}}
 	c, w := setupTestContext(http.MethodDelete, "/tasks/123", nil, params)
+	infrastructure.SetPrincipal(c, infrastructure.Principal{UserID: "admin-id", Role: domain.RoleAdmin})
 
-	mockUsecase.EXPECT().RemoveTask(mock.Anything, "123").Return(nil)
+	// the authenticated admin is handed to the usecase for the audit log
+	admin := domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}
+	mockUsecase.EXPECT().RemoveTask(mock.Anything, admin, "123", int64(0)).Return(nil)
 
 	controller.DeleteTask(c)
 
@@ -125,44 +229,1181 @@ func TestTaskController_DeleteTask_Success(t *testing.T) {
 	mockUsecase.AssertExpectations(t)
 }
 
-// --- User Controller Tests ---
+func TestTaskController_GetTaskById_SetsETag(t *testing.T) {
+	mockUsecase := new(mocks.MockTaskUsecase)
+	controller := controllers.NewTaskController(mockUsecase)
 
-func TestUserController_RegisterUser_Fail_AlreadyExists(t *testing.T) {
-	mockUsecase := new(mocks.MockUserUsecase)
-	controller := controllers.NewUserController(mockUsecase)
+	params := gin.Params{{Key: "id", Value: "1"}}
+	c, w := setupTestContext(http.MethodGet, "/tasks/1", nil, params)
 
-	credentials := domain.Credentials{UserName: "taken", Password: "p"}
-	c, w := setupTestContext(http.MethodPost, "/user/register", credentials, nil)
+	mockUsecase.EXPECT().RetrieveTaskByID(mock.Anything, "1").Return(domain.Task{ID: "1", Version: 4}, nil)
 
-	// Mock Usecase returning a wrapped ErrAleadyExists
-	mockUsecase.EXPECT().RegisterUser(mock.Anything, "taken", "p").Return(domain.User{}, domain.ErrAleadyExists)
+	controller.GetTaskById(c)
 
-	controller.RegisterUser(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"4"`, w.Header().Get("ETag"))
+}
+
+func TestTaskController_UpdateTask_Fail_PreconditionFailed(t *testing.T) {
+	mockUsecase := new(mocks.MockTaskUsecase)
+	controller := controllers.NewTaskController(mockUsecase)
+
+	params := gin.Params{{Key: "id", Value: "1"}}
+	c, w := setupTestContext(http.MethodPut, "/tasks/1", domain.Task{Title: "New"}, params)
+	c.Request.Header.Set("If-Match", `"4"`)
+	infrastructure.SetPrincipal(c, infrastructure.Principal{Role: domain.RoleAdmin})
+
+	// the If-Match version is handed to the usecase through the task
+	mockUsecase.EXPECT().
+		ModifyTask(mock.Anything, domain.Actor{Role: domain.RoleAdmin}, "1", domain.Task{Title: "New", Version: 4}).
+		Return(domain.Task{}, domain.ErrConflict)
+
+	controller.UpdateTask(c)
+
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestTaskController_UpdateTask_Fail_WeakIfMatch(t *testing.T) {
+	mockUsecase := new(mocks.MockTaskUsecase)
+	controller := controllers.NewTaskController(mockUsecase)
+
+	params := gin.Params{{Key: "id", Value: "1"}}
+	c, w := setupTestContext(http.MethodPut, "/tasks/1", domain.Task{Title: "New"}, params)
+	c.Request.Header.Set("If-Match", `W/"4"`)
+	infrastructure.SetPrincipal(c, infrastructure.Principal{Role: domain.RoleAdmin})
+
+	// If-Match compares strongly, a weak tag never matches even when the version does
+	controller.UpdateTask(c)
+
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	mockUsecase.AssertNotCalled(t, "ModifyTask", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTaskController_DeleteTask_Fail_MalformedIfMatch(t *testing.T) {
+	mockUsecase := new(mocks.MockTaskUsecase)
+	controller := controllers.NewTaskController(mockUsecase)
+
+	params := gin.Params{{Key: "id", Value: "1"}}
+	c, w := setupTestContext(http.MethodDelete, "/tasks/1", nil, params)
+	c.Request.Header.Set("If-Match", `"abc"`)
+
+	controller.DeleteTask(c)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
-	var response map[string]string
+	mockUsecase.AssertNotCalled(t, "RemoveTask", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTaskController_GetTrash_Success(t *testing.T) {
+	mockUsecase := new(mocks.MockTaskUsecase)
+	controller := controllers.NewTaskController(mockUsecase)
+	c, w := setupTestContext(http.MethodGet, "/tasks/trash?limit=1", nil, nil)
+
+	deletedAt := time.Now().UTC().Truncate(time.Second)
+	page := domain.TaskPage{Tasks: []domain.Task{{ID: "1", DeletedAt: &deletedAt}}, Total: 2, Limit: 1}
+	mockUsecase.EXPECT().ListDeletedTasks(mock.Anything, int64(1), int64(0)).Return(page, nil)
+
+	controller.GetTrash(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Tasks []domain.Task `json:"tasks"`
+		Next  string        `json:"next"`
+	}
 	json.Unmarshal(w.Body.Bytes(), &response)
-	assert.Equal(t, domain.ErrAleadyExists.Error(), response["error"])
+	assert.Len(t, response.Tasks, 1)
+	assert.True(t, deletedAt.Equal(*response.Tasks[0].DeletedAt))
+	assert.Equal(t, "/tasks/trash?limit=1&offset=1", response.Next)
+}
+
+func TestTaskController_RestoreTask_Fail_NotInTrash(t *testing.T) {
+	mockUsecase := new(mocks.MockTaskUsecase)
+	controller := controllers.NewTaskController(mockUsecase)
+
+	params := gin.Params{{Key: "id", Value: "1"}}
+	c, w := setupTestContext(http.MethodPost, "/tasks/trash/1/restore", nil, params)
+
+	mockUsecase.EXPECT().RestoreTask(mock.Anything, mock.Anything, "1").Return(domain.Task{}, domain.ErrNotFound)
+
+	controller.RestoreTask(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), "task not found in trash")
+}
 
+func TestTaskController_PurgeTask_Success(t *testing.T) {
+	mockUsecase := new(mocks.MockTaskUsecase)
+	controller := controllers.NewTaskController(mockUsecase)
+
+	params := gin.Params{{Key: "id", Value: "1"}}
+	c, w := setupTestContext(http.MethodDelete, "/tasks/trash/1", nil, params)
+	infrastructure.SetPrincipal(c, infrastructure.Principal{UserID: "admin-id", Role: domain.RoleAdmin})
+
+	mockUsecase.EXPECT().PurgeTask(mock.Anything, domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}, "1").Return(nil)
+
+	controller.PurgeTask(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
 	mockUsecase.AssertExpectations(t)
 }
 
-func TestUserController_AuthenticateUser_Fail_InvalidCredentials(t *testing.T) {
-	mockUsecase := new(mocks.MockUserUsecase)
-	controller := controllers.NewUserController(mockUsecase)
+func TestTaskController_BulkCreateTasks_AllCreated(t *testing.T) {
+	mockUsecase := new(mocks.MockTaskUsecase)
+	controller := controllers.NewTaskController(mockUsecase)
 
-	credentials := domain.Credentials{UserName: "baduser", Password: "badpassword"}
-	c, w := setupTestContext(http.MethodPost, "/user/login", credentials, nil)
+	body := gin.H{"tasks": []gin.H{{"title": "a", "description": "d", "status": "todo"}}, "atomic": true}
+	c, w := setupTestContext(http.MethodPost, "/tasks/bulk", body, nil)
+	infrastructure.SetPrincipal(c, infrastructure.Principal{UserID: "admin-id", Role: domain.RoleAdmin})
 
-	// Mock Usecase failing due to validation (bad password) or not found (bad username)
-	mockUsecase.EXPECT().AuthenticateUser(mock.Anything, "baduser", "badpassword").Return("", domain.ErrValidation)
+	result := domain.BulkResult{Atomic: true, Succeeded: 1, Results: []domain.BulkItemResult{{Index: 0, ID: "1", Status: domain.BulkItemSucceeded}}}
+	mockUsecase.EXPECT().
+		BulkCreateTasks(mock.Anything, domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}, mock.MatchedBy(func(tasks []domain.Task) bool {
+			return len(tasks) == 1 && tasks[0].Title == "a"
+		}), true).
+		Return(result, nil)
 
-	controller.AuthenticateUser(c)
+	controller.BulkCreateTasks(c)
 
-	assert.Equal(t, http.StatusBadRequest, w.Code)
-	var response map[string]string
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response domain.BulkResult
 	json.Unmarshal(w.Body.Bytes(), &response)
-	assert.Equal(t, "Invalid username or password", response["error"])
+	assert.Equal(t, result, response)
+}
+
+func TestTaskController_BulkUpdateTasks_PartialFailureIsMultiStatus(t *testing.T) {
+	mockUsecase := new(mocks.MockTaskUsecase)
+	controller := controllers.NewTaskController(mockUsecase)
+
+	body := gin.H{"filter": gin.H{"status": "in_progress", "assignee_id": "user-1"}, "changes": gin.H{"status": "done"}}
+	c, w := setupTestContext(http.MethodPatch, "/tasks/bulk", body, nil)
+
+	result := domain.BulkResult{Succeeded: 1, Failed: 1, Results: []domain.BulkItemResult{
+		{Index: 0, ID: "1", Status: domain.BulkItemSucceeded},
+		{Index: 1, ID: "2", Status: domain.BulkItemFailed, Error: "task not found"},
+	}}
+	mockUsecase.EXPECT().
+		BulkUpdateTasks(mock.Anything, mock.Anything, domain.BulkTaskUpdate{
+			Filter:  &domain.TaskFilter{Status: domain.StatusInProgress, AssigneeID: "user-1"},
+			Changes: domain.Task{Status: domain.StatusDone},
+		}).
+		Return(result, nil)
+
+	controller.BulkUpdateTasks(c)
+
+	assert.Equal(t, http.StatusMultiStatus, w.Code)
+	assert.Contains(t, w.Body.String(), "task not found")
+}
+
+func TestTaskController_BulkDeleteTasks_Fail_Validation(t *testing.T) {
+	mockUsecase := new(mocks.MockTaskUsecase)
+	controller := controllers.NewTaskController(mockUsecase)
+	c, w := setupTestContext(http.MethodPost, "/tasks/bulk/delete", gin.H{"ids": []string{"1", "1"}}, nil)
+
+	mockUsecase.EXPECT().
+		BulkDeleteTasks(mock.Anything, mock.Anything, []string{"1", "1"}, false).
+		Return(domain.BulkResult{}, fmt.Errorf("%w: task \"1\" is listed more than once", domain.ErrValidation))
+
+	controller.BulkDeleteTasks(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestTaskController_GetTasks_ParsesLabelFilters(t *testing.T) {
+	mockUsecase := new(mocks.MockTaskUsecase)
+	controller := controllers.NewTaskController(mockUsecase)
+	c, w := setupTestContext(http.MethodGet, "/tasks?labels_any=urgent,%20backend&labels_all=api", nil, nil)
+
+	expectedFilter := domain.TaskFilter{LabelsAny: []string{"urgent", "backend"}, LabelsAll: []string{"api"}}
+	mockUsecase.EXPECT().RetrieveAllTasks(mock.Anything, expectedFilter).Return(domain.TaskPage{Tasks: []domain.Task{}}, nil)
+
+	controller.GetTasks(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestTaskController_AddTaskLabels_Success(t *testing.T) {
+	mockUsecase := new(mocks.MockTaskUsecase)
+	controller := controllers.NewTaskController(mockUsecase)
+	params := gin.Params{gin.Param{Key: "id", Value: "1"}}
+	c, w := setupTestContext(http.MethodPost, "/tasks/1/labels", gin.H{"labels": []string{"urgent"}}, params)
+	infrastructure.SetPrincipal(c, infrastructure.Principal{UserID: "user-1", Role: domain.RoleUser})
 
+	actor := domain.Actor{UserID: "user-1", Role: domain.RoleUser}
+	mockUsecase.EXPECT().
+		AddTaskLabels(mock.Anything, actor, "1", []string{"urgent"}).
+		Return(domain.Task{ID: "1", Labels: []string{"urgent"}, Version: 2}, nil)
+
+	controller.AddTaskLabels(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"2"`, w.Header().Get("ETag"))
 	mockUsecase.AssertExpectations(t)
 }
+
+func TestTaskController_AddTaskLabels_Fail_UnknownLabel(t *testing.T) {
+	mockUsecase := new(mocks.MockTaskUsecase)
+	controller := controllers.NewTaskController(mockUsecase)
+	params := gin.Params{gin.Param{Key: "id", Value: "1"}}
+	c, w := setupTestContext(http.MethodPost, "/tasks/1/labels", gin.H{"labels": []string{"nope"}}, params)
+
+	mockUsecase.EXPECT().
+		AddTaskLabels(mock.Anything, mock.Anything, "1", []string{"nope"}).
+		Return(domain.Task{}, fmt.Errorf("%w: unknown label \"nope\"", domain.ErrValidation))
+
+	controller.AddTaskLabels(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "unknown label")
+}
+
+func TestTaskController_RemoveTaskLabel_Fail_Forbidden(t *testing.T) {
+	mockUsecase := new(mocks.MockTaskUsecase)
+	controller := controllers.NewTaskController(mockUsecase)
+	params := gin.Params{gin.Param{Key: "id", Value: "1"}, gin.Param{Key: "label", Value: "urgent"}}
+	c, w := setupTestContext(http.MethodDelete, "/tasks/1/labels/urgent", nil, params)
+
+	mockUsecase.EXPECT().
+		RemoveTaskLabel(mock.Anything, mock.Anything, "1", "urgent").
+		Return(domain.Task{}, fmt.Errorf("%w: only the assignee or an admin can update this task", domain.ErrForbidden))
+
+	controller.RemoveTaskLabel(c)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestTaskController_GetTaskById_Subtree(t *testing.T) {
+	mockUsecase := new(mocks.MockTaskUsecase)
+	controller := controllers.NewTaskController(mockUsecase)
+	params := gin.Params{gin.Param{Key: "id", Value: "1"}}
+	c, w := setupTestContext(http.MethodGet, "/tasks/1?subtree=true", nil, params)
+
+	tree := domain.TaskNode{
+		Task:     domain.Task{ID: "1", Version: 3},
+		Subtasks: []domain.TaskNode{{Task: domain.Task{ID: "2", ParentID: "1"}, Subtasks: []domain.TaskNode{}}},
+	}
+	mockUsecase.EXPECT().RetrieveTaskTree(mock.Anything, "1").Return(tree, nil)
+
+	controller.GetTaskById(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+	var response struct {
+		Task struct {
+			ID       string `json:"id"`
+			Subtasks []struct {
+				ID       string `json:"id"`
+				ParentID string `json:"parent_id"`
+			} `json:"subtasks"`
+		} `json:"task"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Equal(t, "1", response.Task.ID)
+	assert.Len(t, response.Task.Subtasks, 1)
+	assert.Equal(t, "1", response.Task.Subtasks[0].ParentID)
+	mockUsecase.AssertNotCalled(t, "RetrieveTaskByID", mock.Anything, mock.Anything)
+}
+
+func TestTaskController_GetTaskById_Fail_InvalidSubtree(t *testing.T) {
+	mockUsecase := new(mocks.MockTaskUsecase)
+	controller := controllers.NewTaskController(mockUsecase)
+	params := gin.Params{gin.Param{Key: "id", Value: "1"}}
+	c, w := setupTestContext(http.MethodGet, "/tasks/1?subtree=maybe", nil, params)
+
+	controller.GetTaskById(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestTaskController_AddTaskBlocker_Fail_Cycle(t *testing.T) {
+	mockUsecase := new(mocks.MockTaskUsecase)
+	controller := controllers.NewTaskController(mockUsecase)
+	params := gin.Params{gin.Param{Key: "id", Value: "1"}}
+	c, w := setupTestContext(http.MethodPost, "/tasks/1/blockers", gin.H{"task_id": "2"}, params)
+
+	mockUsecase.EXPECT().
+		AddTaskBlocker(mock.Anything, mock.Anything, "1", "2").
+		Return(domain.Task{}, fmt.Errorf("%w: linking them would create a cycle", domain.ErrValidation))
+
+	controller.AddTaskBlocker(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "cycle")
+}
+
+func TestTaskController_SetTaskParent_Fail_MissingBody(t *testing.T) {
+	mockUsecase := new(mocks.MockTaskUsecase)
+	controller := controllers.NewTaskController(mockUsecase)
+	params := gin.Params{gin.Param{Key: "id", Value: "1"}}
+	c, w := setupTestContext(http.MethodPut, "/tasks/1/parent", gin.H{}, params)
+
+	controller.SetTaskParent(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUsecase.AssertNotCalled(t, "SetTaskParent", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTaskController_PreviewTaskOccurrences_Success(t *testing.T) {
+	mockUsecase := new(mocks.MockTaskUsecase)
+	controller := controllers.NewTaskController(mockUsecase)
+	params := gin.Params{gin.Param{Key: "id", Value: "1"}}
+	c, w := setupTestContext(http.MethodGet, "/tasks/1/occurrences?count=2", nil, params)
+
+	dates := []time.Time{time.Date(2025, 11, 13, 9, 0, 0, 0, time.UTC), time.Date(2025, 11, 17, 9, 0, 0, 0, time.UTC)}
+	mockUsecase.EXPECT().PreviewTaskOccurrences(mock.Anything, "1", 2).Return(dates, nil)
+
+	controller.PreviewTaskOccurrences(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"occurrences":["2025-11-13T09:00:00Z","2025-11-17T09:00:00Z"]`)
+}
+
+func TestTaskController_PreviewTaskOccurrences_Fail_InvalidCount(t *testing.T) {
+	mockUsecase := new(mocks.MockTaskUsecase)
+	controller := controllers.NewTaskController(mockUsecase)
+	params := gin.Params{gin.Param{Key: "id", Value: "1"}}
+	c, w := setupTestContext(http.MethodGet, "/tasks/1/occurrences?count=many", nil, params)
+
+	controller.PreviewTaskOccurrences(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUsecase.AssertNotCalled(t, "PreviewTaskOccurrences", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTaskController_ExportTasks_WritesCSV(t *testing.T) {
+	mockUsecase := new(mocks.MockTaskUsecase)
+	controller := controllers.NewTaskController(mockUsecase)
+	c, w := setupTestContext(http.MethodGet, "/tasks/export?format=csv&status=todo", nil, nil)
+
+	dueDate := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	mockUsecase.EXPECT().
+		ExportTasks(mock.Anything, domain.TaskFilter{Status: domain.StatusTodo}, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ domain.TaskFilter, fn func(domain.Task) error) error {
+			return fn(domain.Task{ID: "1", Title: "Ship, then rest", Description: "d", Status: domain.StatusTodo, DueDate: dueDate, Labels: []string{"a", "b"}, Version: 2})
+		})
+
+	controller.ExportTasks(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "tasks.csv")
+
+	// a header row, then one record per task with its lists joined by ";"
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.True(t, strings.HasPrefix(lines[0], "id,title,description,status,due_date"))
+	assert.Equal(t, `1,"Ship, then rest",d,todo,2026-03-01T09:00:00Z,,a;b,,,,0,,2`, lines[1])
+}
+
+func TestTaskController_ExportTasks_EscapesFormulas(t *testing.T) {
+	mockUsecase := new(mocks.MockTaskUsecase)
+	controller := controllers.NewTaskController(mockUsecase)
+	c, w := setupTestContext(http.MethodGet, "/tasks/export?format=csv", nil, nil)
+
+	mockUsecase.EXPECT().
+		ExportTasks(mock.Anything, domain.TaskFilter{}, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ domain.TaskFilter, fn func(domain.Task) error) error {
+			return fn(domain.Task{ID: "1", Title: "=HYPERLINK(\"http://evil\")", Description: "+1", Status: domain.StatusTodo, Labels: []string{"@home", "b"}})
+		})
+
+	controller.ExportTasks(c)
+
+	// a spreadsheet shows the cells as text instead of evaluating them
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.Equal(t, `1,"'=HYPERLINK(""http://evil"")",'+1,todo,,,'@home;b,,,,0,,0`, lines[1])
+}
+
+func TestTaskController_ExportTasks_EmptyJSONArray(t *testing.T) {
+	mockUsecase := new(mocks.MockTaskUsecase)
+	controller := controllers.NewTaskController(mockUsecase)
+	c, w := setupTestContext(http.MethodGet, "/tasks/export", nil, nil)
+
+	mockUsecase.EXPECT().ExportTasks(mock.Anything, domain.TaskFilter{}, mock.Anything).Return(nil)
+
+	controller.ExportTasks(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, "[]", w.Body.String())
+}
+
+func TestTaskController_ExportTasks_Fail_UnknownFormat(t *testing.T) {
+	mockUsecase := new(mocks.MockTaskUsecase)
+	controller := controllers.NewTaskController(mockUsecase)
+	c, w := setupTestContext(http.MethodGet, "/tasks/export?format=xlsx", nil, nil)
+
+	controller.ExportTasks(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUsecase.AssertNotCalled(t, "ExportTasks", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTaskController_ImportTasks_CSVRowErrorsAreMultiStatus(t *testing.T) {
+	mockUsecase := new(mocks.MockTaskUsecase)
+	controller := controllers.NewTaskController(mockUsecase)
+	c, w := setupTestContext(http.MethodPost, "/tasks/import?dry_run=true", nil, nil)
+	c.Request = httptest.NewRequest(http.MethodPost, "/tasks/import?dry_run=true", strings.NewReader(
+		"title,status,description,labels\n"+
+			"Ship it,todo,d,a;b\n"+
+			"Too many,todo,d,a,extra\n"))
+	c.Request.Header.Set("Content-Type", "text/csv")
+	infrastructure.SetPrincipal(c, infrastructure.Principal{UserID: "admin-id", Role: domain.RoleAdmin})
+
+	// the columns can come in any order, a broken record only fails its own row
+	mockUsecase.EXPECT().
+		ImportTasks(mock.Anything, domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}, mock.MatchedBy(func(rows []domain.TaskImportRow) bool {
+			return len(rows) == 2 &&
+				rows[0].Row == 1 && rows[0].Err == nil && rows[0].Task.Title == "Ship it" && len(rows[0].Task.Labels) == 2 &&
+				rows[1].Row == 2 && rows[1].Err != nil
+		}), domain.TaskImportOptions{DryRun: true}).
+		Return(domain.TaskImportResult{DryRun: true, Created: 1, Failed: 1, Results: []domain.TaskImportRowResult{
+			{Row: 1, Status: domain.BulkItemSucceeded, Action: domain.TaskImportCreated},
+			{Row: 2, Status: domain.BulkItemFailed, Error: "input validation failed: the record has 5 fields, the header 4"},
+		}}, nil)
+
+	controller.ImportTasks(c)
+
+	assert.Equal(t, http.StatusMultiStatus, w.Code)
+	assert.Contains(t, w.Body.String(), "5 fields")
+}
+
+func TestTaskController_ImportTasks_CSVUnescapesFormulas(t *testing.T) {
+	mockUsecase := new(mocks.MockTaskUsecase)
+	controller := controllers.NewTaskController(mockUsecase)
+	c, w := setupTestContext(http.MethodPost, "/tasks/import", nil, nil)
+	c.Request = httptest.NewRequest(http.MethodPost, "/tasks/import", strings.NewReader(
+		"title,description,labels\n"+
+			"'=1+1,'-d,'@home;b\n"+
+			"''quoted,'plain,a\n"))
+	c.Request.Header.Set("Content-Type", "text/csv")
+	infrastructure.SetPrincipal(c, infrastructure.Principal{UserID: "admin-id", Role: domain.RoleAdmin})
+
+	// an exported cell reads back as it was written, a ' not added by the export is kept
+	mockUsecase.EXPECT().
+		ImportTasks(mock.Anything, mock.Anything, mock.MatchedBy(func(rows []domain.TaskImportRow) bool {
+			return len(rows) == 2 &&
+				rows[0].Task.Title == "=1+1" && rows[0].Task.Description == "-d" && assert.ObjectsAreEqual([]string{"@home", "b"}, rows[0].Task.Labels) &&
+				rows[1].Task.Title == "'quoted" && rows[1].Task.Description == "'plain"
+		}), domain.TaskImportOptions{}).
+		Return(domain.TaskImportResult{Created: 2}, nil)
+
+	controller.ImportTasks(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestTaskController_ImportTasks_Fail_UnreadableFile(t *testing.T) {
+	mockUsecase := new(mocks.MockTaskUsecase)
+	controller := controllers.NewTaskController(mockUsecase)
+
+	// an unknown column or a document that isn't an array fails the whole import
+	for format, body := range map[string]string{
+		"csv":  "title,priority\nShip it,high\n",
+		"json": `{"title": "not an array"}`,
+	} {
+		c, w := setupTestContext(http.MethodPost, "/tasks/import?format="+format, nil, nil)
+		c.Request = httptest.NewRequest(http.MethodPost, "/tasks/import?format="+format, strings.NewReader(body))
+
+		controller.ImportTasks(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, format)
+	}
+
+	// without a format the content type has to name one
+	c, w := setupTestContext(http.MethodPost, "/tasks/import", nil, nil)
+	c.Request.Header.Set("Content-Type", "text/plain")
+
+	controller.ImportTasks(c)
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	mockUsecase.AssertNotCalled(t, "ImportTasks", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// --- User Controller Tests ---
+
+// --- Comment Controller Tests ---
+
+func TestCommentController_CreateComment_UsesAuthenticatedAuthor(t *testing.T) {
+	mockUsecase := new(mocks.MockCommentUsecase)
+	controller := controllers.NewCommentController(mockUsecase)
+
+	params := gin.Params{{Key: "id", Value: "1"}}
+	c, w := setupTestContext(http.MethodPost, "/tasks/1/comments", gin.H{"body": "hello"}, params)
+	infrastructure.SetPrincipal(c, infrastructure.Principal{UserID: "user-1", Role: domain.RoleUser})
+
+	mockUsecase.EXPECT().
+		AddComment(mock.Anything, domain.Actor{UserID: "user-1", Role: domain.RoleUser}, "1", "hello").
+		Return(domain.Comment{ID: "c1", TaskID: "1", AuthorID: "user-1", Body: "hello"}, nil)
+
+	controller.CreateComment(c)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestCommentController_GetComments_Fail_TaskNotFound(t *testing.T) {
+	mockUsecase := new(mocks.MockCommentUsecase)
+	controller := controllers.NewCommentController(mockUsecase)
+
+	params := gin.Params{{Key: "id", Value: "999"}}
+	c, w := setupTestContext(http.MethodGet, "/tasks/999/comments", nil, params)
+
+	mockUsecase.EXPECT().ListComments(mock.Anything, "999", int64(0), int64(0)).Return(domain.CommentPage{}, domain.ErrNotFound)
+
+	controller.GetComments(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestCommentController_DeleteComment_Fail_Forbidden(t *testing.T) {
+	mockUsecase := new(mocks.MockCommentUsecase)
+	controller := controllers.NewCommentController(mockUsecase)
+
+	params := gin.Params{{Key: "id", Value: "1"}, {Key: "commentId", Value: "c1"}}
+	c, w := setupTestContext(http.MethodDelete, "/tasks/1/comments/c1", nil, params)
+	infrastructure.SetPrincipal(c, infrastructure.Principal{UserID: "user-2"})
+
+	mockUsecase.EXPECT().
+		DeleteComment(mock.Anything, mock.Anything, "1", "c1").
+		Return(fmt.Errorf("%w: only the author or an admin can change this comment", domain.ErrForbidden))
+
+	controller.DeleteComment(c)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestUserController_RegisterUser_Fail_AlreadyExists(t *testing.T) {
+	mockUsecase := new(mocks.MockUserUsecase)
+	controller := controllers.NewUserController(mockUsecase)
+
+	credentials := domain.Credentials{UserName: "taken", Password: "p"}
+	c, w := setupTestContext(http.MethodPost, "/user/register", credentials, nil)
+
+	// Mock Usecase returning a wrapped ErrAleadyExists
+	mockUsecase.EXPECT().RegisterUser(mock.Anything, "taken", "p").Return(domain.User{}, domain.ErrAleadyExists)
+
+	controller.RegisterUser(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response map[string]string
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Equal(t, domain.ErrAleadyExists.Error(), response["error"])
+
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestUserController_AuthenticateUser_Fail_InvalidCredentials(t *testing.T) {
+	mockUsecase := new(mocks.MockUserUsecase)
+	controller := controllers.NewUserController(mockUsecase)
+
+	credentials := domain.Credentials{UserName: "baduser", Password: "badpassword"}
+	c, w := setupTestContext(http.MethodPost, "/user/login", credentials, nil)
+
+	// Mock Usecase failing due to validation (bad password) or not found (bad username)
+	mockUsecase.EXPECT().AuthenticateUser(mock.Anything, "baduser", "badpassword", "", mock.Anything).Return(domain.TokenPair{}, domain.ErrValidation)
+
+	controller.AuthenticateUser(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response map[string]string
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Equal(t, "Invalid username or password", response["error"])
+
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestUserController_AuthenticateUser_Fail_Throttled(t *testing.T) {
+	mockUsecase := new(mocks.MockUserUsecase)
+	controller := controllers.NewUserController(mockUsecase)
+
+	credentials := domain.Credentials{UserName: "john", Password: "guess"}
+	c, w := setupTestContext(http.MethodPost, "/user/login", credentials, nil)
+
+	// failures are counted against the address the request came from
+	throttled := &domain.LoginThrottledError{RetryAfter: 2500 * time.Millisecond, Locked: true}
+	mockUsecase.EXPECT().AuthenticateUser(mock.Anything, "john", "guess", "", "192.0.2.1").Return(domain.TokenPair{}, throttled)
+
+	controller.AuthenticateUser(c)
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "3", w.Header().Get("Retry-After"))
+	var response map[string]interface{}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Equal(t, true, response["locked"])
+	assert.Contains(t, response["error"], "locked out")
+
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestUserController_AuthenticateUser_Fail_PasswordChangeRequired(t *testing.T) {
+	mockUsecase := new(mocks.MockUserUsecase)
+	controller := controllers.NewUserController(mockUsecase)
+
+	// 1. Without a new password the client is told to send one
+	c, w := setupTestContext(http.MethodPost, "/user/login", domain.Credentials{UserName: "john", Password: "temporary"}, nil)
+	mockUsecase.EXPECT().AuthenticateUser(mock.Anything, "john", "temporary", "", mock.Anything).Return(domain.TokenPair{}, domain.ErrPasswordChangeRequired)
+
+	controller.AuthenticateUser(c)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	var response map[string]interface{}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Equal(t, true, response["password_change_required"])
+
+	// 2. A rejected new password says why, rather than blaming the credentials
+	credentials := domain.Credentials{UserName: "john", Password: "temporary", NewPassword: "short"}
+	c, w = setupTestContext(http.MethodPost, "/user/login", credentials, nil)
+	mockUsecase.EXPECT().AuthenticateUser(mock.Anything, "john", "temporary", "short", mock.Anything).
+		Return(domain.TokenPair{}, &domain.PasswordPolicyError{Reason: "must be at least 8 characters"})
+
+	controller.AuthenticateUser(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "password must be at least 8 characters")
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestUserController_ChangePassword(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       interface{}
+		err        error
+		wantStatus int
+	}{
+		{name: "success", body: map[string]string{"current_password": "old", "new_password": "new"}, wantStatus: http.StatusOK},
+		{name: "wrong current password", body: map[string]string{"current_password": "old", "new_password": "new"}, err: domain.ErrInvalidCredential, wantStatus: http.StatusForbidden},
+		{name: "weak new password", body: map[string]string{"current_password": "old", "new_password": "new"}, err: &domain.PasswordPolicyError{Reason: "is too common"}, wantStatus: http.StatusBadRequest},
+		{name: "throttled", body: map[string]string{"current_password": "old", "new_password": "new"}, err: &domain.LoginThrottledError{RetryAfter: time.Second}, wantStatus: http.StatusTooManyRequests},
+		{name: "missing new password", body: map[string]string{"current_password": "old"}, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(mocks.MockUserUsecase)
+			controller := controllers.NewUserController(mockUsecase)
+
+			c, w := setupTestContext(http.MethodPost, "/user/password", tt.body, nil)
+			infrastructure.SetPrincipal(c, infrastructure.Principal{UserID: "user-1", Role: domain.RoleUser})
+
+			if tt.wantStatus != http.StatusBadRequest || tt.err != nil {
+				mockUsecase.EXPECT().ChangePassword(mock.Anything, domain.Actor{UserID: "user-1", Role: domain.RoleUser}, "old", "new").
+					Return(domain.TokenPair{AccessToken: "token", RefreshToken: "refresh"}, tt.err)
+			}
+
+			controller.ChangePassword(c)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.Contains(t, w.Body.String(), `"refresh_token":"refresh"`)
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserController_ResetPassword(t *testing.T) {
+	mockUsecase := new(mocks.MockUserUsecase)
+	controller := controllers.NewUserController(mockUsecase)
+	params := gin.Params{{Key: "id", Value: "user-1"}}
+
+	// 1. The body is optional
+	c, w := setupTestContext(http.MethodPost, "/user/user-1/password/reset", nil, params)
+	mockUsecase.EXPECT().ResetPassword(mock.Anything, mock.Anything, "user-1", "").Return(domain.User{MustChangePassword: true}, nil)
+
+	controller.ResetPassword(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"must_change_password":true`)
+
+	// 2. An unknown user
+	c, w = setupTestContext(http.MethodPost, "/user/nobody/password/reset", map[string]string{"temporary_password": "temporary-one"}, gin.Params{{Key: "id", Value: "nobody"}})
+	mockUsecase.EXPECT().ResetPassword(mock.Anything, mock.Anything, "nobody", "temporary-one").Return(domain.User{}, domain.ErrNotFound)
+
+	controller.ResetPassword(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestUserController_GetLoginLockouts_Success(t *testing.T) {
+	mockUsecase := new(mocks.MockUserUsecase)
+	controller := controllers.NewUserController(mockUsecase)
+
+	c, w := setupTestContext(http.MethodGet, "/user/lockouts", nil, nil)
+
+	lockouts := []domain.LoginAttempts{{Kind: domain.LoginAttemptUser, Subject: "john", Failures: 10, LockedUntil: time.Now().Add(time.Hour)}}
+	mockUsecase.EXPECT().ListLoginLockouts(mock.Anything).Return(lockouts, nil)
+
+	controller.GetLoginLockouts(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"subject":"john"`)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestUserController_UnlockLogin(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		kind       domain.LoginAttemptKind
+		subject    string
+		err        error
+		wantStatus int
+	}{
+		{name: "by username", url: "/user/lockouts?user_name=john", kind: domain.LoginAttemptUser, subject: "john", wantStatus: http.StatusOK},
+		{name: "by address", url: "/user/lockouts?ip=10.0.0.1", kind: domain.LoginAttemptIP, subject: "10.0.0.1", wantStatus: http.StatusOK},
+		{name: "nothing recorded", url: "/user/lockouts?user_name=jane", kind: domain.LoginAttemptUser, subject: "jane", err: domain.ErrNotFound, wantStatus: http.StatusNotFound},
+		{name: "empty username", url: "/user/lockouts?user_name=", kind: domain.LoginAttemptUser, subject: "", err: domain.ErrValidation, wantStatus: http.StatusBadRequest},
+		{name: "neither", url: "/user/lockouts", wantStatus: http.StatusBadRequest},
+		{name: "both", url: "/user/lockouts?user_name=john&ip=10.0.0.1", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(mocks.MockUserUsecase)
+			controller := controllers.NewUserController(mockUsecase)
+
+			c, w := setupTestContext(http.MethodDelete, tt.url, nil, nil)
+			infrastructure.SetPrincipal(c, infrastructure.Principal{UserID: "admin-id", Role: domain.RoleAdmin})
+
+			if tt.kind != "" {
+				mockUsecase.EXPECT().UnlockLogin(mock.Anything, domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}, tt.kind, tt.subject).Return(tt.err)
+			}
+
+			controller.UnlockLogin(c)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserController_RefreshToken_Fail_InvalidToken(t *testing.T) {
+	mockUsecase := new(mocks.MockUserUsecase)
+	controller := controllers.NewUserController(mockUsecase)
+
+	body := map[string]string{"refresh_token": "stale"}
+	c, w := setupTestContext(http.MethodPost, "/user/refresh", body, nil)
+
+	mockUsecase.EXPECT().RefreshTokens(mock.Anything, "stale").Return(domain.TokenPair{}, domain.ErrInvalidToken)
+
+	controller.RefreshToken(c)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestUserController_Logout_Success(t *testing.T) {
+	mockUsecase := new(mocks.MockUserUsecase)
+	controller := controllers.NewUserController(mockUsecase)
+
+	body := map[string]string{"refresh_token": "refresh"}
+	c, w := setupTestContext(http.MethodPost, "/user/logout", body, nil)
+
+	// Simulate AuthMiddleware having authenticated the request
+	expiresAt := time.Now().Add(time.Minute)
+	infrastructure.SetPrincipal(c, infrastructure.Principal{UserID: "user-1", TokenID: "jti-1", ExpiresAt: expiresAt})
+
+	mockUsecase.EXPECT().Logout(mock.Anything, "user-1", "refresh", "jti-1", expiresAt).Return(nil)
+
+	controller.Logout(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+// --- Audit Controller Tests ---
+
+func TestAuditController_GetAuditLog_ParsesFilter(t *testing.T) {
+	mockUsecase := new(mocks.MockAuditUsecase)
+	controller := controllers.NewAuditController(mockUsecase)
+	c, w := setupTestContext(http.MethodGet, "/audit?actor_id=admin-1&action=task.delete&from=2024-01-01T00:00:00Z&limit=1", nil, nil)
+
+	expectedFilter := domain.AuditFilter{
+		ActorID: "admin-1",
+		Action:  domain.AuditTaskDeleted,
+		From:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Limit:   1,
+	}
+	expectedPage := domain.AuditPage{Entries: []domain.AuditEntry{{ID: "e1"}}, Total: 2, Limit: 1}
+	mockUsecase.EXPECT().QueryAuditLog(mock.Anything, expectedFilter).Return(expectedPage, nil)
+
+	controller.GetAuditLog(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Entries []domain.AuditEntry `json:"entries"`
+		Next    string              `json:"next"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Len(t, response.Entries, 1)
+	assert.Contains(t, response.Next, "offset=1")
+
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestAuditController_GetAuditLog_Fail_BadTimestamp(t *testing.T) {
+	mockUsecase := new(mocks.MockAuditUsecase)
+	controller := controllers.NewAuditController(mockUsecase)
+	c, w := setupTestContext(http.MethodGet, "/audit?to=yesterday", nil, nil)
+
+	controller.GetAuditLog(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUsecase.AssertNotCalled(t, "QueryAuditLog", mock.Anything, mock.Anything)
+}
+
+func TestAuditController_ExportAuditLog_WritesJSONLines(t *testing.T) {
+	mockUsecase := new(mocks.MockAuditUsecase)
+	controller := controllers.NewAuditController(mockUsecase)
+	c, w := setupTestContext(http.MethodGet, "/audit/export?target_type=task", nil, nil)
+
+	mockUsecase.EXPECT().
+		ExportAuditLog(mock.Anything, domain.AuditFilter{TargetType: domain.AuditTargetTask}, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ domain.AuditFilter, fn func(domain.AuditEntry) error) error {
+			fn(domain.AuditEntry{ID: "e1", Action: domain.AuditTaskCreated})
+			fn(domain.AuditEntry{ID: "e2", Action: domain.AuditTaskDeleted})
+			return nil
+		})
+
+	controller.ExportAuditLog(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+
+	// one JSON document per line
+	lines := bytes.Split(bytes.TrimSpace(w.Body.Bytes()), []byte("\n"))
+	assert.Len(t, lines, 2)
+	var entry domain.AuditEntry
+	assert.NoError(t, json.Unmarshal(lines[1], &entry))
+	assert.Equal(t, "e2", entry.ID)
+}
+
+func TestAuditController_ExportAuditLog_Fail_InvalidFilter(t *testing.T) {
+	mockUsecase := new(mocks.MockAuditUsecase)
+	controller := controllers.NewAuditController(mockUsecase)
+	c, w := setupTestContext(http.MethodGet, "/audit/export?action=nope", nil, nil)
+
+	// nothing was streamed yet, so the error is still a regular response
+	mockUsecase.EXPECT().
+		ExportAuditLog(mock.Anything, domain.AuditFilter{Action: "nope"}, mock.Anything).
+		Return(fmt.Errorf("%w: unknown audit action", domain.ErrValidation))
+
+	controller.ExportAuditLog(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+}
+
+// --- Label Controller Tests ---
+
+func TestLabelController_CreateLabel_Success(t *testing.T) {
+	mockUsecase := new(mocks.MockLabelUsecase)
+	controller := controllers.NewLabelController(mockUsecase)
+	c, w := setupTestContext(http.MethodPost, "/labels", gin.H{"name": "urgent", "color": "#ff0000"}, nil)
+	infrastructure.SetPrincipal(c, infrastructure.Principal{UserID: "admin-1", Role: domain.RoleAdmin})
+
+	mockUsecase.EXPECT().
+		CreateLabel(mock.Anything, domain.Actor{UserID: "admin-1", Role: domain.RoleAdmin}, domain.Label{Name: "urgent", Color: "#ff0000"}).
+		Return(domain.Label{Name: "urgent", Color: "#ff0000"}, nil)
+
+	controller.CreateLabel(c)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestLabelController_UpdateLabel_Fail_AlreadyExists(t *testing.T) {
+	mockUsecase := new(mocks.MockLabelUsecase)
+	controller := controllers.NewLabelController(mockUsecase)
+	params := gin.Params{gin.Param{Key: "name", Value: "bug"}}
+	c, w := setupTestContext(http.MethodPatch, "/labels/bug", gin.H{"name": "defect"}, params)
+
+	mockUsecase.EXPECT().
+		UpdateLabel(mock.Anything, mock.Anything, "bug", domain.Label{Name: "defect"}).
+		Return(domain.Label{}, domain.ErrAleadyExists)
+
+	controller.UpdateLabel(c)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestLabelController_DeleteLabel_ReportsUntaggedTasks(t *testing.T) {
+	mockUsecase := new(mocks.MockLabelUsecase)
+	controller := controllers.NewLabelController(mockUsecase)
+	params := gin.Params{gin.Param{Key: "name", Value: "bug"}}
+	c, w := setupTestContext(http.MethodDelete, "/labels/bug", nil, params)
+
+	mockUsecase.EXPECT().DeleteLabel(mock.Anything, mock.Anything, "bug").Return(int64(4), nil)
+
+	controller.DeleteLabel(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"tasks_updated":4`)
+}
+
+// --- Webhook Controller Tests ---
+
+func TestWebhookController_CreateWebhook_ReturnsSecretOnce(t *testing.T) {
+	mockUsecase := new(mocks.MockWebhookUsecase)
+	controller := controllers.NewWebhookController(mockUsecase)
+	body := gin.H{"url": "https://ci.example.com/hooks", "events": []string{"task.created"}}
+	c, w := setupTestContext(http.MethodPost, "/webhooks", body, nil)
+	infrastructure.SetPrincipal(c, infrastructure.Principal{UserID: "admin-1", Role: domain.RoleAdmin})
+
+	// a webhook is active unless the request says otherwise
+	mockUsecase.EXPECT().
+		CreateWebhook(mock.Anything, domain.Actor{UserID: "admin-1", Role: domain.RoleAdmin}, domain.Webhook{
+			URL: "https://ci.example.com/hooks", Events: []domain.WebhookEvent{domain.WebhookTaskCreated}, Active: true,
+		}).
+		Return(domain.Webhook{ID: "hook-1", URL: "https://ci.example.com/hooks", Secret: "generated-secret-value", Active: true}, nil)
+
+	controller.CreateWebhook(c)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, 1, strings.Count(w.Body.String(), "generated-secret-value"))
+	assert.Contains(t, w.Body.String(), `"secret":"generated-secret-value"`)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestWebhookController_CreateWebhook_Fail_Validation(t *testing.T) {
+	mockUsecase := new(mocks.MockWebhookUsecase)
+	controller := controllers.NewWebhookController(mockUsecase)
+	c, w := setupTestContext(http.MethodPost, "/webhooks", gin.H{"url": "https://ci.example.com/hooks", "events": []string{"task.exploded"}}, nil)
+
+	mockUsecase.EXPECT().
+		CreateWebhook(mock.Anything, mock.Anything, mock.Anything).
+		Return(domain.Webhook{}, fmt.Errorf("%w: unknown event", domain.ErrValidation))
+
+	controller.CreateWebhook(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestWebhookController_GetDeliveries_Paginates(t *testing.T) {
+	mockUsecase := new(mocks.MockWebhookUsecase)
+	controller := controllers.NewWebhookController(mockUsecase)
+	params := gin.Params{gin.Param{Key: "id", Value: "hook-1"}}
+	c, w := setupTestContext(http.MethodGet, "/webhooks/hook-1/deliveries?limit=1", nil, params)
+
+	mockUsecase.EXPECT().
+		ListDeliveries(mock.Anything, "hook-1", int64(1), int64(0)).
+		Return(domain.DeliveryPage{Deliveries: []domain.WebhookDelivery{{ID: "d-2"}}, Total: 2, Limit: 1}, nil)
+
+	controller.GetDeliveries(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Deliveries []domain.WebhookDelivery `json:"deliveries"`
+		Next       string                   `json:"next"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Len(t, response.Deliveries, 1)
+	assert.Equal(t, "/webhooks/hook-1/deliveries?limit=1&offset=1", response.Next)
+}
+
+func TestWebhookController_Redeliver_Fail_Inactive(t *testing.T) {
+	mockUsecase := new(mocks.MockWebhookUsecase)
+	controller := controllers.NewWebhookController(mockUsecase)
+	params := gin.Params{gin.Param{Key: "id", Value: "hook-1"}, gin.Param{Key: "deliveryId", Value: "d-1"}}
+	c, w := setupTestContext(http.MethodPost, "/webhooks/hook-1/deliveries/d-1/redeliver", nil, params)
+
+	mockUsecase.EXPECT().
+		Redeliver(mock.Anything, "hook-1", "d-1").
+		Return(domain.WebhookDelivery{}, fmt.Errorf("%w: webhook is not active", domain.ErrConflict))
+
+	controller.Redeliver(c)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+// --- Task Event Controller Tests ---
+
+func TestTaskEventController_StreamTaskEvents_ReplaysMissedEvents(t *testing.T) {
+	mockUsecase := new(mocks.MockTaskEventUsecase)
+	controller := controllers.NewTaskEventController(mockUsecase)
+	c, w := setupTestContext(http.MethodGet, "/tasks/events?status=todo", nil, nil)
+	c.Request.Header.Set("Last-Event-ID", "epoch-4")
+
+	// the stream ends once the subscription is closed
+	events := make(chan domain.TaskEvent, 1)
+	events <- domain.TaskEvent{ID: "epoch-6", Event: domain.WebhookTaskDeleted, Task: domain.Task{ID: "2"}}
+	close(events)
+
+	mockUsecase.EXPECT().
+		Subscribe("epoch-4", domain.TaskEventFilter{Status: domain.StatusTodo}).
+		Return(&usecases.TaskSubscription{
+			Missed: []domain.TaskEvent{{ID: "epoch-5", Event: domain.WebhookTaskCreated, Task: domain.Task{ID: "1"}}},
+			Events: events,
+		}, nil)
+
+	controller.StreamTaskEvents(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, strings.HasPrefix(w.Header().Get("Content-Type"), "text/event-stream"))
+
+	body := w.Body.String()
+	assert.NotContains(t, body, "event:resync")
+	assert.Contains(t, body, "id:epoch-5\nevent:task.created\n")
+	assert.Contains(t, body, "id:epoch-6\nevent:task.deleted\n")
+	assert.Less(t, strings.Index(body, "epoch-5"), strings.Index(body, "epoch-6"))
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestTaskEventController_StreamTaskEvents_AsksForResync(t *testing.T) {
+	mockUsecase := new(mocks.MockTaskEventUsecase)
+	controller := controllers.NewTaskEventController(mockUsecase)
+	c, w := setupTestContext(http.MethodGet, "/tasks/events?last_event_id=old-1", nil, nil)
+
+	events := make(chan domain.TaskEvent)
+	close(events)
+
+	mockUsecase.EXPECT().
+		Subscribe("old-1", domain.TaskEventFilter{}).
+		Return(&usecases.TaskSubscription{Resync: true, Events: events}, nil)
+
+	controller.StreamTaskEvents(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "event:resync\n")
+}
+
+func TestTaskEventController_StreamTaskEvents_Fail_Validation(t *testing.T) {
+	mockUsecase := new(mocks.MockTaskEventUsecase)
+	controller := controllers.NewTaskEventController(mockUsecase)
+	c, w := setupTestContext(http.MethodGet, "/tasks/events?status=exploded", nil, nil)
+
+	mockUsecase.EXPECT().
+		Subscribe("", domain.TaskEventFilter{Status: "exploded"}).
+		Return(nil, fmt.Errorf("%w: unknown status", domain.ErrValidation))
+
+	controller.StreamTaskEvents(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// --- Calendar Controller Tests ---
+
+func TestCalendarController_GetCalendar_RendersTodos(t *testing.T) {
+	mockUsecase := new(mocks.MockCalendarUsecase)
+	controller := controllers.NewCalendarController(mockUsecase, "")
+	params := gin.Params{gin.Param{Key: "token", Value: "secret"}}
+	c, w := setupTestContext(http.MethodGet, "/calendar/secret/tasks.ics?type=todo", nil, params)
+
+	task := domain.Task{
+		ID:          "7f1c",
+		Title:       "Review; sign, ship",
+		Description: strings.Repeat("é", 60) + "\nsecond line",
+		Status:      domain.StatusDone,
+		DueDate:     time.Date(2026, 3, 1, 9, 30, 0, 0, time.FixedZone("CET", 3600)),
+		Labels:      []string{"release"},
+		Version:     3,
+	}
+	mockUsecase.EXPECT().GetCalendarTasks(mock.Anything, "secret").Return([]domain.Task{task}, nil)
+
+	controller.GetCalendar(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/calendar; charset=utf-8", w.Header().Get("Content-Type"))
+
+	body := w.Body.String()
+	assert.True(t, strings.HasPrefix(body, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.True(t, strings.HasSuffix(body, "END:VTODO\r\nEND:VCALENDAR\r\n"))
+	assert.Contains(t, body, "\r\nUID:7f1c@taskmanager\r\n")
+	assert.Contains(t, body, "\r\nDUE:20260301T083000Z\r\n")
+	assert.Contains(t, body, "\r\nSTATUS:COMPLETED\r\n")
+	assert.Contains(t, body, "\r\nSEQUENCE:2\r\n")
+	assert.Contains(t, body, `SUMMARY:Review\; sign\, ship`)
+	assert.Regexp(t, `\r\nDTSTAMP:\d{8}T\d{6}Z\r\n`, body)
+
+	// long lines are folded at 75 octets without splitting a character
+	for _, line := range strings.Split(strings.TrimSuffix(body, "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), 75, line)
+		assert.True(t, utf8.ValidString(line), line)
+	}
+	unfolded := strings.ReplaceAll(body, "\r\n ", "")
+	assert.Contains(t, unfolded, "DESCRIPTION:"+strings.Repeat("é", 60)+`\nsecond line`+"\r\n")
+}
+
+func TestCalendarController_GetCalendar_EventStatuses(t *testing.T) {
+	mockUsecase := new(mocks.MockCalendarUsecase)
+	controller := controllers.NewCalendarController(mockUsecase, "")
+	params := gin.Params{gin.Param{Key: "token", Value: "secret"}}
+	c, w := setupTestContext(http.MethodGet, "/calendar/secret/tasks.ics", nil, params)
+
+	due := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	mockUsecase.EXPECT().GetCalendarTasks(mock.Anything, "secret").Return([]domain.Task{
+		{ID: "1", Title: "a", Status: domain.StatusInProgress, DueDate: due},
+		{ID: "2", Title: "b", Status: domain.StatusCancelled, DueDate: due},
+	}, nil)
+
+	controller.GetCalendar(c)
+
+	// events are confirmed unless the task was cancelled
+	body := w.Body.String()
+	assert.Equal(t, 2, strings.Count(body, "BEGIN:VEVENT\r\n"))
+	assert.Contains(t, body, "\r\nDTSTART:20260301T090000Z\r\n")
+	assert.Equal(t, 1, strings.Count(body, "STATUS:CONFIRMED"))
+	assert.Equal(t, 1, strings.Count(body, "STATUS:CANCELLED"))
+	assert.NotContains(t, body, "VTODO")
+}
+
+func TestCalendarController_GetCalendar_Fail_UnknownSecret(t *testing.T) {
+	mockUsecase := new(mocks.MockCalendarUsecase)
+	controller := controllers.NewCalendarController(mockUsecase, "")
+	params := gin.Params{gin.Param{Key: "token", Value: "guess"}}
+	c, w := setupTestContext(http.MethodGet, "/calendar/guess/tasks.ics", nil, params)
+
+	mockUsecase.EXPECT().GetCalendarTasks(mock.Anything, "guess").Return(nil, domain.ErrInvalidToken)
+
+	controller.GetCalendar(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestCalendarController_CreateCalendarFeed_ReturnsTheURL(t *testing.T) {
+	mockUsecase := new(mocks.MockCalendarUsecase)
+	controller := controllers.NewCalendarController(mockUsecase, "https://tasks.example.com/")
+	c, w := setupTestContext(http.MethodPost, "/user/calendar", nil, nil)
+	c.Request.Host = "evil.example.com"
+	infrastructure.SetPrincipal(c, infrastructure.Principal{UserID: "user-1"})
+
+	mockUsecase.EXPECT().CreateCalendarFeed(mock.Anything, "user-1").Return(domain.CalendarFeed{UserID: "user-1", TokenHash: "hash"}, "secret", nil)
+
+	controller.CreateCalendarFeed(c)
+
+	// the public base URL wins over the host the request names
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response struct {
+		URL  string          `json:"url"`
+		Feed json.RawMessage `json:"feed"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Equal(t, "https://tasks.example.com/api/v1/calendar/secret/tasks.ics", response.URL)
+	assert.NotContains(t, string(response.Feed), "hash")
+}
+
+func TestCalendarController_CreateCalendarFeed_IgnoresForwardedHeaders(t *testing.T) {
+	mockUsecase := new(mocks.MockCalendarUsecase)
+	controller := controllers.NewCalendarController(mockUsecase, "")
+	c, w := setupTestContext(http.MethodPost, "/user/calendar", nil, nil)
+	c.Request.Host = "tasks.example.com"
+	c.Request.Header.Set("X-Forwarded-Proto", "https")
+	c.Request.Header.Set("X-Forwarded-Host", "evil.example.com")
+	infrastructure.SetPrincipal(c, infrastructure.Principal{UserID: "user-1"})
+
+	mockUsecase.EXPECT().CreateCalendarFeed(mock.Anything, "user-1").Return(domain.CalendarFeed{UserID: "user-1"}, "secret", nil)
+
+	controller.CreateCalendarFeed(c)
+
+	// without a public base URL the feed points at the connection it was created through
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response struct {
+		URL string `json:"url"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Equal(t, "http://tasks.example.com/api/v1/calendar/secret/tasks.ics", response.URL)
+}
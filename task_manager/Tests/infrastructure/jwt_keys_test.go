@@ -0,0 +1,153 @@
+package infrastructure_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	domain "taskmanager/Domain"
+	infrastructure "taskmanager/Infrastructure"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writePEM writes a PEM block into the test's temporary directory and returns its path
+func writePEM(t *testing.T, name string, blockType string, der []byte) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600))
+	return path
+}
+
+func TestLoadJWTKeys_RS256(t *testing.T) {
+	// ARRANGE: A PKCS#1 RSA key
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyFile := writePEM(t, "rsa.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(key))
+
+	// ACT
+	keys, err := infrastructure.LoadJWTKeys(keyFile, nil)
+	require.NoError(t, err)
+	tokenString, err := infrastructure.GenerateJWT(keys, infrastructure.DefaultJWTSettings(), "user-1", "alice", domain.RoleUser)
+	require.NoError(t, err)
+
+	// ASSERT 1: The token names its key and verifies with the public key alone
+	jwks := keys.JWKS()
+	require.Len(t, jwks.Keys, 1)
+	jwk := jwks.Keys[0]
+	assert.Equal(t, "RSA", jwk.KeyType)
+	assert.Equal(t, "RS256", jwk.Algorithm)
+	assert.Equal(t, "sig", jwk.Use)
+	assert.Equal(t, base64.RawURLEncoding.EncodeToString(key.N.Bytes()), jwk.Modulus)
+	assert.Equal(t, "AQAB", jwk.Exponent)
+
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		assert.Equal(t, jwk.KeyID, token.Header["kid"])
+		return &key.PublicKey, nil
+	}, jwt.WithValidMethods([]string{"RS256"}))
+	require.NoError(t, err)
+	assert.True(t, token.Valid)
+
+	// ASSERT 2: The kid is the key's thumbprint, so it doesn't change when the key is loaded again
+	reloaded, err := infrastructure.LoadJWTKeys(keyFile, nil)
+	require.NoError(t, err)
+	assert.Equal(t, jwk.KeyID, reloaded.JWKS().Keys[0].KeyID)
+}
+
+func TestLoadJWTKeys_EdDSAWithVerificationKeys(t *testing.T) {
+	// ARRANGE: A PKCS#8 Ed25519 signing key and the previous RSA key as a PKIX public key
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+	keyFile := writePEM(t, "ed25519.pem", "PRIVATE KEY", der)
+
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err = x509.MarshalPKIXPublicKey(oldKey.Public())
+	require.NoError(t, err)
+	oldKeyFile := writePEM(t, "old.pub.pem", "PUBLIC KEY", der)
+
+	// ACT
+	keys, err := infrastructure.LoadJWTKeys(keyFile, []string{oldKeyFile})
+	require.NoError(t, err)
+
+	// ASSERT: Both keys are published, the signing key first
+	assert.Equal(t, "EdDSA", keys.Algorithm())
+	assert.Equal(t, []string{"EdDSA", "RS256"}, keys.Algorithms())
+
+	jwks := keys.JWKS()
+	require.Len(t, jwks.Keys, 2)
+	assert.Equal(t, "OKP", jwks.Keys[0].KeyType)
+	assert.Equal(t, "Ed25519", jwks.Keys[0].Curve)
+	assert.Equal(t, base64.RawURLEncoding.EncodeToString(publicKey), jwks.Keys[0].X)
+	assert.Equal(t, "RSA", jwks.Keys[1].KeyType)
+	assert.NotEqual(t, jwks.Keys[0].KeyID, jwks.Keys[1].KeyID)
+}
+
+func TestLoadJWTKeys_InvalidKeys(t *testing.T) {
+	// a key too short to sign with
+	weakKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	_, err = infrastructure.LoadJWTKeys(writePEM(t, "weak.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(weakKey)), nil)
+	assert.Error(t, err)
+
+	// a public key where the private key is expected
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+	publicKeyFile := writePEM(t, "ed25519.pub.pem", "PUBLIC KEY", der)
+	_, err = infrastructure.LoadJWTKeys(publicKeyFile, nil)
+	assert.Error(t, err)
+
+	// a file that is not PEM
+	notPEM := filepath.Join(t.TempDir(), "key.txt")
+	require.NoError(t, os.WriteFile(notPEM, []byte("not a key"), 0600))
+	der, err = x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+	keyFile := writePEM(t, "ed25519.pem", "PRIVATE KEY", der)
+	_, err = infrastructure.LoadJWTKeys(keyFile, []string{notPEM})
+	assert.Error(t, err)
+
+	// a missing file
+	_, err = infrastructure.LoadJWTKeys(filepath.Join(t.TempDir(), "missing.pem"), nil)
+	assert.Error(t, err)
+}
+
+func TestJWTKeysFromEnv(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+	keyFile := writePEM(t, "ed25519.pem", "PRIVATE KEY", der)
+
+	// 1. Without a private key the secret signs, and nothing is published
+	t.Setenv("JWT_PRIVATE_KEY_FILE", "")
+	t.Setenv("JWT_VERIFICATION_KEY_FILES", "")
+	t.Setenv("JWT_SECRET", "secret")
+	keys, err := infrastructure.JWTKeysFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, "HS256", keys.Algorithm())
+	assert.Empty(t, keys.JWKS().Keys)
+
+	// 2. Verification keys need a private key to rotate from
+	t.Setenv("JWT_VERIFICATION_KEY_FILES", keyFile)
+	_, err = infrastructure.JWTKeysFromEnv()
+	assert.Error(t, err)
+
+	// 3. The private key takes precedence over the secret
+	t.Setenv("JWT_VERIFICATION_KEY_FILES", "")
+	t.Setenv("JWT_PRIVATE_KEY_FILE", keyFile)
+	keys, err = infrastructure.JWTKeysFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, "EdDSA", keys.Algorithm())
+	assert.Len(t, keys.JWKS().Keys, 1)
+}
@@ -1,33 +1,22 @@
 package infrastructure_test
 
 import (
-	"os"
 	domain "taskmanager/Domain"
 	infrastructure "taskmanager/Infrastructure"
 	"testing"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-// temporarily sets the JWT_SECRET for testing
-func setTestSecret(secret string) func() {
-	// Set the required environment variable
-	os.Setenv("JWT_SECRET", secret)
-
-	// Return a cleanup function
-	return func() {
-		os.Unsetenv("JWT_SECRET")
-	}
-}
-
 func TestGenerateJWT_Success(t *testing.T) {
 
-	// ARRANGE 1: Set a secret and ensure it is cleaned up.
+	// ARRANGE 1: Sign with a shared secret.
 	testSecret := "test_secret_key_123"
-	cleanup := setTestSecret(testSecret)
-	defer cleanup()
+	keys, err := infrastructure.NewHMACJWTKeys(testSecret)
+	require.NoError(t, err)
 
 	// ARRANGE 2: Define expected claims data.
 	expectedUserID := "a1b2c3d4-e5f6-7890-1234-567890abcdef"
@@ -35,7 +24,8 @@ func TestGenerateJWT_Success(t *testing.T) {
 	expectedRole := domain.RoleAdmin
 
 	// ACT: Generate the JWT token.
-	tokenString, err := infrastructure.GenerateJWT(expectedUserID, expectedUserName, expectedRole)
+	settings := infrastructure.JWTSettings{Issuer: "https://tasks.example.com", Audience: "tasks-api", Leeway: time.Second}
+	tokenString, err := infrastructure.GenerateJWT(keys, settings, expectedUserID, expectedUserName, expectedRole)
 
 	// ASSERT 1: Check for no error and that a token string was returned
 	require.NoError(t, err, "GenerateJWT should not return an error")
@@ -59,17 +49,68 @@ func TestGenerateJWT_Success(t *testing.T) {
 	assert.Equal(t, expectedUserID, claims["user_id"], "Claim 'user_id' mismatch")
 	assert.Equal(t, expectedUserName, claims["user_name"], "Claim 'user_name' mismatch")
 	assert.Equal(t, expectedRole, domain.UserRole(claims["role"].(float64)), "Claim 'role' mismatch")
+	assert.NotEmpty(t, claims["jti"], "Claim 'jti' should identify the token")
+
+	// Verify the registered claims
+	assert.Equal(t, settings.Issuer, claims["iss"])
+	assert.Equal(t, []interface{}{settings.Audience}, claims["aud"])
+	assert.Equal(t, claims["iat"], claims["nbf"], "Token should be valid from the moment it is issued")
+	assert.Equal(t, claims["iat"].(float64)+infrastructure.AccessTokenTTL.Seconds(), claims["exp"])
 }
 
-func TestGenerateJWT_NoSecret(t *testing.T) {
-	// ARRANGE: Ensure JWT_SECRET is NOT set (Unset any potential residual value).
-	os.Unsetenv("JWT_SECRET")
+func TestParseJWT(t *testing.T) {
+	keys, err := infrastructure.NewHMACJWTKeys("test_secret_key_123")
+	require.NoError(t, err)
+	settings := infrastructure.DefaultJWTSettings()
+
+	tokenString, err := infrastructure.GenerateJWT(keys, settings, "user-1", "alice", domain.RoleUser)
+	require.NoError(t, err)
+
+	// 1. The claims GenerateJWT signed come back typed
+	claims, err := infrastructure.ParseJWT(keys, settings, tokenString)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", claims.UserID)
+	assert.Equal(t, "alice", claims.UserName)
+	require.NotNil(t, claims.Role)
+	assert.Equal(t, domain.RoleUser, *claims.Role)
+	assert.NotEmpty(t, claims.ID)
+
+	// 2. Services expecting another issuer or audience reject the token
+	otherIssuer := settings
+	otherIssuer.Issuer = "someone-else"
+	_, err = infrastructure.ParseJWT(keys, otherIssuer, tokenString)
+	assert.ErrorIs(t, err, jwt.ErrTokenInvalidIssuer)
+
+	otherAudience := settings
+	otherAudience.Audience = "another-service"
+	_, err = infrastructure.ParseJWT(keys, otherAudience, tokenString)
+	assert.ErrorIs(t, err, jwt.ErrTokenInvalidAudience)
+}
 
-	// ACT: Generate the JWT token.
-	tokenString, err := infrastructure.GenerateJWT("id", "user", domain.RoleUser)
+func TestJWTSettings_Validate(t *testing.T) {
+	assert.NoError(t, infrastructure.DefaultJWTSettings().Validate())
+
+	invalid := []infrastructure.JWTSettings{
+		{Issuer: "", Audience: "taskmanager"},
+		{Issuer: "taskmanager", Audience: ""},
+		{Issuer: "taskmanager", Audience: "taskmanager", Leeway: -time.Second},
+		{Issuer: "taskmanager", Audience: "taskmanager", Leeway: infrastructure.AccessTokenTTL},
+	}
+	for _, settings := range invalid {
+		assert.ErrorIs(t, settings.Validate(), domain.ErrValidation, "%+v", settings)
+	}
+}
+
+func TestJWTKeysFromEnv_NoSecret(t *testing.T) {
+	// ARRANGE: Neither a private key nor JWT_SECRET is set.
+	t.Setenv("JWT_PRIVATE_KEY_FILE", "")
+	t.Setenv("JWT_VERIFICATION_KEY_FILES", "")
+	t.Setenv("JWT_SECRET", "")
+
+	// ACT
+	keys, err := infrastructure.JWTKeysFromEnv()
 
-	// ASSERT: The JWT library will likely panic/error when signing with an empty key,
-	// We verify an error is returned and no token is present.
-	assert.Error(t, err, "GenerateJWT should return an error when JWT_SECRET is missing or empty")
-	assert.Empty(t, tokenString, "Token string should be empty on error")
+	// ASSERT: Tokens can't be signed, so the keys are refused up front.
+	assert.Error(t, err, "JWTKeysFromEnv should return an error when JWT_SECRET is missing or empty")
+	assert.Nil(t, keys)
 }
@@ -0,0 +1,208 @@
+package infrastructure_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	domain "taskmanager/Domain"
+	infrastructure "taskmanager/Infrastructure"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testReminder(kind domain.ReminderKind) domain.Reminder {
+	return domain.NewReminder(kind, domain.Task{
+		ID:         "42",
+		Title:      "Write report\r\nBcc: someone@example.com",
+		AssigneeID: "user-1",
+		DueDate:    time.Date(2025, 11, 12, 14, 30, 0, 0, time.UTC),
+	})
+}
+
+func TestLogNotifier_WritesReminder(t *testing.T) {
+	var out bytes.Buffer
+	notifier := infrastructure.NewLogNotifier(log.New(&out, "", 0))
+
+	require.NoError(t, notifier.Notify(context.Background(), testReminder(domain.ReminderOverdue)))
+
+	assert.Contains(t, out.String(), "(42) was due 2025-11-12 14:30 UTC")
+}
+
+func TestFileNotifier_AppendsJSONLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reminders.jsonl")
+	notifier := infrastructure.NewFileNotifier(path)
+
+	require.NoError(t, notifier.Notify(context.Background(), testReminder(domain.ReminderDueSoon)))
+	require.NoError(t, notifier.Notify(context.Background(), testReminder(domain.ReminderOverdue)))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+
+	var reminder domain.Reminder
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &reminder))
+	assert.Equal(t, domain.ReminderOverdue, reminder.Kind)
+	assert.Equal(t, "42", reminder.TaskID)
+}
+
+// fakeSMTPServer accepts one session on a local port and sends the commands and the mail data it received
+func fakeSMTPServer(t *testing.T) (string, <-chan []string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	received := make(chan []string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var lines []string
+		reader := bufio.NewReader(conn)
+		reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+
+		reply("220 localhost ESMTP fake")
+		inData := false
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				break
+			}
+			line = strings.TrimRight(line, "\r\n")
+			lines = append(lines, line)
+
+			switch {
+			case inData && line == ".":
+				inData = false
+				reply("250 queued")
+			case inData:
+			case strings.HasPrefix(line, "EHLO"):
+				reply("250 localhost")
+			case line == "DATA":
+				inData = true
+				reply("354 go ahead")
+			case line == "QUIT":
+				reply("221 bye")
+				received <- lines
+				return
+			default:
+				reply("250 ok")
+			}
+		}
+		received <- lines
+	}()
+
+	return listener.Addr().String(), received
+}
+
+func TestSMTPNotifier_SendsMail(t *testing.T) {
+	addr, received := fakeSMTPServer(t)
+	notifier, err := infrastructure.NewSMTPNotifier(infrastructure.SMTPConfig{
+		Addr: addr,
+		From: "tasks@example.com",
+		To:   []string{"team@example.com", "lead@example.com"},
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, notifier.Notify(ctx, testReminder(domain.ReminderDueSoon)))
+
+	lines := <-received
+	session := strings.Join(lines, "\n")
+	assert.Contains(t, session, "MAIL FROM:<tasks@example.com>")
+	assert.Contains(t, session, "RCPT TO:<team@example.com>")
+	assert.Contains(t, session, "RCPT TO:<lead@example.com>")
+	assert.Contains(t, session, "To: team@example.com, lead@example.com")
+	assert.Contains(t, session, "(42) is due 2025-11-12 14:30 UTC")
+
+	// the line break in the title is encoded instead of starting a new header
+	for _, line := range lines {
+		assert.False(t, strings.HasPrefix(line, "Bcc:"), "the title injected a header")
+	}
+}
+
+func TestSMTPNotifier_Fail_ServerDown(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	listener.Close()
+
+	notifier, err := infrastructure.NewSMTPNotifier(infrastructure.SMTPConfig{Addr: addr, From: "a@example.com", To: []string{"b@example.com"}})
+	require.NoError(t, err)
+
+	assert.Error(t, notifier.Notify(context.Background(), testReminder(domain.ReminderDueSoon)))
+}
+
+// stallingSMTPServer greets every client and then never answers
+func stallingSMTPServer(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	done := make(chan struct{})
+	t.Cleanup(func() {
+		close(done)
+		listener.Close()
+	})
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Write([]byte("220 localhost ESMTP fake\r\n"))
+			go func() {
+				<-done
+				conn.Close()
+			}()
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func TestSMTPNotifier_Fail_StallingServer(t *testing.T) {
+	addr := stallingSMTPServer(t)
+
+	// 1. Without a deadline on the context the configured timeout ends the conversation
+	notifier, err := infrastructure.NewSMTPNotifier(infrastructure.SMTPConfig{Addr: addr, From: "a@example.com", To: []string{"b@example.com"}, Timeout: 100 * time.Millisecond})
+	require.NoError(t, err)
+
+	start := time.Now()
+	assert.Error(t, notifier.Notify(context.Background(), testReminder(domain.ReminderDueSoon)))
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	// 2. Cancelling the context, as on shutdown, ends it right away
+	notifier, err = infrastructure.NewSMTPNotifier(infrastructure.SMTPConfig{Addr: addr, From: "a@example.com", To: []string{"b@example.com"}, Timeout: time.Hour})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	start = time.Now()
+	assert.Error(t, notifier.Notify(ctx, testReminder(domain.ReminderDueSoon)))
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestNewSMTPNotifier_Fail_InvalidConfig(t *testing.T) {
+	_, err := infrastructure.NewSMTPNotifier(infrastructure.SMTPConfig{Addr: "localhost", From: "a@example.com", To: []string{"b@example.com"}})
+	assert.Error(t, err)
+
+	_, err = infrastructure.NewSMTPNotifier(infrastructure.SMTPConfig{Addr: "localhost:25", From: "a@example.com"})
+	assert.Error(t, err)
+
+	_, err = infrastructure.NewSMTPNotifier(infrastructure.SMTPConfig{Addr: "localhost:25", From: "a@example.com", To: []string{"b@example.com"}, Timeout: -time.Second})
+	assert.Error(t, err)
+}
@@ -0,0 +1,51 @@
+package infrastructure_test
+
+import (
+	"strings"
+	domain "taskmanager/Domain"
+	infrastructure "taskmanager/Infrastructure"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckPasswordPolicy(t *testing.T) {
+	policy := domain.PasswordPolicy{MinLength: 8, MinClasses: 3, RejectBreached: true}
+
+	tests := []struct {
+		name     string
+		password string
+		reason   string // empty when the password is accepted
+	}{
+		{name: "accepted", password: "Tr0mbone-x"},
+		{name: "too short", password: "Ab1!", reason: "at least 8 characters"},
+		{name: "length counts characters", password: "Äöü1Äöü1", reason: ""},
+		{name: "too long for bcrypt", password: "Aa1" + strings.Repeat("x", domain.MaxPasswordBytes), reason: "at most 72 bytes"},
+		{name: "too few classes", password: "alllowercase1", reason: "at least 3 of"},
+		{name: "the username", password: "John_Doe1", reason: "must not be the username"},
+		{name: "breached", password: "Password123", reason: "known data breaches"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := infrastructure.CheckPasswordPolicy(policy, "john_doe1", tt.password)
+
+			if tt.reason == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var weak *domain.PasswordPolicyError
+			assert.ErrorAs(t, err, &weak)
+			assert.ErrorIs(t, err, domain.ErrValidation)
+			assert.Contains(t, err.Error(), tt.reason)
+		})
+	}
+}
+
+func TestCheckPasswordPolicy_BreachedListCanBeTurnedOff(t *testing.T) {
+	policy := domain.PasswordPolicy{MinLength: 8}
+
+	assert.True(t, infrastructure.IsBreachedPassword("PASSWORD123"))
+	assert.NoError(t, infrastructure.CheckPasswordPolicy(policy, "john", "password123"))
+	assert.False(t, infrastructure.IsBreachedPassword("# Commonly breached passwords, one per line in lowercase. New passwords are checked against this"))
+}
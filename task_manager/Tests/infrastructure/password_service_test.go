@@ -2,6 +2,7 @@ package infrastructure_test
 
 import (
 	"errors"
+	"strings"
 	domain "taskmanager/Domain"
 	infrastructure "taskmanager/Infrastructure"
 	"testing"
@@ -53,3 +54,78 @@ func TestComparePassword_Mismatch(t *testing.T) {
 	// CRITICAL ASSERT: Check that the error is the expected domain error (ErrValidation).
 	assert.True(t, errors.Is(err, domain.ErrValidation), "Mismatch error should be domain.ErrValidation")
 }
+
+func TestPasswordHasher_Argon2id(t *testing.T) {
+	hasher, err := infrastructure.NewPasswordHasher(infrastructure.PasswordHashArgon2id, 0, infrastructure.DefaultArgon2Params)
+	require.NoError(t, err)
+
+	hashed, err := hasher.Hash("securepassword")
+	require.NoError(t, err)
+
+	// the algorithm and its parameters are encoded in the hash
+	assert.True(t, strings.HasPrefix(hashed, "$argon2id$v=19$m=19456,t=2,p=1$"), hashed)
+	assert.NoError(t, hasher.Compare(hashed, "securepassword"))
+	assert.ErrorIs(t, hasher.Compare(hashed, "wrongpassword"), domain.ErrValidation)
+	assert.False(t, hasher.NeedsRehash(hashed))
+
+	// salted, so the same password never hashes the same
+	again, err := hasher.Hash("securepassword")
+	require.NoError(t, err)
+	assert.NotEqual(t, hashed, again)
+
+	// package level comparison understands argon2id too
+	assert.NoError(t, infrastructure.ComparePassword(hashed, "securepassword"))
+}
+
+func TestPasswordHasher_NeedsRehash(t *testing.T) {
+	weak := infrastructure.Argon2Params{Memory: 64, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+	strong := infrastructure.Argon2Params{Memory: 128, Iterations: 2, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+
+	weakHasher, err := infrastructure.NewPasswordHasher(infrastructure.PasswordHashArgon2id, 0, weak)
+	require.NoError(t, err)
+	strongHasher, err := infrastructure.NewPasswordHasher(infrastructure.PasswordHashArgon2id, 0, strong)
+	require.NoError(t, err)
+	bcryptHasher, err := infrastructure.NewPasswordHasher(infrastructure.PasswordHashBcrypt, bcrypt.MinCost, weak)
+	require.NoError(t, err)
+
+	weakHash, err := weakHasher.Hash("securepassword")
+	require.NoError(t, err)
+	bcryptHash, err := bcryptHasher.Hash("securepassword")
+	require.NoError(t, err)
+	defaultCostHash, err := infrastructure.HashPassword("securepassword")
+	require.NoError(t, err)
+
+	// a hash made with other parameters or another algorithm still verifies, but should be replaced
+	assert.NoError(t, strongHasher.Compare(weakHash, "securepassword"))
+	assert.True(t, strongHasher.NeedsRehash(weakHash))
+	assert.NoError(t, strongHasher.Compare(bcryptHash, "securepassword"))
+	assert.True(t, strongHasher.NeedsRehash(bcryptHash))
+
+	assert.False(t, bcryptHasher.NeedsRehash(bcryptHash))
+	assert.True(t, bcryptHasher.NeedsRehash(defaultCostHash), "another bcrypt cost")
+	assert.True(t, bcryptHasher.NeedsRehash(weakHash))
+}
+
+func TestPasswordHasher_MalformedHash(t *testing.T) {
+	hasher, err := infrastructure.NewPasswordHasher(infrastructure.PasswordHashArgon2id, 0, infrastructure.DefaultArgon2Params)
+	require.NoError(t, err)
+
+	// a damaged hash is a server problem, not a wrong password
+	for _, hash := range []string{"$argon2id$v=19$m=64,t=1$c2FsdHNhbHQ$a2V5", "$argon2id$v=16$m=64,t=1,p=1$c2FsdHNhbHQ$a2V5a2V5a2V5a2V5a2V5", "not a hash"} {
+		err := hasher.Compare(hash, "securepassword")
+		assert.Error(t, err, hash)
+		assert.NotErrorIs(t, err, domain.ErrValidation, hash)
+		assert.True(t, hasher.NeedsRehash(hash), hash)
+	}
+}
+
+func TestNewPasswordHasher_InvalidSettings(t *testing.T) {
+	_, err := infrastructure.NewPasswordHasher("md5", infrastructure.DefaultBcryptCost, infrastructure.DefaultArgon2Params)
+	assert.ErrorIs(t, err, domain.ErrValidation)
+
+	_, err = infrastructure.NewPasswordHasher(infrastructure.PasswordHashBcrypt, 3, infrastructure.DefaultArgon2Params)
+	assert.ErrorIs(t, err, domain.ErrValidation)
+
+	_, err = infrastructure.NewPasswordHasher(infrastructure.PasswordHashArgon2id, 0, infrastructure.Argon2Params{Memory: 64, Iterations: 0, Parallelism: 1, SaltLength: 16, KeyLength: 32})
+	assert.ErrorIs(t, err, domain.ErrValidation)
+}
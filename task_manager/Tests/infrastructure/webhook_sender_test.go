@@ -0,0 +1,86 @@
+package infrastructure_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	domain "taskmanager/Domain"
+	infrastructure "taskmanager/Infrastructure"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDelivery() domain.WebhookDelivery {
+	return domain.WebhookDelivery{
+		ID:      "delivery-1",
+		EventID: "event-1",
+		Event:   domain.WebhookTaskCreated,
+		Payload: json.RawMessage(`{"id":"event-1","event":"task.created","data":{"id":"42"}}`),
+	}
+}
+
+func TestHTTPWebhookSender_PostsSignedPayload(t *testing.T) {
+	const secret = "0123456789abcdef"
+
+	var received *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sender := infrastructure.NewHTTPWebhookSender(5 * time.Second)
+	delivery := testDelivery()
+
+	status, err := sender.Send(context.Background(), domain.Webhook{URL: server.URL, Secret: secret}, delivery)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, status)
+	assert.Equal(t, http.MethodPost, received.Method)
+	assert.Equal(t, "application/json", received.Header.Get("Content-Type"))
+	assert.Equal(t, "task.created", received.Header.Get(infrastructure.WebhookEventHeader))
+	assert.Equal(t, "delivery-1", received.Header.Get(infrastructure.WebhookDeliveryHeader))
+	assert.JSONEq(t, string(delivery.Payload), string(body))
+
+	// the receiver can check the body against the signature with the shared secret
+	signature := received.Header.Get(infrastructure.WebhookSignatureHeader)
+	assert.True(t, infrastructure.VerifyWebhookSignature(secret, body, signature))
+	assert.False(t, infrastructure.VerifyWebhookSignature("another-secret-value", body, signature))
+}
+
+func TestSignWebhookPayload_KnownVector(t *testing.T) {
+	// HMAC-SHA256 test case 2 of RFC 4231
+	signature := infrastructure.SignWebhookPayload("Jefe", []byte("what do ya want for nothing?"))
+
+	assert.Equal(t, "sha256=5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843", signature)
+}
+
+func TestHTTPWebhookSender_FailsOnErrorStatusAndRedirect(t *testing.T) {
+	sender := infrastructure.NewHTTPWebhookSender(5 * time.Second)
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	status, err := sender.Send(context.Background(), domain.Webhook{URL: failing.URL, Secret: "s"}, testDelivery())
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusInternalServerError, status)
+
+	// redirects are not followed, they would drop the payload
+	redirecting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, failing.URL, http.StatusFound)
+	}))
+	defer redirecting.Close()
+
+	status, err = sender.Send(context.Background(), domain.Webhook{URL: redirecting.URL, Secret: "s"}, testDelivery())
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusFound, status)
+}
@@ -7,6 +7,8 @@ package mocks
 import (
 	"context"
 	domain "taskmanager/Domain"
+	repositories "taskmanager/Repositories"
+	"time"
 
 	mock "github.com/stretchr/testify/mock"
 	"go.mongodb.org/mongo-driver/bson"
@@ -39,6 +41,154 @@ func (_m *MockTaskRepository) EXPECT() *MockTaskRepository_Expecter {
 	return &MockTaskRepository_Expecter{mock: &_m.Mock}
 }
 
+// BulkCreate provides a mock function for the type MockTaskRepository
+func (_mock *MockTaskRepository) BulkCreate(ctx context.Context, tasks []domain.Task, atomic bool) ([]error, error) {
+	ret := _mock.Called(ctx, tasks, atomic)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkCreate")
+	}
+
+	var r0 []error
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []domain.Task, bool) ([]error, error)); ok {
+		return returnFunc(ctx, tasks, atomic)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []domain.Task, bool) []error); ok {
+		r0 = returnFunc(ctx, tasks, atomic)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]error)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []domain.Task, bool) error); ok {
+		r1 = returnFunc(ctx, tasks, atomic)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTaskRepository_BulkCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BulkCreate'
+type MockTaskRepository_BulkCreate_Call struct {
+	*mock.Call
+}
+
+// BulkCreate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tasks []domain.Task
+//   - atomic bool
+func (_e *MockTaskRepository_Expecter) BulkCreate(ctx interface{}, tasks interface{}, atomic interface{}) *MockTaskRepository_BulkCreate_Call {
+	return &MockTaskRepository_BulkCreate_Call{Call: _e.mock.On("BulkCreate", ctx, tasks, atomic)}
+}
+
+func (_c *MockTaskRepository_BulkCreate_Call) Run(run func(ctx context.Context, tasks []domain.Task, atomic bool)) *MockTaskRepository_BulkCreate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []domain.Task
+		if args[1] != nil {
+			arg1 = args[1].([]domain.Task)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskRepository_BulkCreate_Call) Return(errs []error, err error) *MockTaskRepository_BulkCreate_Call {
+	_c.Call.Return(errs, err)
+	return _c
+}
+
+func (_c *MockTaskRepository_BulkCreate_Call) RunAndReturn(run func(ctx context.Context, tasks []domain.Task, atomic bool) ([]error, error)) *MockTaskRepository_BulkCreate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BulkUpdate provides a mock function for the type MockTaskRepository
+func (_mock *MockTaskRepository) BulkUpdate(ctx context.Context, updates []repositories.TaskUpdate, atomic bool) ([]error, error) {
+	ret := _mock.Called(ctx, updates, atomic)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkUpdate")
+	}
+
+	var r0 []error
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []repositories.TaskUpdate, bool) ([]error, error)); ok {
+		return returnFunc(ctx, updates, atomic)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []repositories.TaskUpdate, bool) []error); ok {
+		r0 = returnFunc(ctx, updates, atomic)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]error)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []repositories.TaskUpdate, bool) error); ok {
+		r1 = returnFunc(ctx, updates, atomic)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTaskRepository_BulkUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BulkUpdate'
+type MockTaskRepository_BulkUpdate_Call struct {
+	*mock.Call
+}
+
+// BulkUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - updates []repositories.TaskUpdate
+//   - atomic bool
+func (_e *MockTaskRepository_Expecter) BulkUpdate(ctx interface{}, updates interface{}, atomic interface{}) *MockTaskRepository_BulkUpdate_Call {
+	return &MockTaskRepository_BulkUpdate_Call{Call: _e.mock.On("BulkUpdate", ctx, updates, atomic)}
+}
+
+func (_c *MockTaskRepository_BulkUpdate_Call) Run(run func(ctx context.Context, updates []repositories.TaskUpdate, atomic bool)) *MockTaskRepository_BulkUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []repositories.TaskUpdate
+		if args[1] != nil {
+			arg1 = args[1].([]repositories.TaskUpdate)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskRepository_BulkUpdate_Call) Return(errs []error, err error) *MockTaskRepository_BulkUpdate_Call {
+	_c.Call.Return(errs, err)
+	return _c
+}
+
+func (_c *MockTaskRepository_BulkUpdate_Call) RunAndReturn(run func(ctx context.Context, updates []repositories.TaskUpdate, atomic bool) ([]error, error)) *MockTaskRepository_BulkUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function for the type MockTaskRepository
 func (_mock *MockTaskRepository) Create(ctx context.Context, task domain.Task) (domain.Task, error) {
 	ret := _mock.Called(ctx, task)
@@ -106,16 +256,16 @@ func (_c *MockTaskRepository_Create_Call) RunAndReturn(run func(ctx context.Cont
 }
 
 // Delete provides a mock function for the type MockTaskRepository
-func (_mock *MockTaskRepository) Delete(ctx context.Context, id string) error {
-	ret := _mock.Called(ctx, id)
+func (_mock *MockTaskRepository) Delete(ctx context.Context, id string, expectedVersion int64, deletedAt time.Time) error {
+	ret := _mock.Called(ctx, id, expectedVersion, deletedAt)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Delete")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64, time.Time) error); ok {
+		r0 = returnFunc(ctx, id, expectedVersion, deletedAt)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -130,11 +280,13 @@ type MockTaskRepository_Delete_Call struct {
 // Delete is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id string
-func (_e *MockTaskRepository_Expecter) Delete(ctx interface{}, id interface{}) *MockTaskRepository_Delete_Call {
-	return &MockTaskRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+//   - expectedVersion int64
+//   - deletedAt time.Time
+func (_e *MockTaskRepository_Expecter) Delete(ctx interface{}, id interface{}, expectedVersion interface{}, deletedAt interface{}) *MockTaskRepository_Delete_Call {
+	return &MockTaskRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id, expectedVersion, deletedAt)}
 }
 
-func (_c *MockTaskRepository_Delete_Call) Run(run func(ctx context.Context, id string)) *MockTaskRepository_Delete_Call {
+func (_c *MockTaskRepository_Delete_Call) Run(run func(ctx context.Context, id string, expectedVersion int64, deletedAt time.Time)) *MockTaskRepository_Delete_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -144,9 +296,19 @@ func (_c *MockTaskRepository_Delete_Call) Run(run func(ctx context.Context, id s
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
@@ -157,29 +319,29 @@ func (_c *MockTaskRepository_Delete_Call) Return(err error) *MockTaskRepository_
 	return _c
 }
 
-func (_c *MockTaskRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockTaskRepository_Delete_Call {
+func (_c *MockTaskRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, id string, expectedVersion int64, deletedAt time.Time) error) *MockTaskRepository_Delete_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAll provides a mock function for the type MockTaskRepository
-func (_mock *MockTaskRepository) GetAll(ctx context.Context) ([]domain.Task, error) {
+// DistinctStatuses provides a mock function for the type MockTaskRepository
+func (_mock *MockTaskRepository) DistinctStatuses(ctx context.Context) ([]string, error) {
 	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAll")
+		panic("no return value specified for DistinctStatuses")
 	}
 
-	var r0 []domain.Task
+	var r0 []string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]domain.Task, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]string, error)); ok {
 		return returnFunc(ctx)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) []domain.Task); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []string); ok {
 		r0 = returnFunc(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.Task)
+			r0 = ret.Get(0).([]string)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
@@ -190,6 +352,140 @@ func (_mock *MockTaskRepository) GetAll(ctx context.Context) ([]domain.Task, err
 	return r0, r1
 }
 
+// MockTaskRepository_DistinctStatuses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DistinctStatuses'
+type MockTaskRepository_DistinctStatuses_Call struct {
+	*mock.Call
+}
+
+// DistinctStatuses is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockTaskRepository_Expecter) DistinctStatuses(ctx interface{}) *MockTaskRepository_DistinctStatuses_Call {
+	return &MockTaskRepository_DistinctStatuses_Call{Call: _e.mock.On("DistinctStatuses", ctx)}
+}
+
+func (_c *MockTaskRepository_DistinctStatuses_Call) Run(run func(ctx context.Context)) *MockTaskRepository_DistinctStatuses_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskRepository_DistinctStatuses_Call) Return(ss []string, err error) *MockTaskRepository_DistinctStatuses_Call {
+	_c.Call.Return(ss, err)
+	return _c
+}
+
+func (_c *MockTaskRepository_DistinctStatuses_Call) RunAndReturn(run func(ctx context.Context) ([]string, error)) *MockTaskRepository_DistinctStatuses_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DropLabel provides a mock function for the type MockTaskRepository
+func (_mock *MockTaskRepository) DropLabel(ctx context.Context, name string) (int64, error) {
+	ret := _mock.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DropLabel")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return returnFunc(ctx, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = returnFunc(ctx, name)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTaskRepository_DropLabel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DropLabel'
+type MockTaskRepository_DropLabel_Call struct {
+	*mock.Call
+}
+
+// DropLabel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockTaskRepository_Expecter) DropLabel(ctx interface{}, name interface{}) *MockTaskRepository_DropLabel_Call {
+	return &MockTaskRepository_DropLabel_Call{Call: _e.mock.On("DropLabel", ctx, name)}
+}
+
+func (_c *MockTaskRepository_DropLabel_Call) Run(run func(ctx context.Context, name string)) *MockTaskRepository_DropLabel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskRepository_DropLabel_Call) Return(n int64, err error) *MockTaskRepository_DropLabel_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockTaskRepository_DropLabel_Call) RunAndReturn(run func(ctx context.Context, name string) (int64, error)) *MockTaskRepository_DropLabel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAll provides a mock function for the type MockTaskRepository
+func (_mock *MockTaskRepository) GetAll(ctx context.Context, filter domain.TaskFilter) ([]domain.Task, int64, error) {
+	ret := _mock.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []domain.Task
+	var r1 int64
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.TaskFilter) ([]domain.Task, int64, error)); ok {
+		return returnFunc(ctx, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.TaskFilter) []domain.Task); ok {
+		r0 = returnFunc(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Task)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.TaskFilter) int64); ok {
+		r1 = returnFunc(ctx, filter)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, domain.TaskFilter) error); ok {
+		r2 = returnFunc(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
 // MockTaskRepository_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
 type MockTaskRepository_GetAll_Call struct {
 	*mock.Call
@@ -197,29 +493,35 @@ type MockTaskRepository_GetAll_Call struct {
 
 // GetAll is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockTaskRepository_Expecter) GetAll(ctx interface{}) *MockTaskRepository_GetAll_Call {
-	return &MockTaskRepository_GetAll_Call{Call: _e.mock.On("GetAll", ctx)}
+//   - filter domain.TaskFilter
+func (_e *MockTaskRepository_Expecter) GetAll(ctx interface{}, filter interface{}) *MockTaskRepository_GetAll_Call {
+	return &MockTaskRepository_GetAll_Call{Call: _e.mock.On("GetAll", ctx, filter)}
 }
 
-func (_c *MockTaskRepository_GetAll_Call) Run(run func(ctx context.Context)) *MockTaskRepository_GetAll_Call {
+func (_c *MockTaskRepository_GetAll_Call) Run(run func(ctx context.Context, filter domain.TaskFilter)) *MockTaskRepository_GetAll_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 domain.TaskFilter
+		if args[1] != nil {
+			arg1 = args[1].(domain.TaskFilter)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockTaskRepository_GetAll_Call) Return(tasks []domain.Task, err error) *MockTaskRepository_GetAll_Call {
-	_c.Call.Return(tasks, err)
+func (_c *MockTaskRepository_GetAll_Call) Return(tasks []domain.Task, n int64, err error) *MockTaskRepository_GetAll_Call {
+	_c.Call.Return(tasks, n, err)
 	return _c
 }
 
-func (_c *MockTaskRepository_GetAll_Call) RunAndReturn(run func(ctx context.Context) ([]domain.Task, error)) *MockTaskRepository_GetAll_Call {
+func (_c *MockTaskRepository_GetAll_Call) RunAndReturn(run func(ctx context.Context, filter domain.TaskFilter) ([]domain.Task, int64, error)) *MockTaskRepository_GetAll_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -290,63 +592,761 @@ func (_c *MockTaskRepository_GetByID_Call) RunAndReturn(run func(ctx context.Con
 	return _c
 }
 
-// Update provides a mock function for the type MockTaskRepository
-func (_mock *MockTaskRepository) Update(ctx context.Context, id string, updates bson.M) (domain.Task, error) {
-	ret := _mock.Called(ctx, id, updates)
+// GetByIDs provides a mock function for the type MockTaskRepository
+func (_mock *MockTaskRepository) GetByIDs(ctx context.Context, ids []string) ([]domain.Task, error) {
+	ret := _mock.Called(ctx, ids)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Update")
+		panic("no return value specified for GetByIDs")
 	}
 
-	var r0 domain.Task
+	var r0 []domain.Task
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bson.M) (domain.Task, error)); ok {
-		return returnFunc(ctx, id, updates)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) ([]domain.Task, error)); ok {
+		return returnFunc(ctx, ids)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bson.M) domain.Task); ok {
-		r0 = returnFunc(ctx, id, updates)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) []domain.Task); ok {
+		r0 = returnFunc(ctx, ids)
 	} else {
-		r0 = ret.Get(0).(domain.Task)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Task)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, bson.M) error); ok {
-		r1 = returnFunc(ctx, id, updates)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, ids)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockTaskRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
-type MockTaskRepository_Update_Call struct {
+// MockTaskRepository_GetByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByIDs'
+type MockTaskRepository_GetByIDs_Call struct {
 	*mock.Call
 }
 
-// Update is a helper method to define mock.On call
+// GetByIDs is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-//   - updates bson.M
-func (_e *MockTaskRepository_Expecter) Update(ctx interface{}, id interface{}, updates interface{}) *MockTaskRepository_Update_Call {
-	return &MockTaskRepository_Update_Call{Call: _e.mock.On("Update", ctx, id, updates)}
+//   - ids []string
+func (_e *MockTaskRepository_Expecter) GetByIDs(ctx interface{}, ids interface{}) *MockTaskRepository_GetByIDs_Call {
+	return &MockTaskRepository_GetByIDs_Call{Call: _e.mock.On("GetByIDs", ctx, ids)}
 }
 
-func (_c *MockTaskRepository_Update_Call) Run(run func(ctx context.Context, id string, updates bson.M)) *MockTaskRepository_Update_Call {
+func (_c *MockTaskRepository_GetByIDs_Call) Run(run func(ctx context.Context, ids []string)) *MockTaskRepository_GetByIDs_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 []string
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].([]string)
 		}
-		var arg2 bson.M
-		if args[2] != nil {
-			arg2 = args[2].(bson.M)
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskRepository_GetByIDs_Call) Return(tasks []domain.Task, err error) *MockTaskRepository_GetByIDs_Call {
+	_c.Call.Return(tasks, err)
+	return _c
+}
+
+func (_c *MockTaskRepository_GetByIDs_Call) RunAndReturn(run func(ctx context.Context, ids []string) ([]domain.Task, error)) *MockTaskRepository_GetByIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDeletedByID provides a mock function for the type MockTaskRepository
+func (_mock *MockTaskRepository) GetDeletedByID(ctx context.Context, id string) (domain.Task, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeletedByID")
+	}
+
+	var r0 domain.Task
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (domain.Task, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) domain.Task); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.Task)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTaskRepository_GetDeletedByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeletedByID'
+type MockTaskRepository_GetDeletedByID_Call struct {
+	*mock.Call
+}
+
+// GetDeletedByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockTaskRepository_Expecter) GetDeletedByID(ctx interface{}, id interface{}) *MockTaskRepository_GetDeletedByID_Call {
+	return &MockTaskRepository_GetDeletedByID_Call{Call: _e.mock.On("GetDeletedByID", ctx, id)}
+}
+
+func (_c *MockTaskRepository_GetDeletedByID_Call) Run(run func(ctx context.Context, id string)) *MockTaskRepository_GetDeletedByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskRepository_GetDeletedByID_Call) Return(task domain.Task, err error) *MockTaskRepository_GetDeletedByID_Call {
+	_c.Call.Return(task, err)
+	return _c
+}
+
+func (_c *MockTaskRepository_GetDeletedByID_Call) RunAndReturn(run func(ctx context.Context, id string) (domain.Task, error)) *MockTaskRepository_GetDeletedByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSubtasks provides a mock function for the type MockTaskRepository
+func (_mock *MockTaskRepository) GetSubtasks(ctx context.Context, parentIDs []string) ([]domain.Task, error) {
+	ret := _mock.Called(ctx, parentIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubtasks")
+	}
+
+	var r0 []domain.Task
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) ([]domain.Task, error)); ok {
+		return returnFunc(ctx, parentIDs)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) []domain.Task); ok {
+		r0 = returnFunc(ctx, parentIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Task)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, parentIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTaskRepository_GetSubtasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSubtasks'
+type MockTaskRepository_GetSubtasks_Call struct {
+	*mock.Call
+}
+
+// GetSubtasks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - parentIDs []string
+func (_e *MockTaskRepository_Expecter) GetSubtasks(ctx interface{}, parentIDs interface{}) *MockTaskRepository_GetSubtasks_Call {
+	return &MockTaskRepository_GetSubtasks_Call{Call: _e.mock.On("GetSubtasks", ctx, parentIDs)}
+}
+
+func (_c *MockTaskRepository_GetSubtasks_Call) Run(run func(ctx context.Context, parentIDs []string)) *MockTaskRepository_GetSubtasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskRepository_GetSubtasks_Call) Return(tasks []domain.Task, err error) *MockTaskRepository_GetSubtasks_Call {
+	_c.Call.Return(tasks, err)
+	return _c
+}
+
+func (_c *MockTaskRepository_GetSubtasks_Call) RunAndReturn(run func(ctx context.Context, parentIDs []string) ([]domain.Task, error)) *MockTaskRepository_GetSubtasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDeleted provides a mock function for the type MockTaskRepository
+func (_mock *MockTaskRepository) ListDeleted(ctx context.Context, deletedBefore time.Time, limit int64, offset int64) ([]domain.Task, int64, error) {
+	ret := _mock.Called(ctx, deletedBefore, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeleted")
+	}
+
+	var r0 []domain.Task
+	var r1 int64
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, int64, int64) ([]domain.Task, int64, error)); ok {
+		return returnFunc(ctx, deletedBefore, limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, int64, int64) []domain.Task); ok {
+		r0 = returnFunc(ctx, deletedBefore, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Task)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time, int64, int64) int64); ok {
+		r1 = returnFunc(ctx, deletedBefore, limit, offset)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, time.Time, int64, int64) error); ok {
+		r2 = returnFunc(ctx, deletedBefore, limit, offset)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockTaskRepository_ListDeleted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDeleted'
+type MockTaskRepository_ListDeleted_Call struct {
+	*mock.Call
+}
+
+// ListDeleted is a helper method to define mock.On call
+//   - ctx context.Context
+//   - deletedBefore time.Time
+//   - limit int64
+//   - offset int64
+func (_e *MockTaskRepository_Expecter) ListDeleted(ctx interface{}, deletedBefore interface{}, limit interface{}, offset interface{}) *MockTaskRepository_ListDeleted_Call {
+	return &MockTaskRepository_ListDeleted_Call{Call: _e.mock.On("ListDeleted", ctx, deletedBefore, limit, offset)}
+}
+
+func (_c *MockTaskRepository_ListDeleted_Call) Run(run func(ctx context.Context, deletedBefore time.Time, limit int64, offset int64)) *MockTaskRepository_ListDeleted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 int64
+		if args[3] != nil {
+			arg3 = args[3].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskRepository_ListDeleted_Call) Return(tasks []domain.Task, n int64, err error) *MockTaskRepository_ListDeleted_Call {
+	_c.Call.Return(tasks, n, err)
+	return _c
+}
+
+func (_c *MockTaskRepository_ListDeleted_Call) RunAndReturn(run func(ctx context.Context, deletedBefore time.Time, limit int64, offset int64) ([]domain.Task, int64, error)) *MockTaskRepository_ListDeleted_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Purge provides a mock function for the type MockTaskRepository
+func (_mock *MockTaskRepository) Purge(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Purge")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTaskRepository_Purge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Purge'
+type MockTaskRepository_Purge_Call struct {
+	*mock.Call
+}
+
+// Purge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockTaskRepository_Expecter) Purge(ctx interface{}, id interface{}) *MockTaskRepository_Purge_Call {
+	return &MockTaskRepository_Purge_Call{Call: _e.mock.On("Purge", ctx, id)}
+}
+
+func (_c *MockTaskRepository_Purge_Call) Run(run func(ctx context.Context, id string)) *MockTaskRepository_Purge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskRepository_Purge_Call) Return(err error) *MockTaskRepository_Purge_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTaskRepository_Purge_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockTaskRepository_Purge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RenameLabel provides a mock function for the type MockTaskRepository
+func (_mock *MockTaskRepository) RenameLabel(ctx context.Context, from string, to string) (int64, error) {
+	ret := _mock.Called(ctx, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenameLabel")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (int64, error)); ok {
+		return returnFunc(ctx, from, to)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) int64); ok {
+		r0 = returnFunc(ctx, from, to)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTaskRepository_RenameLabel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenameLabel'
+type MockTaskRepository_RenameLabel_Call struct {
+	*mock.Call
+}
+
+// RenameLabel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - from string
+//   - to string
+func (_e *MockTaskRepository_Expecter) RenameLabel(ctx interface{}, from interface{}, to interface{}) *MockTaskRepository_RenameLabel_Call {
+	return &MockTaskRepository_RenameLabel_Call{Call: _e.mock.On("RenameLabel", ctx, from, to)}
+}
+
+func (_c *MockTaskRepository_RenameLabel_Call) Run(run func(ctx context.Context, from string, to string)) *MockTaskRepository_RenameLabel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskRepository_RenameLabel_Call) Return(n int64, err error) *MockTaskRepository_RenameLabel_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockTaskRepository_RenameLabel_Call) RunAndReturn(run func(ctx context.Context, from string, to string) (int64, error)) *MockTaskRepository_RenameLabel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplaceStatus provides a mock function for the type MockTaskRepository
+func (_mock *MockTaskRepository) ReplaceStatus(ctx context.Context, from string, to domain.TaskStatus) (int64, error) {
+	ret := _mock.Called(ctx, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceStatus")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.TaskStatus) (int64, error)); ok {
+		return returnFunc(ctx, from, to)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.TaskStatus) int64); ok {
+		r0 = returnFunc(ctx, from, to)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, domain.TaskStatus) error); ok {
+		r1 = returnFunc(ctx, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTaskRepository_ReplaceStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceStatus'
+type MockTaskRepository_ReplaceStatus_Call struct {
+	*mock.Call
+}
+
+// ReplaceStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - from string
+//   - to domain.TaskStatus
+func (_e *MockTaskRepository_Expecter) ReplaceStatus(ctx interface{}, from interface{}, to interface{}) *MockTaskRepository_ReplaceStatus_Call {
+	return &MockTaskRepository_ReplaceStatus_Call{Call: _e.mock.On("ReplaceStatus", ctx, from, to)}
+}
+
+func (_c *MockTaskRepository_ReplaceStatus_Call) Run(run func(ctx context.Context, from string, to domain.TaskStatus)) *MockTaskRepository_ReplaceStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 domain.TaskStatus
+		if args[2] != nil {
+			arg2 = args[2].(domain.TaskStatus)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskRepository_ReplaceStatus_Call) Return(n int64, err error) *MockTaskRepository_ReplaceStatus_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockTaskRepository_ReplaceStatus_Call) RunAndReturn(run func(ctx context.Context, from string, to domain.TaskStatus) (int64, error)) *MockTaskRepository_ReplaceStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Restore provides a mock function for the type MockTaskRepository
+func (_mock *MockTaskRepository) Restore(ctx context.Context, id string) (domain.Task, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 domain.Task
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (domain.Task, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) domain.Task); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.Task)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTaskRepository_Restore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restore'
+type MockTaskRepository_Restore_Call struct {
+	*mock.Call
+}
+
+// Restore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockTaskRepository_Expecter) Restore(ctx interface{}, id interface{}) *MockTaskRepository_Restore_Call {
+	return &MockTaskRepository_Restore_Call{Call: _e.mock.On("Restore", ctx, id)}
+}
+
+func (_c *MockTaskRepository_Restore_Call) Run(run func(ctx context.Context, id string)) *MockTaskRepository_Restore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskRepository_Restore_Call) Return(task domain.Task, err error) *MockTaskRepository_Restore_Call {
+	_c.Call.Return(task, err)
+	return _c
+}
+
+func (_c *MockTaskRepository_Restore_Call) RunAndReturn(run func(ctx context.Context, id string) (domain.Task, error)) *MockTaskRepository_Restore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Search provides a mock function for the type MockTaskRepository
+func (_mock *MockTaskRepository) Search(ctx context.Context, query domain.TaskSearchQuery) ([]domain.TaskSearchHit, int64, error) {
+	ret := _mock.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []domain.TaskSearchHit
+	var r1 int64
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.TaskSearchQuery) ([]domain.TaskSearchHit, int64, error)); ok {
+		return returnFunc(ctx, query)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.TaskSearchQuery) []domain.TaskSearchHit); ok {
+		r0 = returnFunc(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TaskSearchHit)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.TaskSearchQuery) int64); ok {
+		r1 = returnFunc(ctx, query)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, domain.TaskSearchQuery) error); ok {
+		r2 = returnFunc(ctx, query)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockTaskRepository_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type MockTaskRepository_Search_Call struct {
+	*mock.Call
+}
+
+// Search is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query domain.TaskSearchQuery
+func (_e *MockTaskRepository_Expecter) Search(ctx interface{}, query interface{}) *MockTaskRepository_Search_Call {
+	return &MockTaskRepository_Search_Call{Call: _e.mock.On("Search", ctx, query)}
+}
+
+func (_c *MockTaskRepository_Search_Call) Run(run func(ctx context.Context, query domain.TaskSearchQuery)) *MockTaskRepository_Search_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.TaskSearchQuery
+		if args[1] != nil {
+			arg1 = args[1].(domain.TaskSearchQuery)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskRepository_Search_Call) Return(taskSearchHits []domain.TaskSearchHit, n int64, err error) *MockTaskRepository_Search_Call {
+	_c.Call.Return(taskSearchHits, n, err)
+	return _c
+}
+
+func (_c *MockTaskRepository_Search_Call) RunAndReturn(run func(ctx context.Context, query domain.TaskSearchQuery) ([]domain.TaskSearchHit, int64, error)) *MockTaskRepository_Search_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Stream provides a mock function for the type MockTaskRepository
+func (_mock *MockTaskRepository) Stream(ctx context.Context, filter domain.TaskFilter, fn func(task domain.Task) error) error {
+	ret := _mock.Called(ctx, filter, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stream")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.TaskFilter, func(task domain.Task) error) error); ok {
+		r0 = returnFunc(ctx, filter, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTaskRepository_Stream_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stream'
+type MockTaskRepository_Stream_Call struct {
+	*mock.Call
+}
+
+// Stream is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.TaskFilter
+//   - fn func(task domain.Task) error
+func (_e *MockTaskRepository_Expecter) Stream(ctx interface{}, filter interface{}, fn interface{}) *MockTaskRepository_Stream_Call {
+	return &MockTaskRepository_Stream_Call{Call: _e.mock.On("Stream", ctx, filter, fn)}
+}
+
+func (_c *MockTaskRepository_Stream_Call) Run(run func(ctx context.Context, filter domain.TaskFilter, fn func(task domain.Task) error)) *MockTaskRepository_Stream_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.TaskFilter
+		if args[1] != nil {
+			arg1 = args[1].(domain.TaskFilter)
+		}
+		var arg2 func(task domain.Task) error
+		if args[2] != nil {
+			arg2 = args[2].(func(task domain.Task) error)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskRepository_Stream_Call) Return(err error) *MockTaskRepository_Stream_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTaskRepository_Stream_Call) RunAndReturn(run func(ctx context.Context, filter domain.TaskFilter, fn func(task domain.Task) error) error) *MockTaskRepository_Stream_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockTaskRepository
+func (_mock *MockTaskRepository) Update(ctx context.Context, id string, updates bson.M, expectedVersion int64) (domain.Task, error) {
+	ret := _mock.Called(ctx, id, updates, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 domain.Task
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bson.M, int64) (domain.Task, error)); ok {
+		return returnFunc(ctx, id, updates, expectedVersion)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bson.M, int64) domain.Task); ok {
+		r0 = returnFunc(ctx, id, updates, expectedVersion)
+	} else {
+		r0 = ret.Get(0).(domain.Task)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, bson.M, int64) error); ok {
+		r1 = returnFunc(ctx, id, updates, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTaskRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockTaskRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - updates bson.M
+//   - expectedVersion int64
+func (_e *MockTaskRepository_Expecter) Update(ctx interface{}, id interface{}, updates interface{}, expectedVersion interface{}) *MockTaskRepository_Update_Call {
+	return &MockTaskRepository_Update_Call{Call: _e.mock.On("Update", ctx, id, updates, expectedVersion)}
+}
+
+func (_c *MockTaskRepository_Update_Call) Run(run func(ctx context.Context, id string, updates bson.M, expectedVersion int64)) *MockTaskRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 bson.M
+		if args[2] != nil {
+			arg2 = args[2].(bson.M)
+		}
+		var arg3 int64
+		if args[3] != nil {
+			arg3 = args[3].(int64)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
@@ -357,7 +1357,7 @@ func (_c *MockTaskRepository_Update_Call) Return(task domain.Task, err error) *M
 	return _c
 }
 
-func (_c *MockTaskRepository_Update_Call) RunAndReturn(run func(ctx context.Context, id string, updates bson.M) (domain.Task, error)) *MockTaskRepository_Update_Call {
+func (_c *MockTaskRepository_Update_Call) RunAndReturn(run func(ctx context.Context, id string, updates bson.M, expectedVersion int64) (domain.Task, error)) *MockTaskRepository_Update_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -7,6 +7,7 @@ package mocks
 import (
 	"context"
 	domain "taskmanager/Domain"
+	"time"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -38,223 +39,1405 @@ func (_m *MockTaskUsecase) EXPECT() *MockTaskUsecase_Expecter {
 	return &MockTaskUsecase_Expecter{mock: &_m.Mock}
 }
 
+// AddTaskBlocker provides a mock function for the type MockTaskUsecase
+func (_mock *MockTaskUsecase) AddTaskBlocker(ctx context.Context, actor domain.Actor, id string, blockerId string) (domain.Task, error) {
+	ret := _mock.Called(ctx, actor, id, blockerId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddTaskBlocker")
+	}
+
+	var r0 domain.Task
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Actor, string, string) (domain.Task, error)); ok {
+		return returnFunc(ctx, actor, id, blockerId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Actor, string, string) domain.Task); ok {
+		r0 = returnFunc(ctx, actor, id, blockerId)
+	} else {
+		r0 = ret.Get(0).(domain.Task)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.Actor, string, string) error); ok {
+		r1 = returnFunc(ctx, actor, id, blockerId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTaskUsecase_AddTaskBlocker_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddTaskBlocker'
+type MockTaskUsecase_AddTaskBlocker_Call struct {
+	*mock.Call
+}
+
+// AddTaskBlocker is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actor domain.Actor
+//   - id string
+//   - blockerId string
+func (_e *MockTaskUsecase_Expecter) AddTaskBlocker(ctx interface{}, actor interface{}, id interface{}, blockerId interface{}) *MockTaskUsecase_AddTaskBlocker_Call {
+	return &MockTaskUsecase_AddTaskBlocker_Call{Call: _e.mock.On("AddTaskBlocker", ctx, actor, id, blockerId)}
+}
+
+func (_c *MockTaskUsecase_AddTaskBlocker_Call) Run(run func(ctx context.Context, actor domain.Actor, id string, blockerId string)) *MockTaskUsecase_AddTaskBlocker_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.Actor
+		if args[1] != nil {
+			arg1 = args[1].(domain.Actor)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskUsecase_AddTaskBlocker_Call) Return(task domain.Task, err error) *MockTaskUsecase_AddTaskBlocker_Call {
+	_c.Call.Return(task, err)
+	return _c
+}
+
+func (_c *MockTaskUsecase_AddTaskBlocker_Call) RunAndReturn(run func(ctx context.Context, actor domain.Actor, id string, blockerId string) (domain.Task, error)) *MockTaskUsecase_AddTaskBlocker_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddTaskLabels provides a mock function for the type MockTaskUsecase
+func (_mock *MockTaskUsecase) AddTaskLabels(ctx context.Context, actor domain.Actor, id string, labels []string) (domain.Task, error) {
+	ret := _mock.Called(ctx, actor, id, labels)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddTaskLabels")
+	}
+
+	var r0 domain.Task
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Actor, string, []string) (domain.Task, error)); ok {
+		return returnFunc(ctx, actor, id, labels)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Actor, string, []string) domain.Task); ok {
+		r0 = returnFunc(ctx, actor, id, labels)
+	} else {
+		r0 = ret.Get(0).(domain.Task)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.Actor, string, []string) error); ok {
+		r1 = returnFunc(ctx, actor, id, labels)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTaskUsecase_AddTaskLabels_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddTaskLabels'
+type MockTaskUsecase_AddTaskLabels_Call struct {
+	*mock.Call
+}
+
+// AddTaskLabels is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actor domain.Actor
+//   - id string
+//   - labels []string
+func (_e *MockTaskUsecase_Expecter) AddTaskLabels(ctx interface{}, actor interface{}, id interface{}, labels interface{}) *MockTaskUsecase_AddTaskLabels_Call {
+	return &MockTaskUsecase_AddTaskLabels_Call{Call: _e.mock.On("AddTaskLabels", ctx, actor, id, labels)}
+}
+
+func (_c *MockTaskUsecase_AddTaskLabels_Call) Run(run func(ctx context.Context, actor domain.Actor, id string, labels []string)) *MockTaskUsecase_AddTaskLabels_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.Actor
+		if args[1] != nil {
+			arg1 = args[1].(domain.Actor)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 []string
+		if args[3] != nil {
+			arg3 = args[3].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskUsecase_AddTaskLabels_Call) Return(task domain.Task, err error) *MockTaskUsecase_AddTaskLabels_Call {
+	_c.Call.Return(task, err)
+	return _c
+}
+
+func (_c *MockTaskUsecase_AddTaskLabels_Call) RunAndReturn(run func(ctx context.Context, actor domain.Actor, id string, labels []string) (domain.Task, error)) *MockTaskUsecase_AddTaskLabels_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AssignTask provides a mock function for the type MockTaskUsecase
+func (_mock *MockTaskUsecase) AssignTask(ctx context.Context, actor domain.Actor, id string, assigneeId string) (domain.Task, error) {
+	ret := _mock.Called(ctx, actor, id, assigneeId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignTask")
+	}
+
+	var r0 domain.Task
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Actor, string, string) (domain.Task, error)); ok {
+		return returnFunc(ctx, actor, id, assigneeId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Actor, string, string) domain.Task); ok {
+		r0 = returnFunc(ctx, actor, id, assigneeId)
+	} else {
+		r0 = ret.Get(0).(domain.Task)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.Actor, string, string) error); ok {
+		r1 = returnFunc(ctx, actor, id, assigneeId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTaskUsecase_AssignTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AssignTask'
+type MockTaskUsecase_AssignTask_Call struct {
+	*mock.Call
+}
+
+// AssignTask is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actor domain.Actor
+//   - id string
+//   - assigneeId string
+func (_e *MockTaskUsecase_Expecter) AssignTask(ctx interface{}, actor interface{}, id interface{}, assigneeId interface{}) *MockTaskUsecase_AssignTask_Call {
+	return &MockTaskUsecase_AssignTask_Call{Call: _e.mock.On("AssignTask", ctx, actor, id, assigneeId)}
+}
+
+func (_c *MockTaskUsecase_AssignTask_Call) Run(run func(ctx context.Context, actor domain.Actor, id string, assigneeId string)) *MockTaskUsecase_AssignTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.Actor
+		if args[1] != nil {
+			arg1 = args[1].(domain.Actor)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskUsecase_AssignTask_Call) Return(task domain.Task, err error) *MockTaskUsecase_AssignTask_Call {
+	_c.Call.Return(task, err)
+	return _c
+}
+
+func (_c *MockTaskUsecase_AssignTask_Call) RunAndReturn(run func(ctx context.Context, actor domain.Actor, id string, assigneeId string) (domain.Task, error)) *MockTaskUsecase_AssignTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BulkCreateTasks provides a mock function for the type MockTaskUsecase
+func (_mock *MockTaskUsecase) BulkCreateTasks(ctx context.Context, actor domain.Actor, tasks []domain.Task, atomic bool) (domain.BulkResult, error) {
+	ret := _mock.Called(ctx, actor, tasks, atomic)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkCreateTasks")
+	}
+
+	var r0 domain.BulkResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Actor, []domain.Task, bool) (domain.BulkResult, error)); ok {
+		return returnFunc(ctx, actor, tasks, atomic)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Actor, []domain.Task, bool) domain.BulkResult); ok {
+		r0 = returnFunc(ctx, actor, tasks, atomic)
+	} else {
+		r0 = ret.Get(0).(domain.BulkResult)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.Actor, []domain.Task, bool) error); ok {
+		r1 = returnFunc(ctx, actor, tasks, atomic)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTaskUsecase_BulkCreateTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BulkCreateTasks'
+type MockTaskUsecase_BulkCreateTasks_Call struct {
+	*mock.Call
+}
+
+// BulkCreateTasks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actor domain.Actor
+//   - tasks []domain.Task
+//   - atomic bool
+func (_e *MockTaskUsecase_Expecter) BulkCreateTasks(ctx interface{}, actor interface{}, tasks interface{}, atomic interface{}) *MockTaskUsecase_BulkCreateTasks_Call {
+	return &MockTaskUsecase_BulkCreateTasks_Call{Call: _e.mock.On("BulkCreateTasks", ctx, actor, tasks, atomic)}
+}
+
+func (_c *MockTaskUsecase_BulkCreateTasks_Call) Run(run func(ctx context.Context, actor domain.Actor, tasks []domain.Task, atomic bool)) *MockTaskUsecase_BulkCreateTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.Actor
+		if args[1] != nil {
+			arg1 = args[1].(domain.Actor)
+		}
+		var arg2 []domain.Task
+		if args[2] != nil {
+			arg2 = args[2].([]domain.Task)
+		}
+		var arg3 bool
+		if args[3] != nil {
+			arg3 = args[3].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskUsecase_BulkCreateTasks_Call) Return(bulkResult domain.BulkResult, err error) *MockTaskUsecase_BulkCreateTasks_Call {
+	_c.Call.Return(bulkResult, err)
+	return _c
+}
+
+func (_c *MockTaskUsecase_BulkCreateTasks_Call) RunAndReturn(run func(ctx context.Context, actor domain.Actor, tasks []domain.Task, atomic bool) (domain.BulkResult, error)) *MockTaskUsecase_BulkCreateTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BulkDeleteTasks provides a mock function for the type MockTaskUsecase
+func (_mock *MockTaskUsecase) BulkDeleteTasks(ctx context.Context, actor domain.Actor, ids []string, atomic bool) (domain.BulkResult, error) {
+	ret := _mock.Called(ctx, actor, ids, atomic)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkDeleteTasks")
+	}
+
+	var r0 domain.BulkResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Actor, []string, bool) (domain.BulkResult, error)); ok {
+		return returnFunc(ctx, actor, ids, atomic)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Actor, []string, bool) domain.BulkResult); ok {
+		r0 = returnFunc(ctx, actor, ids, atomic)
+	} else {
+		r0 = ret.Get(0).(domain.BulkResult)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.Actor, []string, bool) error); ok {
+		r1 = returnFunc(ctx, actor, ids, atomic)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTaskUsecase_BulkDeleteTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BulkDeleteTasks'
+type MockTaskUsecase_BulkDeleteTasks_Call struct {
+	*mock.Call
+}
+
+// BulkDeleteTasks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actor domain.Actor
+//   - ids []string
+//   - atomic bool
+func (_e *MockTaskUsecase_Expecter) BulkDeleteTasks(ctx interface{}, actor interface{}, ids interface{}, atomic interface{}) *MockTaskUsecase_BulkDeleteTasks_Call {
+	return &MockTaskUsecase_BulkDeleteTasks_Call{Call: _e.mock.On("BulkDeleteTasks", ctx, actor, ids, atomic)}
+}
+
+func (_c *MockTaskUsecase_BulkDeleteTasks_Call) Run(run func(ctx context.Context, actor domain.Actor, ids []string, atomic bool)) *MockTaskUsecase_BulkDeleteTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.Actor
+		if args[1] != nil {
+			arg1 = args[1].(domain.Actor)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		var arg3 bool
+		if args[3] != nil {
+			arg3 = args[3].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskUsecase_BulkDeleteTasks_Call) Return(bulkResult domain.BulkResult, err error) *MockTaskUsecase_BulkDeleteTasks_Call {
+	_c.Call.Return(bulkResult, err)
+	return _c
+}
+
+func (_c *MockTaskUsecase_BulkDeleteTasks_Call) RunAndReturn(run func(ctx context.Context, actor domain.Actor, ids []string, atomic bool) (domain.BulkResult, error)) *MockTaskUsecase_BulkDeleteTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BulkUpdateTasks provides a mock function for the type MockTaskUsecase
+func (_mock *MockTaskUsecase) BulkUpdateTasks(ctx context.Context, actor domain.Actor, update domain.BulkTaskUpdate) (domain.BulkResult, error) {
+	ret := _mock.Called(ctx, actor, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkUpdateTasks")
+	}
+
+	var r0 domain.BulkResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Actor, domain.BulkTaskUpdate) (domain.BulkResult, error)); ok {
+		return returnFunc(ctx, actor, update)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Actor, domain.BulkTaskUpdate) domain.BulkResult); ok {
+		r0 = returnFunc(ctx, actor, update)
+	} else {
+		r0 = ret.Get(0).(domain.BulkResult)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.Actor, domain.BulkTaskUpdate) error); ok {
+		r1 = returnFunc(ctx, actor, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTaskUsecase_BulkUpdateTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BulkUpdateTasks'
+type MockTaskUsecase_BulkUpdateTasks_Call struct {
+	*mock.Call
+}
+
+// BulkUpdateTasks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actor domain.Actor
+//   - update domain.BulkTaskUpdate
+func (_e *MockTaskUsecase_Expecter) BulkUpdateTasks(ctx interface{}, actor interface{}, update interface{}) *MockTaskUsecase_BulkUpdateTasks_Call {
+	return &MockTaskUsecase_BulkUpdateTasks_Call{Call: _e.mock.On("BulkUpdateTasks", ctx, actor, update)}
+}
+
+func (_c *MockTaskUsecase_BulkUpdateTasks_Call) Run(run func(ctx context.Context, actor domain.Actor, update domain.BulkTaskUpdate)) *MockTaskUsecase_BulkUpdateTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.Actor
+		if args[1] != nil {
+			arg1 = args[1].(domain.Actor)
+		}
+		var arg2 domain.BulkTaskUpdate
+		if args[2] != nil {
+			arg2 = args[2].(domain.BulkTaskUpdate)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskUsecase_BulkUpdateTasks_Call) Return(bulkResult domain.BulkResult, err error) *MockTaskUsecase_BulkUpdateTasks_Call {
+	_c.Call.Return(bulkResult, err)
+	return _c
+}
+
+func (_c *MockTaskUsecase_BulkUpdateTasks_Call) RunAndReturn(run func(ctx context.Context, actor domain.Actor, update domain.BulkTaskUpdate) (domain.BulkResult, error)) *MockTaskUsecase_BulkUpdateTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateTask provides a mock function for the type MockTaskUsecase
 func (_mock *MockTaskUsecase) CreateTask(ctx context.Context, task domain.Task) (domain.Task, error) {
 	ret := _mock.Called(ctx, task)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateTask")
+		panic("no return value specified for CreateTask")
+	}
+
+	var r0 domain.Task
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Task) (domain.Task, error)); ok {
+		return returnFunc(ctx, task)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Task) domain.Task); ok {
+		r0 = returnFunc(ctx, task)
+	} else {
+		r0 = ret.Get(0).(domain.Task)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.Task) error); ok {
+		r1 = returnFunc(ctx, task)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTaskUsecase_CreateTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTask'
+type MockTaskUsecase_CreateTask_Call struct {
+	*mock.Call
+}
+
+// CreateTask is a helper method to define mock.On call
+//   - ctx context.Context
+//   - task domain.Task
+func (_e *MockTaskUsecase_Expecter) CreateTask(ctx interface{}, task interface{}) *MockTaskUsecase_CreateTask_Call {
+	return &MockTaskUsecase_CreateTask_Call{Call: _e.mock.On("CreateTask", ctx, task)}
+}
+
+func (_c *MockTaskUsecase_CreateTask_Call) Run(run func(ctx context.Context, task domain.Task)) *MockTaskUsecase_CreateTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.Task
+		if args[1] != nil {
+			arg1 = args[1].(domain.Task)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskUsecase_CreateTask_Call) Return(task1 domain.Task, err error) *MockTaskUsecase_CreateTask_Call {
+	_c.Call.Return(task1, err)
+	return _c
+}
+
+func (_c *MockTaskUsecase_CreateTask_Call) RunAndReturn(run func(ctx context.Context, task domain.Task) (domain.Task, error)) *MockTaskUsecase_CreateTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExportTasks provides a mock function for the type MockTaskUsecase
+func (_mock *MockTaskUsecase) ExportTasks(ctx context.Context, filter domain.TaskFilter, fn func(task domain.Task) error) error {
+	ret := _mock.Called(ctx, filter, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportTasks")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.TaskFilter, func(task domain.Task) error) error); ok {
+		r0 = returnFunc(ctx, filter, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTaskUsecase_ExportTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportTasks'
+type MockTaskUsecase_ExportTasks_Call struct {
+	*mock.Call
+}
+
+// ExportTasks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.TaskFilter
+//   - fn func(task domain.Task) error
+func (_e *MockTaskUsecase_Expecter) ExportTasks(ctx interface{}, filter interface{}, fn interface{}) *MockTaskUsecase_ExportTasks_Call {
+	return &MockTaskUsecase_ExportTasks_Call{Call: _e.mock.On("ExportTasks", ctx, filter, fn)}
+}
+
+func (_c *MockTaskUsecase_ExportTasks_Call) Run(run func(ctx context.Context, filter domain.TaskFilter, fn func(task domain.Task) error)) *MockTaskUsecase_ExportTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.TaskFilter
+		if args[1] != nil {
+			arg1 = args[1].(domain.TaskFilter)
+		}
+		var arg2 func(task domain.Task) error
+		if args[2] != nil {
+			arg2 = args[2].(func(task domain.Task) error)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskUsecase_ExportTasks_Call) Return(err error) *MockTaskUsecase_ExportTasks_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTaskUsecase_ExportTasks_Call) RunAndReturn(run func(ctx context.Context, filter domain.TaskFilter, fn func(task domain.Task) error) error) *MockTaskUsecase_ExportTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ImportTasks provides a mock function for the type MockTaskUsecase
+func (_mock *MockTaskUsecase) ImportTasks(ctx context.Context, actor domain.Actor, rows []domain.TaskImportRow, options domain.TaskImportOptions) (domain.TaskImportResult, error) {
+	ret := _mock.Called(ctx, actor, rows, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportTasks")
+	}
+
+	var r0 domain.TaskImportResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Actor, []domain.TaskImportRow, domain.TaskImportOptions) (domain.TaskImportResult, error)); ok {
+		return returnFunc(ctx, actor, rows, options)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Actor, []domain.TaskImportRow, domain.TaskImportOptions) domain.TaskImportResult); ok {
+		r0 = returnFunc(ctx, actor, rows, options)
+	} else {
+		r0 = ret.Get(0).(domain.TaskImportResult)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.Actor, []domain.TaskImportRow, domain.TaskImportOptions) error); ok {
+		r1 = returnFunc(ctx, actor, rows, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTaskUsecase_ImportTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportTasks'
+type MockTaskUsecase_ImportTasks_Call struct {
+	*mock.Call
+}
+
+// ImportTasks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actor domain.Actor
+//   - rows []domain.TaskImportRow
+//   - options domain.TaskImportOptions
+func (_e *MockTaskUsecase_Expecter) ImportTasks(ctx interface{}, actor interface{}, rows interface{}, options interface{}) *MockTaskUsecase_ImportTasks_Call {
+	return &MockTaskUsecase_ImportTasks_Call{Call: _e.mock.On("ImportTasks", ctx, actor, rows, options)}
+}
+
+func (_c *MockTaskUsecase_ImportTasks_Call) Run(run func(ctx context.Context, actor domain.Actor, rows []domain.TaskImportRow, options domain.TaskImportOptions)) *MockTaskUsecase_ImportTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.Actor
+		if args[1] != nil {
+			arg1 = args[1].(domain.Actor)
+		}
+		var arg2 []domain.TaskImportRow
+		if args[2] != nil {
+			arg2 = args[2].([]domain.TaskImportRow)
+		}
+		var arg3 domain.TaskImportOptions
+		if args[3] != nil {
+			arg3 = args[3].(domain.TaskImportOptions)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskUsecase_ImportTasks_Call) Return(taskImportResult domain.TaskImportResult, err error) *MockTaskUsecase_ImportTasks_Call {
+	_c.Call.Return(taskImportResult, err)
+	return _c
+}
+
+func (_c *MockTaskUsecase_ImportTasks_Call) RunAndReturn(run func(ctx context.Context, actor domain.Actor, rows []domain.TaskImportRow, options domain.TaskImportOptions) (domain.TaskImportResult, error)) *MockTaskUsecase_ImportTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDeletedTasks provides a mock function for the type MockTaskUsecase
+func (_mock *MockTaskUsecase) ListDeletedTasks(ctx context.Context, limit int64, offset int64) (domain.TaskPage, error) {
+	ret := _mock.Called(ctx, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeletedTasks")
+	}
+
+	var r0 domain.TaskPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64) (domain.TaskPage, error)); ok {
+		return returnFunc(ctx, limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64) domain.TaskPage); ok {
+		r0 = returnFunc(ctx, limit, offset)
+	} else {
+		r0 = ret.Get(0).(domain.TaskPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = returnFunc(ctx, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTaskUsecase_ListDeletedTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDeletedTasks'
+type MockTaskUsecase_ListDeletedTasks_Call struct {
+	*mock.Call
+}
+
+// ListDeletedTasks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int64
+//   - offset int64
+func (_e *MockTaskUsecase_Expecter) ListDeletedTasks(ctx interface{}, limit interface{}, offset interface{}) *MockTaskUsecase_ListDeletedTasks_Call {
+	return &MockTaskUsecase_ListDeletedTasks_Call{Call: _e.mock.On("ListDeletedTasks", ctx, limit, offset)}
+}
+
+func (_c *MockTaskUsecase_ListDeletedTasks_Call) Run(run func(ctx context.Context, limit int64, offset int64)) *MockTaskUsecase_ListDeletedTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskUsecase_ListDeletedTasks_Call) Return(taskPage domain.TaskPage, err error) *MockTaskUsecase_ListDeletedTasks_Call {
+	_c.Call.Return(taskPage, err)
+	return _c
+}
+
+func (_c *MockTaskUsecase_ListDeletedTasks_Call) RunAndReturn(run func(ctx context.Context, limit int64, offset int64) (domain.TaskPage, error)) *MockTaskUsecase_ListDeletedTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MigrateTaskStatuses provides a mock function for the type MockTaskUsecase
+func (_mock *MockTaskUsecase) MigrateTaskStatuses(ctx context.Context) (int64, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MigrateTaskStatuses")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTaskUsecase_MigrateTaskStatuses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MigrateTaskStatuses'
+type MockTaskUsecase_MigrateTaskStatuses_Call struct {
+	*mock.Call
+}
+
+// MigrateTaskStatuses is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockTaskUsecase_Expecter) MigrateTaskStatuses(ctx interface{}) *MockTaskUsecase_MigrateTaskStatuses_Call {
+	return &MockTaskUsecase_MigrateTaskStatuses_Call{Call: _e.mock.On("MigrateTaskStatuses", ctx)}
+}
+
+func (_c *MockTaskUsecase_MigrateTaskStatuses_Call) Run(run func(ctx context.Context)) *MockTaskUsecase_MigrateTaskStatuses_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskUsecase_MigrateTaskStatuses_Call) Return(n int64, err error) *MockTaskUsecase_MigrateTaskStatuses_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockTaskUsecase_MigrateTaskStatuses_Call) RunAndReturn(run func(ctx context.Context) (int64, error)) *MockTaskUsecase_MigrateTaskStatuses_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ModifyTask provides a mock function for the type MockTaskUsecase
+func (_mock *MockTaskUsecase) ModifyTask(ctx context.Context, actor domain.Actor, id string, updatedTask domain.Task) (domain.Task, error) {
+	ret := _mock.Called(ctx, actor, id, updatedTask)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ModifyTask")
 	}
 
 	var r0 domain.Task
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Task) (domain.Task, error)); ok {
-		return returnFunc(ctx, task)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Actor, string, domain.Task) (domain.Task, error)); ok {
+		return returnFunc(ctx, actor, id, updatedTask)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Task) domain.Task); ok {
-		r0 = returnFunc(ctx, task)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Actor, string, domain.Task) domain.Task); ok {
+		r0 = returnFunc(ctx, actor, id, updatedTask)
 	} else {
 		r0 = ret.Get(0).(domain.Task)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.Task) error); ok {
-		r1 = returnFunc(ctx, task)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.Actor, string, domain.Task) error); ok {
+		r1 = returnFunc(ctx, actor, id, updatedTask)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockTaskUsecase_CreateTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTask'
-type MockTaskUsecase_CreateTask_Call struct {
+// MockTaskUsecase_ModifyTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ModifyTask'
+type MockTaskUsecase_ModifyTask_Call struct {
 	*mock.Call
 }
 
-// CreateTask is a helper method to define mock.On call
+// ModifyTask is a helper method to define mock.On call
 //   - ctx context.Context
-//   - task domain.Task
-func (_e *MockTaskUsecase_Expecter) CreateTask(ctx interface{}, task interface{}) *MockTaskUsecase_CreateTask_Call {
-	return &MockTaskUsecase_CreateTask_Call{Call: _e.mock.On("CreateTask", ctx, task)}
+//   - actor domain.Actor
+//   - id string
+//   - updatedTask domain.Task
+func (_e *MockTaskUsecase_Expecter) ModifyTask(ctx interface{}, actor interface{}, id interface{}, updatedTask interface{}) *MockTaskUsecase_ModifyTask_Call {
+	return &MockTaskUsecase_ModifyTask_Call{Call: _e.mock.On("ModifyTask", ctx, actor, id, updatedTask)}
+}
+
+func (_c *MockTaskUsecase_ModifyTask_Call) Run(run func(ctx context.Context, actor domain.Actor, id string, updatedTask domain.Task)) *MockTaskUsecase_ModifyTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.Actor
+		if args[1] != nil {
+			arg1 = args[1].(domain.Actor)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 domain.Task
+		if args[3] != nil {
+			arg3 = args[3].(domain.Task)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskUsecase_ModifyTask_Call) Return(task domain.Task, err error) *MockTaskUsecase_ModifyTask_Call {
+	_c.Call.Return(task, err)
+	return _c
+}
+
+func (_c *MockTaskUsecase_ModifyTask_Call) RunAndReturn(run func(ctx context.Context, actor domain.Actor, id string, updatedTask domain.Task) (domain.Task, error)) *MockTaskUsecase_ModifyTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PreviewTaskOccurrences provides a mock function for the type MockTaskUsecase
+func (_mock *MockTaskUsecase) PreviewTaskOccurrences(ctx context.Context, id string, count int) ([]time.Time, error) {
+	ret := _mock.Called(ctx, id, count)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PreviewTaskOccurrences")
+	}
+
+	var r0 []time.Time
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) ([]time.Time, error)); ok {
+		return returnFunc(ctx, id, count)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) []time.Time); ok {
+		r0 = returnFunc(ctx, id, count)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]time.Time)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = returnFunc(ctx, id, count)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTaskUsecase_PreviewTaskOccurrences_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PreviewTaskOccurrences'
+type MockTaskUsecase_PreviewTaskOccurrences_Call struct {
+	*mock.Call
+}
+
+// PreviewTaskOccurrences is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - count int
+func (_e *MockTaskUsecase_Expecter) PreviewTaskOccurrences(ctx interface{}, id interface{}, count interface{}) *MockTaskUsecase_PreviewTaskOccurrences_Call {
+	return &MockTaskUsecase_PreviewTaskOccurrences_Call{Call: _e.mock.On("PreviewTaskOccurrences", ctx, id, count)}
+}
+
+func (_c *MockTaskUsecase_PreviewTaskOccurrences_Call) Run(run func(ctx context.Context, id string, count int)) *MockTaskUsecase_PreviewTaskOccurrences_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskUsecase_PreviewTaskOccurrences_Call) Return(times []time.Time, err error) *MockTaskUsecase_PreviewTaskOccurrences_Call {
+	_c.Call.Return(times, err)
+	return _c
+}
+
+func (_c *MockTaskUsecase_PreviewTaskOccurrences_Call) RunAndReturn(run func(ctx context.Context, id string, count int) ([]time.Time, error)) *MockTaskUsecase_PreviewTaskOccurrences_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeExpiredTasks provides a mock function for the type MockTaskUsecase
+func (_mock *MockTaskUsecase) PurgeExpiredTasks(ctx context.Context, retention time.Duration) (int64, error) {
+	ret := _mock.Called(ctx, retention)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeExpiredTasks")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Duration) (int64, error)); ok {
+		return returnFunc(ctx, retention)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Duration) int64); ok {
+		r0 = returnFunc(ctx, retention)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Duration) error); ok {
+		r1 = returnFunc(ctx, retention)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTaskUsecase_PurgeExpiredTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeExpiredTasks'
+type MockTaskUsecase_PurgeExpiredTasks_Call struct {
+	*mock.Call
+}
+
+// PurgeExpiredTasks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - retention time.Duration
+func (_e *MockTaskUsecase_Expecter) PurgeExpiredTasks(ctx interface{}, retention interface{}) *MockTaskUsecase_PurgeExpiredTasks_Call {
+	return &MockTaskUsecase_PurgeExpiredTasks_Call{Call: _e.mock.On("PurgeExpiredTasks", ctx, retention)}
+}
+
+func (_c *MockTaskUsecase_PurgeExpiredTasks_Call) Run(run func(ctx context.Context, retention time.Duration)) *MockTaskUsecase_PurgeExpiredTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Duration
+		if args[1] != nil {
+			arg1 = args[1].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskUsecase_PurgeExpiredTasks_Call) Return(n int64, err error) *MockTaskUsecase_PurgeExpiredTasks_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockTaskUsecase_PurgeExpiredTasks_Call) RunAndReturn(run func(ctx context.Context, retention time.Duration) (int64, error)) *MockTaskUsecase_PurgeExpiredTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeTask provides a mock function for the type MockTaskUsecase
+func (_mock *MockTaskUsecase) PurgeTask(ctx context.Context, actor domain.Actor, id string) error {
+	ret := _mock.Called(ctx, actor, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeTask")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Actor, string) error); ok {
+		r0 = returnFunc(ctx, actor, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTaskUsecase_PurgeTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeTask'
+type MockTaskUsecase_PurgeTask_Call struct {
+	*mock.Call
+}
+
+// PurgeTask is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actor domain.Actor
+//   - id string
+func (_e *MockTaskUsecase_Expecter) PurgeTask(ctx interface{}, actor interface{}, id interface{}) *MockTaskUsecase_PurgeTask_Call {
+	return &MockTaskUsecase_PurgeTask_Call{Call: _e.mock.On("PurgeTask", ctx, actor, id)}
+}
+
+func (_c *MockTaskUsecase_PurgeTask_Call) Run(run func(ctx context.Context, actor domain.Actor, id string)) *MockTaskUsecase_PurgeTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.Actor
+		if args[1] != nil {
+			arg1 = args[1].(domain.Actor)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskUsecase_PurgeTask_Call) Return(err error) *MockTaskUsecase_PurgeTask_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTaskUsecase_PurgeTask_Call) RunAndReturn(run func(ctx context.Context, actor domain.Actor, id string) error) *MockTaskUsecase_PurgeTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveTask provides a mock function for the type MockTaskUsecase
+func (_mock *MockTaskUsecase) RemoveTask(ctx context.Context, actor domain.Actor, id string, expectedVersion int64) error {
+	ret := _mock.Called(ctx, actor, id, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveTask")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Actor, string, int64) error); ok {
+		r0 = returnFunc(ctx, actor, id, expectedVersion)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTaskUsecase_RemoveTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveTask'
+type MockTaskUsecase_RemoveTask_Call struct {
+	*mock.Call
+}
+
+// RemoveTask is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actor domain.Actor
+//   - id string
+//   - expectedVersion int64
+func (_e *MockTaskUsecase_Expecter) RemoveTask(ctx interface{}, actor interface{}, id interface{}, expectedVersion interface{}) *MockTaskUsecase_RemoveTask_Call {
+	return &MockTaskUsecase_RemoveTask_Call{Call: _e.mock.On("RemoveTask", ctx, actor, id, expectedVersion)}
+}
+
+func (_c *MockTaskUsecase_RemoveTask_Call) Run(run func(ctx context.Context, actor domain.Actor, id string, expectedVersion int64)) *MockTaskUsecase_RemoveTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.Actor
+		if args[1] != nil {
+			arg1 = args[1].(domain.Actor)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 int64
+		if args[3] != nil {
+			arg3 = args[3].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskUsecase_RemoveTask_Call) Return(err error) *MockTaskUsecase_RemoveTask_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTaskUsecase_RemoveTask_Call) RunAndReturn(run func(ctx context.Context, actor domain.Actor, id string, expectedVersion int64) error) *MockTaskUsecase_RemoveTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveTaskBlocker provides a mock function for the type MockTaskUsecase
+func (_mock *MockTaskUsecase) RemoveTaskBlocker(ctx context.Context, actor domain.Actor, id string, blockerId string) (domain.Task, error) {
+	ret := _mock.Called(ctx, actor, id, blockerId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveTaskBlocker")
+	}
+
+	var r0 domain.Task
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Actor, string, string) (domain.Task, error)); ok {
+		return returnFunc(ctx, actor, id, blockerId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Actor, string, string) domain.Task); ok {
+		r0 = returnFunc(ctx, actor, id, blockerId)
+	} else {
+		r0 = ret.Get(0).(domain.Task)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.Actor, string, string) error); ok {
+		r1 = returnFunc(ctx, actor, id, blockerId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTaskUsecase_RemoveTaskBlocker_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveTaskBlocker'
+type MockTaskUsecase_RemoveTaskBlocker_Call struct {
+	*mock.Call
+}
+
+// RemoveTaskBlocker is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actor domain.Actor
+//   - id string
+//   - blockerId string
+func (_e *MockTaskUsecase_Expecter) RemoveTaskBlocker(ctx interface{}, actor interface{}, id interface{}, blockerId interface{}) *MockTaskUsecase_RemoveTaskBlocker_Call {
+	return &MockTaskUsecase_RemoveTaskBlocker_Call{Call: _e.mock.On("RemoveTaskBlocker", ctx, actor, id, blockerId)}
 }
 
-func (_c *MockTaskUsecase_CreateTask_Call) Run(run func(ctx context.Context, task domain.Task)) *MockTaskUsecase_CreateTask_Call {
+func (_c *MockTaskUsecase_RemoveTaskBlocker_Call) Run(run func(ctx context.Context, actor domain.Actor, id string, blockerId string)) *MockTaskUsecase_RemoveTaskBlocker_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 domain.Task
+		var arg1 domain.Actor
 		if args[1] != nil {
-			arg1 = args[1].(domain.Task)
+			arg1 = args[1].(domain.Actor)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockTaskUsecase_CreateTask_Call) Return(task1 domain.Task, err error) *MockTaskUsecase_CreateTask_Call {
-	_c.Call.Return(task1, err)
+func (_c *MockTaskUsecase_RemoveTaskBlocker_Call) Return(task domain.Task, err error) *MockTaskUsecase_RemoveTaskBlocker_Call {
+	_c.Call.Return(task, err)
 	return _c
 }
 
-func (_c *MockTaskUsecase_CreateTask_Call) RunAndReturn(run func(ctx context.Context, task domain.Task) (domain.Task, error)) *MockTaskUsecase_CreateTask_Call {
+func (_c *MockTaskUsecase_RemoveTaskBlocker_Call) RunAndReturn(run func(ctx context.Context, actor domain.Actor, id string, blockerId string) (domain.Task, error)) *MockTaskUsecase_RemoveTaskBlocker_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ModifyTask provides a mock function for the type MockTaskUsecase
-func (_mock *MockTaskUsecase) ModifyTask(ctx context.Context, id string, updatedTask domain.Task) (domain.Task, error) {
-	ret := _mock.Called(ctx, id, updatedTask)
+// RemoveTaskLabel provides a mock function for the type MockTaskUsecase
+func (_mock *MockTaskUsecase) RemoveTaskLabel(ctx context.Context, actor domain.Actor, id string, label string) (domain.Task, error) {
+	ret := _mock.Called(ctx, actor, id, label)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ModifyTask")
+		panic("no return value specified for RemoveTaskLabel")
 	}
 
 	var r0 domain.Task
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.Task) (domain.Task, error)); ok {
-		return returnFunc(ctx, id, updatedTask)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Actor, string, string) (domain.Task, error)); ok {
+		return returnFunc(ctx, actor, id, label)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.Task) domain.Task); ok {
-		r0 = returnFunc(ctx, id, updatedTask)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Actor, string, string) domain.Task); ok {
+		r0 = returnFunc(ctx, actor, id, label)
 	} else {
 		r0 = ret.Get(0).(domain.Task)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, domain.Task) error); ok {
-		r1 = returnFunc(ctx, id, updatedTask)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.Actor, string, string) error); ok {
+		r1 = returnFunc(ctx, actor, id, label)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockTaskUsecase_ModifyTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ModifyTask'
-type MockTaskUsecase_ModifyTask_Call struct {
+// MockTaskUsecase_RemoveTaskLabel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveTaskLabel'
+type MockTaskUsecase_RemoveTaskLabel_Call struct {
 	*mock.Call
 }
 
-// ModifyTask is a helper method to define mock.On call
+// RemoveTaskLabel is a helper method to define mock.On call
 //   - ctx context.Context
+//   - actor domain.Actor
 //   - id string
-//   - updatedTask domain.Task
-func (_e *MockTaskUsecase_Expecter) ModifyTask(ctx interface{}, id interface{}, updatedTask interface{}) *MockTaskUsecase_ModifyTask_Call {
-	return &MockTaskUsecase_ModifyTask_Call{Call: _e.mock.On("ModifyTask", ctx, id, updatedTask)}
+//   - label string
+func (_e *MockTaskUsecase_Expecter) RemoveTaskLabel(ctx interface{}, actor interface{}, id interface{}, label interface{}) *MockTaskUsecase_RemoveTaskLabel_Call {
+	return &MockTaskUsecase_RemoveTaskLabel_Call{Call: _e.mock.On("RemoveTaskLabel", ctx, actor, id, label)}
 }
 
-func (_c *MockTaskUsecase_ModifyTask_Call) Run(run func(ctx context.Context, id string, updatedTask domain.Task)) *MockTaskUsecase_ModifyTask_Call {
+func (_c *MockTaskUsecase_RemoveTaskLabel_Call) Run(run func(ctx context.Context, actor domain.Actor, id string, label string)) *MockTaskUsecase_RemoveTaskLabel_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 domain.Actor
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(domain.Actor)
 		}
-		var arg2 domain.Task
+		var arg2 string
 		if args[2] != nil {
-			arg2 = args[2].(domain.Task)
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockTaskUsecase_ModifyTask_Call) Return(task domain.Task, err error) *MockTaskUsecase_ModifyTask_Call {
+func (_c *MockTaskUsecase_RemoveTaskLabel_Call) Return(task domain.Task, err error) *MockTaskUsecase_RemoveTaskLabel_Call {
 	_c.Call.Return(task, err)
 	return _c
 }
 
-func (_c *MockTaskUsecase_ModifyTask_Call) RunAndReturn(run func(ctx context.Context, id string, updatedTask domain.Task) (domain.Task, error)) *MockTaskUsecase_ModifyTask_Call {
+func (_c *MockTaskUsecase_RemoveTaskLabel_Call) RunAndReturn(run func(ctx context.Context, actor domain.Actor, id string, label string) (domain.Task, error)) *MockTaskUsecase_RemoveTaskLabel_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RemoveTask provides a mock function for the type MockTaskUsecase
-func (_mock *MockTaskUsecase) RemoveTask(ctx context.Context, id string) error {
-	ret := _mock.Called(ctx, id)
+// RestoreTask provides a mock function for the type MockTaskUsecase
+func (_mock *MockTaskUsecase) RestoreTask(ctx context.Context, actor domain.Actor, id string) (domain.Task, error) {
+	ret := _mock.Called(ctx, actor, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RemoveTask")
+		panic("no return value specified for RestoreTask")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = returnFunc(ctx, id)
+	var r0 domain.Task
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Actor, string) (domain.Task, error)); ok {
+		return returnFunc(ctx, actor, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Actor, string) domain.Task); ok {
+		r0 = returnFunc(ctx, actor, id)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(domain.Task)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.Actor, string) error); ok {
+		r1 = returnFunc(ctx, actor, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockTaskUsecase_RemoveTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveTask'
-type MockTaskUsecase_RemoveTask_Call struct {
+// MockTaskUsecase_RestoreTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreTask'
+type MockTaskUsecase_RestoreTask_Call struct {
 	*mock.Call
 }
 
-// RemoveTask is a helper method to define mock.On call
+// RestoreTask is a helper method to define mock.On call
 //   - ctx context.Context
+//   - actor domain.Actor
 //   - id string
-func (_e *MockTaskUsecase_Expecter) RemoveTask(ctx interface{}, id interface{}) *MockTaskUsecase_RemoveTask_Call {
-	return &MockTaskUsecase_RemoveTask_Call{Call: _e.mock.On("RemoveTask", ctx, id)}
+func (_e *MockTaskUsecase_Expecter) RestoreTask(ctx interface{}, actor interface{}, id interface{}) *MockTaskUsecase_RestoreTask_Call {
+	return &MockTaskUsecase_RestoreTask_Call{Call: _e.mock.On("RestoreTask", ctx, actor, id)}
 }
 
-func (_c *MockTaskUsecase_RemoveTask_Call) Run(run func(ctx context.Context, id string)) *MockTaskUsecase_RemoveTask_Call {
+func (_c *MockTaskUsecase_RestoreTask_Call) Run(run func(ctx context.Context, actor domain.Actor, id string)) *MockTaskUsecase_RestoreTask_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 domain.Actor
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(domain.Actor)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockTaskUsecase_RemoveTask_Call) Return(err error) *MockTaskUsecase_RemoveTask_Call {
-	_c.Call.Return(err)
+func (_c *MockTaskUsecase_RestoreTask_Call) Return(task domain.Task, err error) *MockTaskUsecase_RestoreTask_Call {
+	_c.Call.Return(task, err)
 	return _c
 }
 
-func (_c *MockTaskUsecase_RemoveTask_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockTaskUsecase_RemoveTask_Call {
+func (_c *MockTaskUsecase_RestoreTask_Call) RunAndReturn(run func(ctx context.Context, actor domain.Actor, id string) (domain.Task, error)) *MockTaskUsecase_RestoreTask_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // RetrieveAllTasks provides a mock function for the type MockTaskUsecase
-func (_mock *MockTaskUsecase) RetrieveAllTasks(ctx context.Context) ([]domain.Task, error) {
-	ret := _mock.Called(ctx)
+func (_mock *MockTaskUsecase) RetrieveAllTasks(ctx context.Context, filter domain.TaskFilter) (domain.TaskPage, error) {
+	ret := _mock.Called(ctx, filter)
 
 	if len(ret) == 0 {
 		panic("no return value specified for RetrieveAllTasks")
 	}
 
-	var r0 []domain.Task
+	var r0 domain.TaskPage
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]domain.Task, error)); ok {
-		return returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.TaskFilter) (domain.TaskPage, error)); ok {
+		return returnFunc(ctx, filter)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) []domain.Task); ok {
-		r0 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.TaskFilter) domain.TaskPage); ok {
+		r0 = returnFunc(ctx, filter)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.Task)
-		}
+		r0 = ret.Get(0).(domain.TaskPage)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.TaskFilter) error); ok {
+		r1 = returnFunc(ctx, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -268,29 +1451,35 @@ type MockTaskUsecase_RetrieveAllTasks_Call struct {
 
 // RetrieveAllTasks is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockTaskUsecase_Expecter) RetrieveAllTasks(ctx interface{}) *MockTaskUsecase_RetrieveAllTasks_Call {
-	return &MockTaskUsecase_RetrieveAllTasks_Call{Call: _e.mock.On("RetrieveAllTasks", ctx)}
+//   - filter domain.TaskFilter
+func (_e *MockTaskUsecase_Expecter) RetrieveAllTasks(ctx interface{}, filter interface{}) *MockTaskUsecase_RetrieveAllTasks_Call {
+	return &MockTaskUsecase_RetrieveAllTasks_Call{Call: _e.mock.On("RetrieveAllTasks", ctx, filter)}
 }
 
-func (_c *MockTaskUsecase_RetrieveAllTasks_Call) Run(run func(ctx context.Context)) *MockTaskUsecase_RetrieveAllTasks_Call {
+func (_c *MockTaskUsecase_RetrieveAllTasks_Call) Run(run func(ctx context.Context, filter domain.TaskFilter)) *MockTaskUsecase_RetrieveAllTasks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 domain.TaskFilter
+		if args[1] != nil {
+			arg1 = args[1].(domain.TaskFilter)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockTaskUsecase_RetrieveAllTasks_Call) Return(tasks []domain.Task, err error) *MockTaskUsecase_RetrieveAllTasks_Call {
-	_c.Call.Return(tasks, err)
+func (_c *MockTaskUsecase_RetrieveAllTasks_Call) Return(taskPage domain.TaskPage, err error) *MockTaskUsecase_RetrieveAllTasks_Call {
+	_c.Call.Return(taskPage, err)
 	return _c
 }
 
-func (_c *MockTaskUsecase_RetrieveAllTasks_Call) RunAndReturn(run func(ctx context.Context) ([]domain.Task, error)) *MockTaskUsecase_RetrieveAllTasks_Call {
+func (_c *MockTaskUsecase_RetrieveAllTasks_Call) RunAndReturn(run func(ctx context.Context, filter domain.TaskFilter) (domain.TaskPage, error)) *MockTaskUsecase_RetrieveAllTasks_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -360,3 +1549,297 @@ func (_c *MockTaskUsecase_RetrieveTaskByID_Call) RunAndReturn(run func(ctx conte
 	_c.Call.Return(run)
 	return _c
 }
+
+// RetrieveTaskTree provides a mock function for the type MockTaskUsecase
+func (_mock *MockTaskUsecase) RetrieveTaskTree(ctx context.Context, id string) (domain.TaskNode, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveTaskTree")
+	}
+
+	var r0 domain.TaskNode
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (domain.TaskNode, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) domain.TaskNode); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.TaskNode)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTaskUsecase_RetrieveTaskTree_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetrieveTaskTree'
+type MockTaskUsecase_RetrieveTaskTree_Call struct {
+	*mock.Call
+}
+
+// RetrieveTaskTree is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockTaskUsecase_Expecter) RetrieveTaskTree(ctx interface{}, id interface{}) *MockTaskUsecase_RetrieveTaskTree_Call {
+	return &MockTaskUsecase_RetrieveTaskTree_Call{Call: _e.mock.On("RetrieveTaskTree", ctx, id)}
+}
+
+func (_c *MockTaskUsecase_RetrieveTaskTree_Call) Run(run func(ctx context.Context, id string)) *MockTaskUsecase_RetrieveTaskTree_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskUsecase_RetrieveTaskTree_Call) Return(taskNode domain.TaskNode, err error) *MockTaskUsecase_RetrieveTaskTree_Call {
+	_c.Call.Return(taskNode, err)
+	return _c
+}
+
+func (_c *MockTaskUsecase_RetrieveTaskTree_Call) RunAndReturn(run func(ctx context.Context, id string) (domain.TaskNode, error)) *MockTaskUsecase_RetrieveTaskTree_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchTasks provides a mock function for the type MockTaskUsecase
+func (_mock *MockTaskUsecase) SearchTasks(ctx context.Context, rawQuery string, limit int64, offset int64) (domain.TaskSearchResult, error) {
+	ret := _mock.Called(ctx, rawQuery, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchTasks")
+	}
+
+	var r0 domain.TaskSearchResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64, int64) (domain.TaskSearchResult, error)); ok {
+		return returnFunc(ctx, rawQuery, limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64, int64) domain.TaskSearchResult); ok {
+		r0 = returnFunc(ctx, rawQuery, limit, offset)
+	} else {
+		r0 = ret.Get(0).(domain.TaskSearchResult)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int64, int64) error); ok {
+		r1 = returnFunc(ctx, rawQuery, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTaskUsecase_SearchTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchTasks'
+type MockTaskUsecase_SearchTasks_Call struct {
+	*mock.Call
+}
+
+// SearchTasks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - rawQuery string
+//   - limit int64
+//   - offset int64
+func (_e *MockTaskUsecase_Expecter) SearchTasks(ctx interface{}, rawQuery interface{}, limit interface{}, offset interface{}) *MockTaskUsecase_SearchTasks_Call {
+	return &MockTaskUsecase_SearchTasks_Call{Call: _e.mock.On("SearchTasks", ctx, rawQuery, limit, offset)}
+}
+
+func (_c *MockTaskUsecase_SearchTasks_Call) Run(run func(ctx context.Context, rawQuery string, limit int64, offset int64)) *MockTaskUsecase_SearchTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 int64
+		if args[3] != nil {
+			arg3 = args[3].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskUsecase_SearchTasks_Call) Return(taskSearchResult domain.TaskSearchResult, err error) *MockTaskUsecase_SearchTasks_Call {
+	_c.Call.Return(taskSearchResult, err)
+	return _c
+}
+
+func (_c *MockTaskUsecase_SearchTasks_Call) RunAndReturn(run func(ctx context.Context, rawQuery string, limit int64, offset int64) (domain.TaskSearchResult, error)) *MockTaskUsecase_SearchTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetTaskParent provides a mock function for the type MockTaskUsecase
+func (_mock *MockTaskUsecase) SetTaskParent(ctx context.Context, actor domain.Actor, id string, parentId string) (domain.Task, error) {
+	ret := _mock.Called(ctx, actor, id, parentId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTaskParent")
+	}
+
+	var r0 domain.Task
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Actor, string, string) (domain.Task, error)); ok {
+		return returnFunc(ctx, actor, id, parentId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Actor, string, string) domain.Task); ok {
+		r0 = returnFunc(ctx, actor, id, parentId)
+	} else {
+		r0 = ret.Get(0).(domain.Task)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.Actor, string, string) error); ok {
+		r1 = returnFunc(ctx, actor, id, parentId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTaskUsecase_SetTaskParent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTaskParent'
+type MockTaskUsecase_SetTaskParent_Call struct {
+	*mock.Call
+}
+
+// SetTaskParent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actor domain.Actor
+//   - id string
+//   - parentId string
+func (_e *MockTaskUsecase_Expecter) SetTaskParent(ctx interface{}, actor interface{}, id interface{}, parentId interface{}) *MockTaskUsecase_SetTaskParent_Call {
+	return &MockTaskUsecase_SetTaskParent_Call{Call: _e.mock.On("SetTaskParent", ctx, actor, id, parentId)}
+}
+
+func (_c *MockTaskUsecase_SetTaskParent_Call) Run(run func(ctx context.Context, actor domain.Actor, id string, parentId string)) *MockTaskUsecase_SetTaskParent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.Actor
+		if args[1] != nil {
+			arg1 = args[1].(domain.Actor)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskUsecase_SetTaskParent_Call) Return(task domain.Task, err error) *MockTaskUsecase_SetTaskParent_Call {
+	_c.Call.Return(task, err)
+	return _c
+}
+
+func (_c *MockTaskUsecase_SetTaskParent_Call) RunAndReturn(run func(ctx context.Context, actor domain.Actor, id string, parentId string) (domain.Task, error)) *MockTaskUsecase_SetTaskParent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnassignTask provides a mock function for the type MockTaskUsecase
+func (_mock *MockTaskUsecase) UnassignTask(ctx context.Context, actor domain.Actor, id string) (domain.Task, error) {
+	ret := _mock.Called(ctx, actor, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnassignTask")
+	}
+
+	var r0 domain.Task
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Actor, string) (domain.Task, error)); ok {
+		return returnFunc(ctx, actor, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Actor, string) domain.Task); ok {
+		r0 = returnFunc(ctx, actor, id)
+	} else {
+		r0 = ret.Get(0).(domain.Task)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.Actor, string) error); ok {
+		r1 = returnFunc(ctx, actor, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTaskUsecase_UnassignTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnassignTask'
+type MockTaskUsecase_UnassignTask_Call struct {
+	*mock.Call
+}
+
+// UnassignTask is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actor domain.Actor
+//   - id string
+func (_e *MockTaskUsecase_Expecter) UnassignTask(ctx interface{}, actor interface{}, id interface{}) *MockTaskUsecase_UnassignTask_Call {
+	return &MockTaskUsecase_UnassignTask_Call{Call: _e.mock.On("UnassignTask", ctx, actor, id)}
+}
+
+func (_c *MockTaskUsecase_UnassignTask_Call) Run(run func(ctx context.Context, actor domain.Actor, id string)) *MockTaskUsecase_UnassignTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.Actor
+		if args[1] != nil {
+			arg1 = args[1].(domain.Actor)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskUsecase_UnassignTask_Call) Return(task domain.Task, err error) *MockTaskUsecase_UnassignTask_Call {
+	_c.Call.Return(task, err)
+	return _c
+}
+
+func (_c *MockTaskUsecase_UnassignTask_Call) RunAndReturn(run func(ctx context.Context, actor domain.Actor, id string) (domain.Task, error)) *MockTaskUsecase_UnassignTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -7,6 +7,7 @@ package mocks
 import (
 	"context"
 	domain "taskmanager/Domain"
+	"time"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -116,6 +117,72 @@ func (_c *MockUserRepository_DoesUserExist_Call) RunAndReturn(run func(ctx conte
 	return _c
 }
 
+// GetUserByID provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) GetUserByID(ctx context.Context, userId string) (domain.User, error) {
+	ret := _mock.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByID")
+	}
+
+	var r0 domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (domain.User, error)); ok {
+		return returnFunc(ctx, userId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) domain.User); ok {
+		r0 = returnFunc(ctx, userId)
+	} else {
+		r0 = ret.Get(0).(domain.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_GetUserByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByID'
+type MockUserRepository_GetUserByID_Call struct {
+	*mock.Call
+}
+
+// GetUserByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+func (_e *MockUserRepository_Expecter) GetUserByID(ctx interface{}, userId interface{}) *MockUserRepository_GetUserByID_Call {
+	return &MockUserRepository_GetUserByID_Call{Call: _e.mock.On("GetUserByID", ctx, userId)}
+}
+
+func (_c *MockUserRepository_GetUserByID_Call) Run(run func(ctx context.Context, userId string)) *MockUserRepository_GetUserByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_GetUserByID_Call) Return(user domain.User, err error) *MockUserRepository_GetUserByID_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockUserRepository_GetUserByID_Call) RunAndReturn(run func(ctx context.Context, userId string) (domain.User, error)) *MockUserRepository_GetUserByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // IsDatabaseEmpty provides a mock function for the type MockUserRepository
 func (_mock *MockUserRepository) IsDatabaseEmpty(ctx context.Context) (bool, error) {
 	ret := _mock.Called(ctx)
@@ -299,6 +366,75 @@ func (_c *MockUserRepository_PromoteUser_Call) RunAndReturn(run func(ctx context
 	return _c
 }
 
+// ReplacePasswordHash provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) ReplacePasswordHash(ctx context.Context, userId string, currentHash string, newHash string) error {
+	ret := _mock.Called(ctx, userId, currentHash, newHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplacePasswordHash")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = returnFunc(ctx, userId, currentHash, newHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_ReplacePasswordHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplacePasswordHash'
+type MockUserRepository_ReplacePasswordHash_Call struct {
+	*mock.Call
+}
+
+// ReplacePasswordHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+//   - currentHash string
+//   - newHash string
+func (_e *MockUserRepository_Expecter) ReplacePasswordHash(ctx interface{}, userId interface{}, currentHash interface{}, newHash interface{}) *MockUserRepository_ReplacePasswordHash_Call {
+	return &MockUserRepository_ReplacePasswordHash_Call{Call: _e.mock.On("ReplacePasswordHash", ctx, userId, currentHash, newHash)}
+}
+
+func (_c *MockUserRepository_ReplacePasswordHash_Call) Run(run func(ctx context.Context, userId string, currentHash string, newHash string)) *MockUserRepository_ReplacePasswordHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_ReplacePasswordHash_Call) Return(err error) *MockUserRepository_ReplacePasswordHash_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_ReplacePasswordHash_Call) RunAndReturn(run func(ctx context.Context, userId string, currentHash string, newHash string) error) *MockUserRepository_ReplacePasswordHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SaveUser provides a mock function for the type MockUserRepository
 func (_mock *MockUserRepository) SaveUser(ctx context.Context, user domain.User) (domain.User, error) {
 	ret := _mock.Called(ctx, user)
@@ -364,3 +500,87 @@ func (_c *MockUserRepository_SaveUser_Call) RunAndReturn(run func(ctx context.Co
 	_c.Call.Return(run)
 	return _c
 }
+
+// UpdatePassword provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) UpdatePassword(ctx context.Context, userId string, hashedPassword string, mustChange bool, changedAt time.Time) (domain.User, error) {
+	ret := _mock.Called(ctx, userId, hashedPassword, mustChange, changedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePassword")
+	}
+
+	var r0 domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, bool, time.Time) (domain.User, error)); ok {
+		return returnFunc(ctx, userId, hashedPassword, mustChange, changedAt)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, bool, time.Time) domain.User); ok {
+		r0 = returnFunc(ctx, userId, hashedPassword, mustChange, changedAt)
+	} else {
+		r0 = ret.Get(0).(domain.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, bool, time.Time) error); ok {
+		r1 = returnFunc(ctx, userId, hashedPassword, mustChange, changedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_UpdatePassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePassword'
+type MockUserRepository_UpdatePassword_Call struct {
+	*mock.Call
+}
+
+// UpdatePassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+//   - hashedPassword string
+//   - mustChange bool
+//   - changedAt time.Time
+func (_e *MockUserRepository_Expecter) UpdatePassword(ctx interface{}, userId interface{}, hashedPassword interface{}, mustChange interface{}, changedAt interface{}) *MockUserRepository_UpdatePassword_Call {
+	return &MockUserRepository_UpdatePassword_Call{Call: _e.mock.On("UpdatePassword", ctx, userId, hashedPassword, mustChange, changedAt)}
+}
+
+func (_c *MockUserRepository_UpdatePassword_Call) Run(run func(ctx context.Context, userId string, hashedPassword string, mustChange bool, changedAt time.Time)) *MockUserRepository_UpdatePassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 bool
+		if args[3] != nil {
+			arg3 = args[3].(bool)
+		}
+		var arg4 time.Time
+		if args[4] != nil {
+			arg4 = args[4].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_UpdatePassword_Call) Return(user domain.User, err error) *MockUserRepository_UpdatePassword_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockUserRepository_UpdatePassword_Call) RunAndReturn(run func(ctx context.Context, userId string, hashedPassword string, mustChange bool, changedAt time.Time) (domain.User, error)) *MockUserRepository_UpdatePassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -7,6 +7,7 @@ package mocks
 import (
 	"context"
 	domain "taskmanager/Domain"
+	"time"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -39,25 +40,25 @@ func (_m *MockUserUsecase) EXPECT() *MockUserUsecase_Expecter {
 }
 
 // AuthenticateUser provides a mock function for the type MockUserUsecase
-func (_mock *MockUserUsecase) AuthenticateUser(ctx context.Context, userName string, password string) (string, error) {
-	ret := _mock.Called(ctx, userName, password)
+func (_mock *MockUserUsecase) AuthenticateUser(ctx context.Context, userName string, password string, newPassword string, clientIP string) (domain.TokenPair, error) {
+	ret := _mock.Called(ctx, userName, password, newPassword, clientIP)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AuthenticateUser")
 	}
 
-	var r0 string
+	var r0 domain.TokenPair
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
-		return returnFunc(ctx, userName, password)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) (domain.TokenPair, error)); ok {
+		return returnFunc(ctx, userName, password, newPassword, clientIP)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
-		r0 = returnFunc(ctx, userName, password)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) domain.TokenPair); ok {
+		r0 = returnFunc(ctx, userName, password, newPassword, clientIP)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(domain.TokenPair)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = returnFunc(ctx, userName, password)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = returnFunc(ctx, userName, password, newPassword, clientIP)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -73,11 +74,13 @@ type MockUserUsecase_AuthenticateUser_Call struct {
 //   - ctx context.Context
 //   - userName string
 //   - password string
-func (_e *MockUserUsecase_Expecter) AuthenticateUser(ctx interface{}, userName interface{}, password interface{}) *MockUserUsecase_AuthenticateUser_Call {
-	return &MockUserUsecase_AuthenticateUser_Call{Call: _e.mock.On("AuthenticateUser", ctx, userName, password)}
+//   - newPassword string
+//   - clientIP string
+func (_e *MockUserUsecase_Expecter) AuthenticateUser(ctx interface{}, userName interface{}, password interface{}, newPassword interface{}, clientIP interface{}) *MockUserUsecase_AuthenticateUser_Call {
+	return &MockUserUsecase_AuthenticateUser_Call{Call: _e.mock.On("AuthenticateUser", ctx, userName, password, newPassword, clientIP)}
 }
 
-func (_c *MockUserUsecase_AuthenticateUser_Call) Run(run func(ctx context.Context, userName string, password string)) *MockUserUsecase_AuthenticateUser_Call {
+func (_c *MockUserUsecase_AuthenticateUser_Call) Run(run func(ctx context.Context, userName string, password string, newPassword string, clientIP string)) *MockUserUsecase_AuthenticateUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -91,28 +94,382 @@ func (_c *MockUserUsecase_AuthenticateUser_Call) Run(run func(ctx context.Contex
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserUsecase_AuthenticateUser_Call) Return(tokenPair domain.TokenPair, err error) *MockUserUsecase_AuthenticateUser_Call {
+	_c.Call.Return(tokenPair, err)
+	return _c
+}
+
+func (_c *MockUserUsecase_AuthenticateUser_Call) RunAndReturn(run func(ctx context.Context, userName string, password string, newPassword string, clientIP string) (domain.TokenPair, error)) *MockUserUsecase_AuthenticateUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ChangePassword provides a mock function for the type MockUserUsecase
+func (_mock *MockUserUsecase) ChangePassword(ctx context.Context, actor domain.Actor, currentPassword string, newPassword string) (domain.TokenPair, error) {
+	ret := _mock.Called(ctx, actor, currentPassword, newPassword)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangePassword")
+	}
+
+	var r0 domain.TokenPair
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Actor, string, string) (domain.TokenPair, error)); ok {
+		return returnFunc(ctx, actor, currentPassword, newPassword)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Actor, string, string) domain.TokenPair); ok {
+		r0 = returnFunc(ctx, actor, currentPassword, newPassword)
+	} else {
+		r0 = ret.Get(0).(domain.TokenPair)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.Actor, string, string) error); ok {
+		r1 = returnFunc(ctx, actor, currentPassword, newPassword)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserUsecase_ChangePassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangePassword'
+type MockUserUsecase_ChangePassword_Call struct {
+	*mock.Call
+}
+
+// ChangePassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actor domain.Actor
+//   - currentPassword string
+//   - newPassword string
+func (_e *MockUserUsecase_Expecter) ChangePassword(ctx interface{}, actor interface{}, currentPassword interface{}, newPassword interface{}) *MockUserUsecase_ChangePassword_Call {
+	return &MockUserUsecase_ChangePassword_Call{Call: _e.mock.On("ChangePassword", ctx, actor, currentPassword, newPassword)}
+}
+
+func (_c *MockUserUsecase_ChangePassword_Call) Run(run func(ctx context.Context, actor domain.Actor, currentPassword string, newPassword string)) *MockUserUsecase_ChangePassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.Actor
+		if args[1] != nil {
+			arg1 = args[1].(domain.Actor)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockUserUsecase_AuthenticateUser_Call) Return(s string, err error) *MockUserUsecase_AuthenticateUser_Call {
-	_c.Call.Return(s, err)
+func (_c *MockUserUsecase_ChangePassword_Call) Return(tokenPair domain.TokenPair, err error) *MockUserUsecase_ChangePassword_Call {
+	_c.Call.Return(tokenPair, err)
 	return _c
 }
 
-func (_c *MockUserUsecase_AuthenticateUser_Call) RunAndReturn(run func(ctx context.Context, userName string, password string) (string, error)) *MockUserUsecase_AuthenticateUser_Call {
+func (_c *MockUserUsecase_ChangePassword_Call) RunAndReturn(run func(ctx context.Context, actor domain.Actor, currentPassword string, newPassword string) (domain.TokenPair, error)) *MockUserUsecase_ChangePassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CheckAccessTokenUser provides a mock function for the type MockUserUsecase
+func (_mock *MockUserUsecase) CheckAccessTokenUser(ctx context.Context, userId string, issuedAt time.Time) error {
+	ret := _mock.Called(ctx, userId, issuedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckAccessTokenUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = returnFunc(ctx, userId, issuedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserUsecase_CheckAccessTokenUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckAccessTokenUser'
+type MockUserUsecase_CheckAccessTokenUser_Call struct {
+	*mock.Call
+}
+
+// CheckAccessTokenUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+//   - issuedAt time.Time
+func (_e *MockUserUsecase_Expecter) CheckAccessTokenUser(ctx interface{}, userId interface{}, issuedAt interface{}) *MockUserUsecase_CheckAccessTokenUser_Call {
+	return &MockUserUsecase_CheckAccessTokenUser_Call{Call: _e.mock.On("CheckAccessTokenUser", ctx, userId, issuedAt)}
+}
+
+func (_c *MockUserUsecase_CheckAccessTokenUser_Call) Run(run func(ctx context.Context, userId string, issuedAt time.Time)) *MockUserUsecase_CheckAccessTokenUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserUsecase_CheckAccessTokenUser_Call) Return(err error) *MockUserUsecase_CheckAccessTokenUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserUsecase_CheckAccessTokenUser_Call) RunAndReturn(run func(ctx context.Context, userId string, issuedAt time.Time) error) *MockUserUsecase_CheckAccessTokenUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsAccessTokenRevoked provides a mock function for the type MockUserUsecase
+func (_mock *MockUserUsecase) IsAccessTokenRevoked(ctx context.Context, tokenId string) (bool, error) {
+	ret := _mock.Called(ctx, tokenId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsAccessTokenRevoked")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return returnFunc(ctx, tokenId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = returnFunc(ctx, tokenId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, tokenId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserUsecase_IsAccessTokenRevoked_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsAccessTokenRevoked'
+type MockUserUsecase_IsAccessTokenRevoked_Call struct {
+	*mock.Call
+}
+
+// IsAccessTokenRevoked is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenId string
+func (_e *MockUserUsecase_Expecter) IsAccessTokenRevoked(ctx interface{}, tokenId interface{}) *MockUserUsecase_IsAccessTokenRevoked_Call {
+	return &MockUserUsecase_IsAccessTokenRevoked_Call{Call: _e.mock.On("IsAccessTokenRevoked", ctx, tokenId)}
+}
+
+func (_c *MockUserUsecase_IsAccessTokenRevoked_Call) Run(run func(ctx context.Context, tokenId string)) *MockUserUsecase_IsAccessTokenRevoked_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserUsecase_IsAccessTokenRevoked_Call) Return(b bool, err error) *MockUserUsecase_IsAccessTokenRevoked_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockUserUsecase_IsAccessTokenRevoked_Call) RunAndReturn(run func(ctx context.Context, tokenId string) (bool, error)) *MockUserUsecase_IsAccessTokenRevoked_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListLoginLockouts provides a mock function for the type MockUserUsecase
+func (_mock *MockUserUsecase) ListLoginLockouts(ctx context.Context) ([]domain.LoginAttempts, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListLoginLockouts")
+	}
+
+	var r0 []domain.LoginAttempts
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]domain.LoginAttempts, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []domain.LoginAttempts); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.LoginAttempts)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserUsecase_ListLoginLockouts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListLoginLockouts'
+type MockUserUsecase_ListLoginLockouts_Call struct {
+	*mock.Call
+}
+
+// ListLoginLockouts is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockUserUsecase_Expecter) ListLoginLockouts(ctx interface{}) *MockUserUsecase_ListLoginLockouts_Call {
+	return &MockUserUsecase_ListLoginLockouts_Call{Call: _e.mock.On("ListLoginLockouts", ctx)}
+}
+
+func (_c *MockUserUsecase_ListLoginLockouts_Call) Run(run func(ctx context.Context)) *MockUserUsecase_ListLoginLockouts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserUsecase_ListLoginLockouts_Call) Return(loginAttemptss []domain.LoginAttempts, err error) *MockUserUsecase_ListLoginLockouts_Call {
+	_c.Call.Return(loginAttemptss, err)
+	return _c
+}
+
+func (_c *MockUserUsecase_ListLoginLockouts_Call) RunAndReturn(run func(ctx context.Context) ([]domain.LoginAttempts, error)) *MockUserUsecase_ListLoginLockouts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Logout provides a mock function for the type MockUserUsecase
+func (_mock *MockUserUsecase) Logout(ctx context.Context, userId string, refreshToken string, accessTokenId string, accessTokenExpiresAt time.Time) error {
+	ret := _mock.Called(ctx, userId, refreshToken, accessTokenId, accessTokenExpiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Logout")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, time.Time) error); ok {
+		r0 = returnFunc(ctx, userId, refreshToken, accessTokenId, accessTokenExpiresAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserUsecase_Logout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Logout'
+type MockUserUsecase_Logout_Call struct {
+	*mock.Call
+}
+
+// Logout is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+//   - refreshToken string
+//   - accessTokenId string
+//   - accessTokenExpiresAt time.Time
+func (_e *MockUserUsecase_Expecter) Logout(ctx interface{}, userId interface{}, refreshToken interface{}, accessTokenId interface{}, accessTokenExpiresAt interface{}) *MockUserUsecase_Logout_Call {
+	return &MockUserUsecase_Logout_Call{Call: _e.mock.On("Logout", ctx, userId, refreshToken, accessTokenId, accessTokenExpiresAt)}
+}
+
+func (_c *MockUserUsecase_Logout_Call) Run(run func(ctx context.Context, userId string, refreshToken string, accessTokenId string, accessTokenExpiresAt time.Time)) *MockUserUsecase_Logout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 time.Time
+		if args[4] != nil {
+			arg4 = args[4].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserUsecase_Logout_Call) Return(err error) *MockUserUsecase_Logout_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserUsecase_Logout_Call) RunAndReturn(run func(ctx context.Context, userId string, refreshToken string, accessTokenId string, accessTokenExpiresAt time.Time) error) *MockUserUsecase_Logout_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // PromoteUser provides a mock function for the type MockUserUsecase
-func (_mock *MockUserUsecase) PromoteUser(ctx context.Context, userId string) (domain.User, error) {
-	ret := _mock.Called(ctx, userId)
+func (_mock *MockUserUsecase) PromoteUser(ctx context.Context, actor domain.Actor, userId string) (domain.User, error) {
+	ret := _mock.Called(ctx, actor, userId)
 
 	if len(ret) == 0 {
 		panic("no return value specified for PromoteUser")
@@ -120,16 +477,16 @@ func (_mock *MockUserUsecase) PromoteUser(ctx context.Context, userId string) (d
 
 	var r0 domain.User
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (domain.User, error)); ok {
-		return returnFunc(ctx, userId)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Actor, string) (domain.User, error)); ok {
+		return returnFunc(ctx, actor, userId)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) domain.User); ok {
-		r0 = returnFunc(ctx, userId)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Actor, string) domain.User); ok {
+		r0 = returnFunc(ctx, actor, userId)
 	} else {
 		r0 = ret.Get(0).(domain.User)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, userId)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.Actor, string) error); ok {
+		r1 = returnFunc(ctx, actor, userId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -143,24 +500,30 @@ type MockUserUsecase_PromoteUser_Call struct {
 
 // PromoteUser is a helper method to define mock.On call
 //   - ctx context.Context
+//   - actor domain.Actor
 //   - userId string
-func (_e *MockUserUsecase_Expecter) PromoteUser(ctx interface{}, userId interface{}) *MockUserUsecase_PromoteUser_Call {
-	return &MockUserUsecase_PromoteUser_Call{Call: _e.mock.On("PromoteUser", ctx, userId)}
+func (_e *MockUserUsecase_Expecter) PromoteUser(ctx interface{}, actor interface{}, userId interface{}) *MockUserUsecase_PromoteUser_Call {
+	return &MockUserUsecase_PromoteUser_Call{Call: _e.mock.On("PromoteUser", ctx, actor, userId)}
 }
 
-func (_c *MockUserUsecase_PromoteUser_Call) Run(run func(ctx context.Context, userId string)) *MockUserUsecase_PromoteUser_Call {
+func (_c *MockUserUsecase_PromoteUser_Call) Run(run func(ctx context.Context, actor domain.Actor, userId string)) *MockUserUsecase_PromoteUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 domain.Actor
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(domain.Actor)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
@@ -171,7 +534,73 @@ func (_c *MockUserUsecase_PromoteUser_Call) Return(user domain.User, err error)
 	return _c
 }
 
-func (_c *MockUserUsecase_PromoteUser_Call) RunAndReturn(run func(ctx context.Context, userId string) (domain.User, error)) *MockUserUsecase_PromoteUser_Call {
+func (_c *MockUserUsecase_PromoteUser_Call) RunAndReturn(run func(ctx context.Context, actor domain.Actor, userId string) (domain.User, error)) *MockUserUsecase_PromoteUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RefreshTokens provides a mock function for the type MockUserUsecase
+func (_mock *MockUserUsecase) RefreshTokens(ctx context.Context, refreshToken string) (domain.TokenPair, error) {
+	ret := _mock.Called(ctx, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefreshTokens")
+	}
+
+	var r0 domain.TokenPair
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (domain.TokenPair, error)); ok {
+		return returnFunc(ctx, refreshToken)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) domain.TokenPair); ok {
+		r0 = returnFunc(ctx, refreshToken)
+	} else {
+		r0 = ret.Get(0).(domain.TokenPair)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, refreshToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserUsecase_RefreshTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefreshTokens'
+type MockUserUsecase_RefreshTokens_Call struct {
+	*mock.Call
+}
+
+// RefreshTokens is a helper method to define mock.On call
+//   - ctx context.Context
+//   - refreshToken string
+func (_e *MockUserUsecase_Expecter) RefreshTokens(ctx interface{}, refreshToken interface{}) *MockUserUsecase_RefreshTokens_Call {
+	return &MockUserUsecase_RefreshTokens_Call{Call: _e.mock.On("RefreshTokens", ctx, refreshToken)}
+}
+
+func (_c *MockUserUsecase_RefreshTokens_Call) Run(run func(ctx context.Context, refreshToken string)) *MockUserUsecase_RefreshTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserUsecase_RefreshTokens_Call) Return(tokenPair domain.TokenPair, err error) *MockUserUsecase_RefreshTokens_Call {
+	_c.Call.Return(tokenPair, err)
+	return _c
+}
+
+func (_c *MockUserUsecase_RefreshTokens_Call) RunAndReturn(run func(ctx context.Context, refreshToken string) (domain.TokenPair, error)) *MockUserUsecase_RefreshTokens_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -247,3 +676,150 @@ func (_c *MockUserUsecase_RegisterUser_Call) RunAndReturn(run func(ctx context.C
 	_c.Call.Return(run)
 	return _c
 }
+
+// ResetPassword provides a mock function for the type MockUserUsecase
+func (_mock *MockUserUsecase) ResetPassword(ctx context.Context, actor domain.Actor, userId string, temporaryPassword string) (domain.User, error) {
+	ret := _mock.Called(ctx, actor, userId, temporaryPassword)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetPassword")
+	}
+
+	var r0 domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Actor, string, string) (domain.User, error)); ok {
+		return returnFunc(ctx, actor, userId, temporaryPassword)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Actor, string, string) domain.User); ok {
+		r0 = returnFunc(ctx, actor, userId, temporaryPassword)
+	} else {
+		r0 = ret.Get(0).(domain.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.Actor, string, string) error); ok {
+		r1 = returnFunc(ctx, actor, userId, temporaryPassword)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserUsecase_ResetPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetPassword'
+type MockUserUsecase_ResetPassword_Call struct {
+	*mock.Call
+}
+
+// ResetPassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actor domain.Actor
+//   - userId string
+//   - temporaryPassword string
+func (_e *MockUserUsecase_Expecter) ResetPassword(ctx interface{}, actor interface{}, userId interface{}, temporaryPassword interface{}) *MockUserUsecase_ResetPassword_Call {
+	return &MockUserUsecase_ResetPassword_Call{Call: _e.mock.On("ResetPassword", ctx, actor, userId, temporaryPassword)}
+}
+
+func (_c *MockUserUsecase_ResetPassword_Call) Run(run func(ctx context.Context, actor domain.Actor, userId string, temporaryPassword string)) *MockUserUsecase_ResetPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.Actor
+		if args[1] != nil {
+			arg1 = args[1].(domain.Actor)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserUsecase_ResetPassword_Call) Return(user domain.User, err error) *MockUserUsecase_ResetPassword_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockUserUsecase_ResetPassword_Call) RunAndReturn(run func(ctx context.Context, actor domain.Actor, userId string, temporaryPassword string) (domain.User, error)) *MockUserUsecase_ResetPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnlockLogin provides a mock function for the type MockUserUsecase
+func (_mock *MockUserUsecase) UnlockLogin(ctx context.Context, actor domain.Actor, kind domain.LoginAttemptKind, subject string) error {
+	ret := _mock.Called(ctx, actor, kind, subject)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnlockLogin")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Actor, domain.LoginAttemptKind, string) error); ok {
+		r0 = returnFunc(ctx, actor, kind, subject)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserUsecase_UnlockLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnlockLogin'
+type MockUserUsecase_UnlockLogin_Call struct {
+	*mock.Call
+}
+
+// UnlockLogin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actor domain.Actor
+//   - kind domain.LoginAttemptKind
+//   - subject string
+func (_e *MockUserUsecase_Expecter) UnlockLogin(ctx interface{}, actor interface{}, kind interface{}, subject interface{}) *MockUserUsecase_UnlockLogin_Call {
+	return &MockUserUsecase_UnlockLogin_Call{Call: _e.mock.On("UnlockLogin", ctx, actor, kind, subject)}
+}
+
+func (_c *MockUserUsecase_UnlockLogin_Call) Run(run func(ctx context.Context, actor domain.Actor, kind domain.LoginAttemptKind, subject string)) *MockUserUsecase_UnlockLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.Actor
+		if args[1] != nil {
+			arg1 = args[1].(domain.Actor)
+		}
+		var arg2 domain.LoginAttemptKind
+		if args[2] != nil {
+			arg2 = args[2].(domain.LoginAttemptKind)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserUsecase_UnlockLogin_Call) Return(err error) *MockUserUsecase_UnlockLogin_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserUsecase_UnlockLogin_Call) RunAndReturn(run func(ctx context.Context, actor domain.Actor, kind domain.LoginAttemptKind, subject string) error) *MockUserUsecase_UnlockLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,289 @@
+package repositories_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	domain "taskmanager/Domain"
+	repositories "taskmanager/Repositories"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type FileStoreTestSuite struct {
+	suite.Suite
+	Dir string // storage directory of the current test
+}
+
+// SetupTest gives every test an empty storage directory
+func (suite *FileStoreTestSuite) SetupTest() {
+	suite.Dir = suite.T().TempDir()
+}
+
+// a helper to open the store of the current test
+func (suite *FileStoreTestSuite) openStore() (*repositories.FileStore, repositories.TaskRepository) {
+
+	store, err := repositories.OpenFileStore(suite.Dir)
+	suite.Require().NoError(err, "OpenFileStore should not return an error")
+	suite.T().Cleanup(func() { store.Close() })
+
+	return store, repositories.NewFileTaskRepository(store, "tasks")
+}
+
+// a helper to insert a task for setup
+func (suite *FileStoreTestSuite) createTask(repo repositories.TaskRepository, taskId string) {
+	_, err := repo.Create(context.Background(), domain.Task{ID: taskId, Title: "task " + taskId, Status: domain.StatusTodo})
+	suite.Require().NoError(err, "failed to insert a task during setup")
+}
+
+func TestFileStoreSuite(t *testing.T) {
+	suite.Run(t, new(FileStoreTestSuite))
+}
+
+func (suite *FileStoreTestSuite) TestReopen_AfterClose() {
+
+	// ARRANGE: create, update and delete tasks, then close the store
+	store, taskRepo := suite.openStore()
+	userRepo := repositories.NewFileUserRepository(store, "users")
+
+	suite.createTask(taskRepo, "1")
+	suite.createTask(taskRepo, "2")
+	_, err := taskRepo.Update(context.Background(), "1", bson.M{"title": "updated"}, 0)
+	suite.Require().NoError(err)
+	suite.Require().NoError(taskRepo.Delete(context.Background(), "2", 0, time.Now()))
+
+	user := domain.User{ID: uuid.New(), UserName: "username", HashedPassword: "password"}
+	_, err = userRepo.SaveUser(context.Background(), user)
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(store.Close(), "Close should not return an error")
+
+	// ACT: open the same directory again
+	store, taskRepo = suite.openStore()
+	userRepo = repositories.NewFileUserRepository(store, "users")
+
+	// ASSERT: every change survived
+	task, err := taskRepo.GetByID(context.Background(), "1")
+	suite.Require().NoError(err)
+	suite.Assert().Equal("updated", task.Title)
+	suite.Assert().Equal(int64(1), task.Version)
+
+	_, err = taskRepo.GetByID(context.Background(), "2")
+	suite.Assert().True(errors.Is(err, domain.ErrNotFound), "the deleted task should stay deleted")
+
+	_, _, _, err = userRepo.DoesUserExist(context.Background(), "username")
+	suite.Assert().NoError(err, "the saved user should be found")
+}
+
+func (suite *FileStoreTestSuite) TestReopen_WithoutClose() {
+
+	// ARRANGE: write tasks and "crash" without closing the store
+	_, taskRepo := suite.openStore()
+	suite.createTask(taskRepo, "1")
+	suite.createTask(taskRepo, "2")
+
+	// ACT
+	_, taskRepo = suite.openStore()
+
+	// ASSERT: the journal is replayed in order
+	tasks, total, err := taskRepo.GetAll(context.Background(), domain.TaskFilter{})
+	suite.Require().NoError(err)
+	suite.Assert().Equal(int64(2), total)
+	suite.Assert().Equal("1", tasks[0].ID)
+	suite.Assert().Equal("2", tasks[1].ID)
+}
+
+func (suite *FileStoreTestSuite) TestReopen_DiscardsTornJournalTail() {
+
+	// ARRANGE: write a task, then simulate a crash in the middle of the next write
+	_, taskRepo := suite.openStore()
+	suite.createTask(taskRepo, "1")
+
+	journal, err := os.OpenFile(filepath.Join(suite.Dir, "journal.wal"), os.O_WRONLY|os.O_APPEND, 0o644)
+	suite.Require().NoError(err)
+	_, err = journal.Write([]byte{0x20, 0x00, 0x00, 0x00, 0xde, 0xad})
+	suite.Require().NoError(err)
+	suite.Require().NoError(journal.Close())
+
+	// ACT: reopen and keep writing
+	_, taskRepo = suite.openStore()
+	suite.createTask(taskRepo, "2")
+	_, taskRepo = suite.openStore()
+
+	// ASSERT: the complete records are kept, the torn one is dropped
+	_, total, err := taskRepo.GetAll(context.Background(), domain.TaskFilter{})
+	suite.Require().NoError(err)
+	suite.Assert().Equal(int64(2), total, "both complete writes should survive")
+}
+
+func (suite *FileStoreTestSuite) TestReopen_KeepsBulkBatches() {
+
+	// ARRANGE: a batch of creates and a batch of updates, then "crash"
+	_, taskRepo := suite.openStore()
+	tasks := []domain.Task{{ID: "1", Title: "one"}, {ID: "2", Title: "two"}, {ID: "3", Title: "three"}}
+	errs, err := taskRepo.BulkCreate(context.Background(), tasks, true)
+	suite.Require().NoError(err)
+	suite.Require().Equal([]error{nil, nil, nil}, errs)
+
+	updates := []repositories.TaskUpdate{
+		{ID: "1", Updates: bson.M{"title": "first"}, ExpectedVersion: 0},
+		{ID: "3", Updates: bson.M{"title": "third"}, ExpectedVersion: 0},
+	}
+	_, err = taskRepo.BulkUpdate(context.Background(), updates, false)
+	suite.Require().NoError(err)
+
+	// ACT
+	_, taskRepo = suite.openStore()
+
+	// ASSERT: both batches are replayed
+	tasks, total, err := taskRepo.GetAll(context.Background(), domain.TaskFilter{})
+	suite.Require().NoError(err)
+	suite.Require().Equal(int64(3), total)
+	suite.Assert().Equal("first", tasks[0].Title)
+	suite.Assert().Equal("two", tasks[1].Title)
+	suite.Assert().Equal("third", tasks[2].Title)
+}
+
+func (suite *FileStoreTestSuite) TestReopen_TornBulkBatchStoresNothing() {
+
+	// ARRANGE: a stored task, then a crash halfway through writing a batch
+	_, taskRepo := suite.openStore()
+	suite.createTask(taskRepo, "0")
+
+	journalPath := filepath.Join(suite.Dir, "journal.wal")
+	before, err := os.Stat(journalPath)
+	suite.Require().NoError(err)
+
+	tasks := []domain.Task{{ID: "1", Title: "one"}, {ID: "2", Title: "two"}, {ID: "3", Title: "three"}}
+	_, err = taskRepo.BulkCreate(context.Background(), tasks, true)
+	suite.Require().NoError(err)
+
+	after, err := os.Stat(journalPath)
+	suite.Require().NoError(err)
+
+	// the first tasks of the batch made it to the file, the last ones didn't
+	suite.Require().NoError(os.Truncate(journalPath, before.Size()+(after.Size()-before.Size())*2/3))
+
+	// ACT
+	_, taskRepo = suite.openStore()
+
+	// ASSERT: none of the batch is stored, the task written before it is
+	_, total, err := taskRepo.GetAll(context.Background(), domain.TaskFilter{})
+	suite.Require().NoError(err)
+	suite.Assert().Equal(int64(1), total, "a torn batch must not be applied partially")
+	for _, task := range tasks {
+		_, err := taskRepo.GetByID(context.Background(), task.ID)
+		suite.Assert().True(errors.Is(err, domain.ErrNotFound), "task %s of the torn batch should not exist", task.ID)
+	}
+}
+
+func (suite *FileStoreTestSuite) TestReopen_IgnoresJournalOlderThanSnapshot() {
+
+	// ARRANGE: keep a copy of the journal, then delete the task and compact
+	store, taskRepo := suite.openStore()
+	suite.createTask(taskRepo, "1")
+
+	journalPath := filepath.Join(suite.Dir, "journal.wal")
+	oldJournal, err := os.ReadFile(journalPath)
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(taskRepo.Delete(context.Background(), "1", 0, time.Now()))
+	suite.Require().NoError(store.Close())
+
+	// simulate a crash after the snapshot was written but before the journal was replaced
+	suite.Require().NoError(os.WriteFile(journalPath, oldJournal, 0o644))
+
+	// ACT
+	_, taskRepo = suite.openStore()
+
+	// ASSERT: the stale journal is not replayed on top of the newer snapshot
+	_, err = taskRepo.GetByID(context.Background(), "1")
+	suite.Assert().True(errors.Is(err, domain.ErrNotFound), "the deleted task should not come back")
+}
+
+func (suite *FileStoreTestSuite) TestOpen_CorruptSnapshot() {
+
+	// ARRANGE
+	err := os.WriteFile(filepath.Join(suite.Dir, "snapshot.db"), []byte("not a snapshot"), 0o644)
+	suite.Require().NoError(err)
+
+	// ACT
+	_, err = repositories.OpenFileStore(suite.Dir)
+
+	// ASSERT: a damaged snapshot is never silently dropped
+	suite.Assert().Error(err, "OpenFileStore should refuse a corrupt snapshot")
+}
+
+func (suite *FileStoreTestSuite) TestReopen_RebuildsSearchIndex() {
+
+	// ARRANGE
+	store, taskRepo := suite.openStore()
+	_, err := taskRepo.Create(context.Background(), domain.Task{ID: "1", Title: "Deploy api"})
+	suite.Require().NoError(err)
+	suite.Require().NoError(store.Close())
+
+	// ACT
+	_, taskRepo = suite.openStore()
+	query, err := domain.ParseTaskSearchQuery("deploy")
+	suite.Require().NoError(err)
+	hits, _, err := taskRepo.Search(context.Background(), query)
+
+	// ASSERT: tasks loaded from disk are searchable
+	suite.Require().NoError(err)
+	suite.Assert().Len(hits, 1)
+}
+
+func (suite *FileStoreTestSuite) TestReopen_KeepsAuditLog() {
+
+	// ARRANGE: the store isn't closed, the entry only lives in the journal
+	store, _ := suite.openStore()
+	auditRepo := repositories.NewFileAuditRepository(store, "audit_log")
+	entry := domain.AuditEntry{
+		ID:         "e1",
+		ActorID:    "admin-1",
+		Action:     domain.AuditTaskDeleted,
+		TargetType: domain.AuditTargetTask,
+		TargetID:   "1",
+		Before:     json.RawMessage(`{"id":"1"}`),
+		Timestamp:  time.Now().UTC().Truncate(time.Millisecond),
+	}
+	suite.Require().NoError(auditRepo.Append(context.Background(), entry))
+
+	// ACT
+	store, _ = suite.openStore()
+	entries, _, err := repositories.NewFileAuditRepository(store, "audit_log").Query(context.Background(), domain.AuditFilter{})
+
+	// ASSERT
+	suite.Require().NoError(err)
+	suite.Require().Len(entries, 1)
+	suite.Assert().Equal(entry, entries[0])
+}
+
+func (suite *FileStoreTestSuite) TestReopen_KeepsTrash() {
+
+	// ARRANGE
+	store, taskRepo := suite.openStore()
+	_, err := taskRepo.Create(context.Background(), domain.Task{ID: "1", Title: "Deploy api"})
+	suite.Require().NoError(err)
+	suite.Require().NoError(taskRepo.Delete(context.Background(), "1", 0, time.Now()))
+	suite.Require().NoError(store.Close())
+
+	// ACT
+	_, taskRepo = suite.openStore()
+
+	// ASSERT: the task is still in the trash and stays out of search results
+	_, err = taskRepo.GetDeletedByID(context.Background(), "1")
+	suite.Require().NoError(err)
+
+	query, err := domain.ParseTaskSearchQuery("deploy")
+	suite.Require().NoError(err)
+	_, total, err := taskRepo.Search(context.Background(), query)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(int64(0), total)
+}
@@ -0,0 +1,172 @@
+package repositories_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	domain "taskmanager/Domain"
+	repositories "taskmanager/Repositories"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type InMemoryAuditRepoTestSuite struct {
+	suite.Suite
+	AuditRepo repositories.AuditRepository // the repo we test
+}
+
+// SetupTest gives every test a fresh, empty repository
+func (suite *InMemoryAuditRepoTestSuite) SetupTest() {
+	suite.AuditRepo = repositories.NewInMemoryAuditRepository()
+}
+
+// a helper to append an entry for setup
+func (suite *InMemoryAuditRepoTestSuite) setupEntry(entryId string, actorId string, action domain.AuditAction, targetId string, timestamp time.Time) domain.AuditEntry {
+
+	entry := domain.AuditEntry{
+		ID:         entryId,
+		ActorID:    actorId,
+		Action:     action,
+		TargetType: domain.AuditTargetTask,
+		TargetID:   targetId,
+		After:      json.RawMessage(`{"id":"` + targetId + `"}`),
+		Timestamp:  timestamp.UTC().Truncate(time.Millisecond),
+	}
+
+	err := suite.AuditRepo.Append(context.Background(), entry)
+	suite.Require().NoError(err, "failed to append an audit entry during setup")
+
+	return entry
+}
+
+func TestInMemoryAuditRepoSuite(t *testing.T) {
+	suite.Run(t, new(InMemoryAuditRepoTestSuite))
+}
+
+func (suite *InMemoryAuditRepoTestSuite) TestAppend_KeepsBeforeAndAfter() {
+
+	// ARRANGE
+	entry := domain.AuditEntry{
+		ID:         "e1",
+		ActorID:    "admin-1",
+		Action:     domain.AuditTaskUpdated,
+		TargetType: domain.AuditTargetTask,
+		TargetID:   "task-1",
+		Before:     json.RawMessage(`{"title":"old"}`),
+		After:      json.RawMessage(`{"title":"new"}`),
+		Timestamp:  time.Now().UTC().Truncate(time.Millisecond),
+	}
+
+	// ACT
+	err := suite.AuditRepo.Append(context.Background(), entry)
+
+	// ASSERT: the entry reads back exactly as written
+	suite.Require().NoError(err)
+	entries, total, err := suite.AuditRepo.Query(context.Background(), domain.AuditFilter{})
+	suite.Require().NoError(err)
+	suite.Assert().Equal(int64(1), total)
+	suite.Require().Len(entries, 1)
+	suite.Assert().Equal(entry, entries[0])
+}
+
+func (suite *InMemoryAuditRepoTestSuite) TestAppend_DuplicateID() {
+
+	// ARRANGE
+	entry := suite.setupEntry("e1", "admin-1", domain.AuditTaskCreated, "task-1", time.Now())
+
+	// ACT: entries can't be overwritten
+	entry.ActorID = "someone-else"
+	err := suite.AuditRepo.Append(context.Background(), entry)
+
+	// ASSERT
+	suite.Assert().True(errors.Is(err, domain.ErrAleadyExists))
+}
+
+func (suite *InMemoryAuditRepoTestSuite) TestQuery_NewestFirstAndFiltered() {
+
+	// ARRANGE
+	now := time.Now()
+	suite.setupEntry("e1", "admin-1", domain.AuditTaskCreated, "task-1", now)
+	suite.setupEntry("e2", "admin-1", domain.AuditTaskUpdated, "task-1", now.Add(time.Minute))
+	suite.setupEntry("e3", "admin-2", domain.AuditTaskUpdated, "task-2", now.Add(2*time.Minute))
+	suite.setupEntry("e4", "admin-1", domain.AuditTaskUpdated, "task-1", now.Add(3*time.Minute))
+
+	// ACT
+	entries, total, err := suite.AuditRepo.Query(context.Background(), domain.AuditFilter{
+		ActorID: "admin-1",
+		Action:  domain.AuditTaskUpdated,
+	})
+
+	// ASSERT
+	suite.Require().NoError(err)
+	suite.Assert().Equal(int64(2), total)
+	suite.Require().Len(entries, 2)
+	suite.Assert().Equal("e4", entries[0].ID)
+	suite.Assert().Equal("e2", entries[1].ID)
+}
+
+func (suite *InMemoryAuditRepoTestSuite) TestQuery_TimeRangeAndPagination() {
+
+	// ARRANGE
+	now := time.Now()
+	for i, id := range []string{"e1", "e2", "e3", "e4", "e5"} {
+		suite.setupEntry(id, "admin-1", domain.AuditTaskUpdated, "task-1", now.Add(time.Duration(i)*time.Minute))
+	}
+
+	// ACT: from is inclusive and to exclusive, so e2, e3 and e4 match
+	entries, total, err := suite.AuditRepo.Query(context.Background(), domain.AuditFilter{
+		From:   now.Add(time.Minute).Truncate(time.Millisecond),
+		To:     now.Add(4 * time.Minute).Truncate(time.Millisecond),
+		Limit:  2,
+		Offset: 1,
+	})
+
+	// ASSERT: the second page of one starts after e4
+	suite.Require().NoError(err)
+	suite.Assert().Equal(int64(3), total)
+	suite.Require().Len(entries, 2)
+	suite.Assert().Equal("e3", entries[0].ID)
+	suite.Assert().Equal("e2", entries[1].ID)
+}
+
+func (suite *InMemoryAuditRepoTestSuite) TestStream_OldestFirst() {
+
+	// ARRANGE: appended out of order
+	now := time.Now()
+	suite.setupEntry("e2", "admin-1", domain.AuditTaskUpdated, "task-1", now.Add(time.Minute))
+	suite.setupEntry("e1", "admin-1", domain.AuditTaskCreated, "task-1", now)
+	suite.setupEntry("e3", "admin-1", domain.AuditTaskDeleted, "task-2", now.Add(2*time.Minute))
+
+	// ACT
+	var ids []string
+	err := suite.AuditRepo.Stream(context.Background(), domain.AuditFilter{TargetID: "task-1"}, func(entry domain.AuditEntry) error {
+		ids = append(ids, entry.ID)
+		return nil
+	})
+
+	// ASSERT
+	suite.Require().NoError(err)
+	suite.Assert().Equal([]string{"e1", "e2"}, ids)
+}
+
+func (suite *InMemoryAuditRepoTestSuite) TestStream_StopsOnCallbackError() {
+
+	// ARRANGE
+	now := time.Now()
+	suite.setupEntry("e1", "admin-1", domain.AuditTaskCreated, "task-1", now)
+	suite.setupEntry("e2", "admin-1", domain.AuditTaskUpdated, "task-1", now.Add(time.Minute))
+	stop := errors.New("client went away")
+
+	// ACT
+	calls := 0
+	err := suite.AuditRepo.Stream(context.Background(), domain.AuditFilter{}, func(entry domain.AuditEntry) error {
+		calls++
+		return stop
+	})
+
+	// ASSERT
+	suite.Assert().True(errors.Is(err, stop))
+	suite.Assert().Equal(1, calls)
+}
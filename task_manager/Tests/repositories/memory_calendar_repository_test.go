@@ -0,0 +1,49 @@
+package repositories_test
+
+import (
+	"context"
+	domain "taskmanager/Domain"
+	repositories "taskmanager/Repositories"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryCalendarFeedRepository_SaveReplacesTheFeedOfAUser(t *testing.T) {
+	repo := repositories.NewInMemoryCalendarFeedRepository()
+	ctx := context.Background()
+	createdAt := time.Now().UTC().Truncate(time.Millisecond)
+
+	require.NoError(t, repo.SaveFeed(ctx, domain.CalendarFeed{UserID: "user-1", TokenHash: "old", CreatedAt: createdAt}))
+	require.NoError(t, repo.SaveFeed(ctx, domain.CalendarFeed{UserID: "user-1", TokenHash: "new", CreatedAt: createdAt}))
+
+	feed, err := repo.GetFeedByHash(ctx, "new")
+	require.NoError(t, err)
+	assert.Equal(t, domain.CalendarFeed{UserID: "user-1", TokenHash: "new", CreatedAt: createdAt}, feed)
+
+	// the old secret no longer finds the feed
+	_, err = repo.GetFeedByHash(ctx, "old")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	byUser, err := repo.GetFeedByUser(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, feed, byUser)
+}
+
+func TestInMemoryCalendarFeedRepository_DeleteFeed(t *testing.T) {
+	repo := repositories.NewInMemoryCalendarFeedRepository()
+	ctx := context.Background()
+
+	require.NoError(t, repo.SaveFeed(ctx, domain.CalendarFeed{UserID: "user-1", TokenHash: "hash"}))
+	require.NoError(t, repo.DeleteFeed(ctx, "user-1"))
+
+	_, err := repo.GetFeedByUser(ctx, "user-1")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	_, err = repo.GetFeedByHash(ctx, "hash")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	// there is nothing left to delete
+	assert.ErrorIs(t, repo.DeleteFeed(ctx, "user-1"), domain.ErrNotFound)
+}
@@ -0,0 +1,144 @@
+package repositories_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	domain "taskmanager/Domain"
+	repositories "taskmanager/Repositories"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type InMemoryCommentRepoTestSuite struct {
+	suite.Suite
+	CommentRepo repositories.CommentRepository // the repo we test
+}
+
+// SetupTest gives every test a fresh, empty repository
+func (suite *InMemoryCommentRepoTestSuite) SetupTest() {
+	suite.CommentRepo = repositories.NewInMemoryCommentRepository()
+}
+
+// a helper to insert a comment for setup
+func (suite *InMemoryCommentRepoTestSuite) setupComment(commentId, taskId string, createdAt time.Time) domain.Comment {
+
+	comment := domain.Comment{
+		ID:        commentId,
+		TaskID:    taskId,
+		AuthorID:  "author-1",
+		Body:      "comment " + commentId,
+		CreatedAt: createdAt.UTC().Truncate(time.Millisecond),
+		UpdatedAt: createdAt.UTC().Truncate(time.Millisecond),
+	}
+
+	_, err := suite.CommentRepo.Create(context.Background(), comment)
+	suite.Require().NoError(err, "failed to insert a comment during setup")
+
+	return comment
+}
+
+func TestInMemoryCommentRepoSuite(t *testing.T) {
+	suite.Run(t, new(InMemoryCommentRepoTestSuite))
+}
+
+func (suite *InMemoryCommentRepoTestSuite) TestListByTask_OldestFirstAndPaginated() {
+
+	// ARRANGE: comments on two tasks, created out of order
+	now := time.Now()
+	suite.setupComment("c3", "task-1", now.Add(2*time.Minute))
+	suite.setupComment("c1", "task-1", now)
+	suite.setupComment("c2", "task-1", now.Add(time.Minute))
+	suite.setupComment("other", "task-2", now)
+
+	// ACT
+	comments, total, err := suite.CommentRepo.ListByTask(context.Background(), "task-1", 2, 1)
+
+	// ASSERT: only task-1's comments are counted, the page starts at the second oldest
+	suite.Require().NoError(err)
+	suite.Assert().Equal(int64(3), total)
+	suite.Require().Len(comments, 2)
+	suite.Assert().Equal("c2", comments[0].ID)
+	suite.Assert().Equal("c3", comments[1].ID)
+}
+
+func (suite *InMemoryCommentRepoTestSuite) TestGetByID_ScopedToTask() {
+
+	// ARRANGE
+	suite.setupComment("c1", "task-1", time.Now())
+
+	// ACT
+	_, err := suite.CommentRepo.GetByID(context.Background(), "task-2", "c1")
+
+	// ASSERT: a comment isn't reachable through another task
+	suite.Assert().True(errors.Is(err, domain.ErrNotFound), "Error should be the domain.ErrNotFound")
+}
+
+func (suite *InMemoryCommentRepoTestSuite) TestUpdate_Success() {
+
+	// ARRANGE
+	created := suite.setupComment("c1", "task-1", time.Now())
+	editedAt := created.CreatedAt.Add(time.Minute)
+
+	// ACT
+	updated, err := suite.CommentRepo.Update(context.Background(), "task-1", "c1", "edited", editedAt)
+
+	// ASSERT
+	suite.Require().NoError(err)
+	suite.Assert().Equal("edited", updated.Body)
+	suite.Assert().True(editedAt.Equal(updated.UpdatedAt))
+	suite.Assert().True(created.CreatedAt.Equal(updated.CreatedAt), "the creation time should be kept")
+}
+
+func (suite *InMemoryCommentRepoTestSuite) TestDelete() {
+
+	// ARRANGE
+	suite.setupComment("c1", "task-1", time.Now())
+
+	// ACT
+	wrongTaskErr := suite.CommentRepo.Delete(context.Background(), "task-2", "c1")
+	err := suite.CommentRepo.Delete(context.Background(), "task-1", "c1")
+	missingErr := suite.CommentRepo.Delete(context.Background(), "task-1", "c1")
+
+	// ASSERT
+	suite.Assert().True(errors.Is(wrongTaskErr, domain.ErrNotFound), "another task's comment can't be deleted")
+	suite.Assert().NoError(err)
+	suite.Assert().True(errors.Is(missingErr, domain.ErrNotFound), "a deleted comment is gone")
+}
+
+func (suite *InMemoryCommentRepoTestSuite) TestDeleteByTask() {
+
+	// ARRANGE
+	now := time.Now()
+	suite.setupComment("c1", "task-1", now)
+	suite.setupComment("c2", "task-1", now)
+	suite.setupComment("other", "task-2", now)
+
+	// ACT
+	err := suite.CommentRepo.DeleteByTask(context.Background(), "task-1")
+	emptyErr := suite.CommentRepo.DeleteByTask(context.Background(), "task-3")
+
+	// ASSERT: only the task's comments are gone, a task without comments is fine
+	suite.Require().NoError(err)
+	suite.Assert().NoError(emptyErr)
+	_, total, err := suite.CommentRepo.ListByTask(context.Background(), "task-1", 0, 0)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(int64(0), total)
+	_, total, err = suite.CommentRepo.ListByTask(context.Background(), "task-2", 0, 0)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(int64(1), total)
+}
+
+func (suite *InMemoryCommentRepoTestSuite) TestCreate_Duplicate() {
+
+	// ARRANGE
+	comment := suite.setupComment("c1", "task-1", time.Now())
+
+	// ACT
+	_, err := suite.CommentRepo.Create(context.Background(), comment)
+
+	// ASSERT
+	suite.Assert().True(errors.Is(err, domain.ErrAleadyExists), fmt.Sprintf("unexpected error: %v", err))
+}
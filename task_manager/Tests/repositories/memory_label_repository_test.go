@@ -0,0 +1,103 @@
+package repositories_test
+
+import (
+	"context"
+	"errors"
+	domain "taskmanager/Domain"
+	repositories "taskmanager/Repositories"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type InMemoryLabelRepoTestSuite struct {
+	suite.Suite
+	LabelRepo repositories.LabelRepository // the repo we test
+}
+
+// SetupTest gives every test a fresh, empty repository
+func (suite *InMemoryLabelRepoTestSuite) SetupTest() {
+	suite.LabelRepo = repositories.NewInMemoryLabelRepository()
+}
+
+// a helper to insert a label for setup
+func (suite *InMemoryLabelRepoTestSuite) setupLabel(name string) domain.Label {
+
+	label := domain.Label{Name: name, Color: domain.DefaultLabelColor}
+
+	_, err := suite.LabelRepo.Create(context.Background(), label)
+	suite.Require().NoError(err, "failed to insert a label during setup")
+
+	return label
+}
+
+func TestInMemoryLabelRepoSuite(t *testing.T) {
+	suite.Run(t, new(InMemoryLabelRepoTestSuite))
+}
+
+func (suite *InMemoryLabelRepoTestSuite) TestGetAll_SortedByName() {
+
+	// ARRANGE
+	suite.setupLabel("urgent")
+	suite.setupLabel("backend")
+
+	// ACT
+	labels, err := suite.LabelRepo.GetAll(context.Background())
+
+	// ASSERT
+	suite.Require().NoError(err)
+	suite.Require().Len(labels, 2)
+	suite.Assert().Equal("backend", labels[0].Name)
+	suite.Assert().Equal("urgent", labels[1].Name)
+}
+
+func (suite *InMemoryLabelRepoTestSuite) TestCreate_Duplicate() {
+
+	// ARRANGE
+	label := suite.setupLabel("backend")
+
+	// ACT
+	_, err := suite.LabelRepo.Create(context.Background(), label)
+
+	// ASSERT
+	suite.Assert().True(errors.Is(err, domain.ErrAleadyExists), "Error should be the domain.ErrAleadyExists")
+}
+
+func (suite *InMemoryLabelRepoTestSuite) TestUpdate_Rename() {
+
+	// ARRANGE
+	suite.setupLabel("bug")
+
+	// ACT
+	_, err := suite.LabelRepo.Update(context.Background(), "bug", domain.Label{Name: "defect", Color: "#ff0000"})
+
+	// ASSERT: the label only exists under its new name
+	suite.Require().NoError(err)
+	_, err = suite.LabelRepo.GetByName(context.Background(), "bug")
+	suite.Assert().True(errors.Is(err, domain.ErrNotFound))
+	label, err := suite.LabelRepo.GetByName(context.Background(), "defect")
+	suite.Require().NoError(err)
+	suite.Assert().Equal("#ff0000", label.Color)
+}
+
+func (suite *InMemoryLabelRepoTestSuite) TestUpdate_RenameToExistingLabel() {
+
+	// ARRANGE
+	suite.setupLabel("bug")
+	suite.setupLabel("defect")
+
+	// ACT
+	_, err := suite.LabelRepo.Update(context.Background(), "bug", domain.Label{Name: "defect"})
+
+	// ASSERT
+	suite.Assert().True(errors.Is(err, domain.ErrAleadyExists), "Error should be the domain.ErrAleadyExists")
+}
+
+func (suite *InMemoryLabelRepoTestSuite) TestDelete_NotFound() {
+
+	// ACT
+	err := suite.LabelRepo.Delete(context.Background(), "missing")
+
+	// ASSERT
+	suite.Assert().True(errors.Is(err, domain.ErrNotFound), "Error should be the domain.ErrNotFound")
+}
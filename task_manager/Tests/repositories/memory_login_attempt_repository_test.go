@@ -0,0 +1,125 @@
+package repositories_test
+
+import (
+	"context"
+	domain "taskmanager/Domain"
+	repositories "taskmanager/Repositories"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingLimits count every attempt without ever throttling one
+var countingLimits = domain.LoginLimits{Window: time.Hour}
+
+func TestInMemoryLoginAttemptRepository_ReserveAttemptCountsWithinTheWindow(t *testing.T) {
+	repo := repositories.NewInMemoryLoginAttemptRepository()
+	ctx := context.Background()
+	start := time.Now()
+
+	_, err := repo.GetAttempts(ctx, domain.LoginAttemptUser, "alice")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	for i := 0; i < 3; i++ {
+		_, err := repo.ReserveAttempt(ctx, domain.LoginAttemptUser, "alice", start.Add(time.Duration(i)*time.Minute), countingLimits)
+		require.NoError(t, err)
+	}
+
+	// the same subject counted by address is a different counter
+	_, err = repo.ReserveAttempt(ctx, domain.LoginAttemptIP, "alice", start, countingLimits)
+	require.NoError(t, err)
+
+	attempts, err := repo.GetAttempts(ctx, domain.LoginAttemptUser, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, 3, attempts.Failures)
+	assert.Equal(t, start.Add(2*time.Minute), attempts.LastFailure)
+
+	// a failure after the window starts over
+	attempts, err = repo.ReserveAttempt(ctx, domain.LoginAttemptUser, "alice", start.Add(3*time.Hour), countingLimits)
+	require.NoError(t, err)
+	assert.Equal(t, 1, attempts.Failures)
+}
+
+func TestInMemoryLoginAttemptRepository_ReserveAttemptChecksTheLimits(t *testing.T) {
+	repo := repositories.NewInMemoryLoginAttemptRepository()
+	ctx := context.Background()
+	now := time.Now()
+	limits := domain.LoginLimits{Window: time.Hour, UserDelayAfter: 2, BaseDelay: time.Minute, MaxDelay: time.Hour}
+
+	for i := 0; i < 2; i++ {
+		_, err := repo.ReserveAttempt(ctx, domain.LoginAttemptUser, "alice", now, limits)
+		require.NoError(t, err)
+	}
+
+	// the third attempt has to wait for the delay, and isn't counted
+	_, err := repo.ReserveAttempt(ctx, domain.LoginAttemptUser, "alice", now, limits)
+	var throttled *domain.LoginThrottledError
+	require.ErrorAs(t, err, &throttled)
+	assert.Equal(t, time.Minute, throttled.RetryAfter)
+	assert.False(t, throttled.Locked)
+
+	// once it has passed only one attempt gets through, a parallel one waits for the next delay
+	attempts, err := repo.ReserveAttempt(ctx, domain.LoginAttemptUser, "alice", now.Add(time.Minute), limits)
+	require.NoError(t, err)
+	assert.Equal(t, 3, attempts.Failures)
+	_, err = repo.ReserveAttempt(ctx, domain.LoginAttemptUser, "alice", now.Add(time.Minute), limits)
+	require.ErrorAs(t, err, &throttled)
+	assert.Equal(t, 2*time.Minute, throttled.RetryAfter)
+
+	// a released attempt isn't a failure, but the next one is still spaced out from it
+	require.NoError(t, repo.ReleaseAttempt(ctx, domain.LoginAttemptUser, "alice"))
+	attempts, err = repo.GetAttempts(ctx, domain.LoginAttemptUser, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, 2, attempts.Failures)
+	assert.Equal(t, now.Add(time.Minute), attempts.LastFailure)
+
+	assert.ErrorIs(t, repo.ReleaseAttempt(ctx, domain.LoginAttemptUser, "bob"), domain.ErrNotFound)
+}
+
+func TestInMemoryLoginAttemptRepository_LockAndListLocked(t *testing.T) {
+	repo := repositories.NewInMemoryLoginAttemptRepository()
+	ctx := context.Background()
+	now := time.Now()
+
+	require.NoError(t, repo.Lock(ctx, domain.LoginAttemptIP, "10.0.0.1", now.Add(time.Minute)))
+	require.NoError(t, repo.Lock(ctx, domain.LoginAttemptUser, "alice", now.Add(time.Hour)))
+	require.NoError(t, repo.Lock(ctx, domain.LoginAttemptUser, "bob", now.Add(-time.Minute)))
+
+	// the longest lockout comes first, an expired one isn't listed
+	locked, err := repo.ListLocked(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, locked, 2)
+	assert.Equal(t, "alice", locked[0].Subject)
+	assert.Equal(t, "10.0.0.1", locked[1].Subject)
+	assert.Equal(t, domain.LoginAttemptIP, locked[1].Kind)
+
+	require.NoError(t, repo.Reset(ctx, domain.LoginAttemptUser, "alice"))
+	assert.ErrorIs(t, repo.Reset(ctx, domain.LoginAttemptUser, "alice"), domain.ErrNotFound)
+
+	locked, err = repo.ListLocked(ctx, now)
+	require.NoError(t, err)
+	assert.Len(t, locked, 1)
+}
+
+func TestInMemoryLoginAttemptRepository_PrunesForgottenFailures(t *testing.T) {
+	repo := repositories.NewInMemoryLoginAttemptRepository()
+	ctx := context.Background()
+	start := time.Now()
+
+	_, err := repo.ReserveAttempt(ctx, domain.LoginAttemptIP, "10.0.0.1", start, domain.LoginLimits{Window: time.Minute})
+	require.NoError(t, err)
+	_, err = repo.ReserveAttempt(ctx, domain.LoginAttemptIP, "10.0.0.2", start, domain.LoginLimits{Window: time.Minute})
+	require.NoError(t, err)
+	require.NoError(t, repo.Lock(ctx, domain.LoginAttemptIP, "10.0.0.2", start.Add(time.Hour)))
+
+	// the next failure after the window drops the counters that are neither recent nor locked
+	_, err = repo.ReserveAttempt(ctx, domain.LoginAttemptIP, "10.0.0.3", start.Add(2*time.Minute), domain.LoginLimits{Window: time.Minute})
+	require.NoError(t, err)
+
+	_, err = repo.GetAttempts(ctx, domain.LoginAttemptIP, "10.0.0.1")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	_, err = repo.GetAttempts(ctx, domain.LoginAttemptIP, "10.0.0.2")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,37 @@
+package repositories_test
+
+import (
+	"context"
+	domain "taskmanager/Domain"
+	repositories "taskmanager/Repositories"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryReminderRepository_MarkSent(t *testing.T) {
+	repo := repositories.NewInMemoryReminderRepository()
+	ctx := context.Background()
+	task := domain.Task{ID: "1", Title: "Report", DueDate: time.Date(2025, 11, 12, 9, 0, 0, 0, time.UTC)}
+	reminder := domain.NewReminder(domain.ReminderDueSoon, task)
+
+	sent, err := repo.WasSent(ctx, reminder.Key)
+	require.NoError(t, err)
+	assert.False(t, sent)
+
+	require.NoError(t, repo.MarkSent(ctx, reminder))
+	require.NoError(t, repo.MarkSent(ctx, reminder))
+
+	sent, err = repo.WasSent(ctx, reminder.Key)
+	require.NoError(t, err)
+	assert.True(t, sent)
+
+	// the overdue reminder and a moved due date are separate reminders
+	sent, _ = repo.WasSent(ctx, domain.NewReminder(domain.ReminderOverdue, task).Key)
+	assert.False(t, sent)
+	task.DueDate = task.DueDate.Add(24 * time.Hour)
+	sent, _ = repo.WasSent(ctx, domain.NewReminder(domain.ReminderDueSoon, task).Key)
+	assert.False(t, sent)
+}
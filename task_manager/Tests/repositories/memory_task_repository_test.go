@@ -0,0 +1,701 @@
+package repositories_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	domain "taskmanager/Domain"
+	repositories "taskmanager/Repositories"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type InMemoryTaskRepoTestSuite struct {
+	suite.Suite
+	TaskRepo repositories.TaskRepository // the repo we test
+}
+
+// SetupTest gives every test a fresh, empty repository
+func (suite *InMemoryTaskRepoTestSuite) SetupTest() {
+	suite.TaskRepo = repositories.NewInMemoryTaskRepository()
+}
+
+// a helper to insert a task for setup
+func (suite *InMemoryTaskRepoTestSuite) setupTask(taskId, title string) domain.Task {
+
+	task := domain.Task{
+		ID:          taskId,
+		Title:       title,
+		Description: "task description",
+		DueDate:     time.Now().UTC().Add(24 * time.Hour).Truncate(time.Millisecond),
+		Status:      domain.StatusTodo,
+	}
+
+	_, err := suite.TaskRepo.Create(context.Background(), task)
+	suite.Require().NoError(err, "failed to insert a task during setup")
+
+	return task
+}
+
+func TestInMemoryTaskRepoSuite(t *testing.T) {
+	suite.Run(t, new(InMemoryTaskRepoTestSuite))
+}
+
+func (suite *InMemoryTaskRepoTestSuite) TestGetById_Success() {
+
+	// ARRANGE
+	expectedTask := suite.setupTask("1", "test task")
+
+	// ACT
+	actualTask, err := suite.TaskRepo.GetByID(context.Background(), expectedTask.ID)
+
+	// ASSERT
+	suite.Require().NoError(err, "GetByID should not return an error for existing task")
+	suite.Assert().Equal(expectedTask, actualTask, "Returned task should match the stored task")
+}
+
+func (suite *InMemoryTaskRepoTestSuite) TestGetById_NotFound() {
+
+	// ACT
+	_, err := suite.TaskRepo.GetByID(context.Background(), "99")
+
+	// ASSERT
+	suite.Assert().True(errors.Is(err, domain.ErrNotFound), "Error should be the domain.ErrNotFound")
+}
+
+func (suite *InMemoryTaskRepoTestSuite) TestCreate_Duplicate() {
+
+	// ARRANGE
+	task := suite.setupTask("1", "test task")
+
+	// ACT
+	_, err := suite.TaskRepo.Create(context.Background(), task)
+
+	// ASSERT
+	suite.Assert().True(errors.Is(err, domain.ErrAleadyExists), "Error should be the domain.ErrAleadyExists")
+}
+
+func (suite *InMemoryTaskRepoTestSuite) TestCreate_TruncatesDueDateLikeMongo() {
+
+	// ARRANGE: a due date with sub-millisecond precision
+	dueDate := time.Date(2030, 1, 2, 3, 4, 5, 123456789, time.UTC)
+
+	// ACT
+	_, err := suite.TaskRepo.Create(context.Background(), domain.Task{ID: "1", Title: "task", DueDate: dueDate})
+	suite.Require().NoError(err)
+
+	storedTask, err := suite.TaskRepo.GetByID(context.Background(), "1")
+
+	// ASSERT: BSON dates only keep milliseconds
+	suite.Require().NoError(err)
+	suite.Assert().True(dueDate.Truncate(time.Millisecond).Equal(storedTask.DueDate), "due date should be stored with millisecond precision")
+}
+
+func (suite *InMemoryTaskRepoTestSuite) TestGetAll_FilterSortAndPaginate() {
+
+	// ARRANGE: Insert tasks whose titles share a prefix
+	suite.setupTask("1", "Deploy api")
+	suite.setupTask("2", "Deploy web")
+	suite.setupTask("3", "Write docs")
+
+	// ACT: ask for the second page of "deploy" tasks sorted by title descending
+	filter := domain.TaskFilter{TitlePrefix: "deploy", SortBy: domain.TaskSortByTitle, SortDesc: true, Limit: 1, Offset: 1}
+	tasks, total, err := suite.TaskRepo.GetAll(context.Background(), filter)
+
+	// ASSERT: total counts every match, the page holds only one task
+	suite.Require().NoError(err, "GetAll should not return an error")
+	suite.Assert().Equal(int64(2), total, "total should count every matching task")
+	suite.Require().Len(tasks, 1, "the page should be limited to one task")
+	suite.Assert().Equal("Deploy api", tasks[0].Title, "the second page should hold the second title")
+}
+
+func (suite *InMemoryTaskRepoTestSuite) TestGetAll_OffsetPastEnd() {
+
+	// ARRANGE
+	suite.setupTask("1", "task one")
+
+	// ACT
+	tasks, total, err := suite.TaskRepo.GetAll(context.Background(), domain.TaskFilter{Offset: 5})
+
+	// ASSERT
+	suite.Require().NoError(err)
+	suite.Assert().Equal(int64(1), total)
+	suite.Assert().Empty(tasks, "a page past the end should be empty")
+}
+
+func (suite *InMemoryTaskRepoTestSuite) TestStream_IgnoresPaginationAndStopsOnError() {
+
+	// ARRANGE
+	suite.setupTask("1", "Deploy api")
+	suite.setupTask("2", "Deploy web")
+	suite.setupTask("3", "Write docs")
+
+	// ACT: every match is streamed in sort order, the page doesn't apply
+	var titles []string
+	filter := domain.TaskFilter{TitlePrefix: "deploy", SortBy: domain.TaskSortByTitle, SortDesc: true, Limit: 1, Offset: 1}
+	err := suite.TaskRepo.Stream(context.Background(), filter, func(task domain.Task) error {
+		titles = append(titles, task.Title)
+		return nil
+	})
+
+	// ASSERT
+	suite.Require().NoError(err)
+	suite.Assert().Equal([]string{"Deploy web", "Deploy api"}, titles)
+
+	// an error of the callback ends the stream and is returned
+	stop := errors.New("stop")
+	calls := 0
+	err = suite.TaskRepo.Stream(context.Background(), domain.TaskFilter{}, func(task domain.Task) error {
+		calls++
+		return stop
+	})
+	suite.Assert().ErrorIs(err, stop)
+	suite.Assert().Equal(1, calls)
+}
+
+func (suite *InMemoryTaskRepoTestSuite) TestUpdate_Success() {
+
+	// ARRANGE
+	intialTask := suite.setupTask("1", "test task")
+
+	// ACT
+	updatedTask, err := suite.TaskRepo.Update(context.Background(), intialTask.ID, bson.M{"title": "updated"}, 0)
+
+	// ASSERT: the field changed and the version was bumped
+	suite.Require().NoError(err, "Update shouldn't return an error on success")
+	suite.Assert().Equal("updated", updatedTask.Title)
+	suite.Assert().Equal(intialTask.Description, updatedTask.Description, "untouched fields should be kept")
+	suite.Assert().Equal(int64(1), updatedTask.Version, "the update should bump the version")
+}
+
+func (suite *InMemoryTaskRepoTestSuite) TestUpdate_VersionConflict() {
+
+	// ARRANGE
+	intialTask := suite.setupTask("1", "test task")
+
+	// ACT
+	_, err := suite.TaskRepo.Update(context.Background(), intialTask.ID, bson.M{"title": "second"}, 5)
+
+	// ASSERT
+	suite.Assert().True(errors.Is(err, domain.ErrConflict), "Error should be the domain.ErrConflict")
+}
+
+func (suite *InMemoryTaskRepoTestSuite) TestUpdate_NotFound() {
+
+	// ACT
+	_, err := suite.TaskRepo.Update(context.Background(), "1", bson.M{"title": "title"}, 0)
+
+	// ASSERT
+	suite.Assert().True(errors.Is(err, domain.ErrNotFound), "Error should be the domain.ErrNotFound")
+}
+
+func (suite *InMemoryTaskRepoTestSuite) TestDelete_Success() {
+
+	// ARRANGE
+	intialTask := suite.setupTask("1", "test task")
+
+	// ACT
+	err := suite.TaskRepo.Delete(context.Background(), intialTask.ID, 0, time.Now())
+
+	// ASSERT
+	suite.Require().NoError(err, "Delete shouldn't return an error for success")
+	_, err = suite.TaskRepo.GetByID(context.Background(), intialTask.ID)
+	suite.Assert().True(errors.Is(err, domain.ErrNotFound), "the task should be gone")
+}
+
+func (suite *InMemoryTaskRepoTestSuite) TestDelete_NotFound() {
+
+	// ACT
+	err := suite.TaskRepo.Delete(context.Background(), "1", 0, time.Now())
+
+	// ASSERT
+	suite.Assert().True(errors.Is(err, domain.ErrNotFound), "Error should be the domain.ErrNotFound")
+}
+
+func (suite *InMemoryTaskRepoTestSuite) TestDelete_HidesTaskFromQueries() {
+
+	// ARRANGE
+	suite.setupTask("1", "deleted task")
+	suite.setupTask("2", "live task")
+
+	// ACT
+	suite.Require().NoError(suite.TaskRepo.Delete(context.Background(), "1", 0, time.Now()))
+
+	// ASSERT: the task is gone from the list, updates and a second delete
+	tasks, total, err := suite.TaskRepo.GetAll(context.Background(), domain.TaskFilter{})
+	suite.Require().NoError(err)
+	suite.Assert().Equal(int64(1), total)
+	suite.Assert().Equal("2", tasks[0].ID)
+
+	_, err = suite.TaskRepo.Update(context.Background(), "1", bson.M{"title": "changed"}, 0)
+	suite.Assert().True(errors.Is(err, domain.ErrNotFound), "a deleted task can't be updated")
+
+	err = suite.TaskRepo.Delete(context.Background(), "1", 0, time.Now())
+	suite.Assert().True(errors.Is(err, domain.ErrNotFound), "a deleted task can't be deleted again")
+}
+
+func (suite *InMemoryTaskRepoTestSuite) TestListDeleted_NewestFirstWithCutoff() {
+
+	// ARRANGE
+	now := time.Now()
+	for i, id := range []string{"1", "2", "3"} {
+		suite.setupTask(id, "task "+id)
+		suite.Require().NoError(suite.TaskRepo.Delete(context.Background(), id, 0, now.Add(-time.Duration(i)*time.Hour)))
+	}
+	suite.setupTask("4", "live task")
+
+	// ACT
+	all, total, err := suite.TaskRepo.ListDeleted(context.Background(), time.Time{}, 2, 0)
+	suite.Require().NoError(err)
+	expired, _, err := suite.TaskRepo.ListDeleted(context.Background(), now.Add(-30*time.Minute), 0, 0)
+	suite.Require().NoError(err)
+
+	// ASSERT: task 1 was deleted last, only 2 and 3 were deleted before the cutoff
+	suite.Assert().Equal(int64(3), total)
+	suite.Require().Len(all, 2)
+	suite.Assert().Equal("1", all[0].ID)
+	suite.Assert().NotNil(all[0].DeletedAt)
+	suite.Require().Len(expired, 2)
+	suite.Assert().Equal("2", expired[0].ID)
+	suite.Assert().Equal("3", expired[1].ID)
+}
+
+func (suite *InMemoryTaskRepoTestSuite) TestRestore_Success() {
+
+	// ARRANGE
+	suite.setupTask("1", "deploy api")
+	suite.Require().NoError(suite.TaskRepo.Delete(context.Background(), "1", 0, time.Now()))
+
+	// ACT
+	restored, err := suite.TaskRepo.Restore(context.Background(), "1")
+
+	// ASSERT: the task is live and searchable again, each move bumped the version
+	suite.Require().NoError(err)
+	suite.Assert().Nil(restored.DeletedAt)
+	suite.Assert().Equal(int64(2), restored.Version)
+
+	task, err := suite.TaskRepo.GetByID(context.Background(), "1")
+	suite.Require().NoError(err)
+	suite.Assert().Equal(restored, task)
+
+	_, total := suite.search("deploy")
+	suite.Assert().Equal(int64(1), total)
+}
+
+func (suite *InMemoryTaskRepoTestSuite) TestRestore_NotInTrash() {
+
+	// ARRANGE
+	suite.setupTask("1", "live task")
+
+	// ACT
+	_, err := suite.TaskRepo.Restore(context.Background(), "1")
+
+	// ASSERT
+	suite.Assert().True(errors.Is(err, domain.ErrNotFound), "only deleted tasks can be restored")
+}
+
+func (suite *InMemoryTaskRepoTestSuite) TestPurge_OnlyFromTrash() {
+
+	// ARRANGE
+	suite.setupTask("1", "live task")
+	suite.setupTask("2", "deleted task")
+	suite.Require().NoError(suite.TaskRepo.Delete(context.Background(), "2", 0, time.Now()))
+
+	// ACT
+	liveErr := suite.TaskRepo.Purge(context.Background(), "1")
+	err := suite.TaskRepo.Purge(context.Background(), "2")
+
+	// ASSERT
+	suite.Assert().True(errors.Is(liveErr, domain.ErrNotFound), "a live task can't be purged")
+	suite.Require().NoError(err)
+	_, err = suite.TaskRepo.GetDeletedByID(context.Background(), "2")
+	suite.Assert().True(errors.Is(err, domain.ErrNotFound), "the purged task should be gone for good")
+}
+
+func (suite *InMemoryTaskRepoTestSuite) TestReplaceStatus_Success() {
+
+	// ARRANGE: insert tasks holding a legacy status
+	var created domain.Task
+	for _, id := range []string{"1", "2"} {
+		var err error
+		created, err = suite.TaskRepo.Create(context.Background(), domain.Task{ID: id, Title: "task", Status: "pending"})
+		suite.Require().NoError(err)
+	}
+
+	// ACT
+	modified, err := suite.TaskRepo.ReplaceStatus(context.Background(), "pending", domain.StatusTodo)
+
+	// ASSERT: both tasks are migrated and the legacy status is gone
+	suite.Require().NoError(err, "ReplaceStatus shouldn't return an error")
+	suite.Assert().Equal(int64(2), modified, "both tasks should be migrated")
+
+	statuses, err := suite.TaskRepo.DistinctStatuses(context.Background())
+	suite.Require().NoError(err, "DistinctStatuses shouldn't return an error")
+	suite.Assert().Equal([]string{"todo"}, statuses, "only the new status should remain")
+
+	// a migrated task has a new version, like any other change
+	migrated, err := suite.TaskRepo.GetByID(context.Background(), "2")
+	suite.Require().NoError(err)
+	suite.Assert().Equal(created.Version+1, migrated.Version, "the version should be bumped")
+}
+
+func (suite *InMemoryTaskRepoTestSuite) TestConcurrentUpdates() {
+
+	// ARRANGE
+	intialTask := suite.setupTask("1", "test task")
+
+	// ACT: update the same task from many goroutines at once
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := suite.TaskRepo.Update(context.Background(), intialTask.ID, bson.M{"title": fmt.Sprintf("title %d", i)}, 0)
+			suite.Assert().NoError(err)
+		}(i)
+	}
+	wg.Wait()
+
+	// ASSERT: no update was lost
+	storedTask, err := suite.TaskRepo.GetByID(context.Background(), intialTask.ID)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(int64(50), storedTask.Version, "every update should bump the version once")
+}
+
+func (suite *InMemoryTaskRepoTestSuite) TestGetByIDs_SkipsMissingAndDeleted() {
+
+	// ARRANGE
+	suite.setupTask("1", "first")
+	suite.setupTask("2", "second")
+	suite.Require().NoError(suite.TaskRepo.Delete(context.Background(), "2", 0, time.Now()))
+
+	// ACT
+	tasks, err := suite.TaskRepo.GetByIDs(context.Background(), []string{"1", "2", "99"})
+
+	// ASSERT
+	suite.Require().NoError(err)
+	suite.Require().Len(tasks, 1)
+	suite.Assert().Equal("1", tasks[0].ID)
+}
+
+func (suite *InMemoryTaskRepoTestSuite) TestBulkCreate_ReportsEachTask() {
+
+	// ARRANGE
+	suite.setupTask("1", "existing")
+	tasks := []domain.Task{
+		{ID: "2", Title: "new", Status: domain.StatusTodo, Version: 1},
+		{ID: "1", Title: "duplicate", Status: domain.StatusTodo, Version: 1},
+	}
+
+	// ACT
+	errs, err := suite.TaskRepo.BulkCreate(context.Background(), tasks, false)
+
+	// ASSERT: the valid task is stored even though the other one failed
+	suite.Require().NoError(err)
+	suite.Require().Len(errs, 2)
+	suite.Assert().NoError(errs[0])
+	suite.Assert().True(errors.Is(errs[1], domain.ErrAleadyExists))
+
+	_, err = suite.TaskRepo.GetByID(context.Background(), "2")
+	suite.Assert().NoError(err, "the valid task should be stored")
+}
+
+func (suite *InMemoryTaskRepoTestSuite) TestBulkCreate_AtomicStoresNothingOnFailure() {
+
+	// ARRANGE: the batch repeats an id
+	tasks := []domain.Task{
+		{ID: "1", Title: "first", Status: domain.StatusTodo, Version: 1},
+		{ID: "1", Title: "again", Status: domain.StatusTodo, Version: 1},
+	}
+
+	// ACT
+	errs, err := suite.TaskRepo.BulkCreate(context.Background(), tasks, true)
+
+	// ASSERT
+	suite.Require().NoError(err)
+	suite.Assert().NoError(errs[0])
+	suite.Assert().True(errors.Is(errs[1], domain.ErrAleadyExists))
+
+	_, err = suite.TaskRepo.GetByID(context.Background(), "1")
+	suite.Assert().True(errors.Is(err, domain.ErrNotFound), "an atomic batch with a failure should store nothing")
+}
+
+func (suite *InMemoryTaskRepoTestSuite) TestBulkUpdate_ReportsEachUpdate() {
+
+	// ARRANGE: setupTask stores tasks at version 0
+	suite.setupTask("1", "first")
+	suite.setupTask("2", "second")
+	updates := []repositories.TaskUpdate{
+		{ID: "1", Updates: bson.M{"status": domain.StatusInProgress}, ExpectedVersion: 0},
+		{ID: "2", Updates: bson.M{"status": domain.StatusInProgress}, ExpectedVersion: 3},
+		{ID: "99", Updates: bson.M{"status": domain.StatusInProgress}, ExpectedVersion: 0},
+	}
+
+	// ACT
+	errs, err := suite.TaskRepo.BulkUpdate(context.Background(), updates, false)
+
+	// ASSERT
+	suite.Require().NoError(err)
+	suite.Require().Len(errs, 3)
+	suite.Assert().NoError(errs[0])
+	suite.Assert().True(errors.Is(errs[1], domain.ErrConflict))
+	suite.Assert().True(errors.Is(errs[2], domain.ErrNotFound))
+
+	task, err := suite.TaskRepo.GetByID(context.Background(), "1")
+	suite.Require().NoError(err)
+	suite.Assert().Equal(domain.StatusInProgress, task.Status)
+	suite.Assert().Equal(int64(1), task.Version)
+}
+
+func (suite *InMemoryTaskRepoTestSuite) TestBulkUpdate_AtomicChangesNothingOnFailure() {
+
+	// ARRANGE
+	suite.setupTask("1", "first")
+	updates := []repositories.TaskUpdate{
+		{ID: "1", Updates: bson.M{"title": "renamed"}, ExpectedVersion: 0},
+		{ID: "99", Updates: bson.M{"title": "renamed"}, ExpectedVersion: 0},
+	}
+
+	// ACT
+	errs, err := suite.TaskRepo.BulkUpdate(context.Background(), updates, true)
+
+	// ASSERT
+	suite.Require().NoError(err)
+	suite.Assert().NoError(errs[0])
+	suite.Assert().True(errors.Is(errs[1], domain.ErrNotFound))
+
+	task, err := suite.TaskRepo.GetByID(context.Background(), "1")
+	suite.Require().NoError(err)
+	suite.Assert().Equal("first", task.Title, "an atomic batch with a failure should change nothing")
+}
+
+func (suite *InMemoryTaskRepoTestSuite) TestBulkUpdate_DeletedAtMovesToTrash() {
+
+	// ARRANGE
+	suite.setupTask("1", "deploy api")
+
+	// ACT
+	errs, err := suite.TaskRepo.BulkUpdate(context.Background(), []repositories.TaskUpdate{
+		{ID: "1", Updates: bson.M{"deleted_at": time.Now()}, ExpectedVersion: 0},
+	}, false)
+
+	// ASSERT: the task is in the trash and no longer searchable
+	suite.Require().NoError(err)
+	suite.Require().NoError(errs[0])
+
+	_, err = suite.TaskRepo.GetDeletedByID(context.Background(), "1")
+	suite.Assert().NoError(err)
+	_, total := suite.search("deploy")
+	suite.Assert().Equal(int64(0), total)
+}
+
+func (suite *InMemoryTaskRepoTestSuite) TestGetSubtasks_SkipsOtherParentsAndTrash() {
+
+	// ARRANGE
+	for _, task := range []domain.Task{
+		{ID: "root"},
+		{ID: "b", ParentID: "root", DueDate: time.Now().Add(time.Hour)},
+		{ID: "a", ParentID: "root", DueDate: time.Now()},
+		{ID: "trashed", ParentID: "root"},
+		{ID: "other", ParentID: "elsewhere"},
+	} {
+		_, err := suite.TaskRepo.Create(context.Background(), task)
+		suite.Require().NoError(err, "failed to insert a task during setup")
+	}
+	suite.Require().NoError(suite.TaskRepo.Delete(context.Background(), "trashed", 0, time.Now()))
+
+	// ACT
+	subtasks, err := suite.TaskRepo.GetSubtasks(context.Background(), []string{"root"})
+
+	// ASSERT: ordered by due date like a task list
+	suite.Require().NoError(err)
+	suite.Require().Len(subtasks, 2)
+	suite.Assert().Equal("a", subtasks[0].ID)
+	suite.Assert().Equal("b", subtasks[1].ID)
+}
+
+func (suite *InMemoryTaskRepoTestSuite) setupLabeledTasks() {
+	tasks := []domain.Task{
+		{ID: "1", Title: "api", Labels: []string{"backend", "urgent"}},
+		{ID: "2", Title: "ui", Labels: []string{"frontend"}},
+		{ID: "3", Title: "db", Labels: []string{"backend"}},
+	}
+	for _, task := range tasks {
+		_, err := suite.TaskRepo.Create(context.Background(), task)
+		suite.Require().NoError(err, "failed to insert a task during setup")
+	}
+}
+
+func (suite *InMemoryTaskRepoTestSuite) TestGetAll_FiltersByLabels() {
+
+	// ARRANGE
+	suite.setupLabeledTasks()
+
+	// ACT
+	anyOf, anyTotal, err := suite.TaskRepo.GetAll(context.Background(), domain.TaskFilter{LabelsAny: []string{"urgent", "frontend"}})
+	suite.Require().NoError(err)
+	allOf, allTotal, err := suite.TaskRepo.GetAll(context.Background(), domain.TaskFilter{LabelsAll: []string{"backend", "urgent"}})
+	suite.Require().NoError(err)
+
+	// ASSERT: any-of matches a task with one of the labels, all-of only a task with every label
+	suite.Assert().Equal(int64(2), anyTotal)
+	suite.Assert().ElementsMatch([]string{"1", "2"}, []string{anyOf[0].ID, anyOf[1].ID})
+	suite.Assert().Equal(int64(1), allTotal)
+	suite.Assert().Equal("1", allOf[0].ID)
+}
+
+func (suite *InMemoryTaskRepoTestSuite) TestRenameLabel_UpdatesEveryTask() {
+
+	// ARRANGE: task 1 already has the new name, so it must not end up twice
+	suite.setupLabeledTasks()
+	_, err := suite.TaskRepo.Update(context.Background(), "1", bson.M{"labels": []string{"backend", "server", "urgent"}}, 0)
+	suite.Require().NoError(err)
+
+	// ACT
+	renamed, err := suite.TaskRepo.RenameLabel(context.Background(), "backend", "server")
+
+	// ASSERT
+	suite.Require().NoError(err)
+	suite.Assert().Equal(int64(2), renamed)
+
+	first, err := suite.TaskRepo.GetByID(context.Background(), "1")
+	suite.Require().NoError(err)
+	suite.Assert().Equal([]string{"server", "urgent"}, first.Labels)
+	suite.Assert().Equal(int64(2), first.Version, "a rename should bump the version")
+
+	third, err := suite.TaskRepo.GetByID(context.Background(), "3")
+	suite.Require().NoError(err)
+	suite.Assert().Equal([]string{"server"}, third.Labels)
+}
+
+func (suite *InMemoryTaskRepoTestSuite) TestDropLabel_RemovesItFromEveryTask() {
+
+	// ARRANGE
+	suite.setupLabeledTasks()
+
+	// ACT
+	dropped, err := suite.TaskRepo.DropLabel(context.Background(), "backend")
+
+	// ASSERT
+	suite.Require().NoError(err)
+	suite.Assert().Equal(int64(2), dropped)
+
+	_, total, err := suite.TaskRepo.GetAll(context.Background(), domain.TaskFilter{LabelsAny: []string{"backend"}})
+	suite.Require().NoError(err)
+	suite.Assert().Equal(int64(0), total)
+
+	first, err := suite.TaskRepo.GetByID(context.Background(), "1")
+	suite.Require().NoError(err)
+	suite.Assert().Equal([]string{"urgent"}, first.Labels)
+}
+
+func (suite *InMemoryTaskRepoTestSuite) setupSearchTasks() {
+	tasks := []domain.Task{
+		{ID: "1", Title: "Deploy api", Description: "roll out the new api release"},
+		{ID: "2", Title: "Write release notes", Description: "notes for the api deploy"},
+		{ID: "3", Title: "Fix login", Description: "users are logged out after the deployment"},
+	}
+	for _, task := range tasks {
+		_, err := suite.TaskRepo.Create(context.Background(), task)
+		suite.Require().NoError(err)
+	}
+}
+
+func (suite *InMemoryTaskRepoTestSuite) search(raw string) ([]domain.TaskSearchHit, int64) {
+	query, err := domain.ParseTaskSearchQuery(raw)
+	suite.Require().NoError(err)
+
+	hits, total, err := suite.TaskRepo.Search(context.Background(), query)
+	suite.Require().NoError(err)
+
+	return hits, total
+}
+
+func hitIDs(hits []domain.TaskSearchHit) []string {
+	ids := make([]string, len(hits))
+	for i, hit := range hits {
+		ids[i] = hit.Task.ID
+	}
+	return ids
+}
+
+func (suite *InMemoryTaskRepoTestSuite) TestSearch_RanksTitleMatchesFirst() {
+
+	// ARRANGE
+	suite.setupSearchTasks()
+
+	// ACT
+	hits, total := suite.search("deploy")
+
+	// ASSERT: a title match outranks a description match, "deployment" is not "deploy"
+	suite.Assert().Equal(int64(2), total)
+	suite.Assert().Equal([]string{"1", "2"}, hitIDs(hits))
+	suite.Assert().Greater(hits[0].Score, hits[1].Score)
+}
+
+func (suite *InMemoryTaskRepoTestSuite) TestSearch_Phrase() {
+
+	// ARRANGE
+	suite.setupSearchTasks()
+
+	// ACT: both tasks contain the words, only one has them next to each other
+	hits, _ := suite.search(`"api release"`)
+
+	// ASSERT
+	suite.Assert().Equal([]string{"1"}, hitIDs(hits))
+}
+
+func (suite *InMemoryTaskRepoTestSuite) TestSearch_PrefixMustMatch() {
+
+	// ARRANGE
+	suite.setupSearchTasks()
+
+	// ACT: any of the words, but only tasks with a word starting with "deploy"
+	hits, _ := suite.search("api release deploy*")
+
+	// ASSERT: task 3 matches the prefix but none of the words
+	suite.Assert().ElementsMatch([]string{"1", "2"}, hitIDs(hits))
+
+	hits, _ = suite.search("deploy*")
+	suite.Assert().ElementsMatch([]string{"1", "2", "3"}, hitIDs(hits))
+}
+
+func (suite *InMemoryTaskRepoTestSuite) TestSearch_FollowsUpdatesAndDeletes() {
+
+	// ARRANGE
+	suite.setupSearchTasks()
+
+	// ACT: rename one task and delete another
+	_, err := suite.TaskRepo.Update(context.Background(), "3", bson.M{"title": "Fix deploy script"}, 0)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.TaskRepo.Delete(context.Background(), "1", 0, time.Now()))
+
+	hits, total := suite.search("deploy")
+
+	// ASSERT
+	suite.Assert().Equal(int64(2), total)
+	suite.Assert().Equal([]string{"3", "2"}, hitIDs(hits))
+}
+
+func (suite *InMemoryTaskRepoTestSuite) TestSearch_Paginates() {
+
+	// ARRANGE
+	suite.setupSearchTasks()
+
+	query, err := domain.ParseTaskSearchQuery("deploy*")
+	suite.Require().NoError(err)
+	query.Limit = 1
+	query.Offset = 1
+
+	// ACT
+	hits, total, err := suite.TaskRepo.Search(context.Background(), query)
+
+	// ASSERT
+	suite.Require().NoError(err)
+	suite.Assert().Equal(int64(3), total)
+	suite.Assert().Len(hits, 1)
+}
@@ -0,0 +1,108 @@
+package repositories_test
+
+import (
+	"context"
+	"errors"
+	domain "taskmanager/Domain"
+	repositories "taskmanager/Repositories"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type InMemoryTokenRepoTestSuite struct {
+	suite.Suite
+	TokenRepo repositories.TokenRepository // the repo we test
+}
+
+// SetupTest gives every test a fresh, empty repository
+func (suite *InMemoryTokenRepoTestSuite) SetupTest() {
+	suite.TokenRepo = repositories.NewInMemoryTokenRepository()
+}
+
+// helper to store a refresh token for setup
+func (suite *InMemoryTokenRepoTestSuite) setupRefreshToken(id, familyId, hash string) domain.RefreshToken {
+
+	token := domain.RefreshToken{
+		ID:        id,
+		FamilyID:  familyId,
+		UserID:    "user-1",
+		TokenHash: hash,
+		ExpiresAt: time.Now().UTC().Add(time.Hour).Truncate(time.Millisecond),
+		CreatedAt: time.Now().UTC().Truncate(time.Millisecond),
+	}
+
+	err := suite.TokenRepo.SaveRefreshToken(context.Background(), token)
+	suite.Require().NoError(err, "Failed to insert refresh token during setup")
+
+	return token
+}
+
+func TestInMemoryTokenRepoSuite(t *testing.T) {
+	suite.Run(t, new(InMemoryTokenRepoTestSuite))
+}
+
+func (suite *InMemoryTokenRepoTestSuite) TestGetRefreshTokenByHash() {
+
+	// ARRANGE
+	token := suite.setupRefreshToken("1", "family", "hash")
+
+	// ACT
+	storedToken, err := suite.TokenRepo.GetRefreshTokenByHash(context.Background(), "hash")
+	_, missingErr := suite.TokenRepo.GetRefreshTokenByHash(context.Background(), "unknown")
+
+	// ASSERT
+	suite.Require().NoError(err)
+	suite.Assert().Equal(token, storedToken)
+	suite.Assert().True(errors.Is(missingErr, domain.ErrNotFound), "error should be domain.ErrNotFound")
+}
+
+func (suite *InMemoryTokenRepoTestSuite) TestRotateRefreshToken_OnlyOnce() {
+
+	// ARRANGE
+	suite.setupRefreshToken("1", "family", "hash")
+
+	// ACT
+	firstErr := suite.TokenRepo.RotateRefreshToken(context.Background(), "1", "2")
+	secondErr := suite.TokenRepo.RotateRefreshToken(context.Background(), "1", "3")
+
+	// ASSERT
+	suite.Assert().NoError(firstErr, "the first rotation should succeed")
+	suite.Assert().True(errors.Is(secondErr, domain.ErrConflict), "a second rotation should conflict")
+}
+
+func (suite *InMemoryTokenRepoTestSuite) TestRevokeTokenFamily() {
+
+	// ARRANGE: two tokens in the family and one outside of it
+	suite.setupRefreshToken("1", "family", "hash-1")
+	suite.setupRefreshToken("2", "family", "hash-2")
+	suite.setupRefreshToken("3", "other", "hash-3")
+
+	// ACT
+	err := suite.TokenRepo.RevokeTokenFamily(context.Background(), "family")
+
+	// ASSERT
+	suite.Require().NoError(err)
+	for hash, revoked := range map[string]bool{"hash-1": true, "hash-2": true, "hash-3": false} {
+		token, err := suite.TokenRepo.GetRefreshTokenByHash(context.Background(), hash)
+		suite.Require().NoError(err)
+		suite.Assert().Equal(revoked, token.Revoked, "unexpected revocation state for %s", hash)
+	}
+}
+
+func (suite *InMemoryTokenRepoTestSuite) TestRevokeAccessToken() {
+
+	// ACT
+	err := suite.TokenRepo.RevokeAccessToken(context.Background(), "jti", time.Now().Add(time.Minute))
+	suite.Require().NoError(err)
+
+	revoked, err := suite.TokenRepo.IsAccessTokenRevoked(context.Background(), "jti")
+	suite.Require().NoError(err)
+	notRevoked, err := suite.TokenRepo.IsAccessTokenRevoked(context.Background(), "other")
+	suite.Require().NoError(err)
+
+	// ASSERT
+	suite.Assert().True(revoked)
+	suite.Assert().False(notRevoked)
+}
@@ -0,0 +1,177 @@
+package repositories_test
+
+import (
+	"context"
+	"errors"
+	domain "taskmanager/Domain"
+	repositories "taskmanager/Repositories"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type InMemoryUserRepoTestSuite struct {
+	suite.Suite
+	UserRepo repositories.UserRepository // the repo we test
+}
+
+// SetupTest gives every test a fresh, empty repository
+func (suite *InMemoryUserRepoTestSuite) SetupTest() {
+	suite.UserRepo = repositories.NewInMemoryUserRepository()
+}
+
+// helper to insert a user for setup
+func (suite *InMemoryUserRepoTestSuite) setupUser(userName string, role domain.UserRole) domain.User {
+
+	user := domain.User{
+		ID:             uuid.New(),
+		UserName:       userName,
+		HashedPassword: "password", // not actually hashed, just test data
+		Role:           role,
+	}
+
+	_, err := suite.UserRepo.SaveUser(context.Background(), user)
+	suite.Require().NoError(err, "Failed to insert user during setup")
+
+	return user
+}
+
+func TestInMemoryUserRepoSuite(t *testing.T) {
+	suite.Run(t, new(InMemoryUserRepoTestSuite))
+}
+
+func (suite *InMemoryUserRepoTestSuite) TestIsUserNameAvailable() {
+
+	// ARRANGE
+	suite.setupUser("takenuser", domain.RoleUser)
+
+	// ACT
+	availableErr := suite.UserRepo.IsUsernameAvailable(context.Background(), "freeuser")
+	takenErr := suite.UserRepo.IsUsernameAvailable(context.Background(), "takenuser")
+
+	// ASSERT
+	suite.Assert().NoError(availableErr, "user name should be available")
+	suite.Assert().True(errors.Is(takenErr, domain.ErrAleadyExists), "error should be domain.ErrAleadyExists")
+}
+
+func (suite *InMemoryUserRepoTestSuite) TestSaveUser_Fail_Duplicate() {
+
+	// ARRANGE
+	existing := suite.setupUser("takenuser", domain.RoleUser)
+
+	// ACT & ASSERT: neither the id nor the username can be saved twice
+	_, err := suite.UserRepo.SaveUser(context.Background(), domain.User{ID: existing.ID, UserName: "other"})
+	suite.Assert().ErrorIs(err, domain.ErrAleadyExists)
+
+	_, err = suite.UserRepo.SaveUser(context.Background(), domain.User{ID: uuid.New(), UserName: "takenuser"})
+	suite.Assert().ErrorIs(err, domain.ErrAleadyExists)
+}
+
+func (suite *InMemoryUserRepoTestSuite) TestIsDatabaseEmpty() {
+
+	// ACT & ASSERT: empty at first, not empty after a save
+	isEmpty, err := suite.UserRepo.IsDatabaseEmpty(context.Background())
+	suite.Require().NoError(err)
+	suite.Assert().True(isEmpty, "IsDatabaseEmpty should return true for empty database")
+
+	suite.setupUser("username", domain.RoleUser)
+
+	isEmpty, err = suite.UserRepo.IsDatabaseEmpty(context.Background())
+	suite.Require().NoError(err)
+	suite.Assert().False(isEmpty, "IsDatabaseEmpty should return false for non-empty database")
+}
+
+func (suite *InMemoryUserRepoTestSuite) TestDoesUserExist() {
+
+	// ARRANGE
+	insertedUser := suite.setupUser("username", domain.RoleAdmin)
+
+	// ACT
+	userId, password, role, err := suite.UserRepo.DoesUserExist(context.Background(), "username")
+	_, _, _, missingErr := suite.UserRepo.DoesUserExist(context.Background(), "nobody")
+
+	// ASSERT
+	suite.Require().NoError(err)
+	suite.Assert().Equal(insertedUser.ID.String(), userId)
+	suite.Assert().Equal(insertedUser.HashedPassword, password)
+	suite.Assert().Equal(domain.RoleAdmin, role)
+	suite.Assert().True(errors.Is(missingErr, domain.ErrNotFound), "error should be domain.ErrNotFound")
+}
+
+func (suite *InMemoryUserRepoTestSuite) TestPromoteUser() {
+
+	// ARRANGE
+	insertedUser := suite.setupUser("username", domain.RoleUser)
+
+	// ACT
+	promotedUser, err := suite.UserRepo.PromoteUser(context.Background(), insertedUser.ID.String())
+
+	// ASSERT: the returned and the stored user are both admins
+	suite.Require().NoError(err)
+	suite.Assert().Equal(domain.RoleAdmin, promotedUser.Role)
+
+	storedUser, err := suite.UserRepo.GetUserByID(context.Background(), insertedUser.ID.String())
+	suite.Require().NoError(err)
+	suite.Assert().Equal(domain.RoleAdmin, storedUser.Role)
+}
+
+func (suite *InMemoryUserRepoTestSuite) TestPromoteUser_NotFound() {
+
+	// ACT
+	_, err := suite.UserRepo.PromoteUser(context.Background(), uuid.NewString())
+
+	// ASSERT
+	suite.Assert().True(errors.Is(err, domain.ErrNotFound), "error should be domain.ErrNotFound")
+}
+
+func (suite *InMemoryUserRepoTestSuite) TestGetUserByID_InvalidId() {
+
+	// ACT
+	_, err := suite.UserRepo.GetUserByID(context.Background(), "not-a-uuid")
+
+	// ASSERT
+	suite.Assert().True(errors.Is(err, domain.ErrNotFound), "error should be domain.ErrNotFound")
+}
+
+func (suite *InMemoryUserRepoTestSuite) TestUpdatePassword() {
+
+	// ARRANGE
+	insertedUser := suite.setupUser("username", domain.RoleUser)
+	changedAt := time.Now().UTC().Truncate(time.Millisecond)
+
+	// ACT
+	updatedUser, err := suite.UserRepo.UpdatePassword(context.Background(), insertedUser.ID.String(), "new-hash", true, changedAt)
+	_, missingErr := suite.UserRepo.UpdatePassword(context.Background(), uuid.New().String(), "new-hash", true, changedAt)
+
+	// ASSERT: the returned and the stored user both have the new password
+	suite.Require().NoError(err)
+	suite.Assert().Equal("new-hash", updatedUser.HashedPassword)
+	suite.Assert().True(updatedUser.MustChangePassword)
+
+	storedUser, err := suite.UserRepo.GetUserByID(context.Background(), insertedUser.ID.String())
+	suite.Require().NoError(err)
+	suite.Assert().Equal("new-hash", storedUser.HashedPassword)
+	suite.Assert().True(storedUser.MustChangePassword)
+	suite.Assert().True(changedAt.Equal(storedUser.PasswordChangedAt))
+	suite.Assert().True(errors.Is(missingErr, domain.ErrNotFound), "error should be domain.ErrNotFound")
+}
+
+func (suite *InMemoryUserRepoTestSuite) TestReplacePasswordHash() {
+
+	// ARRANGE
+	insertedUser := suite.setupUser("username", domain.RoleUser)
+
+	// ACT: the second swap no longer finds the hash it expects
+	err := suite.UserRepo.ReplacePasswordHash(context.Background(), insertedUser.ID.String(), "password", "stronger")
+	staleErr := suite.UserRepo.ReplacePasswordHash(context.Background(), insertedUser.ID.String(), "password", "other")
+
+	// ASSERT
+	suite.Require().NoError(err)
+	suite.Assert().True(errors.Is(staleErr, domain.ErrConflict), "error should be domain.ErrConflict")
+
+	storedUser, err := suite.UserRepo.GetUserByID(context.Background(), insertedUser.ID.String())
+	suite.Require().NoError(err)
+	suite.Assert().Equal("stronger", storedUser.HashedPassword)
+}
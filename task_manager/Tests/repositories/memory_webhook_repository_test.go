@@ -0,0 +1,163 @@
+package repositories_test
+
+import (
+	"context"
+	"errors"
+	domain "taskmanager/Domain"
+	repositories "taskmanager/Repositories"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type InMemoryWebhookRepoTestSuite struct {
+	suite.Suite
+	WebhookRepo repositories.WebhookRepository // the repo we test
+}
+
+// SetupTest gives every test a fresh, empty repository
+func (suite *InMemoryWebhookRepoTestSuite) SetupTest() {
+	suite.WebhookRepo = repositories.NewInMemoryWebhookRepository()
+}
+
+// a helper to insert a webhook for setup
+func (suite *InMemoryWebhookRepoTestSuite) setupWebhook(id string) domain.Webhook {
+
+	webhook := domain.Webhook{
+		ID:        id,
+		URL:       "https://example.com/" + id,
+		Events:    []domain.WebhookEvent{domain.WebhookTaskCreated},
+		Secret:    "0123456789abcdef",
+		Active:    true,
+		CreatedAt: time.Now().UTC().Truncate(time.Millisecond),
+	}
+
+	_, err := suite.WebhookRepo.Create(context.Background(), webhook)
+	suite.Require().NoError(err, "failed to insert a webhook during setup")
+
+	return webhook
+}
+
+// a helper to log a delivery for setup, later deliveries get later timestamps
+func (suite *InMemoryWebhookRepoTestSuite) setupDelivery(id string, webhookId string, minute int, status domain.DeliveryStatus) {
+
+	delivery := domain.WebhookDelivery{
+		ID:        id,
+		WebhookID: webhookId,
+		Event:     domain.WebhookTaskCreated,
+		Payload:   []byte(`{}`),
+		Status:    status,
+		CreatedAt: time.Date(2025, 1, 1, 12, minute, 0, 0, time.UTC),
+	}
+
+	suite.Require().NoError(suite.WebhookRepo.SaveDelivery(context.Background(), delivery), "failed to insert a delivery during setup")
+}
+
+func TestInMemoryWebhookRepoSuite(t *testing.T) {
+	suite.Run(t, new(InMemoryWebhookRepoTestSuite))
+}
+
+func (suite *InMemoryWebhookRepoTestSuite) TestCreate_KeepsTheSecret() {
+
+	// ARRANGE
+	webhook := suite.setupWebhook("hook-1")
+
+	// ACT
+	stored, err := suite.WebhookRepo.GetByID(context.Background(), "hook-1")
+
+	// ASSERT: the secret isn't sent to clients but has to be stored for signing
+	suite.Require().NoError(err)
+	suite.Assert().Equal(webhook.Secret, stored.Secret)
+	suite.Assert().Equal(webhook.Events, stored.Events)
+}
+
+func (suite *InMemoryWebhookRepoTestSuite) TestUpdate_NotFound() {
+
+	// ACT
+	_, err := suite.WebhookRepo.Update(context.Background(), domain.Webhook{ID: "missing"})
+
+	// ASSERT
+	suite.Assert().True(errors.Is(err, domain.ErrNotFound))
+}
+
+func (suite *InMemoryWebhookRepoTestSuite) TestSaveDelivery_ReplacesEarlierState() {
+
+	// ARRANGE
+	suite.setupWebhook("hook-1")
+	suite.setupDelivery("d-1", "hook-1", 0, domain.DeliveryPending)
+
+	// ACT
+	delivery, err := suite.WebhookRepo.GetDelivery(context.Background(), "d-1")
+	suite.Require().NoError(err)
+	delivery.Status = domain.DeliverySucceeded
+	delivery.Attempts = 2
+	suite.Require().NoError(suite.WebhookRepo.SaveDelivery(context.Background(), delivery))
+
+	// ASSERT
+	stored, err := suite.WebhookRepo.GetDelivery(context.Background(), "d-1")
+	suite.Require().NoError(err)
+	suite.Assert().Equal(domain.DeliverySucceeded, stored.Status)
+	suite.Assert().Equal(2, stored.Attempts)
+}
+
+func (suite *InMemoryWebhookRepoTestSuite) TestListDeliveries_NewestFirstPerWebhook() {
+
+	// ARRANGE
+	suite.setupWebhook("hook-1")
+	suite.setupWebhook("hook-2")
+	suite.setupDelivery("d-1", "hook-1", 0, domain.DeliverySucceeded)
+	suite.setupDelivery("d-2", "hook-2", 1, domain.DeliverySucceeded)
+	suite.setupDelivery("d-3", "hook-1", 2, domain.DeliveryFailed)
+	suite.setupDelivery("d-4", "hook-1", 3, domain.DeliveryPending)
+
+	// ACT
+	deliveries, total, err := suite.WebhookRepo.ListDeliveries(context.Background(), "hook-1", 2, 1)
+
+	// ASSERT
+	suite.Require().NoError(err)
+	suite.Assert().Equal(int64(3), total)
+	suite.Require().Len(deliveries, 2)
+	suite.Assert().Equal("d-3", deliveries[0].ID)
+	suite.Assert().Equal("d-1", deliveries[1].ID)
+}
+
+func (suite *InMemoryWebhookRepoTestSuite) TestGetPendingDeliveries() {
+
+	// ARRANGE
+	suite.setupWebhook("hook-1")
+	suite.setupDelivery("d-1", "hook-1", 0, domain.DeliverySucceeded)
+	suite.setupDelivery("d-2", "hook-1", 1, domain.DeliveryPending)
+
+	// ACT
+	pending, err := suite.WebhookRepo.GetPendingDeliveries(context.Background())
+
+	// ASSERT
+	suite.Require().NoError(err)
+	suite.Require().Len(pending, 1)
+	suite.Assert().Equal("d-2", pending[0].ID)
+}
+
+func (suite *InMemoryWebhookRepoTestSuite) TestDelete_RemovesTheDeliveryLog() {
+
+	// ARRANGE
+	suite.setupWebhook("hook-1")
+	suite.setupWebhook("hook-2")
+	suite.setupDelivery("d-1", "hook-1", 0, domain.DeliverySucceeded)
+	suite.setupDelivery("d-2", "hook-2", 1, domain.DeliverySucceeded)
+
+	// ACT
+	err := suite.WebhookRepo.Delete(context.Background(), "hook-1")
+
+	// ASSERT
+	suite.Require().NoError(err)
+	_, err = suite.WebhookRepo.GetByID(context.Background(), "hook-1")
+	suite.Assert().True(errors.Is(err, domain.ErrNotFound))
+	_, err = suite.WebhookRepo.GetDelivery(context.Background(), "d-1")
+	suite.Assert().True(errors.Is(err, domain.ErrNotFound))
+	_, err = suite.WebhookRepo.GetDelivery(context.Background(), "d-2")
+	suite.Assert().NoError(err)
+
+	err = suite.WebhookRepo.Delete(context.Background(), "hook-1")
+	suite.Assert().True(errors.Is(err, domain.ErrNotFound))
+}
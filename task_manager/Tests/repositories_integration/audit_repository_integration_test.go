@@ -0,0 +1,143 @@
+package repositoriesintegration
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	domain "taskmanager/Domain"
+	repositories "taskmanager/Repositories"
+	"testing"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type AuditRepoTestSuite struct {
+	suite.Suite                              // to use suite functionality from testify
+	AuditRepo   repositories.AuditRepository // the repo we test
+	Client      *mongo.Client                // mongo client
+	DBName      string                       // test db name
+}
+
+func (suite *AuditRepoTestSuite) SetupSuite() {
+
+	// Load variables from .env file
+	if err := godotenv.Load("../../config/.env"); err != nil {
+		log.Println("Note: No .env file found, relying on system environment variables.")
+	}
+
+	mongoURI := os.Getenv("MONGO_URI")
+	if mongoURI == "" {
+		log.Fatal("FATAL: MONGO_URI environment variable is not set. Cannot connect to database.")
+	}
+
+	suite.DBName = os.Getenv("MONGO_TEST_DB_NAME")
+	if suite.DBName == "" {
+		suite.DBName = "task_manager_db_test"
+	}
+
+	// connect to mongoDB
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI))
+	if err != nil {
+		log.Fatal("FATAL: unable to connect to test database")
+	}
+
+	// Ping to ensure connection is live
+	if err := client.Ping(ctx, nil); err != nil {
+		log.Fatalf("FATAL: MongoDB ping failed: %v", err)
+	}
+
+	suite.Client = client
+	suite.AuditRepo, err = repositories.NewMongoAuditRepository(suite.Client, suite.DBName, "audit_log")
+	if err != nil {
+		log.Fatalf("FATAL: failed to set up the repository: %v", err)
+	}
+}
+
+func (suite *AuditRepoTestSuite) TearDownSuite() {
+
+	// CLEANUP: Drop the entire test database to ensure a clean slate.
+	suite.Client.Database(suite.DBName).Drop(context.Background())
+
+	// close the connection
+	suite.Client.Disconnect(context.Background())
+}
+
+// TearDownTest clears the audit collection after every test
+func (suite *AuditRepoTestSuite) TearDownTest() {
+	_, err := suite.Client.Database(suite.DBName).Collection("audit_log").DeleteMany(context.Background(), bson.D{})
+	if err != nil {
+		log.Printf("Warning: Failed to clear audit collection after test: %v", err)
+	}
+}
+
+// helper to append an entry about the given task
+func (suite *AuditRepoTestSuite) setupEntry(entryId string, action domain.AuditAction, targetId string, timestamp time.Time) domain.AuditEntry {
+
+	entry := domain.AuditEntry{
+		ID:         entryId,
+		ActorID:    "admin-1",
+		Action:     action,
+		TargetType: domain.AuditTargetTask,
+		TargetID:   targetId,
+		After:      json.RawMessage(`{"id":"` + targetId + `"}`),
+		Timestamp:  timestamp.UTC().Truncate(time.Millisecond),
+	}
+
+	err := suite.AuditRepo.Append(context.Background(), entry)
+	suite.Require().NoError(err, "failed to append an audit entry during setup")
+
+	return entry
+}
+
+func TestAuditRepoSuite(t *testing.T) {
+	suite.Run(t, new(AuditRepoTestSuite))
+}
+
+func (suite *AuditRepoTestSuite) TestQuery_NewestFirstAndFiltered() {
+
+	// ARRANGE
+	now := time.Now()
+	first := suite.setupEntry("e1", domain.AuditTaskCreated, "task-1", now)
+	suite.setupEntry("e2", domain.AuditTaskUpdated, "task-1", now.Add(time.Minute))
+	suite.setupEntry("e3", domain.AuditTaskUpdated, "task-2", now.Add(2*time.Minute))
+
+	// ACT
+	entries, total, err := suite.AuditRepo.Query(context.Background(), domain.AuditFilter{TargetID: "task-1"})
+
+	// ASSERT: the raw JSON snapshots survive the round trip
+	suite.Require().NoError(err)
+	suite.Assert().Equal(int64(2), total)
+	suite.Require().Len(entries, 2)
+	suite.Assert().Equal("e2", entries[0].ID)
+	suite.Assert().Equal(first, entries[1])
+}
+
+func (suite *AuditRepoTestSuite) TestStream_OldestFirstWithinRange() {
+
+	// ARRANGE
+	now := time.Now()
+	suite.setupEntry("e1", domain.AuditTaskCreated, "task-1", now)
+	suite.setupEntry("e2", domain.AuditTaskUpdated, "task-1", now.Add(time.Minute))
+	suite.setupEntry("e3", domain.AuditTaskDeleted, "task-1", now.Add(2*time.Minute))
+
+	// ACT
+	var ids []string
+	filter := domain.AuditFilter{From: now.Add(time.Minute).Truncate(time.Millisecond)}
+	err := suite.AuditRepo.Stream(context.Background(), filter, func(entry domain.AuditEntry) error {
+		ids = append(ids, entry.ID)
+		return nil
+	})
+
+	// ASSERT
+	suite.Require().NoError(err)
+	suite.Assert().Equal([]string{"e2", "e3"}, ids)
+}
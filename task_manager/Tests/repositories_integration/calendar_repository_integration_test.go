@@ -0,0 +1,125 @@
+package repositoriesintegration
+
+import (
+	"context"
+	"log"
+	"os"
+	domain "taskmanager/Domain"
+	repositories "taskmanager/Repositories"
+	"testing"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type CalendarFeedRepoTestSuite struct {
+	suite.Suite                                     // to use suite functionality from testify
+	FeedRepo    repositories.CalendarFeedRepository // the repo we test
+	Client      *mongo.Client                       // mongo client
+	DBName      string                              // test db name
+}
+
+func (suite *CalendarFeedRepoTestSuite) SetupSuite() {
+
+	// Load variables from .env file
+	if err := godotenv.Load("../../config/.env"); err != nil {
+		log.Println("Note: No .env file found, relying on system environment variables.")
+	}
+
+	mongoURI := os.Getenv("MONGO_URI")
+	if mongoURI == "" {
+		log.Fatal("FATAL: MONGO_URI environment variable is not set. Cannot connect to database.")
+	}
+
+	suite.DBName = os.Getenv("MONGO_TEST_DB_NAME")
+	if suite.DBName == "" {
+		suite.DBName = "task_manager_db_test"
+	}
+
+	// connect to mongoDB
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI))
+	if err != nil {
+		log.Fatal("FATAL: unable to connect to test database")
+	}
+
+	// Ping to ensure connection is live
+	if err := client.Ping(ctx, nil); err != nil {
+		log.Fatalf("FATAL: MongoDB ping failed: %v", err)
+	}
+
+	suite.Client = client
+	suite.FeedRepo, err = repositories.NewMongoCalendarFeedRepository(suite.Client, suite.DBName, "calendar_feeds")
+	if err != nil {
+		log.Fatalf("FATAL: failed to set up the repository: %v", err)
+	}
+}
+
+func (suite *CalendarFeedRepoTestSuite) TearDownSuite() {
+
+	// CLEANUP: Drop the entire test database to ensure a clean slate.
+	suite.Client.Database(suite.DBName).Drop(context.Background())
+
+	// close the connection
+	suite.Client.Disconnect(context.Background())
+}
+
+// TearDownTest clears the calendar feed collection after every test
+func (suite *CalendarFeedRepoTestSuite) TearDownTest() {
+	_, err := suite.Client.Database(suite.DBName).Collection("calendar_feeds").DeleteMany(context.Background(), bson.D{})
+	if err != nil {
+		log.Printf("Warning: Failed to clear calendar feed collection after test: %v", err)
+	}
+}
+
+func TestCalendarFeedRepoSuite(t *testing.T) {
+	suite.Run(t, new(CalendarFeedRepoTestSuite))
+}
+
+func (suite *CalendarFeedRepoTestSuite) TestSaveFeed_ReplacesTheFeedOfAUser() {
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// ARRANGE
+	createdAt := time.Now().UTC().Truncate(time.Millisecond)
+	suite.Require().NoError(suite.FeedRepo.SaveFeed(ctx, domain.CalendarFeed{UserID: "user-1", TokenHash: "old", CreatedAt: createdAt}))
+
+	// ACT: a new secret replaces the old one
+	suite.Require().NoError(suite.FeedRepo.SaveFeed(ctx, domain.CalendarFeed{UserID: "user-1", TokenHash: "new", CreatedAt: createdAt}))
+
+	// ASSERT
+	feed, err := suite.FeedRepo.GetFeedByHash(ctx, "new")
+	suite.Require().NoError(err)
+	suite.Assert().Equal(domain.CalendarFeed{UserID: "user-1", TokenHash: "new", CreatedAt: createdAt}, feed)
+
+	_, err = suite.FeedRepo.GetFeedByHash(ctx, "old")
+	suite.Assert().ErrorIs(err, domain.ErrNotFound)
+
+	count, err := suite.Client.Database(suite.DBName).Collection("calendar_feeds").CountDocuments(ctx, bson.M{})
+	suite.Require().NoError(err)
+	suite.Assert().Equal(int64(1), count)
+}
+
+func (suite *CalendarFeedRepoTestSuite) TestDeleteFeed_NotFound() {
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// ARRANGE
+	suite.Require().NoError(suite.FeedRepo.SaveFeed(ctx, domain.CalendarFeed{UserID: "user-1", TokenHash: "hash"}))
+
+	// ACT
+	suite.Require().NoError(suite.FeedRepo.DeleteFeed(ctx, "user-1"))
+
+	// ASSERT: the feed is gone, deleting it again fails
+	_, err := suite.FeedRepo.GetFeedByUser(ctx, "user-1")
+	suite.Assert().ErrorIs(err, domain.ErrNotFound)
+	suite.Assert().ErrorIs(suite.FeedRepo.DeleteFeed(ctx, "user-1"), domain.ErrNotFound)
+}
@@ -0,0 +1,167 @@
+package repositoriesintegration
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	domain "taskmanager/Domain"
+	repositories "taskmanager/Repositories"
+	"testing"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type CommentRepoTestSuite struct {
+	suite.Suite                                // to use suite functionality from testify
+	CommentRepo repositories.CommentRepository // the repo we test
+	Client      *mongo.Client                  // mongo client
+	DBName      string                         // test db name
+}
+
+func (suite *CommentRepoTestSuite) SetupSuite() {
+
+	// Load variables from .env file
+	if err := godotenv.Load("../../config/.env"); err != nil {
+		log.Println("Note: No .env file found, relying on system environment variables.")
+	}
+
+	mongoURI := os.Getenv("MONGO_URI")
+	if mongoURI == "" {
+		log.Fatal("FATAL: MONGO_URI environment variable is not set. Cannot connect to database.")
+	}
+
+	suite.DBName = os.Getenv("MONGO_TEST_DB_NAME")
+	if suite.DBName == "" {
+		suite.DBName = "task_manager_db_test"
+	}
+
+	// connect to mongoDB
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI))
+	if err != nil {
+		log.Fatal("FATAL: unable to connect to test database")
+	}
+
+	// Ping to ensure connection is live
+	if err := client.Ping(ctx, nil); err != nil {
+		log.Fatalf("FATAL: MongoDB ping failed: %v", err)
+	}
+
+	suite.Client = client
+	suite.CommentRepo, err = repositories.NewMongoCommentRepository(suite.Client, suite.DBName, "comments")
+	if err != nil {
+		log.Fatalf("FATAL: failed to set up the repository: %v", err)
+	}
+}
+
+func (suite *CommentRepoTestSuite) TearDownSuite() {
+
+	// CLEANUP: Drop the entire test database to ensure a clean slate.
+	suite.Client.Database(suite.DBName).Drop(context.Background())
+
+	// close the connection
+	suite.Client.Disconnect(context.Background())
+}
+
+// TearDownTest clears the comments collection after every test
+func (suite *CommentRepoTestSuite) TearDownTest() {
+	_, err := suite.Client.Database(suite.DBName).Collection("comments").DeleteMany(context.Background(), bson.D{})
+	if err != nil {
+		log.Printf("Warning: Failed to clear comments collection after test: %v", err)
+	}
+}
+
+// helper to store a comment on the given task
+func (suite *CommentRepoTestSuite) setupComment(commentId, taskId string, createdAt time.Time) domain.Comment {
+
+	comment := domain.Comment{
+		ID:        commentId,
+		TaskID:    taskId,
+		AuthorID:  "author-1",
+		Body:      "comment " + commentId,
+		CreatedAt: createdAt.Truncate(time.Millisecond),
+		UpdatedAt: createdAt.Truncate(time.Millisecond),
+	}
+
+	_, err := suite.CommentRepo.Create(context.Background(), comment)
+	suite.Require().NoError(err, "failed to save a comment during setup")
+
+	return comment
+}
+
+func (suite *CommentRepoTestSuite) TestListByTask_OldestFirstAndPaginated() {
+
+	// ARRANGE
+	now := time.Now()
+	suite.setupComment("c2", "task-1", now.Add(time.Minute))
+	suite.setupComment("c1", "task-1", now)
+	suite.setupComment("other", "task-2", now)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	// ACT
+	comments, total, err := suite.CommentRepo.ListByTask(ctx, "task-1", 1, 0)
+
+	// ASSERT
+	suite.Require().NoError(err)
+	suite.Assert().Equal(int64(2), total)
+	suite.Require().Len(comments, 1)
+	suite.Assert().Equal("c1", comments[0].ID)
+}
+
+func (suite *CommentRepoTestSuite) TestUpdateAndDelete_ScopedToTask() {
+
+	// ARRANGE
+	suite.setupComment("c1", "task-1", time.Now())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	// ACT & ASSERT: the comment can't be reached through another task
+	_, err := suite.CommentRepo.Update(ctx, "task-2", "c1", "edited", time.Now())
+	suite.True(errors.Is(err, domain.ErrNotFound))
+	suite.True(errors.Is(suite.CommentRepo.Delete(ctx, "task-2", "c1"), domain.ErrNotFound))
+
+	updated, err := suite.CommentRepo.Update(ctx, "task-1", "c1", "edited", time.Now())
+	suite.Require().NoError(err)
+	suite.Equal("edited", updated.Body)
+	suite.NoError(suite.CommentRepo.Delete(ctx, "task-1", "c1"))
+}
+
+func (suite *CommentRepoTestSuite) TestDeleteByTask_KeepsOtherTasks() {
+
+	// ARRANGE
+	now := time.Now()
+	suite.setupComment("c1", "task-1", now)
+	suite.setupComment("c2", "task-1", now)
+	suite.setupComment("other", "task-2", now)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	// ACT
+	err := suite.CommentRepo.DeleteByTask(ctx, "task-1")
+
+	// ASSERT
+	suite.Require().NoError(err)
+	_, total, err := suite.CommentRepo.ListByTask(ctx, "task-1", 0, 0)
+	suite.Require().NoError(err)
+	suite.Equal(int64(0), total)
+	_, total, err = suite.CommentRepo.ListByTask(ctx, "task-2", 0, 0)
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), total)
+	suite.NoError(suite.CommentRepo.DeleteByTask(ctx, "task-3"))
+}
+
+func TestCommentRepoSuite(t *testing.T) {
+	suite.Run(t, new(CommentRepoTestSuite))
+}
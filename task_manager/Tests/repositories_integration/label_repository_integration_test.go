@@ -0,0 +1,117 @@
+package repositoriesintegration
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	domain "taskmanager/Domain"
+	repositories "taskmanager/Repositories"
+	"testing"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type LabelRepoTestSuite struct {
+	suite.Suite                              // to use suite functionality from testify
+	LabelRepo   repositories.LabelRepository // the repo we test
+	Client      *mongo.Client                // mongo client
+	DBName      string                       // test db name
+}
+
+func (suite *LabelRepoTestSuite) SetupSuite() {
+
+	// Load variables from .env file
+	if err := godotenv.Load("../../config/.env"); err != nil {
+		log.Println("Note: No .env file found, relying on system environment variables.")
+	}
+
+	mongoURI := os.Getenv("MONGO_URI")
+	if mongoURI == "" {
+		log.Fatal("FATAL: MONGO_URI environment variable is not set. Cannot connect to database.")
+	}
+
+	suite.DBName = os.Getenv("MONGO_TEST_DB_NAME")
+	if suite.DBName == "" {
+		suite.DBName = "task_manager_db_test"
+	}
+
+	// connect to mongoDB
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI))
+	if err != nil {
+		log.Fatal("FATAL: unable to connect to test database")
+	}
+
+	// Ping to ensure connection is live
+	if err := client.Ping(ctx, nil); err != nil {
+		log.Fatalf("FATAL: MongoDB ping failed: %v", err)
+	}
+
+	suite.Client = client
+	suite.LabelRepo, err = repositories.NewMongoLabelRepository(suite.Client, suite.DBName, "labels")
+	if err != nil {
+		log.Fatalf("FATAL: failed to set up the repository: %v", err)
+	}
+}
+
+func (suite *LabelRepoTestSuite) TearDownSuite() {
+
+	// CLEANUP: Drop the entire test database to ensure a clean slate.
+	suite.Client.Database(suite.DBName).Drop(context.Background())
+
+	// close the connection
+	suite.Client.Disconnect(context.Background())
+}
+
+// TearDownTest clears the label collection after every test
+func (suite *LabelRepoTestSuite) TearDownTest() {
+	_, err := suite.Client.Database(suite.DBName).Collection("labels").DeleteMany(context.Background(), bson.D{})
+	if err != nil {
+		log.Printf("Warning: Failed to clear label collection after test: %v", err)
+	}
+}
+
+func TestLabelRepoSuite(t *testing.T) {
+	suite.Run(t, new(LabelRepoTestSuite))
+}
+
+func (suite *LabelRepoTestSuite) TestCreateRenameAndDelete() {
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// ARRANGE
+	_, err := suite.LabelRepo.Create(ctx, domain.Label{Name: "bug", Color: domain.DefaultLabelColor})
+	suite.Require().NoError(err)
+	_, err = suite.LabelRepo.Create(ctx, domain.Label{Name: "api", Color: domain.DefaultLabelColor})
+	suite.Require().NoError(err)
+
+	// ACT & ASSERT: names are unique
+	_, err = suite.LabelRepo.Create(ctx, domain.Label{Name: "bug"})
+	suite.Assert().True(errors.Is(err, domain.ErrAleadyExists))
+
+	_, err = suite.LabelRepo.Update(ctx, "bug", domain.Label{Name: "api"})
+	suite.Assert().True(errors.Is(err, domain.ErrAleadyExists))
+
+	// ACT: rename
+	_, err = suite.LabelRepo.Update(ctx, "bug", domain.Label{Name: "defect", Color: "#ff0000"})
+	suite.Require().NoError(err)
+
+	// ASSERT: sorted by name, only the new name is left
+	labels, err := suite.LabelRepo.GetAll(ctx)
+	suite.Require().NoError(err)
+	suite.Require().Len(labels, 2)
+	suite.Assert().Equal("api", labels[0].Name)
+	suite.Assert().Equal("defect", labels[1].Name)
+
+	suite.Require().NoError(suite.LabelRepo.Delete(ctx, "defect"))
+	suite.Assert().True(errors.Is(suite.LabelRepo.Delete(ctx, "defect"), domain.ErrNotFound))
+}
@@ -0,0 +1,106 @@
+package repositoriesintegration
+
+import (
+	"context"
+	"log"
+	"os"
+	domain "taskmanager/Domain"
+	repositories "taskmanager/Repositories"
+	"testing"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ReminderRepoTestSuite struct {
+	suite.Suite                                  // to use suite functionality from testify
+	ReminderRepo repositories.ReminderRepository // the repo we test
+	Client       *mongo.Client                   // mongo client
+	DBName       string                          // test db name
+}
+
+func (suite *ReminderRepoTestSuite) SetupSuite() {
+
+	// Load variables from .env file
+	if err := godotenv.Load("../../config/.env"); err != nil {
+		log.Println("Note: No .env file found, relying on system environment variables.")
+	}
+
+	mongoURI := os.Getenv("MONGO_URI")
+	if mongoURI == "" {
+		log.Fatal("FATAL: MONGO_URI environment variable is not set. Cannot connect to database.")
+	}
+
+	suite.DBName = os.Getenv("MONGO_TEST_DB_NAME")
+	if suite.DBName == "" {
+		suite.DBName = "task_manager_db_test"
+	}
+
+	// connect to mongoDB
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI))
+	if err != nil {
+		log.Fatal("FATAL: unable to connect to test database")
+	}
+
+	// Ping to ensure connection is live
+	if err := client.Ping(ctx, nil); err != nil {
+		log.Fatalf("FATAL: MongoDB ping failed: %v", err)
+	}
+
+	suite.Client = client
+	suite.ReminderRepo, err = repositories.NewMongoReminderRepository(suite.Client, suite.DBName, "reminders")
+	if err != nil {
+		log.Fatalf("FATAL: failed to set up the repository: %v", err)
+	}
+}
+
+func (suite *ReminderRepoTestSuite) TearDownSuite() {
+
+	// CLEANUP: Drop the entire test database to ensure a clean slate.
+	suite.Client.Database(suite.DBName).Drop(context.Background())
+
+	// close the connection
+	suite.Client.Disconnect(context.Background())
+}
+
+// TearDownTest clears the reminder collection after every test
+func (suite *ReminderRepoTestSuite) TearDownTest() {
+	_, err := suite.Client.Database(suite.DBName).Collection("reminders").DeleteMany(context.Background(), bson.D{})
+	if err != nil {
+		log.Printf("Warning: Failed to clear reminder collection after test: %v", err)
+	}
+}
+
+func TestReminderRepoSuite(t *testing.T) {
+	suite.Run(t, new(ReminderRepoTestSuite))
+}
+
+func (suite *ReminderRepoTestSuite) TestMarkSent_OncePerKey() {
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// ARRANGE
+	reminder := domain.NewReminder(domain.ReminderOverdue, domain.Task{ID: "1", DueDate: time.Now()})
+	reminder.SentAt = time.Now().UTC().Truncate(time.Millisecond)
+
+	// ACT: recording the same reminder twice keeps one record
+	suite.Require().NoError(suite.ReminderRepo.MarkSent(ctx, reminder))
+	suite.Require().NoError(suite.ReminderRepo.MarkSent(ctx, reminder))
+
+	// ASSERT
+	sent, err := suite.ReminderRepo.WasSent(ctx, reminder.Key)
+	suite.Require().NoError(err)
+	suite.Assert().True(sent)
+
+	count, err := suite.Client.Database(suite.DBName).Collection("reminders").CountDocuments(ctx, bson.M{})
+	suite.Require().NoError(err)
+	suite.Assert().Equal(int64(1), count)
+}
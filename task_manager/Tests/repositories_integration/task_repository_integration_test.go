@@ -63,7 +63,10 @@ func (suite *TaskRepoTestSuite) SetupSuite() {
 	// assign the dependencies we need as the suite properties
 	suite.Client = client
 
-	suite.TaskRepo = repositories.NewMongoTaskRepository(suite.Client, suite.DBName, "tasks")
+	suite.TaskRepo, err = repositories.NewMongoTaskRepository(suite.Client, suite.DBName, "tasks")
+	if err != nil {
+		log.Fatalf("FATAL: failed to set up the repository: %v", err)
+	}
 
 }
 
@@ -153,11 +156,56 @@ func (suite *TaskRepoTestSuite) TestGetAll_Success() {
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
 
-	tasks, err := suite.TaskRepo.GetAll(ctx)
+	tasks, total, err := suite.TaskRepo.GetAll(ctx, domain.TaskFilter{})
 
 	// ASSERT: check the count and error
 	suite.Assert().NoError(err, "GetAll should not return an error")
 	suite.Assert().Len(tasks, 3, "GetAll should exactly return three tasks")
+	suite.Assert().Equal(int64(3), total, "GetAll should report three matching tasks")
+}
+
+func (suite *TaskRepoTestSuite) TestGetAll_FilterSortAndPaginate() {
+
+	// ARRANGE: Insert tasks whose titles share a prefix
+	suite.setupTask("1", "Deploy api")
+	suite.setupTask("2", "Deploy web")
+	suite.setupTask("3", "Write docs")
+
+	// ACT: ask for the second page of "deploy" tasks sorted by title descending
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	filter := domain.TaskFilter{TitlePrefix: "deploy", SortBy: "title", SortDesc: true, Limit: 1, Offset: 1}
+	tasks, total, err := suite.TaskRepo.GetAll(ctx, filter)
+
+	// ASSERT: total counts every match, the page holds only one task
+	suite.Require().NoError(err, "GetAll should not return an error")
+	suite.Assert().Equal(int64(2), total, "total should count every matching task")
+	suite.Require().Len(tasks, 1, "the page should be limited to one task")
+	suite.Assert().Equal("Deploy api", tasks[0].Title, "the second page should hold the second title")
+}
+
+func (suite *TaskRepoTestSuite) TestStream_IgnoresPagination() {
+
+	// ARRANGE
+	suite.setupTask("1", "Deploy api")
+	suite.setupTask("2", "Deploy web")
+	suite.setupTask("3", "Write docs")
+
+	// ACT: every match is streamed in sort order, the page doesn't apply
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	var titles []string
+	filter := domain.TaskFilter{TitlePrefix: "deploy", SortBy: "title", SortDesc: true, Limit: 1, Offset: 1}
+	err := suite.TaskRepo.Stream(ctx, filter, func(task domain.Task) error {
+		titles = append(titles, task.Title)
+		return nil
+	})
+
+	// ASSERT
+	suite.Require().NoError(err, "Stream should not return an error")
+	suite.Assert().Equal([]string{"Deploy web", "Deploy api"}, titles)
 }
 
 func (suite *TaskRepoTestSuite) TestGetAll_Empty() {
@@ -168,7 +216,7 @@ func (suite *TaskRepoTestSuite) TestGetAll_Empty() {
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
 
-	tasks, err := suite.TaskRepo.GetAll(ctx)
+	tasks, _, err := suite.TaskRepo.GetAll(ctx, domain.TaskFilter{})
 	// ASSERT
 	suite.Assert().NoError(err, "GetAll should return an empty slice, not an error")
 	suite.Assert().Empty(tasks, "GetAll should return an empty slice")
@@ -220,7 +268,7 @@ func (suite *TaskRepoTestSuite) TestUpdate_Success() {
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
 
-	updatedTask, err := suite.TaskRepo.Update(ctx, intialTask.ID, updates)
+	updatedTask, err := suite.TaskRepo.Update(ctx, intialTask.ID, updates, 0)
 
 	// ASSERT: 1. check the repository call result
 	suite.Require().NoError(err, "Update shouldn't return an error on success")
@@ -236,6 +284,25 @@ func (suite *TaskRepoTestSuite) TestUpdate_Success() {
 	suite.Assert().Equal(dbCheckTask.Title, updates["title"], "Database check confirms title update")
 }
 
+func (suite *TaskRepoTestSuite) TestUpdate_VersionConflict() {
+
+	// ARRANGE: insert a task and update it once so its version moves to 1
+	intialTask := suite.setupTask("1", "test task")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	firstUpdate, err := suite.TaskRepo.Update(ctx, intialTask.ID, bson.M{"title": "first"}, 0)
+	suite.Require().NoError(err, "unconditional update shouldn't fail")
+	suite.Require().Equal(int64(1), firstUpdate.Version, "the update should bump the version")
+
+	// ACT: update again while expecting a stale version
+	_, err = suite.TaskRepo.Update(ctx, intialTask.ID, bson.M{"title": "second"}, 5)
+
+	// ASSERT
+	suite.True(errors.Is(err, domain.ErrConflict), "Error should be the domain.ErrConflict")
+}
+
 func (suite *TaskRepoTestSuite) TestUpdate_NotFound() {
 
 	// ARRANGE: no set up needed
@@ -249,7 +316,7 @@ func (suite *TaskRepoTestSuite) TestUpdate_NotFound() {
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
 
-	_, err := suite.TaskRepo.Update(ctx, "1", updates)
+	_, err := suite.TaskRepo.Update(ctx, "1", updates, 0)
 
 	// ASSERT
 	suite.Require().Error(err, "Update should return error on non-existing task")
@@ -265,18 +332,64 @@ func (suite *TaskRepoTestSuite) TestDelete_Success() {
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
 
-	err := suite.TaskRepo.Delete(ctx, intialTask.ID)
+	err := suite.TaskRepo.Delete(ctx, intialTask.ID, 0, time.Now())
 
 	// ASSERT: 1. check the repo call result
 	suite.Require().NoError(err, "Delete shouldn't return an error for success")
 
-	// ASSERT: 2. confirm the task no longer exists in the database
+	// ASSERT: 2. the task is hidden but still in the database, marked as deleted
+	_, err = suite.TaskRepo.GetByID(ctx, intialTask.ID)
+	suite.Assert().True(errors.Is(err, domain.ErrNotFound), "a deleted task should be hidden")
+
 	collection := suite.Client.Database(suite.DBName).Collection("tasks")
 	var dbCheckTask domain.Task
 
 	err = collection.FindOne(context.Background(), bson.M{"task_id": intialTask.ID}).Decode(&dbCheckTask)
 
-	suite.Assert().True(errors.Is(err, mongo.ErrNoDocuments), "Direct query should confirm the task was deleted")
+	suite.Require().NoError(err, "Direct query should still find the deleted task")
+	suite.Assert().NotNil(dbCheckTask.DeletedAt)
+	suite.Assert().Equal(intialTask.Version+1, dbCheckTask.Version)
+}
+
+func (suite *TaskRepoTestSuite) TestTrash_ListRestoreAndPurge() {
+
+	// ARRANGE: two deleted tasks and a live one
+	suite.setupTask("1", "first")
+	suite.setupTask("2", "second")
+	suite.setupTask("3", "live")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	suite.Require().NoError(suite.TaskRepo.Delete(ctx, "1", 0, now.Add(-2*time.Hour)))
+	suite.Require().NoError(suite.TaskRepo.Delete(ctx, "2", 0, now.Add(-time.Hour)))
+
+	// ACT & ASSERT: 1. the trash lists the most recently deleted first
+	tasks, total, err := suite.TaskRepo.ListDeleted(ctx, time.Time{}, 10, 0)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(int64(2), total)
+	suite.Require().Len(tasks, 2)
+	suite.Assert().Equal("2", tasks[0].ID)
+
+	// 2. a cutoff only lists the older deletions
+	tasks, _, err = suite.TaskRepo.ListDeleted(ctx, now.Add(-90*time.Minute), 10, 0)
+	suite.Require().NoError(err)
+	suite.Require().Len(tasks, 1)
+	suite.Assert().Equal("1", tasks[0].ID)
+
+	// 3. restoring brings the task back
+	restored, err := suite.TaskRepo.Restore(ctx, "2")
+	suite.Require().NoError(err)
+	suite.Assert().Nil(restored.DeletedAt)
+	_, err = suite.TaskRepo.GetByID(ctx, "2")
+	suite.Assert().NoError(err)
+
+	// 4. only tasks in the trash can be purged
+	suite.Assert().True(errors.Is(suite.TaskRepo.Purge(ctx, "3"), domain.ErrNotFound))
+	suite.Require().NoError(suite.TaskRepo.Purge(ctx, "1"))
+	_, err = suite.TaskRepo.GetDeletedByID(ctx, "1")
+	suite.Assert().True(errors.Is(err, domain.ErrNotFound))
 }
 
 func (suite *TaskRepoTestSuite) TestDelete_NotFound() {
@@ -287,13 +400,252 @@ func (suite *TaskRepoTestSuite) TestDelete_NotFound() {
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
 
-	err := suite.TaskRepo.Delete(ctx, "1")
+	err := suite.TaskRepo.Delete(ctx, "1", 0, time.Now())
 
 	// ASSERT
 	suite.Require().Error(err, "Delete should return error on non-existing task")
 	suite.True(errors.Is(err, domain.ErrNotFound), "Error should be the domain.ErrNotFound")
 }
 
+func (suite *TaskRepoTestSuite) TestReplaceStatus_Success() {
+
+	// ARRANGE: insert tasks holding a legacy status
+	suite.setupTask("1", "task one")
+	created := suite.setupTask("2", "task two")
+
+	// ACT
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	modified, err := suite.TaskRepo.ReplaceStatus(ctx, "pending", domain.StatusTodo)
+
+	// ASSERT: both tasks are migrated and the legacy status is gone
+	suite.Require().NoError(err, "ReplaceStatus shouldn't return an error")
+	suite.Assert().Equal(int64(2), modified, "both tasks should be migrated")
+
+	statuses, err := suite.TaskRepo.DistinctStatuses(ctx)
+	suite.Require().NoError(err, "DistinctStatuses shouldn't return an error")
+	suite.Assert().Equal([]string{"todo"}, statuses, "only the new status should remain")
+
+	// a migrated task has a new version, like any other change
+	migrated, err := suite.TaskRepo.GetByID(ctx, "2")
+	suite.Require().NoError(err)
+	suite.Assert().Equal(created.Version+1, migrated.Version, "the version should be bumped")
+}
+
+func (suite *TaskRepoTestSuite) TestSearch_TextIndexAndPrefix() {
+
+	// ARRANGE: two tasks mention "deploy", one only in its description
+	suite.setupTask("1", "Deploy api")
+	_, err := suite.TaskRepo.Create(context.Background(), domain.Task{ID: "2", Title: "Write notes", Description: "deploy checklist"})
+	suite.Require().NoError(err)
+	suite.setupTask("3", "Fix login")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// ACT: the text index ranks the title match first
+	query, err := domain.ParseTaskSearchQuery("deploy")
+	suite.Require().NoError(err)
+	hits, total, err := suite.TaskRepo.Search(ctx, query)
+
+	// ASSERT
+	suite.Require().NoError(err, "Search shouldn't return an error")
+	suite.Assert().Equal(int64(2), total)
+	suite.Require().Len(hits, 2)
+	suite.Assert().Equal("1", hits[0].Task.ID, "the title match should rank first")
+
+	// ACT: prefixes are matched at the start of words
+	query, err = domain.ParseTaskSearchQuery("log*")
+	suite.Require().NoError(err)
+	hits, _, err = suite.TaskRepo.Search(ctx, query)
+
+	// ASSERT
+	suite.Require().NoError(err)
+	suite.Require().Len(hits, 1)
+	suite.Assert().Equal("3", hits[0].Task.ID)
+}
+
+func (suite *TaskRepoTestSuite) TestCreate_Fail_DuplicateID() {
+
+	// ARRANGE: a task that already uses the id
+	suite.setupTask("1", "test task")
+
+	// ACT
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	_, err := suite.TaskRepo.Create(ctx, domain.Task{ID: "1", Title: "copy", Status: domain.StatusTodo})
+
+	// ASSERT: the unique index rejects it like the in-memory repository
+	suite.Assert().ErrorIs(err, domain.ErrAleadyExists)
+
+	count, err := suite.Client.Database(suite.DBName).Collection("tasks").CountDocuments(context.Background(), bson.M{"task_id": "1"})
+	suite.Require().NoError(err)
+	suite.Assert().Equal(int64(1), count)
+}
+
+func (suite *TaskRepoTestSuite) TestBulkCreateAndUpdate() {
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// ACT: create two tasks in one batch
+	errs, err := suite.TaskRepo.BulkCreate(ctx, []domain.Task{
+		{ID: "1", Title: "first", Status: domain.StatusTodo, Version: 1},
+		{ID: "2", Title: "second", Status: domain.StatusTodo, Version: 1},
+	}, false)
+
+	// ASSERT
+	suite.Require().NoError(err, "BulkCreate shouldn't return an error")
+	suite.Assert().Equal([]error{nil, nil}, errs)
+
+	tasks, err := suite.TaskRepo.GetByIDs(ctx, []string{"1", "2", "3"})
+	suite.Require().NoError(err)
+	suite.Assert().Len(tasks, 2)
+
+	// ACT: close the first, delete the second with a stale version and update a missing task
+	errs, err = suite.TaskRepo.BulkUpdate(ctx, []repositories.TaskUpdate{
+		{ID: "1", Updates: bson.M{"status": domain.StatusDone}, ExpectedVersion: 1},
+		{ID: "2", Updates: bson.M{"deleted_at": time.Now()}, ExpectedVersion: 5},
+		{ID: "3", Updates: bson.M{"status": domain.StatusDone}, ExpectedVersion: 1},
+	}, false)
+
+	// ASSERT: the batch tags tell which updates didn't apply
+	suite.Require().NoError(err, "BulkUpdate shouldn't return an error")
+	suite.Require().Len(errs, 3)
+	suite.Assert().NoError(errs[0])
+	suite.Assert().True(errors.Is(errs[1], domain.ErrConflict))
+	suite.Assert().True(errors.Is(errs[2], domain.ErrNotFound))
+
+	task, err := suite.TaskRepo.GetByID(ctx, "1")
+	suite.Require().NoError(err)
+	suite.Assert().Equal(domain.StatusDone, task.Status)
+	suite.Assert().Equal(int64(2), task.Version)
+}
+
+func (suite *TaskRepoTestSuite) TestBulkUpdate_ConcurrentWriterIsAConflict() {
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// ARRANGE: another writer bumps the task from version 1 to 2 before the batch runs
+	_, err := suite.TaskRepo.Create(ctx, domain.Task{ID: "1", Title: "first", Status: domain.StatusTodo, Version: 1})
+	suite.Require().NoError(err)
+	_, err = suite.TaskRepo.Update(ctx, "1", bson.M{"title": "theirs"}, 1)
+	suite.Require().NoError(err)
+
+	// ACT: the batch expects version 1, the task is now exactly one version ahead of it
+	errs, err := suite.TaskRepo.BulkUpdate(ctx, []repositories.TaskUpdate{
+		{ID: "1", Updates: bson.M{"title": "ours"}, ExpectedVersion: 1},
+	}, false)
+
+	// ASSERT: the update didn't apply and isn't reported as applied
+	suite.Require().NoError(err)
+	suite.Require().Len(errs, 1)
+	suite.Assert().True(errors.Is(errs[0], domain.ErrConflict))
+
+	task, err := suite.TaskRepo.GetByID(ctx, "1")
+	suite.Require().NoError(err)
+	suite.Assert().Equal("theirs", task.Title)
+}
+
+func (suite *TaskRepoTestSuite) TestBulkUpdate_SameTaskTwiceIsAConflict() {
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := suite.TaskRepo.Create(ctx, domain.Task{ID: "1", Title: "first", Status: domain.StatusTodo, Version: 1})
+	suite.Require().NoError(err)
+
+	// ACT
+	errs, err := suite.TaskRepo.BulkUpdate(ctx, []repositories.TaskUpdate{
+		{ID: "1", Updates: bson.M{"title": "once"}, ExpectedVersion: 1},
+		{ID: "1", Updates: bson.M{"title": "twice"}, ExpectedVersion: 2},
+	}, false)
+
+	// ASSERT: like the in-memory repository, only the first update applies
+	suite.Require().NoError(err)
+	suite.Assert().NoError(errs[0])
+	suite.Assert().True(errors.Is(errs[1], domain.ErrConflict))
+
+	task, err := suite.TaskRepo.GetByID(ctx, "1")
+	suite.Require().NoError(err)
+	suite.Assert().Equal("once", task.Title)
+}
+
+func (suite *TaskRepoTestSuite) TestLabels_FilterRenameAndDrop() {
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// ARRANGE
+	for _, task := range []domain.Task{
+		{ID: "1", Title: "api", Labels: []string{"backend", "server"}},
+		{ID: "2", Title: "ui", Labels: []string{"frontend"}},
+		{ID: "3", Title: "db", Labels: []string{"backend"}},
+	} {
+		_, err := suite.TaskRepo.Create(ctx, task)
+		suite.Require().NoError(err)
+	}
+
+	// ACT & ASSERT: any-of and all-of filters
+	_, total, err := suite.TaskRepo.GetAll(ctx, domain.TaskFilter{LabelsAny: []string{"frontend", "server"}})
+	suite.Require().NoError(err)
+	suite.Assert().Equal(int64(2), total)
+
+	_, total, err = suite.TaskRepo.GetAll(ctx, domain.TaskFilter{LabelsAll: []string{"backend", "server"}})
+	suite.Require().NoError(err)
+	suite.Assert().Equal(int64(1), total)
+
+	// ACT: rename onto a label task 1 already has
+	renamed, err := suite.TaskRepo.RenameLabel(ctx, "backend", "server")
+
+	// ASSERT: the label isn't duplicated and the versions are bumped
+	suite.Require().NoError(err)
+	suite.Assert().Equal(int64(2), renamed)
+	task, err := suite.TaskRepo.GetByID(ctx, "1")
+	suite.Require().NoError(err)
+	suite.Assert().Equal([]string{"server"}, task.Labels)
+	suite.Assert().Equal(int64(1), task.Version)
+
+	// ACT
+	dropped, err := suite.TaskRepo.DropLabel(ctx, "server")
+
+	// ASSERT
+	suite.Require().NoError(err)
+	suite.Assert().Equal(int64(2), dropped)
+	task, err = suite.TaskRepo.GetByID(ctx, "3")
+	suite.Require().NoError(err)
+	suite.Assert().Empty(task.Labels)
+}
+
+func (suite *TaskRepoTestSuite) TestGetSubtasks() {
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// ARRANGE
+	for _, task := range []domain.Task{
+		{ID: "root", Title: "root"},
+		{ID: "a", Title: "a", ParentID: "root"},
+		{ID: "b", Title: "b", ParentID: "root"},
+		{ID: "a1", Title: "a1", ParentID: "a"},
+	} {
+		_, err := suite.TaskRepo.Create(ctx, task)
+		suite.Require().NoError(err)
+	}
+	suite.Require().NoError(suite.TaskRepo.Delete(ctx, "b", 0, time.Now()))
+
+	// ACT
+	subtasks, err := suite.TaskRepo.GetSubtasks(ctx, []string{"root", "a"})
+
+	// ASSERT: the trashed subtask is left out
+	suite.Require().NoError(err)
+	suite.Require().Len(subtasks, 2)
+	suite.Assert().ElementsMatch([]string{"a", "a1"}, []string{subtasks[0].ID, subtasks[1].ID})
+}
+
 // This function is the entry point for the 'go test' command.
 func TestTaskRepoSuite(t *testing.T) {
 	// looks for the Test* methods in TaskRepoTestSuite
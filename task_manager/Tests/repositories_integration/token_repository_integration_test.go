@@ -0,0 +1,165 @@
+package repositoriesintegration
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	domain "taskmanager/Domain"
+	repositories "taskmanager/Repositories"
+	"testing"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type TokenRepoTestSuite struct {
+	suite.Suite                              // to use suite functionality from testify
+	TokenRepo   repositories.TokenRepository // the repo we test
+	Client      *mongo.Client                // mongo client
+	DBName      string                       // test db name
+}
+
+func (suite *TokenRepoTestSuite) SetupSuite() {
+
+	// Load variables from .env file
+	if err := godotenv.Load("../../config/.env"); err != nil {
+		log.Println("Note: No .env file found, relying on system environment variables.")
+	}
+
+	mongoURI := os.Getenv("MONGO_URI")
+	if mongoURI == "" {
+		log.Fatal("FATAL: MONGO_URI environment variable is not set. Cannot connect to database.")
+	}
+
+	suite.DBName = os.Getenv("MONGO_TEST_DB_NAME")
+	if suite.DBName == "" {
+		suite.DBName = "task_manager_db_test"
+	}
+
+	// connect to mongoDB
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI))
+	if err != nil {
+		log.Fatal("FATAL: unable to connect to test database")
+	}
+
+	// Ping to ensure connection is live
+	if err := client.Ping(ctx, nil); err != nil {
+		log.Fatalf("FATAL: MongoDB ping failed: %v", err)
+	}
+
+	suite.Client = client
+	suite.TokenRepo, err = repositories.NewMongoTokenRepository(suite.Client, suite.DBName, "refresh_tokens", "revoked_tokens")
+	if err != nil {
+		log.Fatalf("FATAL: failed to set up the repository: %v", err)
+	}
+}
+
+func (suite *TokenRepoTestSuite) TearDownSuite() {
+
+	// CLEANUP: Drop the entire test database to ensure a clean slate.
+	suite.Client.Database(suite.DBName).Drop(context.Background())
+
+	// close the connection
+	suite.Client.Disconnect(context.Background())
+}
+
+// TearDownTest clears both token collections after every test
+func (suite *TokenRepoTestSuite) TearDownTest() {
+	for _, name := range []string{"refresh_tokens", "revoked_tokens"} {
+		_, err := suite.Client.Database(suite.DBName).Collection(name).DeleteMany(context.Background(), bson.D{})
+		if err != nil {
+			log.Printf("Warning: Failed to clear %s collection after test: %v", name, err)
+		}
+	}
+}
+
+// helper to store a refresh token of the given family
+func (suite *TokenRepoTestSuite) setupRefreshToken(id, familyId, hash string) domain.RefreshToken {
+
+	token := domain.RefreshToken{
+		ID:        id,
+		FamilyID:  familyId,
+		UserID:    "user-1",
+		TokenHash: hash,
+		ExpiresAt: time.Now().Add(time.Hour).Truncate(time.Millisecond),
+		CreatedAt: time.Now().Truncate(time.Millisecond),
+	}
+
+	err := suite.TokenRepo.SaveRefreshToken(context.Background(), token)
+	suite.Require().NoError(err, "failed to save a refresh token during setup")
+
+	return token
+}
+
+func (suite *TokenRepoTestSuite) TestRotateRefreshToken_OnlyOnce() {
+
+	// ARRANGE
+	token := suite.setupRefreshToken("1", "family-1", "hash-1")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	// ACT: rotate the same token twice
+	firstErr := suite.TokenRepo.RotateRefreshToken(ctx, token.ID, "2")
+	secondErr := suite.TokenRepo.RotateRefreshToken(ctx, token.ID, "3")
+
+	// ASSERT: only the first rotation wins
+	suite.Require().NoError(firstErr, "the first rotation should succeed")
+	suite.True(errors.Is(secondErr, domain.ErrConflict), "a second rotation should be a conflict")
+
+	stored, err := suite.TokenRepo.GetRefreshTokenByHash(ctx, "hash-1")
+	suite.Require().NoError(err)
+	suite.Equal("2", stored.ReplacedBy, "the token should point at its replacement")
+}
+
+func (suite *TokenRepoTestSuite) TestRevokeTokenFamily() {
+
+	// ARRANGE: two tokens of the same family
+	suite.setupRefreshToken("1", "family-1", "hash-1")
+	suite.setupRefreshToken("2", "family-1", "hash-2")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	// ACT
+	err := suite.TokenRepo.RevokeTokenFamily(ctx, "family-1")
+
+	// ASSERT
+	suite.Require().NoError(err)
+	for _, hash := range []string{"hash-1", "hash-2"} {
+		stored, err := suite.TokenRepo.GetRefreshTokenByHash(ctx, hash)
+		suite.Require().NoError(err)
+		suite.True(stored.Revoked, "every token of the family should be revoked")
+	}
+}
+
+func (suite *TokenRepoTestSuite) TestRevokeAccessToken() {
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	// ACT
+	err := suite.TokenRepo.RevokeAccessToken(ctx, "jti-1", time.Now().Add(time.Minute))
+	suite.Require().NoError(err)
+
+	// ASSERT
+	revoked, err := suite.TokenRepo.IsAccessTokenRevoked(ctx, "jti-1")
+	suite.Require().NoError(err)
+	suite.True(revoked, "the revoked token should be reported as revoked")
+
+	revoked, err = suite.TokenRepo.IsAccessTokenRevoked(ctx, "jti-2")
+	suite.Require().NoError(err)
+	suite.False(revoked, "other tokens should not be revoked")
+}
+
+func TestTokenRepoSuite(t *testing.T) {
+	suite.Run(t, new(TokenRepoTestSuite))
+}
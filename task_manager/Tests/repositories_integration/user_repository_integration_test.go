@@ -61,7 +61,10 @@ func (suite *UserRepoTestSuite) SetupSuite() {
 	// assign the dependencies we need as the suite properties
 	suite.Client = client
 
-	suite.UserRepo = repositories.NewMongoUserRepository(suite.Client, suite.DBName, "users")
+	suite.UserRepo, err = repositories.NewMongoUserRepository(suite.Client, suite.DBName, "users")
+	if err != nil {
+		log.Fatalf("FATAL: failed to set up the repository: %v", err)
+	}
 }
 
 func (suite *UserRepoTestSuite) TearDownSuite() {
@@ -193,6 +196,22 @@ func (suite *UserRepoTestSuite) TestSaveUser_success() {
 	suite.Assert().Equal(int(count), 1, "One user should be found in the database")
 }
 
+func (suite *UserRepoTestSuite) TestSaveUser_Fail_Duplicate() {
+
+	// ARRANGE
+	existing := suite.setupUser("username", "password", int(domain.RoleUser))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	// ACT & ASSERT: neither the id nor the username can be saved twice
+	_, err := suite.UserRepo.SaveUser(ctx, domain.User{ID: existing.ID, UserName: "other", HashedPassword: "password"})
+	suite.Assert().ErrorIs(err, domain.ErrAleadyExists)
+
+	_, err = suite.UserRepo.SaveUser(ctx, domain.User{ID: uuid.New(), UserName: "username", HashedPassword: "password"})
+	suite.Assert().ErrorIs(err, domain.ErrAleadyExists)
+}
+
 func (suite *UserRepoTestSuite) TestDoesUserExist_Exist() {
 
 	// ARRANGE
@@ -262,3 +281,58 @@ func (suite *UserRepoTestSuite) TestPromoteUser_NotFound() {
 	suite.Require().Error(err)
 	suite.Assert().True(errors.Is(err, domain.ErrNotFound), "Error should be domain.ErrNotFound")
 }
+
+func (suite *UserRepoTestSuite) TestUpdatePassword_Success() {
+	// ARRANGE
+	insertedUser := suite.setupUser("username", "password", 0)
+	changedAt := time.Now().UTC().Truncate(time.Millisecond)
+
+	// ACT
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	updatedUser, err := suite.UserRepo.UpdatePassword(ctx, insertedUser.ID.String(), "new-hash", true, changedAt)
+
+	// ASSERT: check the repo response
+	suite.Require().NoError(err)
+	suite.Assert().Equal("new-hash", updatedUser.HashedPassword)
+	suite.Assert().True(updatedUser.MustChangePassword)
+
+	// ASSERT 2: Verify the document is updated in the database.
+	collection := suite.Client.Database(suite.DBName).Collection("users")
+	var dbCheckUser domain.User
+	err = collection.FindOne(context.Background(), bson.M{"user_id": insertedUser.ID}).Decode(&dbCheckUser)
+
+	suite.Require().NoError(err)
+	suite.Assert().Equal("new-hash", dbCheckUser.HashedPassword)
+	suite.Assert().True(changedAt.Equal(dbCheckUser.PasswordChangedAt))
+}
+
+func (suite *UserRepoTestSuite) TestUpdatePassword_NotFound() {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	_, err := suite.UserRepo.UpdatePassword(ctx, uuid.New().String(), "new-hash", false, time.Now())
+
+	suite.Assert().True(errors.Is(err, domain.ErrNotFound), "Error should be domain.ErrNotFound")
+}
+
+func (suite *UserRepoTestSuite) TestReplacePasswordHash() {
+	// ARRANGE
+	insertedUser := suite.setupUser("username", "password", 0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	// ACT: the second swap no longer finds the hash it expects
+	err := suite.UserRepo.ReplacePasswordHash(ctx, insertedUser.ID.String(), insertedUser.HashedPassword, "stronger")
+	staleErr := suite.UserRepo.ReplacePasswordHash(ctx, insertedUser.ID.String(), insertedUser.HashedPassword, "other")
+
+	// ASSERT
+	suite.Require().NoError(err)
+	suite.Assert().True(errors.Is(staleErr, domain.ErrConflict), "Error should be domain.ErrConflict")
+
+	storedUser, err := suite.UserRepo.GetUserByID(ctx, insertedUser.ID.String())
+	suite.Require().NoError(err)
+	suite.Assert().Equal("stronger", storedUser.HashedPassword)
+}
@@ -0,0 +1,134 @@
+package repositoriesintegration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	domain "taskmanager/Domain"
+	repositories "taskmanager/Repositories"
+	"testing"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type WebhookRepoTestSuite struct {
+	suite.Suite                                // to use suite functionality from testify
+	WebhookRepo repositories.WebhookRepository // the repo we test
+	Client      *mongo.Client                  // mongo client
+	DBName      string                         // test db name
+}
+
+func (suite *WebhookRepoTestSuite) SetupSuite() {
+
+	// Load variables from .env file
+	if err := godotenv.Load("../../config/.env"); err != nil {
+		log.Println("Note: No .env file found, relying on system environment variables.")
+	}
+
+	mongoURI := os.Getenv("MONGO_URI")
+	if mongoURI == "" {
+		log.Fatal("FATAL: MONGO_URI environment variable is not set. Cannot connect to database.")
+	}
+
+	suite.DBName = os.Getenv("MONGO_TEST_DB_NAME")
+	if suite.DBName == "" {
+		suite.DBName = "task_manager_db_test"
+	}
+
+	// connect to mongoDB
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI))
+	if err != nil {
+		log.Fatal("FATAL: unable to connect to test database")
+	}
+
+	// Ping to ensure connection is live
+	if err := client.Ping(ctx, nil); err != nil {
+		log.Fatalf("FATAL: MongoDB ping failed: %v", err)
+	}
+
+	suite.Client = client
+	suite.WebhookRepo, err = repositories.NewMongoWebhookRepository(suite.Client, suite.DBName, "webhooks", "webhook_deliveries")
+	if err != nil {
+		log.Fatalf("FATAL: failed to set up the repository: %v", err)
+	}
+}
+
+func (suite *WebhookRepoTestSuite) TearDownSuite() {
+
+	// CLEANUP: Drop the entire test database to ensure a clean slate.
+	suite.Client.Database(suite.DBName).Drop(context.Background())
+
+	// close the connection
+	suite.Client.Disconnect(context.Background())
+}
+
+// TearDownTest clears the webhook and delivery collections after every test
+func (suite *WebhookRepoTestSuite) TearDownTest() {
+	for _, collection := range []string{"webhooks", "webhook_deliveries"} {
+		_, err := suite.Client.Database(suite.DBName).Collection(collection).DeleteMany(context.Background(), bson.D{})
+		if err != nil {
+			log.Printf("Warning: Failed to clear %s collection after test: %v", collection, err)
+		}
+	}
+}
+
+func TestWebhookRepoSuite(t *testing.T) {
+	suite.Run(t, new(WebhookRepoTestSuite))
+}
+
+func (suite *WebhookRepoTestSuite) TestDeliveryLog() {
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// ARRANGE
+	webhook := domain.Webhook{
+		ID:        "hook-1",
+		URL:       "https://example.com/hooks",
+		Events:    []domain.WebhookEvent{domain.WebhookTaskCreated},
+		Secret:    "0123456789abcdef",
+		Active:    true,
+		CreatedAt: time.Now().UTC().Truncate(time.Millisecond),
+	}
+	_, err := suite.WebhookRepo.Create(ctx, webhook)
+	suite.Require().NoError(err)
+
+	for i, status := range []domain.DeliveryStatus{domain.DeliverySucceeded, domain.DeliveryFailed, domain.DeliveryPending} {
+		suite.Require().NoError(suite.WebhookRepo.SaveDelivery(ctx, domain.WebhookDelivery{
+			ID:        fmt.Sprintf("d-%d", i+1),
+			WebhookID: webhook.ID,
+			Event:     domain.WebhookTaskCreated,
+			Payload:   []byte(`{"id":"event"}`),
+			Status:    status,
+			CreatedAt: time.Date(2025, 1, 1, 12, i, 0, 0, time.UTC),
+		}))
+	}
+
+	// ACT & ASSERT: the log is listed newest first
+	deliveries, total, err := suite.WebhookRepo.ListDeliveries(ctx, webhook.ID, 2, 0)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(int64(3), total)
+	suite.Require().Len(deliveries, 2)
+	suite.Assert().Equal("d-3", deliveries[0].ID)
+	suite.Assert().JSONEq(`{"id":"event"}`, string(deliveries[0].Payload))
+
+	pending, err := suite.WebhookRepo.GetPendingDeliveries(ctx)
+	suite.Require().NoError(err)
+	suite.Require().Len(pending, 1)
+	suite.Assert().Equal("d-3", pending[0].ID)
+
+	// deleting the webhook drops its log
+	suite.Require().NoError(suite.WebhookRepo.Delete(ctx, webhook.ID))
+	_, err = suite.WebhookRepo.GetDelivery(ctx, "d-1")
+	suite.Assert().True(errors.Is(err, domain.ErrNotFound))
+}
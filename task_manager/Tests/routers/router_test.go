@@ -5,13 +5,15 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
-	"os"
+	"strings"
 	"testing"
 	"time"
 
 	"taskmanager/Delivery/router"
 	domain "taskmanager/Domain"
+	infrastructure "taskmanager/Infrastructure"
 	"taskmanager/Tests/mocks"
+	usecases "taskmanager/Usecases"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
@@ -28,30 +30,45 @@ const standardUserID = "user-5678-efgh"
 
 // --- Setup and Helper Functions ---
 
-func SetupTestRouter(t *testing.T) (*gin.Engine, *mocks.MockTaskUsecase, *mocks.MockUserUsecase) {
-	// Must set JWT_SECRET for middleware to initialize correctly
-	os.Setenv("JWT_SECRET", testSecret)
+func SetupTestRouter(t *testing.T) (*gin.Engine, *mocks.MockTaskUsecase, *mocks.MockUserUsecase, *mocks.MockCommentUsecase, *mocks.MockAuditUsecase, *mocks.MockLabelUsecase, *mocks.MockWebhookUsecase, *mocks.MockTaskEventUsecase, *mocks.MockCalendarUsecase) {
+	// Tokens are signed with the shared test secret
+	jwtKeys, err := infrastructure.NewHMACJWTKeys(testSecret)
+	assert.NoError(t, err)
 
 	// Create mocks
 	// Note: We are using mock interfaces here, but the implementation is identical to the mock setup in the previous response.
 	taskUsecaseMock := new(mocks.MockTaskUsecase)
 	userUsecaseMock := new(mocks.MockUserUsecase)
+	commentUsecaseMock := new(mocks.MockCommentUsecase)
+	auditUsecaseMock := new(mocks.MockAuditUsecase)
+	labelUsecaseMock := new(mocks.MockLabelUsecase)
+	webhookUsecaseMock := new(mocks.MockWebhookUsecase)
+	taskEventUsecaseMock := new(mocks.MockTaskEventUsecase)
+	calendarUsecaseMock := new(mocks.MockCalendarUsecase)
 
-	// Create router
-	r := router.SetupRouter(taskUsecaseMock, userUsecaseMock)
+	// No token is revoked unless a test says otherwise
+	userUsecaseMock.EXPECT().IsAccessTokenRevoked(mock.Anything, mock.Anything).Return(false, nil).Maybe()
+	userUsecaseMock.EXPECT().CheckAccessTokenUser(mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
 
-	// Ensure cleanup
-	t.Cleanup(func() { os.Unsetenv("JWT_SECRET") })
+	// Create router
+	r := router.SetupRouter(taskUsecaseMock, userUsecaseMock, commentUsecaseMock, auditUsecaseMock, labelUsecaseMock, webhookUsecaseMock, taskEventUsecaseMock, calendarUsecaseMock, jwtKeys, infrastructure.DefaultJWTSettings())
 
-	return r, taskUsecaseMock, userUsecaseMock
+	return r, taskUsecaseMock, userUsecaseMock, commentUsecaseMock, auditUsecaseMock, labelUsecaseMock, webhookUsecaseMock, taskEventUsecaseMock, calendarUsecaseMock
 }
 
 // generateTestToken creates a valid, signed JWT for testing
 func generateTestToken(t *testing.T, userID string, role domain.UserRole) string {
+	settings := infrastructure.DefaultJWTSettings()
+	now := time.Now()
 	claims := jwt.MapClaims{
+		"jti":     uuid.New().String(),
 		"user_id": userID,
 		"role":    float64(role), // JWT claims use float64 for numbers
-		"exp":     time.Now().Add(time.Hour).Unix(),
+		"iss":     settings.Issuer,
+		"aud":     settings.Audience,
+		"iat":     now.Unix(),
+		"nbf":     now.Unix(),
+		"exp":     now.Add(time.Hour).Unix(),
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	tokenString, err := token.SignedString([]byte(testSecret))
@@ -89,12 +106,12 @@ func makeRequest(r *gin.Engine, method, url, token string, body ...interface{})
 // --- Router and Middleware Tests ---
 
 func TestRouter_TaskReadRoutes_RequireAuth(t *testing.T) {
-	r, taskMock, _ := SetupTestRouter(t)
+	r, taskMock, _, _, _, _, _, _, _ := SetupTestRouter(t)
 
 	// Case 1: GET /api/v1/tasks - No Token (Should fail AuthMiddleware)
 	w := makeRequest(r, http.MethodGet, "/api/v1/tasks", "")
 	assert.Equal(t, http.StatusUnauthorized, w.Code)
-	taskMock.AssertNotCalled(t, "RetrieveAllTasks", mock.Anything)
+	taskMock.AssertNotCalled(t, "RetrieveAllTasks", mock.Anything, mock.Anything)
 
 	// Case 2: GET /api/v1/tasks/:id - Valid User Token (Should Pass)
 	token := generateTestToken(t, standardUserID, domain.RoleUser)
@@ -105,7 +122,7 @@ func TestRouter_TaskReadRoutes_RequireAuth(t *testing.T) {
 }
 
 func TestRouter_TaskWriteRoutes_RequireAdmin(t *testing.T) {
-	r, taskMock, _ := SetupTestRouter(t)
+	r, taskMock, _, _, _, _, _, _, _ := SetupTestRouter(t)
 
 	// 1. Attempt POST with Regular User Token (Should fail AuthorizationMiddleware)
 	userToken := generateTestToken(t, standardUserID, domain.RoleUser)
@@ -119,34 +136,152 @@ func TestRouter_TaskWriteRoutes_RequireAdmin(t *testing.T) {
 
 	// 2. Attempt DELETE with Admin User Token (Should Pass)
 	adminToken := generateTestToken(t, adminUserID, domain.RoleAdmin)
-	taskMock.EXPECT().RemoveTask(mock.Anything, "1").Return(nil)
+	taskMock.EXPECT().RemoveTask(mock.Anything, mock.Anything, "1", int64(0)).Return(nil)
 
 	w = makeRequest(r, http.MethodDelete, "/api/v1/tasks/1", adminToken)
 	assert.Equal(t, http.StatusOK, w.Code, "Admin should be allowed to DELETE /tasks/:id")
 	taskMock.AssertExpectations(t)
 }
 
+func TestRouter_TaskAssignmentRoutes(t *testing.T) {
+	r, taskMock, _, _, _, _, _, _, _ := SetupTestRouter(t)
+	userToken := generateTestToken(t, standardUserID, domain.RoleUser)
+	adminToken := generateTestToken(t, adminUserID, domain.RoleAdmin)
+
+	// 1. Regular users cannot assign tasks
+	w := makeRequest(r, http.MethodPut, "/api/v1/tasks/1/assignee", userToken, gin.H{"assignee_id": standardUserID})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	taskMock.AssertNotCalled(t, "AssignTask", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	// 2. Regular users can list their own tasks
+	taskMock.EXPECT().
+		RetrieveAllTasks(mock.Anything, domain.TaskFilter{AssigneeID: standardUserID}).
+		Return(domain.TaskPage{}, nil)
+	w = makeRequest(r, http.MethodGet, "/api/v1/tasks/mine", userToken)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// 3. Admins can unassign tasks
+	taskMock.EXPECT().UnassignTask(mock.Anything, mock.Anything, "1").Return(domain.Task{ID: "1"}, nil)
+	w = makeRequest(r, http.MethodDelete, "/api/v1/tasks/1/assignee", adminToken)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	taskMock.AssertExpectations(t)
+}
+
+func TestRouter_CommentRoutes(t *testing.T) {
+	r, _, _, commentMock, _, _, _, _, _ := SetupTestRouter(t)
+	body := map[string]string{"body": "looks good"}
+
+	// 1. Without a token the request never reaches the controller
+	w := makeRequest(r, http.MethodPost, "/api/v1/tasks/1/comments", "", body)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	commentMock.AssertNotCalled(t, "AddComment", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	// 2. Regular users can comment, the author comes from the token
+	userToken := generateTestToken(t, standardUserID, domain.RoleUser)
+	commentMock.EXPECT().
+		AddComment(mock.Anything, domain.Actor{UserID: standardUserID, Role: domain.RoleUser}, "1", "looks good").
+		Return(domain.Comment{ID: "c1", TaskID: "1", AuthorID: standardUserID}, nil)
+	w = makeRequest(r, http.MethodPost, "/api/v1/tasks/1/comments", userToken, body)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	// 3. Editing someone else's comment is rejected by the usecase
+	commentMock.EXPECT().
+		EditComment(mock.Anything, mock.Anything, "1", "c2", "looks good").
+		Return(domain.Comment{}, domain.ErrForbidden)
+	w = makeRequest(r, http.MethodPut, "/api/v1/tasks/1/comments/c2", userToken, body)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	commentMock.AssertExpectations(t)
+}
+
 func TestRouter_UserPromoteRoute_RequireAdmin(t *testing.T) {
-	r, _, userMock := SetupTestRouter(t)
+	r, _, userMock, _, _, _, _, _, _ := SetupTestRouter(t)
 
 	// 1. Attempt PATCH with Regular User Token (Should fail AuthorizationMiddleware)
 	userToken := generateTestToken(t, standardUserID, domain.RoleUser)
 	w := makeRequest(r, http.MethodPatch, "/api/v1/user/123/promote", userToken)
 
 	assert.Equal(t, http.StatusForbidden, w.Code, "User should be forbidden from promoting others")
-	userMock.AssertNotCalled(t, "PromoteUser", mock.Anything, mock.Anything)
+	userMock.AssertNotCalled(t, "PromoteUser", mock.Anything, mock.Anything, mock.Anything)
 
 	// 2. Attempt PATCH with Admin User Token (Should Pass)
 	adminToken := generateTestToken(t, adminUserID, domain.RoleAdmin)
-	userMock.EXPECT().PromoteUser(mock.Anything, "123").Return(domain.User{}, nil)
+	userMock.EXPECT().PromoteUser(mock.Anything, mock.Anything, "123").Return(domain.User{}, nil)
 
 	w = makeRequest(r, http.MethodPatch, "/api/v1/user/123/promote", adminToken)
 	assert.Equal(t, http.StatusOK, w.Code, "Admin should be allowed to PATCH /user/:id/promote")
 	userMock.AssertExpectations(t)
 }
 
+func TestRouter_PasswordRoutes(t *testing.T) {
+	r, _, userMock, _, _, _, _, _, _ := SetupTestRouter(t)
+	body := map[string]string{"current_password": "old", "new_password": "new"}
+
+	// 1. Changing a password needs a token
+	w := makeRequest(r, http.MethodPost, "/api/v1/user/password", "", body)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	userToken := generateTestToken(t, standardUserID, domain.RoleUser)
+	userMock.EXPECT().ChangePassword(mock.Anything, mock.Anything, "old", "new").Return(domain.TokenPair{}, nil)
+	w = makeRequest(r, http.MethodPost, "/api/v1/user/password", userToken, body)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// 2. Only admins reset the password of others
+	w = makeRequest(r, http.MethodPost, "/api/v1/user/123/password/reset", userToken)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	adminToken := generateTestToken(t, adminUserID, domain.RoleAdmin)
+	userMock.EXPECT().ResetPassword(mock.Anything, mock.Anything, "123", "").Return(domain.User{}, nil)
+	w = makeRequest(r, http.MethodPost, "/api/v1/user/123/password/reset", adminToken)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	userMock.AssertExpectations(t)
+}
+
+func TestRouter_LoginLockoutRoutes_RequireAdmin(t *testing.T) {
+	r, _, userMock, _, _, _, _, _, _ := SetupTestRouter(t)
+
+	// 1. Regular users can't see or lift lockouts
+	userToken := generateTestToken(t, standardUserID, domain.RoleUser)
+	w := makeRequest(r, http.MethodGet, "/api/v1/user/lockouts", userToken)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = makeRequest(r, http.MethodDelete, "/api/v1/user/lockouts?user_name=john", userToken)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// 2. Admins can
+	adminToken := generateTestToken(t, adminUserID, domain.RoleAdmin)
+	userMock.EXPECT().ListLoginLockouts(mock.Anything).Return([]domain.LoginAttempts{}, nil)
+	userMock.EXPECT().UnlockLogin(mock.Anything, mock.Anything, domain.LoginAttemptUser, "john").Return(nil)
+
+	w = makeRequest(r, http.MethodGet, "/api/v1/user/lockouts", adminToken)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = makeRequest(r, http.MethodDelete, "/api/v1/user/lockouts?user_name=john", adminToken)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	userMock.AssertExpectations(t)
+}
+
+func TestRouter_LogoutRoute_RequiresAuth(t *testing.T) {
+	r, _, userMock, _, _, _, _, _, _ := SetupTestRouter(t)
+	body := map[string]string{"refresh_token": "refresh"}
+
+	// 1. Without a token the request never reaches the controller
+	w := makeRequest(r, http.MethodPost, "/api/v1/user/logout", "", body)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	userMock.AssertNotCalled(t, "Logout", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	// 2. With a token the caller's session is revoked
+	token := generateTestToken(t, standardUserID, domain.RoleUser)
+	userMock.EXPECT().Logout(mock.Anything, standardUserID, "refresh", mock.Anything, mock.Anything).Return(nil)
+	w = makeRequest(r, http.MethodPost, "/api/v1/user/logout", token, body)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	userMock.AssertExpectations(t)
+}
+
 func TestRouter_PublicRoutes_NoAuthRequired(t *testing.T) {
-	r, _, userMock := SetupTestRouter(t)
+	r, _, userMock, _, _, _, _, _, _ := SetupTestRouter(t)
 	credentials := domain.Credentials{UserName: "test", Password: "p"}
 
 	// Case 1: POST /api/v1/user/register
@@ -155,9 +290,204 @@ func TestRouter_PublicRoutes_NoAuthRequired(t *testing.T) {
 	assert.Equal(t, http.StatusCreated, w.Code)
 
 	// Case 2: POST /api/v1/user/login
-	userMock.EXPECT().AuthenticateUser(mock.Anything, mock.Anything, mock.Anything).Return("token", nil)
+	userMock.EXPECT().AuthenticateUser(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(domain.TokenPair{AccessToken: "token"}, nil)
 	w = makeRequest(r, http.MethodPost, "/api/v1/user/login", "", credentials)
 	assert.Equal(t, http.StatusOK, w.Code)
 
+	// Case 3: POST /api/v1/user/refresh
+	userMock.EXPECT().RefreshTokens(mock.Anything, "refresh").Return(domain.TokenPair{AccessToken: "token"}, nil)
+	w = makeRequest(r, http.MethodPost, "/api/v1/user/refresh", "", map[string]string{"refresh_token": "refresh"})
+	assert.Equal(t, http.StatusOK, w.Code)
+
 	userMock.AssertExpectations(t)
 }
+
+func TestRouter_AuditRoutes_RequireAdmin(t *testing.T) {
+	r, _, _, _, auditMock, _, _, _, _ := SetupTestRouter(t)
+
+	// 1. Regular users can't read the audit log
+	userToken := generateTestToken(t, standardUserID, domain.RoleUser)
+	w := makeRequest(r, http.MethodGet, "/api/v1/audit", userToken)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = makeRequest(r, http.MethodGet, "/api/v1/audit/export", userToken)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	auditMock.AssertNotCalled(t, "QueryAuditLog", mock.Anything, mock.Anything)
+
+	// 2. Admins can
+	adminToken := generateTestToken(t, adminUserID, domain.RoleAdmin)
+	auditMock.EXPECT().QueryAuditLog(mock.Anything, mock.Anything).Return(domain.AuditPage{Entries: []domain.AuditEntry{}}, nil)
+	w = makeRequest(r, http.MethodGet, "/api/v1/audit", adminToken)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	auditMock.AssertExpectations(t)
+}
+
+func TestRouter_TrashRoutes_RequireAdmin(t *testing.T) {
+	r, taskMock, _, _, _, _, _, _, _ := SetupTestRouter(t)
+
+	// 1. Regular users can't see or touch the trash
+	userToken := generateTestToken(t, standardUserID, domain.RoleUser)
+	w := makeRequest(r, http.MethodGet, "/api/v1/tasks/trash", userToken)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = makeRequest(r, http.MethodPost, "/api/v1/tasks/trash/1/restore", userToken)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	taskMock.AssertNotCalled(t, "RetrieveTaskByID", mock.Anything, mock.Anything)
+
+	// 2. Admins reach the trash handlers rather than GET /tasks/:id
+	adminToken := generateTestToken(t, adminUserID, domain.RoleAdmin)
+	taskMock.EXPECT().ListDeletedTasks(mock.Anything, int64(0), int64(0)).Return(domain.TaskPage{Tasks: []domain.Task{}}, nil)
+	w = makeRequest(r, http.MethodGet, "/api/v1/tasks/trash", adminToken)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	taskMock.EXPECT().PurgeTask(mock.Anything, mock.Anything, "1").Return(nil)
+	w = makeRequest(r, http.MethodDelete, "/api/v1/tasks/trash/1", adminToken)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	taskMock.AssertExpectations(t)
+}
+
+func TestRouter_BulkRoutes_RequireAdmin(t *testing.T) {
+	r, taskMock, _, _, _, _, _, _, _ := SetupTestRouter(t)
+	body := map[string]interface{}{"ids": []string{"1"}}
+
+	// 1. Regular users can't run bulk operations
+	userToken := generateTestToken(t, standardUserID, domain.RoleUser)
+	w := makeRequest(r, http.MethodPost, "/api/v1/tasks/bulk/delete", userToken, body)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = makeRequest(r, http.MethodPatch, "/api/v1/tasks/bulk", userToken, body)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	taskMock.AssertNotCalled(t, "BulkDeleteTasks", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	// 2. Admins reach the bulk handlers rather than the comment routes of a task called "bulk"
+	adminToken := generateTestToken(t, adminUserID, domain.RoleAdmin)
+	taskMock.EXPECT().
+		BulkDeleteTasks(mock.Anything, mock.Anything, []string{"1"}, false).
+		Return(domain.BulkResult{Succeeded: 1, Results: []domain.BulkItemResult{{ID: "1", Status: domain.BulkItemSucceeded}}}, nil)
+	w = makeRequest(r, http.MethodPost, "/api/v1/tasks/bulk/delete", adminToken, body)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	taskMock.AssertExpectations(t)
+}
+
+func TestRouter_LabelRoutes_OnlyAdminsManageLabels(t *testing.T) {
+	r, _, _, _, _, labelMock, _, _, _ := SetupTestRouter(t)
+
+	// 1. Regular users can list the labels but not change them
+	userToken := generateTestToken(t, standardUserID, domain.RoleUser)
+	labelMock.EXPECT().ListLabels(mock.Anything).Return([]domain.Label{{Name: "urgent"}}, nil)
+	w := makeRequest(r, http.MethodGet, "/api/v1/labels", userToken)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = makeRequest(r, http.MethodPost, "/api/v1/labels", userToken, map[string]string{"name": "bug"})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = makeRequest(r, http.MethodDelete, "/api/v1/labels/urgent", userToken)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	labelMock.AssertNotCalled(t, "DeleteLabel", mock.Anything, mock.Anything, mock.Anything)
+
+	// 2. Admins can
+	adminToken := generateTestToken(t, adminUserID, domain.RoleAdmin)
+	labelMock.EXPECT().DeleteLabel(mock.Anything, mock.Anything, "urgent").Return(int64(0), nil)
+	w = makeRequest(r, http.MethodDelete, "/api/v1/labels/urgent", adminToken)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	labelMock.AssertExpectations(t)
+}
+
+func TestRouter_WebhookRoutes_AdminOnly(t *testing.T) {
+	r, _, _, _, _, _, webhookMock, _, _ := SetupTestRouter(t)
+
+	// 1. Regular users can't see or manage webhooks
+	userToken := generateTestToken(t, standardUserID, domain.RoleUser)
+	w := makeRequest(r, http.MethodGet, "/api/v1/webhooks", userToken)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = makeRequest(r, http.MethodPost, "/api/v1/webhooks/hook-1/deliveries/delivery-1/redeliver", userToken)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	webhookMock.AssertNotCalled(t, "Redeliver", mock.Anything, mock.Anything, mock.Anything)
+
+	// 2. Admins can
+	adminToken := generateTestToken(t, adminUserID, domain.RoleAdmin)
+	webhookMock.EXPECT().ListWebhooks(mock.Anything).Return([]domain.Webhook{}, nil)
+	w = makeRequest(r, http.MethodGet, "/api/v1/webhooks", adminToken)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	webhookMock.EXPECT().Redeliver(mock.Anything, "hook-1", "delivery-1").
+		Return(domain.WebhookDelivery{ID: "delivery-2", RedeliveryOf: "delivery-1"}, nil)
+	w = makeRequest(r, http.MethodPost, "/api/v1/webhooks/hook-1/deliveries/delivery-1/redeliver", adminToken)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+
+	webhookMock.AssertExpectations(t)
+}
+
+func TestRouter_TaskEventStream_RequiresAuth(t *testing.T) {
+	r, _, _, _, _, _, _, taskEventMock, _ := SetupTestRouter(t)
+
+	// 1. The stream is not public
+	w := makeRequest(r, http.MethodGet, "/api/v1/tasks/events", "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	taskEventMock.AssertNotCalled(t, "Subscribe", mock.Anything, mock.Anything)
+
+	// 2. Any authenticated user can follow it, a closed subscription ends the response
+	events := make(chan domain.TaskEvent)
+	close(events)
+	taskEventMock.EXPECT().
+		Subscribe("", domain.TaskEventFilter{AssigneeID: standardUserID}).
+		Return(&usecases.TaskSubscription{Events: events}, nil)
+
+	userToken := generateTestToken(t, standardUserID, domain.RoleUser)
+	w = makeRequest(r, http.MethodGet, "/api/v1/tasks/events?assignee_id="+standardUserID, userToken)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, strings.HasPrefix(w.Header().Get("Content-Type"), "text/event-stream"))
+
+	taskEventMock.AssertExpectations(t)
+}
+
+func TestRouter_TaskTransferRoutes(t *testing.T) {
+	r, taskMock, _, _, _, _, _, _, _ := SetupTestRouter(t)
+	userToken := generateTestToken(t, standardUserID, domain.RoleUser)
+
+	// 1. Any authenticated user can export, rather than reaching GET /tasks/:id
+	taskMock.EXPECT().ExportTasks(mock.Anything, domain.TaskFilter{}, mock.Anything).Return(nil)
+	w := makeRequest(r, http.MethodGet, "/api/v1/tasks/export?format=ndjson", userToken)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+
+	// 2. Only admins can import
+	w = makeRequest(r, http.MethodPost, "/api/v1/tasks/import", userToken, []interface{}{})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	taskMock.AssertNotCalled(t, "ImportTasks", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	taskMock.AssertExpectations(t)
+}
+
+func TestRouter_CalendarRoutes(t *testing.T) {
+	r, _, _, _, _, _, _, _, calendarMock := SetupTestRouter(t)
+
+	// 1. Managing a feed needs a login, it is always the feed of the caller
+	w := makeRequest(r, http.MethodPost, "/api/v1/user/calendar", "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	calendarMock.EXPECT().
+		CreateCalendarFeed(mock.Anything, standardUserID).
+		Return(domain.CalendarFeed{UserID: standardUserID}, "secret", nil)
+	userToken := generateTestToken(t, standardUserID, domain.RoleUser)
+	w = makeRequest(r, http.MethodPost, "/api/v1/user/calendar", userToken)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), "/api/v1/calendar/secret/tasks.ics")
+
+	// 2. Calendar apps fetch the feed without a token
+	calendarMock.EXPECT().GetCalendarTasks(mock.Anything, "secret").Return([]domain.Task{}, nil)
+	w = makeRequest(r, http.MethodGet, "/api/v1/calendar/secret/tasks.ics", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, strings.HasPrefix(w.Header().Get("Content-Type"), "text/calendar"))
+
+	calendarMock.AssertExpectations(t)
+}
+
+func TestRouter_JWKS_IsPublic(t *testing.T) {
+	r, _, _, _, _, _, _, _, _ := SetupTestRouter(t)
+
+	// other services fetch the keys without a token, the shared secret is never published
+	w := makeRequest(r, http.MethodGet, "/.well-known/jwks.json", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"keys":[]}`, w.Body.String())
+	assert.Equal(t, "public, max-age=300", w.Header().Get("Cache-Control"))
+}
@@ -0,0 +1,120 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "taskmanager/Domain"
+	"taskmanager/Tests/mocks"
+	usecases "taskmanager/Usecases"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type AuditUsecaseTestSuite struct {
+	suite.Suite
+	mockAuditRepo *mocks.MockAuditRepository
+	usecase       usecases.AuditUsecase
+}
+
+func (suite *AuditUsecaseTestSuite) SetupTest() {
+	// Initialize the mock and the usecase before each test
+	suite.mockAuditRepo = new(mocks.MockAuditRepository)
+	suite.usecase = usecases.NewAuditUsecase(suite.mockAuditRepo)
+}
+
+func TestAuditUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(AuditUsecaseTestSuite))
+}
+
+func (suite *AuditUsecaseTestSuite) TestQueryAuditLog_AppliesDefaults() {
+	ctx := context.TODO()
+
+	expectedFilter := domain.AuditFilter{Action: domain.AuditTaskDeleted, Limit: domain.DefaultAuditPageLimit}
+	suite.mockAuditRepo.EXPECT().Query(ctx, expectedFilter).Return(nil, 0, nil)
+
+	page, err := suite.usecase.QueryAuditLog(ctx, domain.AuditFilter{Action: domain.AuditTaskDeleted})
+
+	suite.NoError(err)
+	suite.NotNil(page.Entries)
+	suite.Equal(domain.DefaultAuditPageLimit, page.Limit)
+}
+
+func (suite *AuditUsecaseTestSuite) TestQueryAuditLog_CapsLimit() {
+	ctx := context.TODO()
+
+	suite.mockAuditRepo.EXPECT().
+		Query(ctx, domain.AuditFilter{Limit: domain.MaxAuditPageLimit}).
+		Return([]domain.AuditEntry{{ID: "e1"}}, 1, nil)
+
+	page, err := suite.usecase.QueryAuditLog(ctx, domain.AuditFilter{Limit: 10000})
+
+	suite.NoError(err)
+	suite.Equal(domain.MaxAuditPageLimit, page.Limit)
+	suite.Len(page.Entries, 1)
+}
+
+func (suite *AuditUsecaseTestSuite) TestQueryAuditLog_FiltersByWebhookTarget() {
+	ctx := context.TODO()
+
+	expectedFilter := domain.AuditFilter{TargetType: domain.AuditTargetWebhook, Limit: domain.DefaultAuditPageLimit}
+	suite.mockAuditRepo.EXPECT().Query(ctx, expectedFilter).Return([]domain.AuditEntry{{ID: "e1"}}, 1, nil)
+
+	page, err := suite.usecase.QueryAuditLog(ctx, domain.AuditFilter{TargetType: domain.AuditTargetWebhook})
+
+	suite.NoError(err)
+	suite.Len(page.Entries, 1)
+}
+
+func (suite *AuditUsecaseTestSuite) TestQueryAuditLog_InvalidFilters() {
+	ctx := context.TODO()
+	now := time.Now()
+
+	filters := []domain.AuditFilter{
+		{Action: "task.explode"},
+		{TargetType: "comment"},
+		{From: now, To: now.Add(-time.Hour)},
+		{Limit: -1},
+	}
+
+	for _, filter := range filters {
+		_, err := suite.usecase.QueryAuditLog(ctx, filter)
+		suite.True(errors.Is(err, domain.ErrValidation), "filter %+v should be rejected", filter)
+	}
+	suite.mockAuditRepo.AssertNotCalled(suite.T(), "Query", mock.Anything, mock.Anything)
+}
+
+func (suite *AuditUsecaseTestSuite) TestExportAuditLog_StreamsEntries() {
+	ctx := context.TODO()
+	filter := domain.AuditFilter{TargetType: domain.AuditTargetUser}
+
+	suite.mockAuditRepo.EXPECT().
+		Stream(ctx, filter, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ domain.AuditFilter, fn func(domain.AuditEntry) error) error {
+			if err := fn(domain.AuditEntry{ID: "e1"}); err != nil {
+				return err
+			}
+			return fn(domain.AuditEntry{ID: "e2"})
+		})
+
+	var ids []string
+	err := suite.usecase.ExportAuditLog(ctx, filter, func(entry domain.AuditEntry) error {
+		ids = append(ids, entry.ID)
+		return nil
+	})
+
+	suite.NoError(err)
+	suite.Equal([]string{"e1", "e2"}, ids)
+}
+
+func (suite *AuditUsecaseTestSuite) TestExportAuditLog_InvalidFilter() {
+	ctx := context.TODO()
+
+	err := suite.usecase.ExportAuditLog(ctx, domain.AuditFilter{Action: "nope"}, func(domain.AuditEntry) error { return nil })
+
+	suite.True(errors.Is(err, domain.ErrValidation))
+	suite.mockAuditRepo.AssertNotCalled(suite.T(), "Stream", mock.Anything, mock.Anything, mock.Anything)
+}
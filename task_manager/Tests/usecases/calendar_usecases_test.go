@@ -0,0 +1,80 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	domain "taskmanager/Domain"
+	repositories "taskmanager/Repositories"
+	usecases "taskmanager/Usecases"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendarUsecase_FeedListsAssignedTasksWithDueDates(t *testing.T) {
+	taskRepo := repositories.NewInMemoryTaskRepository()
+	usecase := usecases.NewCalendarUsecase(repositories.NewInMemoryCalendarFeedRepository(), taskRepo)
+	ctx := context.Background()
+
+	due := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	for _, task := range []domain.Task{
+		{ID: "later", AssigneeID: "user-1", DueDate: due.Add(48 * time.Hour)},
+		{ID: "sooner", AssigneeID: "user-1", DueDate: due},
+		{ID: "no-due-date", AssigneeID: "user-1"},
+		{ID: "someone-else", AssigneeID: "user-2", DueDate: due},
+	} {
+		task.Title, task.Description, task.Status, task.Version = "t", "d", domain.StatusTodo, 1
+		_, err := taskRepo.Create(ctx, task)
+		require.NoError(t, err)
+	}
+
+	feed, token, err := usecase.CreateCalendarFeed(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", feed.UserID)
+	assert.NotEmpty(t, token)
+
+	// only the hash of the secret is stored
+	assert.NotContains(t, feed.TokenHash, token)
+
+	tasks, err := usecase.GetCalendarTasks(ctx, token)
+	require.NoError(t, err)
+	require.Len(t, tasks, 2)
+	assert.Equal(t, "sooner", tasks[0].ID)
+	assert.Equal(t, "later", tasks[1].ID)
+}
+
+func TestCalendarUsecase_NewFeedRevokesTheOldURL(t *testing.T) {
+	usecase := usecases.NewCalendarUsecase(repositories.NewInMemoryCalendarFeedRepository(), repositories.NewInMemoryTaskRepository())
+	ctx := context.Background()
+
+	_, oldToken, err := usecase.CreateCalendarFeed(ctx, "user-1")
+	require.NoError(t, err)
+	_, newToken, err := usecase.CreateCalendarFeed(ctx, "user-1")
+	require.NoError(t, err)
+	assert.NotEqual(t, oldToken, newToken)
+
+	_, err = usecase.GetCalendarTasks(ctx, oldToken)
+	assert.ErrorIs(t, err, domain.ErrInvalidToken)
+
+	tasks, err := usecase.GetCalendarTasks(ctx, newToken)
+	require.NoError(t, err)
+	assert.Empty(t, tasks)
+
+	// a revoked feed is gone for good
+	require.NoError(t, usecase.RevokeCalendarFeed(ctx, "user-1"))
+	_, err = usecase.GetCalendarTasks(ctx, newToken)
+	assert.ErrorIs(t, err, domain.ErrInvalidToken)
+	_, err = usecase.GetCalendarFeed(ctx, "user-1")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.ErrorIs(t, usecase.RevokeCalendarFeed(ctx, "user-1"), domain.ErrNotFound)
+}
+
+func TestCalendarUsecase_GetCalendarTasks_Fail_EmptyToken(t *testing.T) {
+	usecase := usecases.NewCalendarUsecase(repositories.NewInMemoryCalendarFeedRepository(), repositories.NewInMemoryTaskRepository())
+
+	_, err := usecase.GetCalendarTasks(context.Background(), "")
+
+	assert.ErrorIs(t, err, domain.ErrInvalidToken)
+}
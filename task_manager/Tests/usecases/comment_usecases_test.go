@@ -0,0 +1,136 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	domain "taskmanager/Domain"
+	"taskmanager/Tests/mocks"
+	usecases "taskmanager/Usecases"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CommentUsecaseTestSuite struct {
+	suite.Suite
+	mockCommentRepo *mocks.MockCommentRepository
+	mockTaskRepo    *mocks.MockTaskRepository
+	usecase         usecases.CommentUsecase
+}
+
+func (suite *CommentUsecaseTestSuite) SetupTest() {
+	// Initialize the mocks and the usecase before each test
+	suite.mockCommentRepo = new(mocks.MockCommentRepository)
+	suite.mockTaskRepo = new(mocks.MockTaskRepository)
+	suite.usecase = usecases.NewCommentUsecase(suite.mockCommentRepo, suite.mockTaskRepo)
+}
+
+func TestCommentUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(CommentUsecaseTestSuite))
+}
+
+var commentAuthor = domain.Actor{UserID: "author-1", Role: domain.RoleUser}
+
+func (suite *CommentUsecaseTestSuite) TestAddComment_Success() {
+	ctx := context.TODO()
+
+	// EXPECT: the comment is stored with the actor as author and a trimmed body
+	suite.mockTaskRepo.EXPECT().GetByID(ctx, "task-1").Return(domain.Task{ID: "task-1"}, nil)
+	suite.mockCommentRepo.EXPECT().
+		Create(ctx, mock.MatchedBy(func(c domain.Comment) bool {
+			return c.ID != "" && c.TaskID == "task-1" && c.AuthorID == "author-1" && c.Body == "looks good" && !c.CreatedAt.IsZero()
+		})).
+		RunAndReturn(func(ctx context.Context, c domain.Comment) (domain.Comment, error) { return c, nil })
+
+	comment, err := suite.usecase.AddComment(ctx, commentAuthor, "task-1", "  looks good \n")
+
+	suite.NoError(err)
+	suite.Equal("author-1", comment.AuthorID)
+	suite.mockCommentRepo.AssertExpectations(suite.T())
+}
+
+func (suite *CommentUsecaseTestSuite) TestAddComment_TaskNotFound() {
+	ctx := context.TODO()
+
+	suite.mockTaskRepo.EXPECT().GetByID(ctx, "missing").Return(domain.Task{}, domain.ErrNotFound)
+
+	_, err := suite.usecase.AddComment(ctx, commentAuthor, "missing", "hello")
+
+	suite.True(errors.Is(err, domain.ErrNotFound))
+	suite.mockCommentRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *CommentUsecaseTestSuite) TestAddComment_InvalidBody() {
+	ctx := context.TODO()
+
+	for _, body := range []string{"   ", strings.Repeat("a", domain.MaxCommentLength+1)} {
+		_, err := suite.usecase.AddComment(ctx, commentAuthor, "task-1", body)
+		suite.True(errors.Is(err, domain.ErrValidation))
+	}
+	suite.mockTaskRepo.AssertNotCalled(suite.T(), "GetByID", mock.Anything, mock.Anything)
+}
+
+func (suite *CommentUsecaseTestSuite) TestListComments_AppliesDefaults() {
+	ctx := context.TODO()
+
+	suite.mockTaskRepo.EXPECT().GetByID(ctx, "task-1").Return(domain.Task{ID: "task-1"}, nil)
+	suite.mockCommentRepo.EXPECT().
+		ListByTask(ctx, "task-1", domain.DefaultTaskPageLimit, int64(0)).
+		Return(nil, int64(0), nil)
+
+	page, err := suite.usecase.ListComments(ctx, "task-1", 0, 0)
+
+	suite.NoError(err)
+	suite.NotNil(page.Comments)
+	suite.Equal(domain.DefaultTaskPageLimit, page.Limit)
+}
+
+func (suite *CommentUsecaseTestSuite) TestEditComment_ByAuthor() {
+	ctx := context.TODO()
+
+	suite.mockCommentRepo.EXPECT().
+		GetByID(ctx, "task-1", "comment-1").
+		Return(domain.Comment{ID: "comment-1", AuthorID: "author-1"}, nil)
+	suite.mockCommentRepo.EXPECT().
+		Update(ctx, "task-1", "comment-1", "edited", mock.AnythingOfType("time.Time")).
+		Return(domain.Comment{ID: "comment-1", Body: "edited", UpdatedAt: time.Now()}, nil)
+
+	comment, err := suite.usecase.EditComment(ctx, commentAuthor, "task-1", "comment-1", "edited")
+
+	suite.NoError(err)
+	suite.Equal("edited", comment.Body)
+}
+
+func (suite *CommentUsecaseTestSuite) TestEditComment_Forbidden_NotAuthor() {
+	ctx := context.TODO()
+	otherUser := domain.Actor{UserID: "someone-else", Role: domain.RoleUser}
+
+	suite.mockCommentRepo.EXPECT().
+		GetByID(ctx, "task-1", "comment-1").
+		Return(domain.Comment{ID: "comment-1", AuthorID: "author-1"}, nil)
+
+	_, err := suite.usecase.EditComment(ctx, otherUser, "task-1", "comment-1", "edited")
+
+	suite.True(errors.Is(err, domain.ErrForbidden))
+	suite.mockCommentRepo.AssertNotCalled(suite.T(), "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommentUsecaseTestSuite) TestDeleteComment_ByAdmin() {
+	ctx := context.TODO()
+	admin := domain.Actor{UserID: "admin-1", Role: domain.RoleAdmin}
+
+	// admins can remove any comment
+	suite.mockCommentRepo.EXPECT().
+		GetByID(ctx, "task-1", "comment-1").
+		Return(domain.Comment{ID: "comment-1", AuthorID: "author-1"}, nil)
+	suite.mockCommentRepo.EXPECT().Delete(ctx, "task-1", "comment-1").Return(nil)
+
+	err := suite.usecase.DeleteComment(ctx, admin, "task-1", "comment-1")
+
+	suite.NoError(err)
+	suite.mockCommentRepo.AssertExpectations(suite.T())
+}
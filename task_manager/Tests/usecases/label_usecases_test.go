@@ -0,0 +1,148 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	domain "taskmanager/Domain"
+	"taskmanager/Tests/mocks"
+	usecases "taskmanager/Usecases"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type LabelUsecaseTestSuite struct {
+	suite.Suite
+	mockLabelRepo *mocks.MockLabelRepository
+	mockTaskRepo  *mocks.MockTaskRepository
+	mockAudit     *mocks.MockAuditRepository
+	usecase       usecases.LabelUsecase
+}
+
+func (suite *LabelUsecaseTestSuite) SetupTest() {
+	// Initialize the mocks and the usecase before each test
+	suite.mockLabelRepo = new(mocks.MockLabelRepository)
+	suite.mockTaskRepo = new(mocks.MockTaskRepository)
+	suite.mockAudit = new(mocks.MockAuditRepository)
+	suite.usecase = usecases.NewLabelUsecase(suite.mockLabelRepo, suite.mockTaskRepo, suite.mockAudit)
+}
+
+func TestLabelUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(LabelUsecaseTestSuite))
+}
+
+var labelAdmin = domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}
+
+// expectLabelAudit expects one audit entry for the given action on a label
+func (suite *LabelUsecaseTestSuite) expectLabelAudit(action domain.AuditAction, name string) {
+	suite.mockAudit.EXPECT().
+		Append(mock.Anything, mock.MatchedBy(func(e domain.AuditEntry) bool {
+			return e.Action == action && e.TargetType == domain.AuditTargetLabel && e.TargetID == name
+		})).
+		Return(nil).Once()
+}
+
+func (suite *LabelUsecaseTestSuite) TestCreateLabel_NormalizesAndDefaultsColor() {
+	ctx := context.TODO()
+
+	suite.mockLabelRepo.EXPECT().
+		Create(ctx, mock.MatchedBy(func(l domain.Label) bool {
+			return l.Name == "backend" && l.Color == domain.DefaultLabelColor && !l.CreatedAt.IsZero()
+		})).
+		RunAndReturn(func(ctx context.Context, l domain.Label) (domain.Label, error) { return l, nil })
+	suite.expectLabelAudit(domain.AuditLabelCreated, "backend")
+
+	label, err := suite.usecase.CreateLabel(ctx, labelAdmin, domain.Label{Name: " Backend "})
+
+	suite.NoError(err)
+	suite.Equal("backend", label.Name)
+	suite.mockAudit.AssertExpectations(suite.T())
+}
+
+func (suite *LabelUsecaseTestSuite) TestCreateLabel_Invalid() {
+	for _, label := range []domain.Label{
+		{Name: ""},
+		{Name: "two words"},
+		{Name: "ok", Color: "red"},
+	} {
+		_, err := suite.usecase.CreateLabel(context.TODO(), labelAdmin, label)
+		suite.True(errors.Is(err, domain.ErrValidation), "label %+v should be rejected", label)
+	}
+	suite.mockLabelRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *LabelUsecaseTestSuite) TestUpdateLabel_RenameCascadesToTasks() {
+	ctx := context.TODO()
+
+	suite.mockLabelRepo.EXPECT().GetByName(ctx, "bug").Return(domain.Label{Name: "bug", Color: "#ff0000"}, nil)
+	suite.mockLabelRepo.EXPECT().GetByName(ctx, "defect").Return(domain.Label{}, domain.ErrNotFound)
+
+	// EXPECT: the tasks are renamed before the definition
+	renamed := suite.mockTaskRepo.EXPECT().RenameLabel(ctx, "bug", "defect").Return(int64(3), nil).Call
+	suite.mockLabelRepo.EXPECT().
+		Update(ctx, "bug", domain.Label{Name: "defect", Color: "#ff0000"}).
+		Return(domain.Label{Name: "defect", Color: "#ff0000"}, nil).
+		NotBefore(renamed)
+	suite.expectLabelAudit(domain.AuditLabelUpdated, "bug")
+
+	label, err := suite.usecase.UpdateLabel(ctx, labelAdmin, "bug", domain.Label{Name: "Defect"})
+
+	suite.NoError(err)
+	suite.Equal("defect", label.Name)
+	suite.mockTaskRepo.AssertExpectations(suite.T())
+}
+
+func (suite *LabelUsecaseTestSuite) TestUpdateLabel_RenameToExistingLabel() {
+	ctx := context.TODO()
+
+	suite.mockLabelRepo.EXPECT().GetByName(ctx, "bug").Return(domain.Label{Name: "bug"}, nil)
+	suite.mockLabelRepo.EXPECT().GetByName(ctx, "defect").Return(domain.Label{Name: "defect"}, nil)
+
+	_, err := suite.usecase.UpdateLabel(ctx, labelAdmin, "bug", domain.Label{Name: "defect"})
+
+	suite.True(errors.Is(err, domain.ErrAleadyExists))
+	suite.mockTaskRepo.AssertNotCalled(suite.T(), "RenameLabel", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *LabelUsecaseTestSuite) TestUpdateLabel_ColorOnlyLeavesTasksAlone() {
+	ctx := context.TODO()
+
+	suite.mockLabelRepo.EXPECT().GetByName(ctx, "bug").Return(domain.Label{Name: "bug", Color: "#ff0000"}, nil)
+	suite.mockLabelRepo.EXPECT().
+		Update(ctx, "bug", domain.Label{Name: "bug", Color: "#00ff00"}).
+		Return(domain.Label{Name: "bug", Color: "#00ff00"}, nil)
+	suite.expectLabelAudit(domain.AuditLabelUpdated, "bug")
+
+	_, err := suite.usecase.UpdateLabel(ctx, labelAdmin, "bug", domain.Label{Color: "#00FF00"})
+
+	suite.NoError(err)
+	suite.mockTaskRepo.AssertNotCalled(suite.T(), "RenameLabel", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *LabelUsecaseTestSuite) TestDeleteLabel_CascadesToTasks() {
+	ctx := context.TODO()
+
+	suite.mockLabelRepo.EXPECT().GetByName(ctx, "bug").Return(domain.Label{Name: "bug"}, nil)
+	dropped := suite.mockTaskRepo.EXPECT().DropLabel(ctx, "bug").Return(int64(2), nil).Call
+	suite.mockLabelRepo.EXPECT().Delete(ctx, "bug").Return(nil).NotBefore(dropped)
+	suite.expectLabelAudit(domain.AuditLabelDeleted, "bug")
+
+	untagged, err := suite.usecase.DeleteLabel(ctx, labelAdmin, "bug")
+
+	suite.NoError(err)
+	suite.Equal(int64(2), untagged)
+	suite.mockLabelRepo.AssertExpectations(suite.T())
+}
+
+func (suite *LabelUsecaseTestSuite) TestDeleteLabel_NotFound() {
+	ctx := context.TODO()
+
+	suite.mockLabelRepo.EXPECT().GetByName(ctx, "bug").Return(domain.Label{}, domain.ErrNotFound)
+
+	_, err := suite.usecase.DeleteLabel(ctx, labelAdmin, "bug")
+
+	suite.True(errors.Is(err, domain.ErrNotFound))
+	suite.mockTaskRepo.AssertNotCalled(suite.T(), "DropLabel", mock.Anything, mock.Anything)
+}
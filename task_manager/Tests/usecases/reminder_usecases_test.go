@@ -0,0 +1,129 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	domain "taskmanager/Domain"
+	"taskmanager/Tests/mocks"
+	usecases "taskmanager/Usecases"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReminderUsecaseTestSuite struct {
+	suite.Suite
+	mockTasks     *mocks.MockTaskRepository
+	mockReminders *mocks.MockReminderRepository
+	mockNotifier  *mocks.MockNotifier
+	usecase       usecases.ReminderUsecase
+}
+
+func (suite *ReminderUsecaseTestSuite) SetupTest() {
+	suite.mockTasks = new(mocks.MockTaskRepository)
+	suite.mockReminders = new(mocks.MockReminderRepository)
+	suite.mockNotifier = new(mocks.MockNotifier)
+	suite.usecase = usecases.NewReminderUsecase(suite.mockTasks, suite.mockReminders, suite.mockNotifier, time.Hour)
+}
+
+// reminderFor matches the reminder of the given kind for a task
+func reminderFor(taskId string, kind domain.ReminderKind) interface{} {
+	return mock.MatchedBy(func(r domain.Reminder) bool {
+		return r.TaskID == taskId && r.Kind == kind
+	})
+}
+
+func (suite *ReminderUsecaseTestSuite) TestSendDueReminders_SendsUnsentReminders() {
+	ctx := context.TODO()
+	now := time.Now()
+
+	// only tasks due within the lead time, or overdue for less than the lookback, are checked
+	suite.mockTasks.EXPECT().
+		GetAll(ctx, mock.MatchedBy(func(f domain.TaskFilter) bool {
+			return f.DueTo.Sub(now) >= time.Hour-time.Minute && now.Sub(f.DueFrom) >= domain.ReminderLookback-time.Minute && f.Offset == 0
+		})).
+		Return([]domain.Task{
+			{ID: "overdue", Status: domain.StatusInProgress, DueDate: now.Add(-time.Hour)},
+			{ID: "soon", Status: domain.StatusTodo, DueDate: now.Add(30 * time.Minute)},
+			{ID: "finished", Status: domain.StatusDone, DueDate: now.Add(10 * time.Minute)},
+			{ID: "reminded", Status: domain.StatusTodo, DueDate: now.Add(20 * time.Minute)},
+		}, int64(4), nil)
+
+	suite.mockReminders.EXPECT().WasSent(ctx, mock.Anything).
+		RunAndReturn(func(ctx context.Context, key string) (bool, error) {
+			return strings.HasPrefix(key, "reminded/"), nil
+		})
+	suite.mockNotifier.EXPECT().Notify(ctx, reminderFor("overdue", domain.ReminderOverdue)).Return(nil).Once()
+	suite.mockNotifier.EXPECT().Notify(ctx, reminderFor("soon", domain.ReminderDueSoon)).Return(nil).Once()
+	suite.mockReminders.EXPECT().
+		MarkSent(mock.Anything, mock.MatchedBy(func(r domain.Reminder) bool { return !r.SentAt.IsZero() })).
+		Return(nil).Twice()
+
+	sent, err := suite.usecase.SendDueReminders(ctx)
+
+	suite.NoError(err)
+	suite.Equal(2, sent)
+	suite.mockNotifier.AssertExpectations(suite.T())
+	suite.mockReminders.AssertExpectations(suite.T())
+}
+
+func (suite *ReminderUsecaseTestSuite) TestSendDueReminders_FailedReminderIsRetried() {
+	ctx := context.TODO()
+	now := time.Now()
+
+	suite.mockTasks.EXPECT().GetAll(ctx, mock.Anything).
+		Return([]domain.Task{
+			{ID: "a", Status: domain.StatusTodo, DueDate: now.Add(10 * time.Minute)},
+			{ID: "b", Status: domain.StatusTodo, DueDate: now.Add(20 * time.Minute)},
+		}, int64(2), nil)
+	suite.mockReminders.EXPECT().WasSent(ctx, mock.Anything).Return(false, nil)
+	suite.mockNotifier.EXPECT().Notify(ctx, reminderFor("a", domain.ReminderDueSoon)).Return(errors.New("mail server down"))
+	suite.mockNotifier.EXPECT().Notify(ctx, reminderFor("b", domain.ReminderDueSoon)).Return(nil)
+	suite.mockReminders.EXPECT().MarkSent(mock.Anything, reminderFor("b", domain.ReminderDueSoon)).Return(nil).Once()
+
+	sent, err := suite.usecase.SendDueReminders(ctx)
+
+	// a isn't recorded, so the next run sends it again
+	suite.ErrorContains(err, "mail server down")
+	suite.Equal(1, sent)
+	suite.mockReminders.AssertExpectations(suite.T())
+}
+
+func TestReminderUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(ReminderUsecaseTestSuite))
+}
+
+func TestReminderScheduler_RunsUntilCancelled(t *testing.T) {
+	mockUsecase := new(mocks.MockReminderUsecase)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// send right away and on every tick, stop after the second run
+	runs := 0
+	mockUsecase.EXPECT().
+		SendDueReminders(mock.Anything).
+		Run(func(context.Context) {
+			runs++
+			if runs == 2 {
+				cancel()
+			}
+		}).
+		Return(0, nil)
+
+	done := make(chan struct{})
+	go func() {
+		usecases.NewReminderScheduler(mockUsecase, time.Millisecond).Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after the context was cancelled")
+	}
+	assert.Equal(t, 2, runs)
+}
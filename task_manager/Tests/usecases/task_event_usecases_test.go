@@ -0,0 +1,188 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	domain "taskmanager/Domain"
+	usecases "taskmanager/Usecases"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// nextTaskEvent waits for the next event of a stream
+func nextTaskEvent(t *testing.T, subscription *usecases.TaskSubscription) domain.TaskEvent {
+	t.Helper()
+
+	select {
+	case event, ok := <-subscription.Events:
+		require.True(t, ok, "the stream ended")
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("no event was streamed")
+		return domain.TaskEvent{}
+	}
+}
+
+func TestTaskEventUsecase_StreamsTaskChanges(t *testing.T) {
+	usecase := usecases.NewTaskEventUsecase(10)
+	ctx := context.Background()
+
+	subscription, err := usecase.Subscribe("", domain.TaskEventFilter{})
+	require.NoError(t, err)
+	defer subscription.Close()
+
+	task := domain.Task{ID: "1", Title: "Ship it", Status: domain.StatusTodo}
+	usecase.Publish(ctx, domain.WebhookTaskCreated, "admin-id", nil, task)
+	usecase.Publish(ctx, domain.WebhookTaskDeleted, "admin-id", task, nil)
+
+	// users aren't part of the task stream
+	usecase.Publish(ctx, domain.WebhookUserPromoted, "admin-id", domain.User{}, domain.User{})
+
+	created := nextTaskEvent(t, subscription)
+	assert.Equal(t, domain.WebhookTaskCreated, created.Event)
+	assert.Equal(t, "admin-id", created.ActorID)
+	assert.Equal(t, task, created.Task)
+	assert.NotEmpty(t, created.ID)
+
+	// a deletion carries the task as it was before
+	deleted := nextTaskEvent(t, subscription)
+	assert.Equal(t, domain.WebhookTaskDeleted, deleted.Event)
+	assert.Equal(t, task, deleted.Task)
+	assert.NotEqual(t, created.ID, deleted.ID)
+
+	assert.Empty(t, subscription.Events)
+}
+
+func TestTaskEventUsecase_FiltersByStatusAndAssignee(t *testing.T) {
+	usecase := usecases.NewTaskEventUsecase(10)
+	ctx := context.Background()
+
+	subscription, err := usecase.Subscribe("", domain.TaskEventFilter{Status: domain.StatusTodo, AssigneeID: "user-1"})
+	require.NoError(t, err)
+	defer subscription.Close()
+
+	mine := domain.Task{ID: "1", Status: domain.StatusTodo, AssigneeID: "user-1"}
+	usecase.Publish(ctx, domain.WebhookTaskCreated, "admin-id", nil, domain.Task{ID: "2", Status: domain.StatusTodo, AssigneeID: "user-2"})
+	usecase.Publish(ctx, domain.WebhookTaskCreated, "admin-id", nil, mine)
+
+	assert.Equal(t, "1", nextTaskEvent(t, subscription).Task.ID)
+
+	// a task leaving the filter is still streamed, so the client can drop it
+	started := mine
+	started.Status = domain.StatusInProgress
+	usecase.Publish(ctx, domain.WebhookTaskUpdated, "user-1", mine, started)
+
+	left := nextTaskEvent(t, subscription)
+	assert.Equal(t, domain.StatusInProgress, left.Task.Status)
+
+	// after that it is no longer of interest
+	done := started
+	done.Status = domain.StatusDone
+	usecase.Publish(ctx, domain.WebhookTaskUpdated, "user-1", started, done)
+	assert.Empty(t, subscription.Events)
+}
+
+func TestTaskEventUsecase_ResumesFromLastEventID(t *testing.T) {
+	usecase := usecases.NewTaskEventUsecase(3)
+	ctx := context.Background()
+
+	first, err := usecase.Subscribe("", domain.TaskEventFilter{})
+	require.NoError(t, err)
+
+	for _, id := range []string{"1", "2", "3"} {
+		usecase.Publish(ctx, domain.WebhookTaskCreated, "admin-id", nil, domain.Task{ID: id})
+	}
+	seen := nextTaskEvent(t, first)
+	first.Close()
+
+	// the client reconnects having only seen the first event
+	resumed, err := usecase.Subscribe(seen.ID, domain.TaskEventFilter{})
+	require.NoError(t, err)
+	defer resumed.Close()
+
+	assert.False(t, resumed.Resync)
+	require.Len(t, resumed.Missed, 2)
+	assert.Equal(t, "2", resumed.Missed[0].Task.ID)
+	assert.Equal(t, "3", resumed.Missed[1].Task.ID)
+
+	// new events follow the replayed ones
+	usecase.Publish(ctx, domain.WebhookTaskCreated, "admin-id", nil, domain.Task{ID: "4"})
+	latest := nextTaskEvent(t, resumed)
+	assert.Equal(t, "4", latest.Task.ID)
+
+	// resuming from the latest event misses nothing
+	upToDate, err := usecase.Subscribe(latest.ID, domain.TaskEventFilter{})
+	require.NoError(t, err)
+	defer upToDate.Close()
+	assert.False(t, upToDate.Resync)
+	assert.Empty(t, upToDate.Missed)
+}
+
+func TestTaskEventUsecase_AsksForResyncWhenEventsAreGone(t *testing.T) {
+	usecase := usecases.NewTaskEventUsecase(2)
+	ctx := context.Background()
+
+	subscription, err := usecase.Subscribe("", domain.TaskEventFilter{})
+	require.NoError(t, err)
+
+	for _, id := range []string{"1", "2", "3", "4"} {
+		usecase.Publish(ctx, domain.WebhookTaskCreated, "admin-id", nil, domain.Task{ID: id})
+	}
+	seen := nextTaskEvent(t, subscription)
+	subscription.Close()
+
+	// the events after the first one were pushed out of the buffer
+	evicted, err := usecase.Subscribe(seen.ID, domain.TaskEventFilter{})
+	require.NoError(t, err)
+	defer evicted.Close()
+	assert.True(t, evicted.Resync)
+	assert.Empty(t, evicted.Missed)
+
+	// ids from before a restart, or made up, can't be resumed from
+	for _, lastEventId := range []string{"another-epoch-1", "garbage"} {
+		unknown, err := usecase.Subscribe(lastEventId, domain.TaskEventFilter{})
+		require.NoError(t, err)
+		assert.True(t, unknown.Resync, lastEventId)
+		unknown.Close()
+	}
+}
+
+func TestTaskEventUsecase_DropsSlowSubscribersAndClosesOnShutdown(t *testing.T) {
+	usecase := usecases.NewTaskEventUsecase(10)
+	ctx := context.Background()
+
+	slow, err := usecase.Subscribe("", domain.TaskEventFilter{})
+	require.NoError(t, err)
+
+	// a stream that is never read from falls behind and is ended
+	for i := 0; i < 100; i++ {
+		usecase.Publish(ctx, domain.WebhookTaskUpdated, "admin-id", domain.Task{ID: "1"}, domain.Task{ID: "1"})
+	}
+	for range slow.Events {
+	}
+
+	live, err := usecase.Subscribe("", domain.TaskEventFilter{})
+	require.NoError(t, err)
+
+	usecase.Close()
+	_, ok := <-live.Events
+	assert.False(t, ok)
+
+	// closing again after shutdown is harmless, and new streams end right away
+	live.Close()
+	late, err := usecase.Subscribe("", domain.TaskEventFilter{})
+	require.NoError(t, err)
+	_, ok = <-late.Events
+	assert.False(t, ok)
+}
+
+func TestTaskEventUsecase_Subscribe_Fail_UnknownStatus(t *testing.T) {
+	usecase := usecases.NewTaskEventUsecase(10)
+
+	_, err := usecase.Subscribe("", domain.TaskEventFilter{Status: "exploded"})
+
+	assert.ErrorIs(t, err, domain.ErrValidation)
+}
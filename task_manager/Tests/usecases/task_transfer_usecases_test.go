@@ -0,0 +1,342 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "taskmanager/Domain"
+	repositories "taskmanager/Repositories"
+	usecases "taskmanager/Usecases"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTransferUsecase returns a task usecase over in-memory storage, with the task repository to inspect
+func newTransferUsecase() (usecases.TaskUsecase, repositories.TaskRepository) {
+	repo := repositories.NewInMemoryTaskRepository()
+	usecase := usecases.NewTaskUsecase(repo, repositories.NewInMemoryUserRepository(), repositories.NewInMemoryCommentRepository(), repositories.NewInMemoryAuditRepository(), repositories.NewInMemoryLabelRepository(), usecases.EventPublishers{})
+	return usecase, repo
+}
+
+// importRows numbers the tasks like a decoded file
+func importRows(tasks ...domain.Task) []domain.TaskImportRow {
+	rows := make([]domain.TaskImportRow, len(tasks))
+	for i, task := range tasks {
+		rows[i] = domain.TaskImportRow{Row: i + 1, Task: task}
+	}
+	return rows
+}
+
+var importAdmin = domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}
+
+func TestExportTasks_StreamsEveryMatchingTask(t *testing.T) {
+	usecase, _ := newTransferUsecase()
+	ctx := context.Background()
+
+	for _, title := range []string{"b", "a", "c"} {
+		_, err := usecase.CreateTask(ctx, domain.Task{Title: title, Description: "d", Status: domain.StatusTodo, CreatedBy: "admin-id"})
+		require.NoError(t, err)
+	}
+	_, err := usecase.CreateTask(ctx, domain.Task{Title: "finished", Description: "d", Status: domain.StatusDone, CreatedBy: "admin-id"})
+	require.NoError(t, err)
+
+	// the page size doesn't apply to an export
+	var titles []string
+	err = usecase.ExportTasks(ctx, domain.TaskFilter{Status: domain.StatusTodo, SortBy: "title", Limit: 1}, func(task domain.Task) error {
+		titles = append(titles, task.Title)
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, titles)
+}
+
+func TestExportTasks_StopsOnCallbackError(t *testing.T) {
+	usecase, _ := newTransferUsecase()
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		_, err := usecase.CreateTask(ctx, domain.Task{Title: "t", Description: "d", Status: domain.StatusTodo})
+		require.NoError(t, err)
+	}
+
+	stop := errors.New("client went away")
+	calls := 0
+	err := usecase.ExportTasks(ctx, domain.TaskFilter{}, func(task domain.Task) error {
+		calls++
+		return stop
+	})
+
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, calls)
+}
+
+func TestExportTasks_InvalidFilter(t *testing.T) {
+	usecase, _ := newTransferUsecase()
+
+	err := usecase.ExportTasks(context.Background(), domain.TaskFilter{SortBy: "password"}, func(task domain.Task) error { return nil })
+
+	assert.ErrorIs(t, err, domain.ErrValidation)
+}
+
+func TestImportTasks_ReportsEachRow(t *testing.T) {
+	usecase, repo := newTransferUsecase()
+	ctx := context.Background()
+
+	keptId := uuid.NewString()
+	rows := importRows(
+		domain.Task{ID: keptId, Title: "kept id", Description: "d", Status: domain.StatusTodo},
+		domain.Task{Title: "missing a description", Status: domain.StatusTodo},
+		domain.Task{ID: "not-a-uuid", Title: "t", Description: "d", Status: domain.StatusTodo},
+	)
+	rows = append(rows, domain.TaskImportRow{Row: 4, Err: errors.New("due_date must be an RFC3339 timestamp")})
+
+	result, err := usecase.ImportTasks(ctx, importAdmin, rows, domain.TaskImportOptions{})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Created)
+	assert.Equal(t, 3, result.Failed)
+	require.Len(t, result.Results, 4)
+	assert.Equal(t, domain.BulkItemSucceeded, result.Results[0].Status)
+	assert.Equal(t, domain.TaskImportCreated, result.Results[0].Action)
+	assert.Contains(t, result.Results[1].Error, "required")
+	assert.Contains(t, result.Results[2].Error, "valid task id")
+	assert.Contains(t, result.Results[3].Error, "RFC3339")
+	assert.Equal(t, 4, result.Results[3].Row)
+
+	// the task keeps its id and is created by the importing admin
+	task, err := repo.GetByID(ctx, keptId)
+	require.NoError(t, err)
+	assert.Equal(t, "admin-id", task.CreatedBy)
+}
+
+func TestImportTasks_DryRunWritesNothing(t *testing.T) {
+	usecase, repo := newTransferUsecase()
+	ctx := context.Background()
+
+	parentId := uuid.NewString()
+	rows := importRows(
+		domain.Task{ID: parentId, Title: "parent", Description: "d", Status: domain.StatusTodo},
+		domain.Task{Title: "child", Description: "d", Status: domain.StatusTodo, ParentID: parentId},
+	)
+
+	result, err := usecase.ImportTasks(ctx, importAdmin, rows, domain.TaskImportOptions{DryRun: true})
+
+	// a row can link to the task of an earlier row, even when it isn't written
+	require.NoError(t, err)
+	assert.True(t, result.DryRun)
+	assert.Equal(t, 2, result.Created)
+	assert.Equal(t, 0, result.Failed)
+
+	tasks, _, err := repo.GetAll(ctx, domain.TaskFilter{})
+	require.NoError(t, err)
+	assert.Empty(t, tasks)
+}
+
+func TestImportTasks_UpsertKeysOnID(t *testing.T) {
+	usecase, repo := newTransferUsecase()
+	ctx := context.Background()
+
+	existing, err := usecase.CreateTask(ctx, domain.Task{Title: "old title", Description: "d", Status: domain.StatusTodo, CreatedBy: "someone"})
+	require.NoError(t, err)
+	row := domain.Task{ID: existing.ID, Title: "new title", Description: "d", Status: domain.StatusInProgress}
+
+	// without upsert an existing id fails its row
+	result, err := usecase.ImportTasks(ctx, importAdmin, importRows(row), domain.TaskImportOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Failed)
+	assert.Contains(t, result.Results[0].Error, "upsert")
+
+	result, err = usecase.ImportTasks(ctx, importAdmin, importRows(row), domain.TaskImportOptions{Upsert: true})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Updated)
+	assert.Equal(t, domain.TaskImportUpdated, result.Results[0].Action)
+
+	task, err := repo.GetByID(ctx, existing.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "new title", task.Title)
+	assert.Equal(t, domain.StatusInProgress, task.Status)
+	assert.Equal(t, "someone", task.CreatedBy)
+	assert.Equal(t, existing.Version+1, task.Version)
+}
+
+func TestImportTasks_UpsertMovesStatusLikeAnUpdate(t *testing.T) {
+	usecase, repo := newTransferUsecase()
+	ctx := context.Background()
+
+	finished, err := usecase.CreateTask(ctx, domain.Task{Title: "finished", Description: "d", Status: domain.StatusDone, CreatedBy: "someone"})
+	require.NoError(t, err)
+	blocker, err := usecase.CreateTask(ctx, domain.Task{Title: "blocker", Description: "d", Status: domain.StatusTodo, CreatedBy: "someone"})
+	require.NoError(t, err)
+	blocked, err := usecase.CreateTask(ctx, domain.Task{Title: "blocked", Description: "d", Status: domain.StatusTodo, CreatedBy: "someone", BlockedBy: []string{blocker.ID}})
+	require.NoError(t, err)
+
+	rows := importRows(
+		// a done task can only be reopened
+		domain.Task{ID: finished.ID, Title: "finished", Description: "d", Status: domain.StatusInProgress},
+		// its blocker isn't finished yet
+		domain.Task{ID: blocked.ID, Title: "blocked", Description: "d", Status: domain.StatusDone, BlockedBy: []string{blocker.ID}},
+	)
+
+	result, err := usecase.ImportTasks(ctx, importAdmin, rows, domain.TaskImportOptions{Upsert: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Failed)
+	assert.Contains(t, result.Results[0].Error, "cannot move task")
+	assert.Contains(t, result.Results[1].Error, blocker.ID)
+
+	task, err := repo.GetByID(ctx, finished.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusDone, task.Status)
+	task, err = repo.GetByID(ctx, blocked.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusTodo, task.Status)
+
+	// once an earlier row finishes the blocker, the task can be completed
+	rows = importRows(
+		domain.Task{ID: blocker.ID, Title: "blocker", Description: "d", Status: domain.StatusDone},
+		domain.Task{ID: blocked.ID, Title: "blocked", Description: "d", Status: domain.StatusDone, BlockedBy: []string{blocker.ID}},
+	)
+	result, err = usecase.ImportTasks(ctx, importAdmin, rows, domain.TaskImportOptions{Upsert: true, DryRun: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Updated)
+}
+
+func TestImportTasks_UpsertCompletingRecurringTaskSpawnsNext(t *testing.T) {
+	usecase, repo := newTransferUsecase()
+	ctx := context.Background()
+
+	dueDate := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	existing, err := usecase.CreateTask(ctx, domain.Task{Title: "standup", Description: "d", Status: domain.StatusTodo, CreatedBy: "someone", DueDate: dueDate, Recurrence: "FREQ=DAILY"})
+	require.NoError(t, err)
+
+	row := domain.Task{ID: existing.ID, Title: "standup", Description: "d", Status: domain.StatusDone, DueDate: dueDate, Recurrence: "FREQ=DAILY"}
+	result, err := usecase.ImportTasks(ctx, importAdmin, importRows(row), domain.TaskImportOptions{Upsert: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Updated)
+
+	// the completed task hands its rule over to the next occurrence
+	task, err := repo.GetByID(ctx, existing.ID)
+	require.NoError(t, err)
+	assert.Empty(t, task.Recurrence)
+
+	tasks, total, err := repo.GetAll(ctx, domain.TaskFilter{Status: domain.StatusTodo})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	assert.Equal(t, "FREQ=DAILY", tasks[0].Recurrence)
+	assert.Equal(t, 2, tasks[0].Occurrence)
+	assert.True(t, dueDate.AddDate(0, 0, 1).Equal(tasks[0].DueDate))
+}
+
+func TestImportTasks_RejectsRepeatedIDsAndCycles(t *testing.T) {
+	usecase, _ := newTransferUsecase()
+	ctx := context.Background()
+
+	a, b := uuid.NewString(), uuid.NewString()
+	rows := importRows(
+		domain.Task{ID: a, Title: "a", Description: "d", Status: domain.StatusTodo},
+		domain.Task{ID: b, Title: "b", Description: "d", Status: domain.StatusTodo, BlockedBy: []string{a}},
+		domain.Task{ID: a, Title: "a again", Description: "d", Status: domain.StatusTodo},
+		domain.Task{Title: "orphan", Description: "d", Status: domain.StatusTodo, ParentID: uuid.NewString()},
+	)
+
+	result, err := usecase.ImportTasks(ctx, importAdmin, rows, domain.TaskImportOptions{Upsert: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Created)
+	assert.Contains(t, result.Results[2].Error, "row 1")
+	assert.Contains(t, result.Results[3].Error, "does not exist")
+
+	// a now waiting on b would close the loop
+	cycle := domain.Task{ID: a, Title: "a", Description: "d", Status: domain.StatusTodo, BlockedBy: []string{b}}
+	result, err = usecase.ImportTasks(ctx, importAdmin, importRows(cycle), domain.TaskImportOptions{Upsert: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Failed)
+	assert.Contains(t, result.Results[0].Error, "cycle")
+}
+
+func TestImportTasks_AssigneeMustExist(t *testing.T) {
+	users := repositories.NewInMemoryUserRepository()
+	usecase := usecases.NewTaskUsecase(repositories.NewInMemoryTaskRepository(), users, repositories.NewInMemoryCommentRepository(), repositories.NewInMemoryAuditRepository(), repositories.NewInMemoryLabelRepository(), usecases.EventPublishers{})
+	ctx := context.Background()
+
+	user, err := users.SaveUser(ctx, domain.User{ID: uuid.New(), UserName: "alice", Role: domain.RoleUser})
+	require.NoError(t, err)
+
+	known := domain.Task{ID: uuid.NewString(), Title: "known", Description: "d", Status: domain.StatusTodo, AssigneeID: user.ID.String()}
+	unknown := domain.Task{Title: "unknown", Description: "d", Status: domain.StatusTodo, AssigneeID: uuid.NewString()}
+	result, err := usecase.ImportTasks(ctx, importAdmin, importRows(known, unknown), domain.TaskImportOptions{})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Created)
+	assert.Contains(t, result.Results[1].Error, "does not exist")
+
+	// an upsert can't hand the task over to an unknown user either
+	known.AssigneeID = uuid.NewString()
+	result, err = usecase.ImportTasks(ctx, importAdmin, importRows(known), domain.TaskImportOptions{Upsert: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Failed)
+	assert.Contains(t, result.Results[0].Error, "does not exist")
+}
+
+// racingTaskRepository writes every task once more right before it is created, like a concurrent import of the same file
+type racingTaskRepository struct {
+	repositories.TaskRepository
+}
+
+func (r racingTaskRepository) Create(ctx context.Context, task domain.Task) (domain.Task, error) {
+	if _, err := r.TaskRepository.Create(ctx, task); err != nil {
+		return domain.Task{}, err
+	}
+	return r.TaskRepository.Create(ctx, task)
+}
+
+func TestImportTasks_TakenIDIsARowError(t *testing.T) {
+	repo := racingTaskRepository{repositories.NewInMemoryTaskRepository()}
+	usecase := usecases.NewTaskUsecase(repo, repositories.NewInMemoryUserRepository(), repositories.NewInMemoryCommentRepository(), repositories.NewInMemoryAuditRepository(), repositories.NewInMemoryLabelRepository(), usecases.EventPublishers{})
+	ctx := context.Background()
+
+	// the id is free when the row is checked, the storage rejects it on insert
+	row := domain.Task{ID: uuid.NewString(), Title: "t", Description: "d", Status: domain.StatusTodo}
+	result, err := usecase.ImportTasks(ctx, importAdmin, importRows(row), domain.TaskImportOptions{})
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Created)
+	assert.Equal(t, 1, result.Failed)
+	assert.Contains(t, result.Results[0].Error, "already exists")
+}
+
+func TestImportTasks_TrashedIDIsARowError(t *testing.T) {
+	usecase, repo := newTransferUsecase()
+	ctx := context.Background()
+
+	trashed, err := usecase.CreateTask(ctx, domain.Task{Title: "t", Description: "d", Status: domain.StatusTodo})
+	require.NoError(t, err)
+	require.NoError(t, repo.Delete(ctx, trashed.ID, trashed.Version, time.Now()))
+
+	for _, dryRun := range []bool{false, true} {
+		result, err := usecase.ImportTasks(ctx, importAdmin, importRows(trashed), domain.TaskImportOptions{Upsert: true, DryRun: dryRun})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Failed)
+		assert.Contains(t, result.Results[0].Error, "in the trash")
+	}
+}
+
+func TestImportTasks_InvalidImports(t *testing.T) {
+	usecase, _ := newTransferUsecase()
+
+	_, err := usecase.ImportTasks(context.Background(), importAdmin, nil, domain.TaskImportOptions{})
+	assert.ErrorIs(t, err, domain.ErrValidation)
+
+	_, err = usecase.ImportTasks(context.Background(), importAdmin, make([]domain.TaskImportRow, domain.MaxImportTasks+1), domain.TaskImportOptions{})
+	assert.ErrorIs(t, err, domain.ErrValidation)
+}
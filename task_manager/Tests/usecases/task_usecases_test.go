@@ -2,10 +2,15 @@ package usecases_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	domain "taskmanager/Domain"
+	repositories "taskmanager/Repositories"
 	"taskmanager/Tests/mocks"
 	usecases "taskmanager/Usecases"
 
@@ -16,14 +21,36 @@ import (
 
 type TaskUsecaseTestSuite struct {
 	suite.Suite
-	mockRepo *mocks.MockTaskRepository
-	usecase  usecases.TaskUsecase
+	mockRepo     *mocks.MockTaskRepository
+	mockUsers    *mocks.MockUserRepository
+	mockComments *mocks.MockCommentRepository
+	mockAudit    *mocks.MockAuditRepository
+	mockLabels   *mocks.MockLabelRepository
+	mockEvents   *mocks.MockEventPublisher
+	usecase      usecases.TaskUsecase
 }
 
 func (suite *TaskUsecaseTestSuite) SetupTest() {
 	// Initialize the mock and the usecase before each test
 	suite.mockRepo = new(mocks.MockTaskRepository)
-	suite.usecase = usecases.NewTaskUsecase(suite.mockRepo)
+	suite.mockUsers = new(mocks.MockUserRepository)
+	suite.mockComments = new(mocks.MockCommentRepository)
+	suite.mockAudit = new(mocks.MockAuditRepository)
+	suite.mockLabels = new(mocks.MockLabelRepository)
+	suite.mockEvents = new(mocks.MockEventPublisher)
+	suite.usecase = usecases.NewTaskUsecase(suite.mockRepo, suite.mockUsers, suite.mockComments, suite.mockAudit, suite.mockLabels, suite.mockEvents)
+
+	// every change is published, tests about the webhooks check the calls themselves
+	suite.mockEvents.EXPECT().Publish(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe()
+}
+
+// expectAudit expects one audit entry for the given action and target
+func (suite *TaskUsecaseTestSuite) expectAudit(action domain.AuditAction, actorId string, targetId string) {
+	suite.mockAudit.EXPECT().
+		Append(mock.Anything, mock.MatchedBy(func(e domain.AuditEntry) bool {
+			return e.Action == action && e.ActorID == actorId && e.TargetType == domain.AuditTargetTask && e.TargetID == targetId
+		})).
+		Return(nil).Once()
 }
 
 // --- 1. Test CreateTask ---
@@ -33,21 +60,25 @@ func (suite *TaskUsecaseTestSuite) TestCreateTask_Success() {
 	inputTask := domain.Task{
 		Title:       "Test Task",
 		Description: "Testing logic",
-		Status:      "pending",
+		Status:      domain.StatusTodo,
 	}
 
 	// EXPECT: The repository to be called with a task that has a generated ID
 	suite.mockRepo.EXPECT().
 		Create(ctx, mock.MatchedBy(func(t domain.Task) bool {
-			return t.Title == inputTask.Title && t.ID != "" // Check if ID was assigned
+			return t.Title == inputTask.Title && t.ID != "" && t.Version == 1 // Check if ID and version were assigned
 		})).
-		Return(domain.Task{ID: "generated-uuid", Title: "Test Task"}, nil)
+		Return(domain.Task{ID: "generated-uuid", Title: "Test Task", CreatedBy: "admin-id"}, nil)
+	suite.expectAudit(domain.AuditTaskCreated, "admin-id", "generated-uuid")
 
 	result, err := suite.usecase.CreateTask(ctx, inputTask)
 
 	suite.NoError(err)
 	suite.Equal("generated-uuid", result.ID)
 	suite.mockRepo.AssertExpectations(suite.T())
+
+	// the webhooks are told about the new task
+	suite.mockEvents.AssertCalled(suite.T(), "Publish", ctx, domain.WebhookTaskCreated, "admin-id", nil, result)
 }
 
 func (suite *TaskUsecaseTestSuite) TestCreateTask_ValidationError() {
@@ -63,7 +94,110 @@ func (suite *TaskUsecaseTestSuite) TestCreateTask_ValidationError() {
 	suite.mockRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
 }
 
-// --- 2. Test RetrieveTaskByID ---
+func (suite *TaskUsecaseTestSuite) TestCreateTask_UnknownAssignee() {
+	ctx := context.TODO()
+	assigneeId := "01234567-89ab-cdef-0123-456789abcdef"
+
+	suite.mockUsers.EXPECT().GetUserByID(ctx, assigneeId).Return(domain.User{}, domain.ErrNotFound)
+
+	_, err := suite.usecase.CreateTask(ctx, domain.Task{Title: "T", Description: "D", Status: domain.StatusTodo, AssigneeID: assigneeId})
+
+	suite.ErrorIs(err, domain.ErrValidation)
+	suite.mockRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+// --- 2. Test RetrieveAllTasks ---
+
+func (suite *TaskUsecaseTestSuite) TestRetrieveAllTasks_AppliesDefaults() {
+	ctx := context.TODO()
+
+	// EXPECT: an empty filter gets the default limit and sort field
+	expectedFilter := domain.TaskFilter{SortBy: domain.TaskSortByDueDate, Limit: domain.DefaultTaskPageLimit}
+	suite.mockRepo.EXPECT().
+		GetAll(ctx, expectedFilter).
+		Return([]domain.Task{{ID: "1"}}, int64(1), nil)
+
+	page, err := suite.usecase.RetrieveAllTasks(ctx, domain.TaskFilter{})
+
+	suite.NoError(err)
+	suite.Len(page.Tasks, 1)
+	suite.Equal(int64(1), page.Total)
+	suite.Equal(domain.DefaultTaskPageLimit, page.Limit)
+}
+
+func (suite *TaskUsecaseTestSuite) TestRetrieveAllTasks_InvalidSortField() {
+	ctx := context.TODO()
+
+	_, err := suite.usecase.RetrieveAllTasks(ctx, domain.TaskFilter{SortBy: "password"})
+
+	suite.Error(err)
+	suite.True(errors.Is(err, domain.ErrValidation))
+	suite.mockRepo.AssertNotCalled(suite.T(), "GetAll", mock.Anything, mock.Anything)
+}
+
+func (suite *TaskUsecaseTestSuite) TestSearchTasks_ParsesQueryAndHighlights() {
+	ctx := context.TODO()
+
+	// EXPECT: words, phrases and prefixes are parsed and the default limit applied
+	expectedQuery := domain.TaskSearchQuery{
+		Terms:    []string{"deploy"},
+		Phrases:  [][]string{{"release", "notes"}},
+		Prefixes: []string{"stag"},
+		Limit:    domain.DefaultTaskPageLimit,
+	}
+	task := domain.Task{ID: "1", Title: "Deploy to staging", Description: "Write the <b>release notes</b> first"}
+	suite.mockRepo.EXPECT().
+		Search(ctx, expectedQuery).
+		Return([]domain.TaskSearchHit{{Task: task, Score: 4}}, int64(1), nil)
+
+	result, err := suite.usecase.SearchTasks(ctx, `Deploy "release notes" stag*`, 0, 0)
+
+	suite.Require().NoError(err)
+	suite.Require().Len(result.Hits, 1)
+	suite.Equal("<mark>Deploy</mark> to <mark>staging</mark>", result.Hits[0].Highlights.Title)
+	suite.Equal("Write the &lt;b&gt;<mark>release notes</mark>&lt;/b&gt; first", result.Hits[0].Highlights.Description)
+}
+
+func (suite *TaskUsecaseTestSuite) TestSearchTasks_SnippetAroundMatch() {
+	ctx := context.TODO()
+
+	description := strings.Repeat("filler words ", 40) + "the deadline moved " + strings.Repeat("more text ", 40)
+	suite.mockRepo.EXPECT().
+		Search(ctx, mock.Anything).
+		Return([]domain.TaskSearchHit{{Task: domain.Task{ID: "1", Description: description}}}, int64(1), nil)
+
+	result, err := suite.usecase.SearchTasks(ctx, "deadline", 0, 0)
+
+	// the snippet is cut around the match on both sides
+	suite.Require().NoError(err)
+	snippet := result.Hits[0].Highlights.Description
+	suite.Contains(snippet, "the <mark>deadline</mark> moved")
+	suite.True(strings.HasPrefix(snippet, "…"))
+	suite.True(strings.HasSuffix(snippet, "…"))
+	suite.Less(len(snippet), len(description))
+}
+
+func (suite *TaskUsecaseTestSuite) TestSearchTasks_EmptyQuery() {
+	ctx := context.TODO()
+
+	_, err := suite.usecase.SearchTasks(ctx, ` "" * `, 0, 0)
+
+	suite.ErrorIs(err, domain.ErrValidation)
+	suite.mockRepo.AssertNotCalled(suite.T(), "Search", mock.Anything, mock.Anything)
+}
+
+func (suite *TaskUsecaseTestSuite) TestCreateTask_UnknownStatus() {
+	ctx := context.TODO()
+	task := domain.Task{Title: "Title", Description: "Description", Status: "finished"}
+
+	_, err := suite.usecase.CreateTask(ctx, task)
+
+	suite.Error(err)
+	suite.True(errors.Is(err, domain.ErrValidation))
+	suite.mockRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+// --- 3. Test RetrieveTaskByID ---
 
 func (suite *TaskUsecaseTestSuite) TestRetrieveTaskByID_Success() {
 	ctx := context.TODO()
@@ -88,11 +222,12 @@ func (suite *TaskUsecaseTestSuite) TestRetrieveTaskByID_NotFound() {
 	suite.True(errors.Is(err, domain.ErrNotFound))
 }
 
-// --- 3. Test ModifyTask ---
+// --- 4. Test ModifyTask ---
 
 func (suite *TaskUsecaseTestSuite) TestModifyTask_Success() {
 	ctx := context.TODO()
 	id := "1"
+	admin := domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}
 	updatedFields := domain.Task{
 		Title: "New Title",
 	}
@@ -100,27 +235,860 @@ func (suite *TaskUsecaseTestSuite) TestModifyTask_Success() {
 	// The logic inside ModifyTask builds a bson.M{"title": "New Title"}
 	expectedUpdates := bson.M{"title": "New Title"}
 
+	suite.mockRepo.EXPECT().GetByID(ctx, id).Return(domain.Task{ID: id, Title: "Old Title"}, nil)
 	suite.mockRepo.EXPECT().
-		Update(ctx, id, expectedUpdates).
+		Update(ctx, id, expectedUpdates, int64(0)).
 		Return(domain.Task{ID: id, Title: "New Title"}, nil)
+	suite.expectAudit(domain.AuditTaskUpdated, "admin-id", id)
 
-	result, err := suite.usecase.ModifyTask(ctx, id, updatedFields)
+	result, err := suite.usecase.ModifyTask(ctx, admin, id, updatedFields)
 
 	suite.NoError(err)
 	suite.Equal("New Title", result.Title)
 }
 
-// --- 4. Test RemoveTask ---
+func (suite *TaskUsecaseTestSuite) TestModifyTask_AssigneeCanUpdate() {
+	ctx := context.TODO()
+	id := "1"
+	user := domain.Actor{UserID: "user-id", Role: domain.RoleUser}
+
+	suite.mockRepo.EXPECT().GetByID(ctx, id).Return(domain.Task{ID: id, AssigneeID: "user-id"}, nil)
+	suite.mockRepo.EXPECT().
+		Update(ctx, id, bson.M{"description": "done on my side"}, int64(0)).
+		Return(domain.Task{ID: id, Description: "done on my side"}, nil)
+	suite.expectAudit(domain.AuditTaskUpdated, "user-id", id)
+
+	result, err := suite.usecase.ModifyTask(ctx, user, id, domain.Task{Description: "done on my side"})
+
+	suite.NoError(err)
+	suite.Equal("done on my side", result.Description)
+}
+
+func (suite *TaskUsecaseTestSuite) TestModifyTask_Forbidden_NotAssignee() {
+	ctx := context.TODO()
+	id := "1"
+	user := domain.Actor{UserID: "user-id", Role: domain.RoleUser}
+
+	suite.mockRepo.EXPECT().GetByID(ctx, id).Return(domain.Task{ID: id, AssigneeID: "someone-else"}, nil)
+
+	_, err := suite.usecase.ModifyTask(ctx, user, id, domain.Task{Title: "Hijacked"})
+
+	suite.Error(err)
+	suite.True(errors.Is(err, domain.ErrForbidden))
+	suite.mockRepo.AssertNotCalled(suite.T(), "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TaskUsecaseTestSuite) TestModifyTask_AllowedTransition() {
+	ctx := context.TODO()
+	admin := domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}
+
+	suite.mockRepo.EXPECT().GetByID(ctx, "1").Return(domain.Task{ID: "1", Status: domain.StatusTodo}, nil)
+	suite.mockRepo.EXPECT().
+		Update(ctx, "1", bson.M{"status": domain.StatusInProgress}, int64(0)).
+		Return(domain.Task{ID: "1", Status: domain.StatusInProgress}, nil)
+	suite.expectAudit(domain.AuditTaskUpdated, "admin-id", "1")
+
+	result, err := suite.usecase.ModifyTask(ctx, admin, "1", domain.Task{Status: domain.StatusInProgress})
+
+	suite.NoError(err)
+	suite.Equal(domain.StatusInProgress, result.Status)
+}
+
+func (suite *TaskUsecaseTestSuite) TestModifyTask_IllegalTransition() {
+	ctx := context.TODO()
+	admin := domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}
+
+	suite.mockRepo.EXPECT().GetByID(ctx, "1").Return(domain.Task{ID: "1", Status: domain.StatusDone}, nil)
+
+	_, err := suite.usecase.ModifyTask(ctx, admin, "1", domain.Task{Status: domain.StatusBlocked})
+
+	suite.Error(err)
+	suite.True(errors.Is(err, domain.ErrValidation))
+	suite.Contains(err.Error(), "allowed next states: todo")
+	suite.mockRepo.AssertNotCalled(suite.T(), "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TaskUsecaseTestSuite) TestModifyTask_PassesExpectedVersion() {
+	ctx := context.TODO()
+	admin := domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}
+
+	suite.mockRepo.EXPECT().GetByID(ctx, "1").Return(domain.Task{ID: "1", Version: 4}, nil)
+	suite.mockRepo.EXPECT().
+		Update(ctx, "1", bson.M{"title": "New"}, int64(3)).
+		Return(domain.Task{}, domain.ErrConflict)
+
+	_, err := suite.usecase.ModifyTask(ctx, admin, "1", domain.Task{Title: "New", Version: 3})
+
+	suite.True(errors.Is(err, domain.ErrConflict))
+	suite.mockAudit.AssertNotCalled(suite.T(), "Append", mock.Anything, mock.Anything)
+}
+
+func (suite *TaskUsecaseTestSuite) TestModifyTask_AuditRecordsBeforeAndAfter() {
+	ctx := context.TODO()
+	admin := domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}
+
+	suite.mockRepo.EXPECT().GetByID(ctx, "1").Return(domain.Task{ID: "1", Title: "Old", Version: 1}, nil)
+	suite.mockRepo.EXPECT().
+		Update(ctx, "1", bson.M{"title": "New"}, int64(0)).
+		Return(domain.Task{ID: "1", Title: "New", Version: 2}, nil)
+
+	var recorded domain.AuditEntry
+	suite.mockAudit.EXPECT().
+		Append(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, entry domain.AuditEntry) { recorded = entry }).
+		Return(nil)
+
+	_, err := suite.usecase.ModifyTask(ctx, admin, "1", domain.Task{Title: "New"})
+
+	suite.NoError(err)
+	suite.NotEmpty(recorded.ID)
+	suite.False(recorded.Timestamp.IsZero())
+
+	var before, after domain.Task
+	suite.NoError(json.Unmarshal(recorded.Before, &before))
+	suite.NoError(json.Unmarshal(recorded.After, &after))
+	suite.Equal("Old", before.Title)
+	suite.Equal("New", after.Title)
+	suite.Equal(int64(2), after.Version)
+}
+
+func (suite *TaskUsecaseTestSuite) TestModifyTask_AuditFailureDoesNotFailUpdate() {
+	ctx := context.TODO()
+	admin := domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}
+
+	suite.mockRepo.EXPECT().GetByID(ctx, "1").Return(domain.Task{ID: "1"}, nil)
+	suite.mockRepo.EXPECT().
+		Update(ctx, "1", bson.M{"title": "New"}, int64(0)).
+		Return(domain.Task{ID: "1", Title: "New"}, nil)
+	suite.mockAudit.EXPECT().Append(mock.Anything, mock.Anything).Return(errors.New("disk full"))
+
+	result, err := suite.usecase.ModifyTask(ctx, admin, "1", domain.Task{Title: "New"})
+
+	suite.NoError(err)
+	suite.Equal("New", result.Title)
+}
+
+// --- 5. Test AssignTask ---
+
+func (suite *TaskUsecaseTestSuite) TestAssignTask_Success() {
+	ctx := context.TODO()
+	assigneeId := "01234567-89ab-cdef-0123-456789abcdef"
+	admin := domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}
+
+	suite.mockUsers.EXPECT().GetUserByID(ctx, assigneeId).Return(domain.User{}, nil)
+	suite.mockRepo.EXPECT().GetByID(ctx, "1").Return(domain.Task{ID: "1"}, nil)
+	suite.mockRepo.EXPECT().
+		Update(ctx, "1", bson.M{"assignee_id": assigneeId}, int64(0)).
+		Return(domain.Task{ID: "1", AssigneeID: assigneeId}, nil)
+	suite.expectAudit(domain.AuditTaskAssigned, "admin-id", "1")
+
+	result, err := suite.usecase.AssignTask(ctx, admin, "1", assigneeId)
+
+	suite.NoError(err)
+	suite.Equal(assigneeId, result.AssigneeID)
+}
+
+func (suite *TaskUsecaseTestSuite) TestAssignTask_InvalidAssignee() {
+	ctx := context.TODO()
+
+	_, err := suite.usecase.AssignTask(ctx, domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}, "1", "not-a-uuid")
+
+	suite.Error(err)
+	suite.True(errors.Is(err, domain.ErrValidation))
+	suite.mockRepo.AssertNotCalled(suite.T(), "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TaskUsecaseTestSuite) TestAssignTask_UnknownAssignee() {
+	ctx := context.TODO()
+	assigneeId := "01234567-89ab-cdef-0123-456789abcdef"
+
+	suite.mockUsers.EXPECT().GetUserByID(ctx, assigneeId).Return(domain.User{}, domain.ErrNotFound)
+
+	_, err := suite.usecase.AssignTask(ctx, domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}, "1", assigneeId)
+
+	suite.ErrorIs(err, domain.ErrValidation)
+	suite.Contains(err.Error(), "does not exist")
+	suite.mockRepo.AssertNotCalled(suite.T(), "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// --- 6. Test RemoveTask ---
 
 func (suite *TaskUsecaseTestSuite) TestRemoveTask_Success() {
 	ctx := context.TODO()
 	id := "delete-me"
+	admin := domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}
+
+	suite.mockRepo.EXPECT().GetByID(ctx, id).Return(domain.Task{ID: id, Title: "Gone"}, nil)
+	suite.mockRepo.EXPECT().Delete(ctx, id, int64(0), mock.AnythingOfType("time.Time")).Return(nil)
+	suite.mockAudit.EXPECT().
+		Append(mock.Anything, mock.MatchedBy(func(e domain.AuditEntry) bool {
+			return e.Action == domain.AuditTaskDeleted && e.TargetID == id && len(e.Before) > 0 && e.After == nil
+		})).
+		Return(nil)
+
+	err := suite.usecase.RemoveTask(ctx, admin, id, 0)
+
+	suite.NoError(err)
+	suite.mockAudit.AssertExpectations(suite.T())
+
+	// a deletion publishes the task as it was before
+	suite.mockEvents.AssertCalled(suite.T(), "Publish", ctx, domain.WebhookTaskDeleted, "admin-id", domain.Task{ID: id, Title: "Gone"}, nil)
+}
+
+func (suite *TaskUsecaseTestSuite) TestRemoveTask_VersionConflict() {
+	ctx := context.TODO()
+	admin := domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}
+
+	suite.mockRepo.EXPECT().GetByID(ctx, "1").Return(domain.Task{ID: "1", Version: 3}, nil)
+	suite.mockRepo.EXPECT().Delete(ctx, "1", int64(2), mock.Anything).Return(domain.ErrConflict)
+
+	err := suite.usecase.RemoveTask(ctx, admin, "1", 2)
+
+	suite.True(errors.Is(err, domain.ErrConflict))
+	suite.mockAudit.AssertNotCalled(suite.T(), "Append", mock.Anything, mock.Anything)
+	suite.mockEvents.AssertNotCalled(suite.T(), "Publish", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// --- 7. Test MigrateTaskStatuses ---
+
+func (suite *TaskUsecaseTestSuite) TestMigrateTaskStatuses() {
+	ctx := context.TODO()
+
+	// "done" is already valid, "Completed" is a known legacy value, "someday" is unknown
+	suite.mockRepo.EXPECT().DistinctStatuses(ctx).Return([]string{"done", "Completed", "someday"}, nil)
+	suite.mockRepo.EXPECT().ReplaceStatus(ctx, "Completed", domain.StatusDone).Return(int64(2), nil)
+	suite.mockRepo.EXPECT().ReplaceStatus(ctx, "someday", domain.StatusTodo).Return(int64(1), nil)
+
+	migrated, err := suite.usecase.MigrateTaskStatuses(ctx)
+
+	suite.NoError(err)
+	suite.Equal(int64(3), migrated)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+// --- 8. Test the trash ---
+
+func (suite *TaskUsecaseTestSuite) TestRestoreTask_Success() {
+	ctx := context.TODO()
+	admin := domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}
+	deletedAt := time.Now()
+
+	suite.mockRepo.EXPECT().GetDeletedByID(ctx, "1").Return(domain.Task{ID: "1", DeletedAt: &deletedAt, Version: 2}, nil)
+	suite.mockRepo.EXPECT().Restore(ctx, "1").Return(domain.Task{ID: "1", Version: 3}, nil)
+	suite.expectAudit(domain.AuditTaskRestored, "admin-id", "1")
 
-	suite.mockRepo.EXPECT().Delete(ctx, id).Return(nil)
+	task, err := suite.usecase.RestoreTask(ctx, admin, "1")
 
-	err := suite.usecase.RemoveTask(ctx, id)
+	suite.NoError(err)
+	suite.Nil(task.DeletedAt)
+	suite.mockAudit.AssertExpectations(suite.T())
+
+	// a restored task is published as an update
+	suite.mockEvents.AssertCalled(suite.T(), "Publish", ctx, domain.WebhookTaskUpdated, "admin-id", mock.Anything, task)
+}
+
+func (suite *TaskUsecaseTestSuite) TestPurgeTask_NotInTrash() {
+	ctx := context.TODO()
+	admin := domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}
+
+	suite.mockRepo.EXPECT().GetDeletedByID(ctx, "1").Return(domain.Task{}, domain.ErrNotFound)
+
+	err := suite.usecase.PurgeTask(ctx, admin, "1")
+
+	suite.True(errors.Is(err, domain.ErrNotFound))
+	suite.mockRepo.AssertNotCalled(suite.T(), "Purge", mock.Anything, mock.Anything)
+	suite.mockComments.AssertNotCalled(suite.T(), "DeleteByTask", mock.Anything, mock.Anything)
+}
+
+func (suite *TaskUsecaseTestSuite) TestPurgeTask_DeletesComments() {
+	ctx := context.TODO()
+	admin := domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}
+	deletedAt := time.Now()
+
+	suite.mockRepo.EXPECT().GetDeletedByID(ctx, "1").Return(domain.Task{ID: "1", DeletedAt: &deletedAt}, nil)
+	suite.mockComments.EXPECT().DeleteByTask(ctx, "1").Return(nil)
+	suite.mockRepo.EXPECT().Purge(ctx, "1").Return(nil)
+	suite.expectAudit(domain.AuditTaskPurged, "admin-id", "1")
+
+	err := suite.usecase.PurgeTask(ctx, admin, "1")
+
+	suite.NoError(err)
+	suite.mockComments.AssertExpectations(suite.T())
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *TaskUsecaseTestSuite) TestPurgeTask_CommentsFailKeepsTask() {
+	ctx := context.TODO()
+	admin := domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}
+	deletedAt := time.Now()
+
+	// the task stays in the trash so the purge can be retried
+	suite.mockRepo.EXPECT().GetDeletedByID(ctx, "1").Return(domain.Task{ID: "1", DeletedAt: &deletedAt}, nil)
+	suite.mockComments.EXPECT().DeleteByTask(ctx, "1").Return(errors.New("db down"))
+
+	err := suite.usecase.PurgeTask(ctx, admin, "1")
+
+	suite.Error(err)
+	suite.mockRepo.AssertNotCalled(suite.T(), "Purge", mock.Anything, mock.Anything)
+}
+
+func (suite *TaskUsecaseTestSuite) TestPurgeExpiredTasks_PurgesOlderThanRetention() {
+	ctx := context.TODO()
+	retention := 24 * time.Hour
+
+	// the cutoff is retention before now
+	expired := []domain.Task{{ID: "1"}, {ID: "2"}}
+	suite.mockRepo.EXPECT().
+		ListDeleted(ctx, mock.MatchedBy(func(cutoff time.Time) bool {
+			return time.Since(cutoff) >= retention && time.Since(cutoff) < retention+time.Minute
+		}), domain.MaxTaskPageLimit, int64(0)).
+		Return(expired, 2, nil).Once()
+
+	// task 2 was restored between the listing and the purge
+	suite.mockRepo.EXPECT().GetDeletedByID(ctx, "1").Return(expired[0], nil)
+	suite.mockComments.EXPECT().DeleteByTask(ctx, "1").Return(nil)
+	suite.mockRepo.EXPECT().Purge(ctx, "1").Return(nil)
+	suite.mockRepo.EXPECT().GetDeletedByID(ctx, "2").Return(domain.Task{}, domain.ErrNotFound)
+
+	// automatic purges are recorded as made by the system
+	suite.mockAudit.EXPECT().
+		Append(mock.Anything, mock.MatchedBy(func(e domain.AuditEntry) bool {
+			return e.Action == domain.AuditTaskPurged && e.ActorID == domain.AuditSystemActor && e.TargetID == "1"
+		})).
+		Return(nil).Once()
+
+	purged, err := suite.usecase.PurgeExpiredTasks(ctx, retention)
 
 	suite.NoError(err)
+	suite.Equal(int64(1), purged)
+	suite.mockRepo.AssertExpectations(suite.T())
+	suite.mockAudit.AssertExpectations(suite.T())
+
+	// purges are audited but not published, the webhooks were told about the deletion already
+	suite.mockEvents.AssertNotCalled(suite.T(), "Publish", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TaskUsecaseTestSuite) TestPurgeExpiredTasks_InvalidRetention() {
+	_, err := suite.usecase.PurgeExpiredTasks(context.TODO(), 0)
+
+	suite.True(errors.Is(err, domain.ErrValidation))
+}
+
+// --- 9. Test the bulk operations ---
+
+func (suite *TaskUsecaseTestSuite) TestBulkCreateTasks_ReportsEachTask() {
+	ctx := context.TODO()
+	admin := domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}
+	tasks := []domain.Task{
+		{Title: "valid", Description: "a valid task", Status: domain.StatusTodo},
+		{Title: "missing a description", Status: domain.StatusTodo},
+	}
+
+	// only the valid task reaches the repository, created by the actor
+	suite.mockRepo.EXPECT().
+		BulkCreate(ctx, mock.MatchedBy(func(batch []domain.Task) bool {
+			return len(batch) == 1 && batch[0].Title == "valid" && batch[0].ID != "" && batch[0].CreatedBy == "admin-id"
+		}), false).
+		Return([]error{nil}, nil)
+	suite.mockAudit.EXPECT().
+		Append(mock.Anything, mock.MatchedBy(func(e domain.AuditEntry) bool {
+			return e.Action == domain.AuditTaskCreated && e.ActorID == "admin-id"
+		})).
+		Return(nil).Once()
+
+	result, err := suite.usecase.BulkCreateTasks(ctx, admin, tasks, false)
+
+	suite.NoError(err)
+	suite.Equal(1, result.Succeeded)
+	suite.Equal(1, result.Failed)
+	suite.Equal(domain.BulkItemSucceeded, result.Results[0].Status)
+	suite.NotNil(result.Results[0].Task)
+	suite.Equal(domain.BulkItemFailed, result.Results[1].Status)
+	suite.Contains(result.Results[1].Error, "required")
+	suite.mockAudit.AssertExpectations(suite.T())
+}
+
+func (suite *TaskUsecaseTestSuite) TestBulkCreateTasks_AtomicStopsOnInvalidTask() {
+	ctx := context.TODO()
+	admin := domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}
+	tasks := []domain.Task{
+		{Title: "valid", Description: "a valid task", Status: domain.StatusTodo},
+		{Title: "bad status", Description: "d", Status: "someday"},
+	}
+
+	result, err := suite.usecase.BulkCreateTasks(ctx, admin, tasks, true)
+
+	suite.NoError(err)
+	suite.True(result.Atomic)
+	suite.Equal(0, result.Succeeded)
+	suite.Equal(domain.BulkItemSkipped, result.Results[0].Status)
+	suite.Equal(domain.BulkItemFailed, result.Results[1].Status)
+	suite.mockRepo.AssertNotCalled(suite.T(), "BulkCreate", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TaskUsecaseTestSuite) TestBulkCreateTasks_RejectsOversizedBatch() {
+	tasks := make([]domain.Task, domain.MaxBulkTasks+1)
+
+	_, err := suite.usecase.BulkCreateTasks(context.TODO(), domain.Actor{}, tasks, false)
+
+	suite.True(errors.Is(err, domain.ErrValidation))
+}
+
+func (suite *TaskUsecaseTestSuite) TestBulkUpdateTasks_ByIDsChecksEachTransition() {
+	ctx := context.TODO()
+	admin := domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}
+
+	// a done task can only be reopened, it can't move to blocked
+	suite.mockRepo.EXPECT().
+		GetByIDs(ctx, []string{"1", "2", "3"}).
+		Return([]domain.Task{
+			{ID: "1", Status: domain.StatusTodo, Version: 2},
+			{ID: "2", Status: domain.StatusDone, Version: 5},
+		}, nil)
+	suite.mockRepo.EXPECT().
+		BulkUpdate(ctx, []repositories.TaskUpdate{
+			{ID: "1", Updates: bson.M{"status": domain.StatusBlocked}, ExpectedVersion: 2},
+		}, false).
+		Return([]error{nil}, nil)
+	suite.expectAudit(domain.AuditTaskUpdated, "admin-id", "1")
+
+	result, err := suite.usecase.BulkUpdateTasks(ctx, admin, domain.BulkTaskUpdate{
+		IDs:     []string{"1", "2", "3"},
+		Changes: domain.Task{Status: domain.StatusBlocked},
+	})
+
+	suite.NoError(err)
+	suite.Equal(1, result.Succeeded)
+	suite.Equal(2, result.Failed)
+	suite.Equal(domain.StatusBlocked, result.Results[0].Task.Status)
+	suite.Equal(int64(3), result.Results[0].Task.Version)
+	suite.Contains(result.Results[1].Error, "cannot move task")
+	suite.Equal("task not found", result.Results[2].Error)
+	suite.mockAudit.AssertExpectations(suite.T())
+}
+
+func (suite *TaskUsecaseTestSuite) TestBulkUpdateTasks_ByFilter() {
+	ctx := context.TODO()
+	admin := domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}
+	due := time.Now().Add(48 * time.Hour)
+
+	suite.mockRepo.EXPECT().
+		GetAll(ctx, mock.MatchedBy(func(f domain.TaskFilter) bool {
+			return f.AssigneeID == "user-1" && f.Limit == domain.MaxBulkTasks+1 && f.Offset == 0
+		})).
+		Return([]domain.Task{{ID: "1"}}, 1, nil)
+	suite.mockRepo.EXPECT().GetByIDs(ctx, []string{"1"}).Return([]domain.Task{{ID: "1", Status: domain.StatusTodo, Version: 1}}, nil)
+	suite.mockRepo.EXPECT().BulkUpdate(ctx, mock.Anything, false).Return([]error{nil}, nil)
+	suite.expectAudit(domain.AuditTaskUpdated, "admin-id", "1")
+
+	result, err := suite.usecase.BulkUpdateTasks(ctx, admin, domain.BulkTaskUpdate{
+		Filter:  &domain.TaskFilter{AssigneeID: "user-1"},
+		Changes: domain.Task{DueDate: due},
+	})
+
+	suite.NoError(err)
+	suite.Equal(1, result.Succeeded)
+	suite.True(due.Truncate(time.Millisecond).Equal(result.Results[0].Task.DueDate))
+}
+
+func (suite *TaskUsecaseTestSuite) TestBulkUpdateTasks_FilterMatchesTooMany() {
+	ctx := context.TODO()
+
+	suite.mockRepo.EXPECT().GetAll(ctx, mock.Anything).Return(nil, domain.MaxBulkTasks+1, nil)
+
+	_, err := suite.usecase.BulkUpdateTasks(ctx, domain.Actor{}, domain.BulkTaskUpdate{
+		Filter:  &domain.TaskFilter{Status: domain.StatusTodo},
+		Changes: domain.Task{Status: domain.StatusDone},
+	})
+
+	suite.True(errors.Is(err, domain.ErrValidation))
+	suite.mockRepo.AssertNotCalled(suite.T(), "BulkUpdate", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TaskUsecaseTestSuite) TestBulkUpdateTasks_InvalidRequests() {
+	ctx := context.TODO()
+
+	requests := []domain.BulkTaskUpdate{
+		{IDs: []string{"1"}},               // no changes
+		{Changes: domain.Task{Title: "t"}}, // nothing selected
+		{IDs: []string{"1"}, Filter: &domain.TaskFilter{}, Changes: domain.Task{Title: "t"}}, // both selections
+		{IDs: []string{"1", "1"}, Changes: domain.Task{Title: "t"}},                          // repeated id
+		{IDs: []string{"1"}, Changes: domain.Task{Status: "someday"}},                        // unknown status
+	}
+
+	for _, request := range requests {
+		_, err := suite.usecase.BulkUpdateTasks(ctx, domain.Actor{}, request)
+		suite.True(errors.Is(err, domain.ErrValidation), "request %+v should be rejected", request)
+	}
+	suite.mockRepo.AssertNotCalled(suite.T(), "GetByIDs", mock.Anything, mock.Anything)
+}
+
+func (suite *TaskUsecaseTestSuite) TestBulkDeleteTasks_AtomicSkipsTheRestOnConflict() {
+	ctx := context.TODO()
+	admin := domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}
+
+	suite.mockRepo.EXPECT().
+		GetByIDs(ctx, []string{"1", "2"}).
+		Return([]domain.Task{{ID: "1", Version: 1}, {ID: "2", Version: 4}}, nil)
+
+	// the second task changed between the read and the write, nothing is deleted
+	suite.mockRepo.EXPECT().
+		BulkUpdate(ctx, mock.MatchedBy(func(writes []repositories.TaskUpdate) bool {
+			return len(writes) == 2 && writes[0].Updates["deleted_at"] != nil && writes[1].ExpectedVersion == 4
+		}), true).
+		Return([]error{nil, domain.ErrConflict}, nil)
+
+	result, err := suite.usecase.BulkDeleteTasks(ctx, admin, []string{"1", "2"}, true)
+
+	suite.NoError(err)
+	suite.Equal(0, result.Succeeded)
+	suite.Equal(1, result.Failed)
+	suite.Equal(1, result.Skipped)
+	suite.Equal(domain.BulkItemSkipped, result.Results[0].Status)
+	suite.Equal(domain.ErrConflict.Error(), result.Results[1].Error)
+	suite.mockAudit.AssertNotCalled(suite.T(), "Append", mock.Anything, mock.Anything)
+}
+
+// --- 10. Test the task labels ---
+
+func (suite *TaskUsecaseTestSuite) TestAddTaskLabels_MergesWithCurrentLabels() {
+	ctx := context.TODO()
+	assignee := domain.Actor{UserID: "user-1", Role: domain.RoleUser}
+
+	suite.mockLabels.EXPECT().GetAll(ctx).Return([]domain.Label{{Name: "backend"}, {Name: "urgent"}}, nil)
+	suite.mockRepo.EXPECT().GetByID(ctx, "1").Return(domain.Task{ID: "1", AssigneeID: "user-1", Labels: []string{"urgent"}, Version: 2}, nil)
+
+	// EXPECT: the names are normalized and merged, the write is conditional on the version read
+	suite.mockRepo.EXPECT().
+		Update(ctx, "1", bson.M{"labels": []string{"backend", "urgent"}}, int64(2)).
+		Return(domain.Task{ID: "1", Labels: []string{"backend", "urgent"}, Version: 3}, nil)
+	suite.expectAudit(domain.AuditTaskUpdated, "user-1", "1")
+
+	task, err := suite.usecase.AddTaskLabels(ctx, assignee, "1", []string{" Backend ", "urgent"})
+
+	suite.NoError(err)
+	suite.Equal([]string{"backend", "urgent"}, task.Labels)
+	suite.mockAudit.AssertExpectations(suite.T())
+}
+
+func (suite *TaskUsecaseTestSuite) TestAddTaskLabels_UnknownLabel() {
+	ctx := context.TODO()
+
+	suite.mockLabels.EXPECT().GetAll(ctx).Return([]domain.Label{{Name: "backend"}}, nil)
+
+	_, err := suite.usecase.AddTaskLabels(ctx, domain.Actor{Role: domain.RoleAdmin}, "1", []string{"frontend"})
+
+	suite.True(errors.Is(err, domain.ErrValidation))
+	suite.Contains(err.Error(), "frontend")
+	suite.mockRepo.AssertNotCalled(suite.T(), "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TaskUsecaseTestSuite) TestAddTaskLabels_NotAssignee() {
+	ctx := context.TODO()
+
+	suite.mockLabels.EXPECT().GetAll(ctx).Return([]domain.Label{{Name: "backend"}}, nil)
+	suite.mockRepo.EXPECT().GetByID(ctx, "1").Return(domain.Task{ID: "1", AssigneeID: "someone-else"}, nil)
+
+	_, err := suite.usecase.AddTaskLabels(ctx, domain.Actor{UserID: "user-1", Role: domain.RoleUser}, "1", []string{"backend"})
+
+	suite.True(errors.Is(err, domain.ErrForbidden))
+}
+
+func (suite *TaskUsecaseTestSuite) TestRemoveTaskLabel_MissingLabelIsNoop() {
+	ctx := context.TODO()
+	task := domain.Task{ID: "1", Labels: []string{"urgent"}, Version: 1}
+
+	suite.mockRepo.EXPECT().GetByID(ctx, "1").Return(task, nil)
+
+	result, err := suite.usecase.RemoveTaskLabel(ctx, domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}, "1", "backend")
+
+	suite.NoError(err)
+	suite.Equal(task, result)
+	suite.mockRepo.AssertNotCalled(suite.T(), "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	suite.mockAudit.AssertNotCalled(suite.T(), "Append", mock.Anything, mock.Anything)
+}
+
+func (suite *TaskUsecaseTestSuite) TestCreateTask_UnknownLabel() {
+	ctx := context.TODO()
+
+	suite.mockLabels.EXPECT().GetAll(ctx).Return([]domain.Label{}, nil)
+
+	_, err := suite.usecase.CreateTask(ctx, domain.Task{Title: "t", Description: "d", Status: domain.StatusTodo, Labels: []string{"urgent"}})
+
+	suite.True(errors.Is(err, domain.ErrValidation))
+	suite.mockRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *TaskUsecaseTestSuite) TestRetrieveAllTasks_NormalizesLabelFilter() {
+	ctx := context.TODO()
+
+	suite.mockRepo.EXPECT().
+		GetAll(ctx, mock.MatchedBy(func(f domain.TaskFilter) bool {
+			return slices.Equal(f.LabelsAny, []string{"backend", "urgent"}) && slices.Equal(f.LabelsAll, []string{"api"})
+		})).
+		Return([]domain.Task{}, int64(0), nil)
+
+	_, err := suite.usecase.RetrieveAllTasks(ctx, domain.TaskFilter{LabelsAny: []string{"Urgent", "backend"}, LabelsAll: []string{"API"}})
+
+	suite.NoError(err)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+// --- 11. Test subtasks and blockers ---
+
+func (suite *TaskUsecaseTestSuite) TestSetTaskParent_Success() {
+	ctx := context.TODO()
+	admin := domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}
+
+	suite.mockRepo.EXPECT().GetByID(ctx, "child").Return(domain.Task{ID: "child", Version: 1}, nil)
+	suite.mockRepo.EXPECT().GetByID(ctx, "parent").Return(domain.Task{ID: "parent", ParentID: "root"}, nil)
+	suite.mockRepo.EXPECT().GetByID(ctx, "root").Return(domain.Task{ID: "root"}, nil)
+	suite.mockRepo.EXPECT().
+		Update(ctx, "child", bson.M{"parent_id": "parent"}, int64(1)).
+		Return(domain.Task{ID: "child", ParentID: "parent", Version: 2}, nil)
+	suite.expectAudit(domain.AuditTaskUpdated, "admin-id", "child")
+
+	task, err := suite.usecase.SetTaskParent(ctx, admin, "child", "parent")
+
+	suite.NoError(err)
+	suite.Equal("parent", task.ParentID)
+	suite.mockAudit.AssertExpectations(suite.T())
+}
+
+func (suite *TaskUsecaseTestSuite) TestSetTaskParent_RejectsCycle() {
+	ctx := context.TODO()
+	admin := domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}
+
+	// a is the grandparent of c, so c can't become the parent of a
+	suite.mockRepo.EXPECT().GetByID(ctx, "a").Return(domain.Task{ID: "a"}, nil)
+	suite.mockRepo.EXPECT().GetByID(ctx, "c").Return(domain.Task{ID: "c", ParentID: "b"}, nil)
+	suite.mockRepo.EXPECT().GetByID(ctx, "b").Return(domain.Task{ID: "b", ParentID: "a"}, nil)
+
+	_, err := suite.usecase.SetTaskParent(ctx, admin, "a", "c")
+
+	suite.True(errors.Is(err, domain.ErrValidation))
+	suite.Contains(err.Error(), "cycle")
+	suite.mockRepo.AssertNotCalled(suite.T(), "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TaskUsecaseTestSuite) TestSetTaskParent_MissingParent() {
+	ctx := context.TODO()
+	admin := domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}
+
+	suite.mockRepo.EXPECT().GetByID(ctx, "a").Return(domain.Task{ID: "a"}, nil)
+	suite.mockRepo.EXPECT().GetByID(ctx, "missing").Return(domain.Task{}, domain.ErrNotFound)
+
+	_, err := suite.usecase.SetTaskParent(ctx, admin, "a", "missing")
+
+	// the task itself exists, so this isn't a 404
+	suite.True(errors.Is(err, domain.ErrValidation))
+}
+
+func (suite *TaskUsecaseTestSuite) TestAddTaskBlocker_RejectsIndirectCycle() {
+	ctx := context.TODO()
+	admin := domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}
+
+	// c waits on b, which waits on a, so a can't wait on c
+	suite.mockRepo.EXPECT().GetByID(ctx, "a").Return(domain.Task{ID: "a"}, nil)
+	suite.mockRepo.EXPECT().GetByIDs(ctx, []string{"c"}).Return([]domain.Task{{ID: "c", BlockedBy: []string{"b"}}}, nil)
+	suite.mockRepo.EXPECT().GetByIDs(ctx, []string{"b"}).Return([]domain.Task{{ID: "b", BlockedBy: []string{"a"}}}, nil)
+
+	_, err := suite.usecase.AddTaskBlocker(ctx, admin, "a", "c")
+
+	suite.True(errors.Is(err, domain.ErrValidation))
+	suite.Contains(err.Error(), "cycle")
+	suite.mockRepo.AssertNotCalled(suite.T(), "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TaskUsecaseTestSuite) TestAddTaskBlocker_Success() {
+	ctx := context.TODO()
+	admin := domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}
+
+	suite.mockRepo.EXPECT().GetByID(ctx, "a").Return(domain.Task{ID: "a", BlockedBy: []string{"x"}, Version: 3}, nil)
+	suite.mockRepo.EXPECT().GetByIDs(ctx, []string{"b"}).Return([]domain.Task{{ID: "b"}}, nil)
+	suite.mockRepo.EXPECT().
+		Update(ctx, "a", bson.M{"blocked_by": []string{"x", "b"}}, int64(3)).
+		Return(domain.Task{ID: "a", BlockedBy: []string{"x", "b"}, Version: 4}, nil)
+	suite.expectAudit(domain.AuditTaskUpdated, "admin-id", "a")
+
+	task, err := suite.usecase.AddTaskBlocker(ctx, admin, "a", "b")
+
+	suite.NoError(err)
+	suite.Equal([]string{"x", "b"}, task.BlockedBy)
+}
+
+func (suite *TaskUsecaseTestSuite) TestAddTaskBlocker_Self() {
+	_, err := suite.usecase.AddTaskBlocker(context.TODO(), domain.Actor{Role: domain.RoleAdmin}, "a", "a")
+
+	suite.True(errors.Is(err, domain.ErrValidation))
+}
+
+func (suite *TaskUsecaseTestSuite) TestModifyTask_DoneRefusedWhileBlocked() {
+	ctx := context.TODO()
+	admin := domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}
+
+	suite.mockRepo.EXPECT().
+		GetByID(ctx, "a").
+		Return(domain.Task{ID: "a", Status: domain.StatusInProgress, BlockedBy: []string{"b", "c", "d"}}, nil)
+
+	// b is done and c was cancelled, only d still blocks
+	suite.mockRepo.EXPECT().
+		GetByIDs(ctx, []string{"b", "c", "d"}).
+		Return([]domain.Task{
+			{ID: "b", Status: domain.StatusDone},
+			{ID: "c", Status: domain.StatusCancelled},
+			{ID: "d", Status: domain.StatusTodo},
+		}, nil)
+
+	_, err := suite.usecase.ModifyTask(ctx, admin, "a", domain.Task{Status: domain.StatusDone})
+
+	suite.True(errors.Is(err, domain.ErrValidation))
+	suite.Contains(err.Error(), "d")
+	suite.mockRepo.AssertNotCalled(suite.T(), "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TaskUsecaseTestSuite) TestRetrieveTaskTree_NestsSubtasks() {
+	ctx := context.TODO()
+
+	suite.mockRepo.EXPECT().GetByID(ctx, "root").Return(domain.Task{ID: "root"}, nil)
+	suite.mockRepo.EXPECT().GetSubtasks(ctx, []string{"root"}).
+		Return([]domain.Task{{ID: "a", ParentID: "root"}, {ID: "b", ParentID: "root"}}, nil)
+	suite.mockRepo.EXPECT().GetSubtasks(ctx, []string{"a", "b"}).
+		Return([]domain.Task{{ID: "a1", ParentID: "a"}}, nil)
+	suite.mockRepo.EXPECT().GetSubtasks(ctx, []string{"a1"}).Return([]domain.Task{}, nil)
+
+	tree, err := suite.usecase.RetrieveTaskTree(ctx, "root")
+
+	suite.NoError(err)
+	suite.Equal("root", tree.ID)
+	suite.Require().Len(tree.Subtasks, 2)
+	suite.Require().Len(tree.Subtasks[0].Subtasks, 1)
+	suite.Equal("a1", tree.Subtasks[0].Subtasks[0].ID)
+	suite.Empty(tree.Subtasks[1].Subtasks)
+}
+
+// --- 12. Test recurring tasks ---
+
+func (suite *TaskUsecaseTestSuite) TestCreateTask_CanonicalRecurrence() {
+	ctx := context.TODO()
+	input := domain.Task{Title: "Handover", Description: "On-call", Status: domain.StatusTodo, Recurrence: "rrule:byday=th,mo;freq=weekly"}
+
+	suite.mockRepo.EXPECT().
+		Create(ctx, mock.MatchedBy(func(t domain.Task) bool {
+			return t.Recurrence == "FREQ=WEEKLY;BYDAY=MO,TH" && t.Occurrence == 1
+		})).
+		Return(domain.Task{ID: "1"}, nil)
+	suite.expectAudit(domain.AuditTaskCreated, "", "1")
+
+	_, err := suite.usecase.CreateTask(ctx, input)
+
+	suite.NoError(err)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *TaskUsecaseTestSuite) TestCreateTask_InvalidRecurrence() {
+	for _, rule := range []string{"FREQ=YEARLY", "FREQ=DAILY;BYDAY=MO", "FREQ=WEEKLY;COUNT=2;UNTIL=20251231", "FREQ=MONTHLY;BYMONTHDAY=32", "INTERVAL=2"} {
+		_, err := suite.usecase.CreateTask(context.TODO(), domain.Task{Title: "T", Description: "D", Status: domain.StatusTodo, Recurrence: rule})
+
+		suite.True(errors.Is(err, domain.ErrValidation), rule)
+	}
+	suite.mockRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *TaskUsecaseTestSuite) TestModifyTask_DoneSpawnsNextOccurrence() {
+	ctx := context.TODO()
+	admin := domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}
+	monday := time.Date(2025, 11, 10, 9, 0, 0, 0, time.UTC)
+	current := domain.Task{
+		ID: "1", Title: "Handover", Description: "On-call", Status: domain.StatusInProgress, DueDate: monday,
+		AssigneeID: "5f2b8c1e-3d4a-4b6c-9e8f-0a1b2c3d4e5f", Labels: []string{"ops"}, BlockedBy: []string{"x"},
+		Recurrence: "FREQ=WEEKLY;BYDAY=MO,TH", Occurrence: 2, Version: 4,
+	}
+	done := current
+	done.Status, done.Recurrence, done.Version = domain.StatusDone, "", 5
+
+	suite.mockRepo.EXPECT().GetByID(ctx, "1").Return(current, nil)
+	suite.mockRepo.EXPECT().GetByIDs(ctx, []string{"x"}).Return([]domain.Task{{ID: "x", Status: domain.StatusDone}}, nil)
+	suite.mockRepo.EXPECT().
+		Update(ctx, "1", bson.M{"status": domain.StatusDone, "recurrence": ""}, int64(0)).
+		Return(done, nil)
+	suite.expectAudit(domain.AuditTaskUpdated, "admin-id", "1")
+
+	// the next occurrence is on Thursday at the same time, without the blockers
+	suite.mockRepo.EXPECT().
+		Create(mock.Anything, mock.MatchedBy(func(t domain.Task) bool {
+			return t.ID != "1" && t.Title == "Handover" && t.Status == domain.StatusTodo &&
+				t.DueDate.Equal(monday.AddDate(0, 0, 3)) && t.AssigneeID == current.AssigneeID && t.BlockedBy == nil &&
+				t.Recurrence == current.Recurrence && t.Occurrence == 3
+		})).
+		Return(domain.Task{ID: "2"}, nil)
+	suite.expectAudit(domain.AuditTaskCreated, "admin-id", "2")
+
+	task, err := suite.usecase.ModifyTask(ctx, admin, "1", domain.Task{Status: domain.StatusDone})
+
+	suite.NoError(err)
+	suite.Empty(task.Recurrence)
+	suite.mockRepo.AssertExpectations(suite.T())
+	suite.mockAudit.AssertExpectations(suite.T())
+}
+
+func (suite *TaskUsecaseTestSuite) TestModifyTask_DoneEndsSeries() {
+	ctx := context.TODO()
+	admin := domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}
+	current := domain.Task{ID: "1", Status: domain.StatusTodo, DueDate: time.Now(), Recurrence: "FREQ=DAILY;COUNT=3", Occurrence: 3}
+
+	suite.mockRepo.EXPECT().GetByID(ctx, "1").Return(current, nil)
+	suite.mockRepo.EXPECT().
+		Update(ctx, "1", bson.M{"status": domain.StatusDone, "recurrence": ""}, int64(0)).
+		Return(domain.Task{ID: "1", Status: domain.StatusDone, DueDate: current.DueDate, Occurrence: 3}, nil)
+	suite.expectAudit(domain.AuditTaskUpdated, "admin-id", "1")
+
+	_, err := suite.usecase.ModifyTask(ctx, admin, "1", domain.Task{Status: domain.StatusDone})
+
+	// the third occurrence was the last one
+	suite.NoError(err)
+	suite.mockRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *TaskUsecaseTestSuite) TestPreviewTaskOccurrences_SkipsShortMonths() {
+	ctx := context.TODO()
+	due := time.Date(2025, 1, 31, 8, 30, 0, 0, time.UTC)
+
+	suite.mockRepo.EXPECT().GetByID(ctx, "1").Return(domain.Task{ID: "1", DueDate: due, Recurrence: "FREQ=MONTHLY;BYMONTHDAY=31", Occurrence: 1}, nil)
+
+	dates, err := suite.usecase.PreviewTaskOccurrences(ctx, "1", 3)
+
+	suite.NoError(err)
+	suite.Equal([]time.Time{
+		time.Date(2025, 3, 31, 8, 30, 0, 0, time.UTC),
+		time.Date(2025, 5, 31, 8, 30, 0, 0, time.UTC),
+		time.Date(2025, 7, 31, 8, 30, 0, 0, time.UTC),
+	}, dates)
+}
+
+func (suite *TaskUsecaseTestSuite) TestPreviewTaskOccurrences_StopsAtUntil() {
+	ctx := context.TODO()
+	due := time.Date(2025, 11, 28, 17, 0, 0, 0, time.UTC)
+
+	// the last day of every other month, until the end of March
+	suite.mockRepo.EXPECT().GetByID(ctx, "1").Return(domain.Task{ID: "1", DueDate: due, Recurrence: "FREQ=MONTHLY;INTERVAL=2;BYMONTHDAY=-1;UNTIL=20260331"}, nil)
+
+	dates, err := suite.usecase.PreviewTaskOccurrences(ctx, "1", 0)
+
+	suite.NoError(err)
+	suite.Equal([]time.Time{
+		time.Date(2025, 11, 30, 17, 0, 0, 0, time.UTC),
+		time.Date(2026, 1, 31, 17, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 31, 17, 0, 0, 0, time.UTC),
+	}, dates)
+}
+
+func (suite *TaskUsecaseTestSuite) TestPreviewTaskOccurrences_NotRecurring() {
+	suite.mockRepo.EXPECT().GetByID(mock.Anything, "1").Return(domain.Task{ID: "1"}, nil)
+
+	_, err := suite.usecase.PreviewTaskOccurrences(context.TODO(), "1", 5)
+
+	suite.True(errors.Is(err, domain.ErrValidation))
 }
 
 func TestTaskUsecaseTestSuite(t *testing.T) {
@@ -0,0 +1,43 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"taskmanager/Tests/mocks"
+	usecases "taskmanager/Usecases"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTrashPurger_RunsUntilCancelled(t *testing.T) {
+	mockUsecase := new(mocks.MockTaskUsecase)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// purge right away and on every tick, stop after the third run
+	runs := 0
+	mockUsecase.EXPECT().
+		PurgeExpiredTasks(mock.Anything, 48*time.Hour).
+		Run(func(context.Context, time.Duration) {
+			runs++
+			if runs == 3 {
+				cancel()
+			}
+		}).
+		Return(0, nil)
+
+	done := make(chan struct{})
+	go func() {
+		usecases.NewTrashPurger(mockUsecase, 48*time.Hour, time.Millisecond).Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after the context was cancelled")
+	}
+	assert.Equal(t, 3, runs)
+}
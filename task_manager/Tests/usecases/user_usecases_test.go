@@ -3,11 +3,15 @@ package usecases_test
 import (
 	"context"
 	"errors"
-	"os"
+	"fmt"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	domain "taskmanager/Domain"
 	infrastructure "taskmanager/Infrastructure"
+	repositories "taskmanager/Repositories"
 	"taskmanager/Tests/mocks"
 	usecases "taskmanager/Usecases"
 
@@ -18,22 +22,50 @@ import (
 
 type UserUsecaseTestSuite struct {
 	suite.Suite
-	mockRepo *mocks.MockUserRepository
-	usecase  usecases.UserUsecase
+	mockRepo      *mocks.MockUserRepository
+	mockTokenRepo *mocks.MockTokenRepository
+	mockAudit     *mocks.MockAuditRepository
+	mockEvents    *mocks.MockEventPublisher
+	loginAttempts repositories.LoginAttemptRepository
+	usecase       usecases.UserUsecase
+}
+
+// testLoginLimits slow a username down after 2 failures and lock it out after 4, an address after 3 and 6.
+// The delays are long enough that a test never outlasts one.
+var testLoginLimits = domain.LoginLimits{
+	Window:           time.Hour,
+	UserDelayAfter:   2,
+	UserLockoutAfter: 4,
+	IPDelayAfter:     3,
+	IPLockoutAfter:   6,
+	BaseDelay:        time.Minute,
+	MaxDelay:         10 * time.Minute,
+	LockoutDuration:  time.Hour,
 }
 
 func (suite *UserUsecaseTestSuite) SetupTest() {
 	suite.mockRepo = new(mocks.MockUserRepository)
-	suite.usecase = usecases.NewUserUsecase(suite.mockRepo)
-
-	// Set JWT_SECRET for infrastructure.GenerateJWT
-	os.Setenv("JWT_SECRET", "test_secret")
+	suite.mockTokenRepo = new(mocks.MockTokenRepository)
+	suite.mockAudit = new(mocks.MockAuditRepository)
+	suite.mockEvents = new(mocks.MockEventPublisher)
+	suite.loginAttempts = repositories.NewInMemoryLoginAttemptRepository()
+	suite.usecase = suite.newUsecase(infrastructure.PasswordHashBcrypt)
 }
 
-func (suite *UserUsecaseTestSuite) TearDownTest() {
-	os.Unsetenv("JWT_SECRET")
+// newUsecase returns a usecase over the suite's mocks that hashes new passwords with the algorithm.
+// bcrypt hashes at the default cost, like infrastructure.HashPassword, argon2id with cheap parameters.
+func (suite *UserUsecaseTestSuite) newUsecase(algorithm string) usecases.UserUsecase {
+	hasher, err := infrastructure.NewPasswordHasher(algorithm, infrastructure.DefaultBcryptCost, testArgon2Params)
+	suite.Require().NoError(err)
+
+	jwtKeys, err := infrastructure.NewHMACJWTKeys("test_secret")
+	suite.Require().NoError(err)
+
+	return usecases.NewUserUsecase(suite.mockRepo, suite.mockTokenRepo, suite.mockAudit, suite.mockEvents, suite.loginAttempts, testLoginLimits, domain.DefaultPasswordPolicy(), hasher, jwtKeys, infrastructure.DefaultJWTSettings())
 }
 
+var testArgon2Params = infrastructure.Argon2Params{Memory: 64, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+
 // --- 1. Test RegisterUser ---
 
 func (suite *UserUsecaseTestSuite) TestRegisterUser_Success_FirstUserIsAdmin() {
@@ -63,10 +95,10 @@ func (suite *UserUsecaseTestSuite) TestRegisterUser_Success_FirstUserIsAdmin() {
 
 func (suite *UserUsecaseTestSuite) TestRegisterUser_Fail_ShortPassword() {
 	ctx := context.TODO()
-	_, err := suite.usecase.RegisterUser(ctx, "user", "123") // 3 chars
+	_, err := suite.usecase.RegisterUser(ctx, "user", "1234567") // 7 chars
 
-	suite.Error(err)
-	suite.Contains(err.Error(), "password should be at least 4 characters")
+	suite.ErrorIs(err, domain.ErrValidation)
+	suite.Contains(err.Error(), "password must be at least 8 characters")
 	suite.mockRepo.AssertNotCalled(suite.T(), "IsUsernameAvailable", mock.Anything, mock.Anything)
 }
 
@@ -76,12 +108,25 @@ func (suite *UserUsecaseTestSuite) TestRegisterUser_Fail_UsernameExists() {
 
 	suite.mockRepo.EXPECT().IsUsernameAvailable(ctx, userName).Return(domain.ErrAleadyExists)
 
-	_, err := suite.usecase.RegisterUser(ctx, userName, "password")
+	_, err := suite.usecase.RegisterUser(ctx, userName, "correct-horse-battery")
 
 	suite.Error(err)
 	suite.True(errors.Is(err, domain.ErrAleadyExists))
 }
 
+func (suite *UserUsecaseTestSuite) TestRegisterUser_Fail_PasswordPolicy() {
+	ctx := context.TODO()
+
+	for _, password := range []string{"Password1", "taken_name", "TAKEN_NAME"} {
+		_, err := suite.usecase.RegisterUser(ctx, "taken_name", password)
+
+		var weak *domain.PasswordPolicyError
+		suite.ErrorAs(err, &weak, password)
+		suite.ErrorIs(err, domain.ErrValidation)
+	}
+	suite.mockRepo.AssertNotCalled(suite.T(), "IsUsernameAvailable", mock.Anything, mock.Anything)
+}
+
 // --- 2. Test AuthenticateUser ---
 
 func (suite *UserUsecaseTestSuite) TestAuthenticateUser_Success() {
@@ -98,12 +143,26 @@ func (suite *UserUsecaseTestSuite) TestAuthenticateUser_Success() {
 		DoesUserExist(ctx, userName).
 		Return(userID, hashedPassword, domain.RoleUser, nil)
 
+	// 2. Mock loading the user, who doesn't have to change their password
+	suite.mockRepo.EXPECT().
+		GetUserByID(ctx, userID).
+		Return(domain.User{ID: uuid.MustParse(userID), UserName: userName}, nil)
+
+	// 3. Mock storing the refresh token of a brand new family
+	suite.mockTokenRepo.EXPECT().
+		SaveRefreshToken(ctx, mock.MatchedBy(func(t domain.RefreshToken) bool {
+			return t.UserID == userID && t.FamilyID != "" && t.TokenHash != ""
+		})).
+		Return(nil)
+
 	// ACT
-	token, err := suite.usecase.AuthenticateUser(ctx, userName, password)
+	tokens, err := suite.usecase.AuthenticateUser(ctx, userName, password, "", "10.0.0.1")
 
 	// ASSERT
 	suite.NoError(err)
-	suite.NotEmpty(token, "Should return a valid JWT string")
+	suite.NotEmpty(tokens.AccessToken, "Should return a valid JWT string")
+	suite.NotEmpty(tokens.RefreshToken, "Should return a refresh token")
+	suite.Equal(int64(infrastructure.AccessTokenTTL.Seconds()), tokens.ExpiresIn)
 }
 
 func (suite *UserUsecaseTestSuite) TestAuthenticateUser_Fail_WrongPassword() {
@@ -119,28 +178,547 @@ func (suite *UserUsecaseTestSuite) TestAuthenticateUser_Fail_WrongPassword() {
 		Return(uuid.New().String(), hashedPassword, domain.RoleUser, nil)
 
 	// ACT
-	token, err := suite.usecase.AuthenticateUser(ctx, userName, wrongPassword)
+	tokens, err := suite.usecase.AuthenticateUser(ctx, userName, wrongPassword, "", "10.0.0.1")
 
 	// ASSERT
 	suite.Error(err)
-	suite.Empty(token)
+	suite.Empty(tokens)
 	suite.True(errors.Is(err, domain.ErrValidation), "Should return validation error on password mismatch")
 }
 
-// --- 3. Test PromoteUser ---
+func (suite *UserUsecaseTestSuite) TestAuthenticateUser_Throttled_DelaysThenLocksOut() {
+	ctx := context.TODO()
+	hashedPassword, _ := infrastructure.HashPassword("correct_one")
+
+	suite.mockRepo.EXPECT().
+		DoesUserExist(ctx, "john_doe").
+		Return(uuid.New().String(), hashedPassword, domain.RoleUser, nil).Times(2)
+
+	for i := 0; i < 2; i++ {
+		_, err := suite.usecase.AuthenticateUser(ctx, "john_doe", "wrong_one", "", "10.0.0.1")
+		suite.ErrorIs(err, domain.ErrValidation)
+	}
+
+	// the third attempt has to wait, even with the right password, and isn't checked at all
+	_, err := suite.usecase.AuthenticateUser(ctx, "john_doe", "correct_one", "", "10.0.0.1")
+
+	var throttled *domain.LoginThrottledError
+	suite.Require().ErrorAs(err, &throttled)
+	suite.ErrorIs(err, domain.ErrTooManyAttempts)
+	suite.False(throttled.Locked)
+	suite.InDelta(time.Minute.Seconds(), throttled.RetryAfter.Seconds(), 1)
+	suite.mockRepo.AssertNumberOfCalls(suite.T(), "DoesUserExist", 2)
+
+	// once the delay has passed the delay doubles with every failure, until the lockout
+	attempts, err := suite.loginAttempts.GetAttempts(ctx, domain.LoginAttemptUser, "john_doe")
+	suite.Require().NoError(err)
+	suite.Equal(2, attempts.Failures)
+	suite.Require().NoError(suite.loginAttempts.Reset(ctx, domain.LoginAttemptUser, "john_doe"))
+	for i := 0; i < 4; i++ {
+		_, err := suite.loginAttempts.ReserveAttempt(ctx, domain.LoginAttemptUser, "john_doe", time.Now().Add(-time.Hour/2), domain.LoginLimits{Window: testLoginLimits.Window})
+		suite.Require().NoError(err)
+	}
+
+	suite.mockRepo.ExpectedCalls = nil
+	suite.mockRepo.EXPECT().
+		DoesUserExist(ctx, "john_doe").
+		Return(uuid.New().String(), hashedPassword, domain.RoleUser, nil).Once()
+
+	_, err = suite.usecase.AuthenticateUser(ctx, "john_doe", "wrong_one", "", "10.0.0.2")
+	suite.ErrorIs(err, domain.ErrValidation)
+
+	_, err = suite.usecase.AuthenticateUser(ctx, "john_doe", "correct_one", "", "10.0.0.2")
+	suite.Require().ErrorAs(err, &throttled)
+	suite.True(throttled.Locked)
+	suite.InDelta(time.Hour.Seconds(), throttled.RetryAfter.Seconds(), 1)
+
+	lockouts, err := suite.usecase.ListLoginLockouts(ctx)
+	suite.Require().NoError(err)
+	suite.Require().Len(lockouts, 1)
+	suite.Equal(domain.LoginAttemptUser, lockouts[0].Kind)
+	suite.Equal("john_doe", lockouts[0].Subject)
+	suite.Equal(5, lockouts[0].Failures)
+}
+
+func (suite *UserUsecaseTestSuite) TestAuthenticateUser_Throttled_ParallelGuessesShareOneAttempt() {
+	ctx := context.TODO()
+	hashedPassword, _ := infrastructure.HashPassword("correct_one")
+
+	// two failures whose delay has passed leave room for a single attempt
+	for i := 0; i < 2; i++ {
+		_, err := suite.loginAttempts.ReserveAttempt(ctx, domain.LoginAttemptUser, "john_doe", time.Now().Add(-time.Hour/2), domain.LoginLimits{Window: testLoginLimits.Window})
+		suite.Require().NoError(err)
+	}
+
+	suite.mockRepo.EXPECT().
+		DoesUserExist(ctx, "john_doe").
+		Return(uuid.New().String(), hashedPassword, domain.RoleUser, nil)
+
+	errs := make([]error, 5)
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = suite.usecase.AuthenticateUser(ctx, "john_doe", "wrong_one", "", fmt.Sprintf("10.0.1.%d", i))
+		}()
+	}
+	wg.Wait()
+
+	// only one guess is checked, the others have to wait for it
+	var checked int
+	for _, err := range errs {
+		if errors.Is(err, domain.ErrValidation) {
+			checked++
+		} else {
+			suite.ErrorIs(err, domain.ErrTooManyAttempts)
+		}
+	}
+	suite.Equal(1, checked)
+	suite.mockRepo.AssertNumberOfCalls(suite.T(), "DoesUserExist", 1)
+
+	attempts, err := suite.loginAttempts.GetAttempts(ctx, domain.LoginAttemptUser, "john_doe")
+	suite.Require().NoError(err)
+	suite.Equal(3, attempts.Failures)
+}
+
+func (suite *UserUsecaseTestSuite) TestAuthenticateUser_Throttled_UnknownUsersAndAddresses() {
+	ctx := context.TODO()
+
+	suite.mockRepo.EXPECT().
+		DoesUserExist(ctx, mock.Anything).
+		Return("", "", domain.RoleUser, domain.ErrNotFound).Times(3)
+
+	// guessing at different usernames from one address runs into the limit of the address
+	for _, userName := range []string{"a", "b", "c"} {
+		_, err := suite.usecase.AuthenticateUser(ctx, userName, "guess", "", "10.0.0.9")
+		suite.ErrorIs(err, domain.ErrNotFound)
+	}
+
+	_, err := suite.usecase.AuthenticateUser(ctx, "d", "guess", "", "10.0.0.9")
+	suite.ErrorIs(err, domain.ErrTooManyAttempts)
+
+	attempts, err := suite.loginAttempts.GetAttempts(ctx, domain.LoginAttemptUser, "a")
+	suite.Require().NoError(err)
+	suite.Equal(1, attempts.Failures)
+}
+
+func (suite *UserUsecaseTestSuite) TestAuthenticateUser_SuccessForgetsTheUsernameOnly() {
+	ctx := context.TODO()
+	hashedPassword, _ := infrastructure.HashPassword("correct_one")
+	userID := uuid.New().String()
+
+	suite.mockRepo.EXPECT().
+		DoesUserExist(ctx, "john_doe").
+		Return(userID, hashedPassword, domain.RoleUser, nil)
+	suite.mockRepo.EXPECT().
+		GetUserByID(ctx, userID).
+		Return(domain.User{ID: uuid.MustParse(userID), UserName: "john_doe"}, nil)
+	suite.mockTokenRepo.EXPECT().
+		SaveRefreshToken(ctx, mock.Anything).
+		Return(nil)
+
+	_, err := suite.usecase.AuthenticateUser(ctx, "john_doe", "wrong_one", "", "10.0.0.1")
+	suite.ErrorIs(err, domain.ErrValidation)
+
+	_, err = suite.usecase.AuthenticateUser(ctx, "john_doe", "correct_one", "", "10.0.0.1")
+	suite.NoError(err)
+
+	_, err = suite.loginAttempts.GetAttempts(ctx, domain.LoginAttemptUser, "john_doe")
+	suite.ErrorIs(err, domain.ErrNotFound)
+
+	attempts, err := suite.loginAttempts.GetAttempts(ctx, domain.LoginAttemptIP, "10.0.0.1")
+	suite.Require().NoError(err)
+	suite.Equal(1, attempts.Failures)
+}
+
+func (suite *UserUsecaseTestSuite) TestAuthenticateUser_RehashesOutdatedHashes() {
+	ctx := context.TODO()
+	usecase := suite.newUsecase(infrastructure.PasswordHashArgon2id)
+	bcryptHash, _ := infrastructure.HashPassword("secret123")
+	userID := uuid.New().String()
+
+	suite.mockRepo.EXPECT().
+		DoesUserExist(ctx, "john_doe").
+		Return(userID, bcryptHash, domain.RoleUser, nil).Once()
+	suite.mockRepo.EXPECT().
+		GetUserByID(ctx, userID).
+		Return(domain.User{ID: uuid.MustParse(userID), UserName: "john_doe"}, nil)
+	suite.mockTokenRepo.EXPECT().
+		SaveRefreshToken(ctx, mock.Anything).
+		Return(nil)
+
+	// 1. A bcrypt hash is replaced by an argon2id hash of the same password
+	var argonHash string
+	suite.mockRepo.EXPECT().
+		ReplacePasswordHash(ctx, userID, bcryptHash, mock.MatchedBy(func(hash string) bool {
+			argonHash = hash
+			return strings.HasPrefix(hash, "$argon2id$v=19$m=64,t=1,p=1$") && infrastructure.ComparePassword(hash, "secret123") == nil
+		})).
+		Return(nil).Once()
+
+	_, err := usecase.AuthenticateUser(ctx, "john_doe", "secret123", "", "10.0.0.1")
+	suite.Require().NoError(err)
+
+	// 2. A current hash is left alone
+	suite.mockRepo.EXPECT().
+		DoesUserExist(ctx, "john_doe").
+		Return(userID, argonHash, domain.RoleUser, nil).Once()
+
+	_, err = usecase.AuthenticateUser(ctx, "john_doe", "secret123", "", "10.0.0.1")
+	suite.Require().NoError(err)
+
+	// 3. A failing rehash doesn't fail the login
+	suite.mockRepo.EXPECT().
+		DoesUserExist(ctx, "john_doe").
+		Return(userID, bcryptHash, domain.RoleUser, nil).Once()
+	suite.mockRepo.EXPECT().
+		ReplacePasswordHash(ctx, userID, bcryptHash, mock.Anything).
+		Return(errors.New("database unavailable")).Once()
+
+	_, err = usecase.AuthenticateUser(ctx, "john_doe", "secret123", "", "10.0.0.1")
+	suite.NoError(err)
+	suite.mockRepo.AssertNumberOfCalls(suite.T(), "ReplacePasswordHash", 2)
+}
+
+func (suite *UserUsecaseTestSuite) TestAuthenticateUser_MustChangePassword() {
+	ctx := context.TODO()
+	hashedPassword, _ := infrastructure.HashPassword("temporary-one")
+	user := domain.User{ID: uuid.New(), UserName: "john_doe", HashedPassword: hashedPassword, MustChangePassword: true}
+
+	suite.mockRepo.EXPECT().
+		DoesUserExist(ctx, "john_doe").
+		Return(user.ID.String(), hashedPassword, domain.RoleUser, nil)
+	suite.mockRepo.EXPECT().
+		GetUserByID(ctx, user.ID.String()).
+		Return(user, nil)
+
+	// 1. The right password alone isn't enough
+	_, err := suite.usecase.AuthenticateUser(ctx, "john_doe", "temporary-one", "", "10.0.0.1")
+	suite.ErrorIs(err, domain.ErrPasswordChangeRequired)
+
+	// 2. The new password has to satisfy the policy and differ from the current one
+	for _, newPassword := range []string{"short", "temporary-one"} {
+		_, err = suite.usecase.AuthenticateUser(ctx, "john_doe", "temporary-one", newPassword, "10.0.0.1")
+		var weak *domain.PasswordPolicyError
+		suite.ErrorAs(err, &weak, newPassword)
+	}
+
+	// 3. A good one is stored and the login goes ahead
+	suite.mockRepo.EXPECT().
+		UpdatePassword(ctx, user.ID.String(), mock.MatchedBy(func(hash string) bool {
+			return infrastructure.ComparePassword(hash, "a-brand-new-one") == nil
+		}), false, mock.Anything).
+		Return(domain.User{ID: user.ID, UserName: "john_doe"}, nil)
+	suite.mockAudit.EXPECT().
+		Append(mock.Anything, mock.MatchedBy(func(e domain.AuditEntry) bool {
+			return e.Action == domain.AuditUserPasswordChanged && e.ActorID == user.ID.String() && e.TargetID == user.ID.String()
+		})).
+		Return(nil)
+	suite.mockTokenRepo.EXPECT().
+		SaveRefreshToken(ctx, mock.Anything).
+		Return(nil)
+
+	tokens, err := suite.usecase.AuthenticateUser(ctx, "john_doe", "temporary-one", "a-brand-new-one", "10.0.0.1")
+
+	suite.NoError(err)
+	suite.NotEmpty(tokens.AccessToken)
+	suite.mockRepo.AssertExpectations(suite.T())
+	suite.mockAudit.AssertExpectations(suite.T())
+}
+
+// --- 3. Test ChangePassword and ResetPassword ---
+
+func (suite *UserUsecaseTestSuite) TestChangePassword_Success() {
+	ctx := context.TODO()
+	hashedPassword, _ := infrastructure.HashPassword("the-old-one")
+	user := domain.User{ID: uuid.New(), UserName: "john_doe", HashedPassword: hashedPassword}
+	actor := domain.Actor{UserID: user.ID.String(), Role: domain.RoleUser}
+
+	suite.mockRepo.EXPECT().GetUserByID(ctx, actor.UserID).Return(user, nil)
+	suite.mockRepo.EXPECT().
+		UpdatePassword(ctx, actor.UserID, mock.MatchedBy(func(hash string) bool {
+			return infrastructure.ComparePassword(hash, "the-new-one") == nil
+		}), false, mock.MatchedBy(func(at time.Time) bool { return time.Since(at) < time.Minute })).
+		Return(domain.User{ID: user.ID, UserName: "john_doe"}, nil)
+	suite.mockAudit.EXPECT().Append(mock.Anything, mock.Anything).Return(nil)
+
+	// the caller gets a new session of their own
+	suite.mockTokenRepo.EXPECT().
+		SaveRefreshToken(ctx, mock.MatchedBy(func(t domain.RefreshToken) bool { return t.UserID == actor.UserID })).
+		Return(nil)
+
+	tokens, err := suite.usecase.ChangePassword(ctx, actor, "the-old-one", "the-new-one")
+
+	suite.NoError(err)
+	suite.NotEmpty(tokens.RefreshToken)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *UserUsecaseTestSuite) TestChangePassword_Fail_WrongCurrentPassword() {
+	ctx := context.TODO()
+	hashedPassword, _ := infrastructure.HashPassword("the-old-one")
+	user := domain.User{ID: uuid.New(), UserName: "john_doe", HashedPassword: hashedPassword}
+	actor := domain.Actor{UserID: user.ID.String(), Role: domain.RoleUser}
+
+	suite.mockRepo.EXPECT().GetUserByID(ctx, actor.UserID).Return(user, nil)
+
+	_, err := suite.usecase.ChangePassword(ctx, actor, "a-guess", "the-new-one")
+	suite.ErrorIs(err, domain.ErrInvalidCredential)
+
+	// wrong guesses count towards the throttle of the username
+	attempts, err := suite.loginAttempts.GetAttempts(ctx, domain.LoginAttemptUser, "john_doe")
+	suite.Require().NoError(err)
+	suite.Equal(1, attempts.Failures)
+
+	_, err = suite.usecase.ChangePassword(ctx, actor, "the-old-one", "john_doe")
+	suite.ErrorIs(err, domain.ErrValidation)
+
+	suite.mockRepo.AssertNotCalled(suite.T(), "UpdatePassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *UserUsecaseTestSuite) TestResetPassword() {
+	ctx := context.TODO()
+	admin := domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}
+	user := domain.User{ID: uuid.New(), UserName: "john_doe", HashedPassword: "current-hash"}
+
+	suite.mockRepo.EXPECT().GetUserByID(ctx, user.ID.String()).Return(user, nil)
+
+	// 1. Without a temporary password the current one is kept
+	suite.mockRepo.EXPECT().
+		UpdatePassword(ctx, user.ID.String(), "current-hash", true, mock.Anything).
+		Return(domain.User{ID: user.ID, MustChangePassword: true}, nil).Once()
+	suite.mockAudit.EXPECT().
+		Append(mock.Anything, mock.MatchedBy(func(e domain.AuditEntry) bool {
+			return e.Action == domain.AuditUserPasswordReset && e.ActorID == "admin-id" &&
+				strings.Contains(string(e.After), `"must_change_password":true`) && !strings.Contains(string(e.After), "current-hash")
+		})).
+		Return(nil)
+
+	result, err := suite.usecase.ResetPassword(ctx, admin, user.ID.String(), "")
+	suite.NoError(err)
+	suite.True(result.MustChangePassword)
+
+	// 2. A temporary password has to satisfy the policy too
+	_, err = suite.usecase.ResetPassword(ctx, admin, user.ID.String(), "qwerty123")
+	suite.ErrorIs(err, domain.ErrValidation)
+
+	suite.mockRepo.EXPECT().
+		UpdatePassword(ctx, user.ID.String(), mock.MatchedBy(func(hash string) bool {
+			return infrastructure.ComparePassword(hash, "temporary-one") == nil
+		}), true, mock.Anything).
+		Return(domain.User{ID: user.ID, MustChangePassword: true}, nil).Once()
+
+	_, err = suite.usecase.ResetPassword(ctx, admin, user.ID.String(), "temporary-one")
+	suite.NoError(err)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+// --- 4. Test UnlockLogin ---
+
+func (suite *UserUsecaseTestSuite) TestUnlockLogin_Success() {
+	ctx := context.TODO()
+	admin := domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}
+
+	suite.Require().NoError(suite.loginAttempts.Lock(ctx, domain.LoginAttemptIP, "10.0.0.1", time.Now().Add(time.Hour)))
+
+	suite.mockAudit.EXPECT().
+		Append(mock.Anything, mock.MatchedBy(func(e domain.AuditEntry) bool {
+			return e.Action == domain.AuditLoginUnlocked && e.ActorID == "admin-id" &&
+				e.TargetType == domain.AuditTargetLogin && e.TargetID == "ip:10.0.0.1" &&
+				strings.Contains(string(e.Before), `"locked_until"`) && e.After == nil
+		})).
+		Return(nil)
+
+	err := suite.usecase.UnlockLogin(ctx, admin, domain.LoginAttemptIP, "10.0.0.1")
+
+	suite.NoError(err)
+	lockouts, err := suite.usecase.ListLoginLockouts(ctx)
+	suite.NoError(err)
+	suite.Empty(lockouts)
+	suite.mockAudit.AssertExpectations(suite.T())
+}
+
+func (suite *UserUsecaseTestSuite) TestUnlockLogin_Fail() {
+	ctx := context.TODO()
+	admin := domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}
+
+	err := suite.usecase.UnlockLogin(ctx, admin, domain.LoginAttemptUser, "nobody")
+	suite.ErrorIs(err, domain.ErrNotFound)
+
+	err = suite.usecase.UnlockLogin(ctx, admin, "email", "nobody")
+	suite.ErrorIs(err, domain.ErrValidation)
+
+	err = suite.usecase.UnlockLogin(ctx, admin, domain.LoginAttemptUser, "")
+	suite.ErrorIs(err, domain.ErrValidation)
+
+	suite.mockAudit.AssertNotCalled(suite.T(), "Append", mock.Anything, mock.Anything)
+}
+
+// --- 5. Test PromoteUser ---
 
 func (suite *UserUsecaseTestSuite) TestPromoteUser_Success() {
 	ctx := context.TODO()
 	targetID := uuid.New().String()
+	admin := domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}
 
+	suite.mockRepo.EXPECT().
+		GetUserByID(ctx, targetID).
+		Return(domain.User{ID: uuid.MustParse(targetID), Role: domain.RoleUser}, nil)
 	suite.mockRepo.EXPECT().
 		PromoteUser(ctx, targetID).
 		Return(domain.User{ID: uuid.MustParse(targetID), Role: domain.RoleAdmin}, nil)
 
-	result, err := suite.usecase.PromoteUser(ctx, targetID)
+	// the promotion is audited with the role before and after
+	suite.mockAudit.EXPECT().
+		Append(mock.Anything, mock.MatchedBy(func(e domain.AuditEntry) bool {
+			return e.Action == domain.AuditUserPromoted && e.ActorID == "admin-id" &&
+				e.TargetType == domain.AuditTargetUser && e.TargetID == targetID &&
+				strings.Contains(string(e.Before), `"role":0`) && strings.Contains(string(e.After), `"role":1`)
+		})).
+		Return(nil)
+
+	// and published to the webhooks
+	suite.mockEvents.EXPECT().
+		Publish(ctx, domain.WebhookUserPromoted, "admin-id", mock.Anything, mock.MatchedBy(func(u domain.User) bool {
+			return u.ID.String() == targetID && u.Role == domain.RoleAdmin
+		})).
+		Return().Once()
+
+	result, err := suite.usecase.PromoteUser(ctx, admin, targetID)
 
 	suite.NoError(err)
 	suite.Equal(domain.RoleAdmin, result.Role)
+	suite.mockAudit.AssertExpectations(suite.T())
+	suite.mockEvents.AssertExpectations(suite.T())
+}
+
+func (suite *UserUsecaseTestSuite) TestPromoteUser_NotFound() {
+	ctx := context.TODO()
+	admin := domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}
+
+	suite.mockRepo.EXPECT().GetUserByID(ctx, "missing").Return(domain.User{}, domain.ErrNotFound)
+
+	_, err := suite.usecase.PromoteUser(ctx, admin, "missing")
+
+	suite.True(errors.Is(err, domain.ErrNotFound))
+	suite.mockAudit.AssertNotCalled(suite.T(), "Append", mock.Anything, mock.Anything)
+	suite.mockEvents.AssertNotCalled(suite.T(), "Publish", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// --- 6. Test RefreshTokens ---
+
+func (suite *UserUsecaseTestSuite) TestRefreshTokens_Success_RotatesToken() {
+	ctx := context.TODO()
+	user := domain.User{ID: uuid.New(), UserName: "john_doe", Role: domain.RoleUser}
+	stored := domain.RefreshToken{
+		ID:        "token-1",
+		FamilyID:  "family-1",
+		UserID:    user.ID.String(),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	suite.mockTokenRepo.EXPECT().GetRefreshTokenByHash(ctx, infrastructure.HashRefreshToken("refresh")).Return(stored, nil)
+	suite.mockRepo.EXPECT().GetUserByID(ctx, user.ID.String()).Return(user, nil)
+	suite.mockTokenRepo.EXPECT().RotateRefreshToken(ctx, "token-1", mock.Anything).Return(nil)
+
+	// the new refresh token stays in the same family
+	suite.mockTokenRepo.EXPECT().
+		SaveRefreshToken(ctx, mock.MatchedBy(func(t domain.RefreshToken) bool {
+			return t.FamilyID == "family-1" && t.ID != "token-1"
+		})).
+		Return(nil)
+
+	tokens, err := suite.usecase.RefreshTokens(ctx, "refresh")
+
+	suite.NoError(err)
+	suite.NotEmpty(tokens.AccessToken)
+	suite.NotEqual("refresh", tokens.RefreshToken)
+}
+
+func (suite *UserUsecaseTestSuite) TestRefreshTokens_Fail_ReuseRevokesFamily() {
+	ctx := context.TODO()
+	stored := domain.RefreshToken{
+		ID:         "token-1",
+		FamilyID:   "family-1",
+		ReplacedBy: "token-2", // already rotated
+		ExpiresAt:  time.Now().Add(time.Hour),
+	}
+
+	suite.mockTokenRepo.EXPECT().GetRefreshTokenByHash(ctx, mock.Anything).Return(stored, nil)
+	suite.mockTokenRepo.EXPECT().RevokeTokenFamily(ctx, "family-1").Return(nil)
+
+	_, err := suite.usecase.RefreshTokens(ctx, "replayed")
+
+	suite.Error(err)
+	suite.True(errors.Is(err, domain.ErrInvalidToken))
+	suite.mockTokenRepo.AssertExpectations(suite.T())
+}
+
+func (suite *UserUsecaseTestSuite) TestRefreshTokens_Fail_PasswordChangedSinceLogin() {
+	ctx := context.TODO()
+	changedAt := time.Now().Add(-time.Minute)
+	user := domain.User{ID: uuid.New(), UserName: "john_doe", PasswordChangedAt: changedAt}
+	stored := domain.RefreshToken{
+		ID:        "token-1",
+		FamilyID:  "family-1",
+		UserID:    user.ID.String(),
+		CreatedAt: changedAt.Add(-time.Hour),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	suite.mockTokenRepo.EXPECT().GetRefreshTokenByHash(ctx, mock.Anything).Return(stored, nil)
+	suite.mockRepo.EXPECT().GetUserByID(ctx, user.ID.String()).Return(user, nil)
+
+	_, err := suite.usecase.RefreshTokens(ctx, "refresh")
+
+	suite.ErrorIs(err, domain.ErrInvalidToken)
+	suite.mockTokenRepo.AssertNotCalled(suite.T(), "RotateRefreshToken", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *UserUsecaseTestSuite) TestCheckAccessTokenUser() {
+	ctx := context.TODO()
+	changedAt := time.Date(2025, 11, 12, 14, 30, 0, 500_000_000, time.UTC)
+	user := domain.User{ID: uuid.New(), UserName: "john_doe", PasswordChangedAt: changedAt}
+	reset := domain.User{ID: uuid.New(), UserName: "forgetful_user", PasswordChangedAt: changedAt, MustChangePassword: true}
+
+	suite.mockRepo.EXPECT().GetUserByID(ctx, user.ID.String()).Return(user, nil)
+	suite.mockRepo.EXPECT().GetUserByID(ctx, reset.ID.String()).Return(reset, nil)
+	suite.mockRepo.EXPECT().GetUserByID(ctx, "unknown").Return(domain.User{}, domain.ErrNotFound)
+
+	// a token issued before the change no longer works, one issued in the same second still does
+	suite.ErrorIs(suite.usecase.CheckAccessTokenUser(ctx, user.ID.String(), changedAt.Add(-time.Second)), domain.ErrInvalidToken)
+	suite.NoError(suite.usecase.CheckAccessTokenUser(ctx, user.ID.String(), changedAt.Truncate(time.Second)))
+
+	suite.ErrorIs(suite.usecase.CheckAccessTokenUser(ctx, reset.ID.String(), changedAt.Add(time.Minute)), domain.ErrPasswordChangeRequired)
+	suite.ErrorIs(suite.usecase.CheckAccessTokenUser(ctx, "unknown", changedAt), domain.ErrInvalidToken)
+}
+
+func (suite *UserUsecaseTestSuite) TestRefreshTokens_Fail_UnknownToken() {
+	ctx := context.TODO()
+
+	suite.mockTokenRepo.EXPECT().GetRefreshTokenByHash(ctx, mock.Anything).Return(domain.RefreshToken{}, domain.ErrNotFound)
+
+	_, err := suite.usecase.RefreshTokens(ctx, "unknown")
+
+	suite.True(errors.Is(err, domain.ErrInvalidToken))
+}
+
+// --- 7. Test Logout ---
+
+func (suite *UserUsecaseTestSuite) TestLogout_RevokesFamilyAndAccessToken() {
+	ctx := context.TODO()
+	expiresAt := time.Now().Add(time.Minute)
+	stored := domain.RefreshToken{ID: "token-1", FamilyID: "family-1", UserID: "user-1"}
+
+	suite.mockTokenRepo.EXPECT().GetRefreshTokenByHash(ctx, mock.Anything).Return(stored, nil)
+	suite.mockTokenRepo.EXPECT().RevokeTokenFamily(ctx, "family-1").Return(nil)
+	suite.mockTokenRepo.EXPECT().RevokeAccessToken(ctx, "jti-1", expiresAt).Return(nil)
+
+	err := suite.usecase.Logout(ctx, "user-1", "refresh", "jti-1", expiresAt)
+
+	suite.NoError(err)
+	suite.mockTokenRepo.AssertExpectations(suite.T())
 }
 
 func TestUserUsecaseTestSuite(t *testing.T) {
@@ -0,0 +1,320 @@
+package usecases_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	domain "taskmanager/Domain"
+	repositories "taskmanager/Repositories"
+	"taskmanager/Tests/mocks"
+	usecases "taskmanager/Usecases"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type WebhookUsecaseTestSuite struct {
+	suite.Suite
+	mockRepo   *mocks.MockWebhookRepository
+	mockAudit  *mocks.MockAuditRepository
+	mockSender *mocks.MockWebhookSender
+	usecase    usecases.WebhookUsecase
+}
+
+func (suite *WebhookUsecaseTestSuite) SetupTest() {
+	// Initialize the mocks and the usecase before each test, the dispatcher is never run
+	suite.mockRepo = new(mocks.MockWebhookRepository)
+	suite.mockAudit = new(mocks.MockAuditRepository)
+	suite.mockSender = new(mocks.MockWebhookSender)
+	dispatcher := usecases.NewWebhookDispatcher(suite.mockRepo, suite.mockSender, 3, time.Millisecond)
+	suite.usecase = usecases.NewWebhookUsecase(suite.mockRepo, suite.mockAudit, dispatcher)
+}
+
+func TestWebhookUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(WebhookUsecaseTestSuite))
+}
+
+var webhookAdmin = domain.Actor{UserID: "admin-id", Role: domain.RoleAdmin}
+
+// expectWebhookAudit expects one audit entry for the given action on a webhook
+func (suite *WebhookUsecaseTestSuite) expectWebhookAudit(action domain.AuditAction) {
+	suite.mockAudit.EXPECT().
+		Append(mock.Anything, mock.MatchedBy(func(e domain.AuditEntry) bool {
+			return e.Action == action && e.TargetType == domain.AuditTargetWebhook && e.ActorID == webhookAdmin.UserID
+		})).
+		Return(nil).Once()
+}
+
+func (suite *WebhookUsecaseTestSuite) TestCreateWebhook_GeneratesSecretAndNormalizesEvents() {
+	ctx := context.TODO()
+
+	suite.mockRepo.EXPECT().
+		Create(ctx, mock.Anything).
+		RunAndReturn(func(ctx context.Context, w domain.Webhook) (domain.Webhook, error) { return w, nil })
+	suite.expectWebhookAudit(domain.AuditWebhookCreated)
+
+	webhook, err := suite.usecase.CreateWebhook(ctx, webhookAdmin, domain.Webhook{
+		URL:    "https://ci.example.com/hooks/tasks",
+		Events: []domain.WebhookEvent{domain.WebhookTaskUpdated, domain.WebhookTaskCreated, domain.WebhookTaskUpdated},
+		Active: true,
+	})
+
+	suite.NoError(err)
+	suite.NotEmpty(webhook.ID)
+	suite.Equal([]domain.WebhookEvent{domain.WebhookTaskCreated, domain.WebhookTaskUpdated}, webhook.Events)
+	suite.GreaterOrEqual(len(webhook.Secret), domain.MinWebhookSecretLength)
+	suite.Equal("admin-id", webhook.CreatedBy)
+	suite.mockAudit.AssertExpectations(suite.T())
+
+	// the secret is never part of the JSON, which keeps it out of the responses and the audit log
+	encoded, _ := json.Marshal(webhook)
+	suite.NotContains(string(encoded), webhook.Secret)
+}
+
+func (suite *WebhookUsecaseTestSuite) TestCreateWebhook_ValidationErrors() {
+	ctx := context.TODO()
+
+	cases := map[string]domain.Webhook{
+		"relative url":  {URL: "/hooks", Events: []domain.WebhookEvent{domain.WebhookTaskCreated}},
+		"ftp url":       {URL: "ftp://example.com/hooks", Events: []domain.WebhookEvent{domain.WebhookTaskCreated}},
+		"no events":     {URL: "https://example.com/hooks"},
+		"unknown event": {URL: "https://example.com/hooks", Events: []domain.WebhookEvent{"task.exploded"}},
+		"short secret":  {URL: "https://example.com/hooks", Events: []domain.WebhookEvent{domain.WebhookTaskCreated}, Secret: "short"},
+	}
+
+	for name, webhook := range cases {
+		_, err := suite.usecase.CreateWebhook(ctx, webhookAdmin, webhook)
+		suite.True(errors.Is(err, domain.ErrValidation), name)
+	}
+	suite.mockRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *WebhookUsecaseTestSuite) TestUpdateWebhook_ChangesOnlyGivenFields() {
+	ctx := context.TODO()
+	before := domain.Webhook{
+		ID:     "hook-1",
+		URL:    "https://example.com/hooks",
+		Events: []domain.WebhookEvent{domain.WebhookTaskCreated},
+		Secret: "0123456789abcdef",
+		Active: true,
+	}
+	inactive := false
+
+	suite.mockRepo.EXPECT().GetByID(ctx, "hook-1").Return(before, nil)
+	suite.mockRepo.EXPECT().
+		Update(ctx, mock.Anything).
+		RunAndReturn(func(ctx context.Context, w domain.Webhook) (domain.Webhook, error) { return w, nil })
+	suite.expectWebhookAudit(domain.AuditWebhookUpdated)
+
+	webhook, err := suite.usecase.UpdateWebhook(ctx, webhookAdmin, "hook-1", domain.WebhookChanges{Active: &inactive})
+
+	suite.NoError(err)
+	suite.False(webhook.Active)
+	suite.Equal(before.URL, webhook.URL)
+	suite.Equal(before.Events, webhook.Events)
+	suite.Equal(before.Secret, webhook.Secret)
+}
+
+func (suite *WebhookUsecaseTestSuite) TestListDeliveries_UnknownWebhook() {
+	ctx := context.TODO()
+
+	suite.mockRepo.EXPECT().GetByID(ctx, "missing").Return(domain.Webhook{}, domain.ErrNotFound)
+
+	_, err := suite.usecase.ListDeliveries(ctx, "missing", 0, 0)
+
+	suite.True(errors.Is(err, domain.ErrNotFound))
+	suite.mockRepo.AssertNotCalled(suite.T(), "ListDeliveries", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *WebhookUsecaseTestSuite) TestListDeliveries_ClampsLimit() {
+	ctx := context.TODO()
+
+	suite.mockRepo.EXPECT().GetByID(ctx, "hook-1").Return(domain.Webhook{ID: "hook-1"}, nil)
+	suite.mockRepo.EXPECT().
+		ListDeliveries(ctx, "hook-1", domain.MaxDeliveryPageLimit, int64(0)).
+		Return([]domain.WebhookDelivery{}, 0, nil)
+
+	page, err := suite.usecase.ListDeliveries(ctx, "hook-1", 1000, 0)
+
+	suite.NoError(err)
+	suite.Equal(domain.MaxDeliveryPageLimit, page.Limit)
+}
+
+func (suite *WebhookUsecaseTestSuite) TestRedeliver_CopiesThePayload() {
+	ctx := context.TODO()
+	original := domain.WebhookDelivery{
+		ID:        "delivery-1",
+		WebhookID: "hook-1",
+		EventID:   "event-1",
+		Event:     domain.WebhookTaskDeleted,
+		Payload:   json.RawMessage(`{"id":"event-1"}`),
+		Status:    domain.DeliveryFailed,
+		Attempts:  3,
+	}
+
+	suite.mockRepo.EXPECT().GetByID(ctx, "hook-1").Return(domain.Webhook{ID: "hook-1", Active: true}, nil)
+	suite.mockRepo.EXPECT().GetDelivery(ctx, "delivery-1").Return(original, nil)
+	suite.mockRepo.EXPECT().
+		SaveDelivery(ctx, mock.MatchedBy(func(d domain.WebhookDelivery) bool {
+			return d.ID != original.ID && d.RedeliveryOf == original.ID && d.Status == domain.DeliveryPending && d.Attempts == 0
+		})).
+		Return(nil)
+
+	delivery, err := suite.usecase.Redeliver(ctx, "hook-1", "delivery-1")
+
+	suite.NoError(err)
+	suite.Equal(original.EventID, delivery.EventID)
+	suite.JSONEq(string(original.Payload), string(delivery.Payload))
+}
+
+func (suite *WebhookUsecaseTestSuite) TestRedeliver_RejectsOtherWebhooksAndInactiveOnes() {
+	ctx := context.TODO()
+
+	// a delivery id only works under its own webhook
+	suite.mockRepo.EXPECT().GetByID(ctx, "hook-1").Return(domain.Webhook{ID: "hook-1", Active: true}, nil)
+	suite.mockRepo.EXPECT().GetDelivery(ctx, "delivery-2").Return(domain.WebhookDelivery{ID: "delivery-2", WebhookID: "hook-2"}, nil)
+
+	_, err := suite.usecase.Redeliver(ctx, "hook-1", "delivery-2")
+	suite.True(errors.Is(err, domain.ErrNotFound))
+
+	// a disabled webhook has to be enabled first
+	suite.mockRepo.EXPECT().GetByID(ctx, "hook-3").Return(domain.Webhook{ID: "hook-3", Active: false}, nil)
+
+	_, err = suite.usecase.Redeliver(ctx, "hook-3", "delivery-3")
+	suite.True(errors.Is(err, domain.ErrConflict))
+
+	suite.mockRepo.AssertNotCalled(suite.T(), "SaveDelivery", mock.Anything, mock.Anything)
+}
+
+// --- the dispatcher, run against the in-memory repository ---
+
+// runDispatcher runs a dispatcher until the test ends
+func runDispatcher(t *testing.T, repo repositories.WebhookRepository, sender *mocks.MockWebhookSender, maxAttempts int) usecases.WebhookUsecase {
+	dispatcher := usecases.NewWebhookDispatcher(repo, sender, maxAttempts, time.Millisecond)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan struct{})
+	go func() {
+		dispatcher.Run(ctx)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	return usecases.NewWebhookUsecase(repo, repositories.NewInMemoryAuditRepository(), dispatcher)
+}
+
+// waitForDelivery waits until the only delivery of the webhook has left the pending state
+func waitForDelivery(t *testing.T, repo repositories.WebhookRepository, webhookId string) domain.WebhookDelivery {
+	var delivery domain.WebhookDelivery
+	require.Eventually(t, func() bool {
+		deliveries, _, err := repo.ListDeliveries(context.Background(), webhookId, 0, 0)
+		if err != nil || len(deliveries) != 1 || deliveries[0].Status == domain.DeliveryPending {
+			return false
+		}
+		delivery = deliveries[0]
+		return true
+	}, 5*time.Second, time.Millisecond)
+
+	return delivery
+}
+
+func TestWebhookDispatcher_RetriesUntilDelivered(t *testing.T) {
+	repo := repositories.NewInMemoryWebhookRepository()
+	sender := new(mocks.MockWebhookSender)
+	usecase := runDispatcher(t, repo, sender, 5)
+
+	subscribed, err := usecase.CreateWebhook(context.Background(), webhookAdmin, domain.Webhook{
+		URL: "https://ci.example.com/hooks", Events: []domain.WebhookEvent{domain.WebhookTaskCreated}, Active: true,
+	})
+	require.NoError(t, err)
+	other, err := usecase.CreateWebhook(context.Background(), webhookAdmin, domain.Webhook{
+		URL: "https://chat.example.com/hooks", Events: []domain.WebhookEvent{domain.WebhookUserPromoted}, Active: true,
+	})
+	require.NoError(t, err)
+
+	// the receiver is down twice, then accepts the event
+	sender.EXPECT().Send(mock.Anything, mock.Anything, mock.Anything).Return(http.StatusServiceUnavailable, errors.New("unavailable")).Times(2)
+	sender.EXPECT().
+		Send(mock.Anything, mock.MatchedBy(func(w domain.Webhook) bool { return w.ID == subscribed.ID }), mock.Anything).
+		Return(http.StatusOK, nil).Once()
+
+	usecase.Publish(context.Background(), domain.WebhookTaskCreated, "admin-id", nil, domain.Task{ID: "task-1", Title: "Ship it"})
+
+	delivery := waitForDelivery(t, repo, subscribed.ID)
+	assert.Equal(t, domain.DeliverySucceeded, delivery.Status)
+	assert.Equal(t, 3, delivery.Attempts)
+	assert.Equal(t, http.StatusOK, delivery.ResponseStatus)
+	assert.Empty(t, delivery.LastError)
+
+	var payload domain.WebhookPayload
+	require.NoError(t, json.Unmarshal(delivery.Payload, &payload))
+	assert.Equal(t, domain.WebhookTaskCreated, payload.Event)
+	assert.Equal(t, delivery.EventID, payload.ID)
+	assert.Equal(t, "admin-id", payload.ActorID)
+	assert.JSONEq(t, `"task-1"`, mustField(t, payload.Data, "id"))
+
+	// the webhook that isn't subscribed got nothing
+	deliveries, _, err := repo.ListDeliveries(context.Background(), other.ID, 0, 0)
+	require.NoError(t, err)
+	assert.Empty(t, deliveries)
+}
+
+func TestWebhookDispatcher_GivesUpAfterMaxAttempts(t *testing.T) {
+	repo := repositories.NewInMemoryWebhookRepository()
+	sender := new(mocks.MockWebhookSender)
+	usecase := runDispatcher(t, repo, sender, 3)
+
+	webhook, err := usecase.CreateWebhook(context.Background(), webhookAdmin, domain.Webhook{
+		URL: "https://ci.example.com/hooks", Events: []domain.WebhookEvent{domain.WebhookTaskDeleted}, Active: true,
+	})
+	require.NoError(t, err)
+
+	sender.EXPECT().Send(mock.Anything, mock.Anything, mock.Anything).Return(http.StatusServiceUnavailable, errors.New("unavailable")).Times(3)
+
+	usecase.Publish(context.Background(), domain.WebhookTaskDeleted, "admin-id", domain.Task{ID: "task-1"}, nil)
+
+	delivery := waitForDelivery(t, repo, webhook.ID)
+	assert.Equal(t, domain.DeliveryFailed, delivery.Status)
+	assert.Equal(t, 3, delivery.Attempts)
+	assert.Equal(t, http.StatusServiceUnavailable, delivery.ResponseStatus)
+	assert.Equal(t, "unavailable", delivery.LastError)
+	sender.AssertExpectations(t)
+}
+
+func TestWebhookDispatcher_ResumesPendingDeliveries(t *testing.T) {
+	repo := repositories.NewInMemoryWebhookRepository()
+	sender := new(mocks.MockWebhookSender)
+
+	// a delivery was interrupted by the last shutdown
+	webhook := domain.Webhook{ID: "hook-1", URL: "https://ci.example.com/hooks", Events: []domain.WebhookEvent{domain.WebhookTaskUpdated}, Active: true}
+	_, err := repo.Create(context.Background(), webhook)
+	require.NoError(t, err)
+	require.NoError(t, repo.SaveDelivery(context.Background(), domain.WebhookDelivery{
+		ID: "delivery-1", WebhookID: "hook-1", EventID: "event-1", Event: domain.WebhookTaskUpdated,
+		Payload: json.RawMessage(`{}`), Status: domain.DeliveryPending, Attempts: 1,
+	}))
+
+	sender.EXPECT().Send(mock.Anything, mock.Anything, mock.Anything).Return(http.StatusNoContent, nil).Once()
+
+	runDispatcher(t, repo, sender, 5)
+
+	delivery := waitForDelivery(t, repo, "hook-1")
+	assert.Equal(t, domain.DeliverySucceeded, delivery.Status)
+	assert.Equal(t, 2, delivery.Attempts)
+}
+
+// mustField returns the JSON of one field of a JSON object
+func mustField(t *testing.T, object json.RawMessage, field string) string {
+	var fields map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(object, &fields))
+	return string(fields[field])
+}
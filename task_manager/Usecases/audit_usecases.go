@@ -0,0 +1,126 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	domain "taskmanager/Domain"
+	repositories "taskmanager/Repositories"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditUsecase interface {
+	QueryAuditLog(ctx context.Context, filter domain.AuditFilter) (domain.AuditPage, error)
+	ExportAuditLog(ctx context.Context, filter domain.AuditFilter, fn func(entry domain.AuditEntry) error) error
+}
+
+type AuditUsecaseImpl struct {
+	auditRepository repositories.AuditRepository
+}
+
+// Constructor for dependency injection
+func NewAuditUsecase(repo repositories.AuditRepository) AuditUsecase {
+	return &AuditUsecaseImpl{
+		auditRepository: repo,
+	}
+}
+
+func (a *AuditUsecaseImpl) QueryAuditLog(ctx context.Context, filter domain.AuditFilter) (domain.AuditPage, error) {
+
+	if filter.Limit < 0 || filter.Offset < 0 {
+		return domain.AuditPage{}, fmt.Errorf("%w: limit and offset must not be negative", domain.ErrValidation)
+	}
+	if filter.Limit == 0 {
+		filter.Limit = domain.DefaultAuditPageLimit
+	}
+	if filter.Limit > domain.MaxAuditPageLimit {
+		filter.Limit = domain.MaxAuditPageLimit
+	}
+
+	if err := validateAuditFilter(filter); err != nil {
+		return domain.AuditPage{}, err
+	}
+
+	entries, total, err := a.auditRepository.Query(ctx, filter)
+	if err != nil {
+		return domain.AuditPage{}, err
+	}
+	if entries == nil {
+		entries = []domain.AuditEntry{}
+	}
+
+	return domain.AuditPage{
+		Entries: entries,
+		Total:   total,
+		Limit:   filter.Limit,
+		Offset:  filter.Offset,
+	}, nil
+}
+
+// ExportAuditLog calls fn with every entry matching the filter, oldest first.
+// The filter is checked before the first call so callers can still report a bad request.
+func (a *AuditUsecaseImpl) ExportAuditLog(ctx context.Context, filter domain.AuditFilter, fn func(entry domain.AuditEntry) error) error {
+
+	if err := validateAuditFilter(filter); err != nil {
+		return err
+	}
+
+	return a.auditRepository.Stream(ctx, filter, fn)
+}
+
+func validateAuditFilter(filter domain.AuditFilter) error {
+
+	if filter.Action != "" && !filter.Action.IsValid() {
+		return fmt.Errorf("%w: unknown audit action %q", domain.ErrValidation, filter.Action)
+	}
+
+	switch filter.TargetType {
+	case "", domain.AuditTargetTask, domain.AuditTargetUser, domain.AuditTargetLabel, domain.AuditTargetWebhook, domain.AuditTargetLogin:
+	default:
+		return fmt.Errorf("%w: unknown audit target type %q", domain.ErrValidation, filter.TargetType)
+	}
+
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return fmt.Errorf("%w: from must be before to", domain.ErrValidation)
+	}
+
+	return nil
+}
+
+// recordAudit appends a mutation to the audit log, before and after are the target's state
+// around the change(nil when it didn't exist).
+// The mutation has already been applied when this runs, so a failing append is logged
+// instead of failing the request.
+func recordAudit(ctx context.Context, repo repositories.AuditRepository, actorId string, action domain.AuditAction, targetType string, targetId string, before interface{}, after interface{}) {
+
+	entry := domain.AuditEntry{
+		ID:         uuid.New().String(),
+		ActorID:    actorId,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetId,
+		Timestamp:  time.Now().UTC().Truncate(time.Millisecond),
+	}
+
+	var err error
+	if before != nil {
+		entry.Before, err = json.Marshal(before)
+	}
+	if err == nil && after != nil {
+		entry.After, err = json.Marshal(after)
+	}
+
+	// the entry is still written when the client has already gone away
+	if err == nil {
+		appendCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		err = repo.Append(appendCtx, entry)
+		cancel()
+	}
+
+	if err != nil {
+		log.Printf("failed to record audit entry %s on %s %s by %q: %v", action, targetType, targetId, actorId, err)
+	}
+}
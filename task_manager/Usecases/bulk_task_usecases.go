@@ -0,0 +1,373 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	domain "taskmanager/Domain"
+	repositories "taskmanager/Repositories"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// BulkCreateTasks validates and creates every task of the batch, the actor becomes their creator
+func (t *TaskUsecaseImpl) BulkCreateTasks(ctx context.Context, actor domain.Actor, tasks []domain.Task, atomic bool) (domain.BulkResult, error) {
+
+	if err := checkBulkSize(len(tasks)); err != nil {
+		return domain.BulkResult{}, err
+	}
+
+	batch := newBulkBatch(len(tasks), atomic)
+
+	// the label definitions are only loaded when some task is labelled
+	var defined map[string]bool
+	for _, task := range tasks {
+		if len(task.Labels) > 0 {
+			var err error
+			if defined, err = t.definedLabels(ctx); err != nil {
+				return domain.BulkResult{}, err
+			}
+			break
+		}
+	}
+
+	// the tasks that passed validation, and where each one sits in the request
+	valid := make([]domain.Task, 0, len(tasks))
+	positions := make([]int, 0, len(tasks))
+	for i, task := range tasks {
+		task.CreatedBy = actor.UserID
+		task, err := prepareNewTask(task)
+		if err == nil {
+			err = checkLabelsDefined(task.Labels, defined)
+		}
+		if err == nil {
+			err = t.checkAssigneeExists(ctx, task.AssigneeID)
+		}
+		if err == nil {
+			err = t.checkNewTaskLinks(ctx, task)
+		}
+		if err != nil {
+			batch.fail(i, err)
+			continue
+		}
+		batch.results[i].ID = task.ID
+		valid = append(valid, task)
+		positions = append(positions, i)
+	}
+	if batch.stopped() {
+		return batch.result(), nil
+	}
+
+	errs, err := t.taskRepository.BulkCreate(ctx, valid, atomic)
+	if err != nil {
+		return domain.BulkResult{}, err
+	}
+	for j, itemErr := range errs {
+		if itemErr != nil {
+			batch.fail(positions[j], itemErr)
+		}
+	}
+	if batch.stopped() {
+		return batch.result(), nil
+	}
+
+	for j, task := range valid {
+		if errs[j] != nil {
+			continue
+		}
+		batch.succeed(positions[j], &valid[j])
+		recordChange(ctx, t.auditRepository, t.publisher, actor.UserID, domain.AuditTaskCreated, domain.AuditTargetTask, task.ID, nil, task)
+	}
+
+	return batch.result(), nil
+}
+
+// BulkUpdateTasks applies the same changes to every selected task.
+// Each task is checked like a single update, its status must be allowed to move to the new one.
+func (t *TaskUsecaseImpl) BulkUpdateTasks(ctx context.Context, actor domain.Actor, update domain.BulkTaskUpdate) (domain.BulkResult, error) {
+
+	changes := update.Changes
+	if changes.Status != "" && !changes.Status.IsValid() {
+		return domain.BulkResult{}, fmt.Errorf("%w: unknown status %q", domain.ErrValidation, changes.Status)
+	}
+	if err := normalizeRecurrence(&changes); err != nil {
+		return domain.BulkResult{}, err
+	}
+	updates := taskChanges(changes)
+	if len(updates) == 0 {
+		return domain.BulkResult{}, fmt.Errorf("%w: changes must set at least one of title, description, due_date, status and recurrence", domain.ErrValidation)
+	}
+
+	ids, err := t.bulkTargets(ctx, update.IDs, update.Filter)
+	if err != nil {
+		return domain.BulkResult{}, err
+	}
+
+	return t.bulkUpdate(ctx, ids, update.Atomic, func(task domain.Task) (bson.M, error) {
+		if err := checkStatusTransition(task.Status, changes.Status); err != nil {
+			return nil, err
+		}
+		if changes.Status == domain.StatusDone && task.Status != domain.StatusDone {
+			if err := t.checkBlockersFinished(ctx, task); err != nil {
+				return nil, err
+			}
+		}
+		// completing a recurring task hands its rule over to the next occurrence
+		if completedRecurrence(task, changes) != "" {
+			completion := maps.Clone(updates)
+			completion["recurrence"] = ""
+			return completion, nil
+		}
+		return updates, nil
+	}, func(before domain.Task) *domain.Task {
+		after := applyTaskChanges(before, changes)
+		rule := completedRecurrence(before, changes)
+		if rule != "" {
+			after.Recurrence = ""
+		}
+		recordChange(ctx, t.auditRepository, t.publisher, actor.UserID, domain.AuditTaskUpdated, domain.AuditTargetTask, before.ID, before, after)
+		if rule != "" {
+			t.spawnNextOccurrence(ctx, actor, after, rule)
+		}
+		return &after
+	})
+}
+
+// BulkDeleteTasks moves every task of the batch to the trash
+func (t *TaskUsecaseImpl) BulkDeleteTasks(ctx context.Context, actor domain.Actor, ids []string, atomic bool) (domain.BulkResult, error) {
+
+	if err := checkBulkIDs(ids); err != nil {
+		return domain.BulkResult{}, err
+	}
+
+	deletedAt := time.Now()
+
+	return t.bulkUpdate(ctx, ids, atomic, func(task domain.Task) (bson.M, error) {
+		return bson.M{"deleted_at": deletedAt}, nil
+	}, func(before domain.Task) *domain.Task {
+		recordChange(ctx, t.auditRepository, t.publisher, actor.UserID, domain.AuditTaskDeleted, domain.AuditTargetTask, before.ID, before, nil)
+		return nil
+	})
+}
+
+// bulkUpdate writes one update per task in a single batch.
+// prepare builds the update of a task or rejects it, applied is called with every task that was
+// changed and returns what to report for it. Every write is conditional on the version read here.
+func (t *TaskUsecaseImpl) bulkUpdate(ctx context.Context, ids []string, atomic bool,
+	prepare func(task domain.Task) (bson.M, error), applied func(before domain.Task) *domain.Task) (domain.BulkResult, error) {
+
+	batch := newBulkBatch(len(ids), atomic)
+
+	current, err := t.taskRepository.GetByIDs(ctx, ids)
+	if err != nil {
+		return domain.BulkResult{}, err
+	}
+	byId := make(map[string]domain.Task, len(current))
+	for _, task := range current {
+		byId[task.ID] = task
+	}
+
+	writes := make([]repositories.TaskUpdate, 0, len(ids))
+	positions := make([]int, 0, len(ids))
+	for i, id := range ids {
+		batch.results[i].ID = id
+
+		task, ok := byId[id]
+		if !ok {
+			batch.fail(i, domain.ErrNotFound)
+			continue
+		}
+		updates, err := prepare(task)
+		if err != nil {
+			batch.fail(i, err)
+			continue
+		}
+
+		writes = append(writes, repositories.TaskUpdate{ID: id, Updates: updates, ExpectedVersion: task.Version})
+		positions = append(positions, i)
+	}
+	if batch.stopped() || len(writes) == 0 {
+		return batch.result(), nil
+	}
+
+	errs, err := t.taskRepository.BulkUpdate(ctx, writes, atomic)
+	if err != nil {
+		return domain.BulkResult{}, err
+	}
+	for j, itemErr := range errs {
+		if itemErr != nil {
+			batch.fail(positions[j], itemErr)
+		}
+	}
+	if batch.stopped() {
+		return batch.result(), nil
+	}
+
+	for j, write := range writes {
+		if errs[j] != nil {
+			continue
+		}
+		task := applied(byId[write.ID])
+		batch.succeed(positions[j], task)
+	}
+
+	return batch.result(), nil
+}
+
+// bulkTargets returns the ids of the tasks a bulk update selects, either listed or matched by a filter
+func (t *TaskUsecaseImpl) bulkTargets(ctx context.Context, ids []string, filter *domain.TaskFilter) ([]string, error) {
+
+	if (len(ids) > 0) == (filter != nil) {
+		return nil, fmt.Errorf("%w: select the tasks either by ids or by filter", domain.ErrValidation)
+	}
+
+	if filter == nil {
+		return ids, checkBulkIDs(ids)
+	}
+
+	// one more than allowed is enough to tell the filter selects too many tasks
+	query := *filter
+	if err := validateTaskFilter(&query); err != nil {
+		return nil, err
+	}
+	query.SortBy = domain.TaskSortByDueDate
+	query.SortDesc = false
+	query.Limit = domain.MaxBulkTasks + 1
+	query.Offset = 0
+
+	tasks, total, err := t.taskRepository.GetAll(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if total > domain.MaxBulkTasks {
+		return nil, fmt.Errorf("%w: the filter matches %d tasks, at most %d can be changed at once",
+			domain.ErrValidation, total, domain.MaxBulkTasks)
+	}
+
+	ids = make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+
+	return ids, nil
+}
+
+// applyTaskChanges returns the task as the update built by taskChanges stores it
+func applyTaskChanges(task domain.Task, changes domain.Task) domain.Task {
+
+	if changes.Title != "" {
+		task.Title = changes.Title
+	}
+	if changes.Description != "" {
+		task.Description = changes.Description
+	}
+	if !changes.DueDate.IsZero() {
+		// dates are stored in UTC with millisecond precision
+		task.DueDate = changes.DueDate.UTC().Truncate(time.Millisecond)
+	}
+	if changes.Status != "" {
+		task.Status = changes.Status
+	}
+	if changes.Recurrence != "" {
+		task.Recurrence = changes.Recurrence
+	}
+	task.Version++
+
+	return task
+}
+
+// checkBulkSize makes sure a batch is neither empty nor too large
+func checkBulkSize(size int) error {
+
+	if size == 0 {
+		return fmt.Errorf("%w: the batch is empty", domain.ErrValidation)
+	}
+	if size > domain.MaxBulkTasks {
+		return fmt.Errorf("%w: at most %d tasks can be sent at once", domain.ErrValidation, domain.MaxBulkTasks)
+	}
+
+	return nil
+}
+
+// checkBulkIDs makes sure a list of task ids is a valid batch without repeats
+func checkBulkIDs(ids []string) error {
+
+	if err := checkBulkSize(len(ids)); err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if id == "" {
+			return fmt.Errorf("%w: ids must not be empty", domain.ErrValidation)
+		}
+		if seen[id] {
+			return fmt.Errorf("%w: task %q is listed more than once", domain.ErrValidation, id)
+		}
+		seen[id] = true
+	}
+
+	return nil
+}
+
+// bulkBatch collects the per item results of a bulk operation
+type bulkBatch struct {
+	atomic  bool
+	failed  bool
+	results []domain.BulkItemResult
+}
+
+func newBulkBatch(size int, atomic bool) *bulkBatch {
+
+	results := make([]domain.BulkItemResult, size)
+	for i := range results {
+		results[i].Index = i
+	}
+
+	return &bulkBatch{atomic: atomic, results: results}
+}
+
+func (b *bulkBatch) fail(i int, err error) {
+
+	message := err.Error()
+	if errors.Is(err, domain.ErrNotFound) {
+		message = "task not found"
+	}
+
+	b.results[i].Status = domain.BulkItemFailed
+	b.results[i].Error = message
+	b.failed = true
+}
+
+func (b *bulkBatch) succeed(i int, task *domain.Task) {
+	b.results[i].Status = domain.BulkItemSucceeded
+	b.results[i].Task = task
+}
+
+// stopped reports whether an all-or-nothing batch has a failed item, nothing more may be written then
+func (b *bulkBatch) stopped() bool {
+	return b.atomic && b.failed
+}
+
+// result counts the outcomes, the items that were never written are reported as skipped
+func (b *bulkBatch) result() domain.BulkResult {
+
+	result := domain.BulkResult{Atomic: b.atomic, Results: b.results}
+	for i := range result.Results {
+		item := &result.Results[i]
+		switch item.Status {
+		case domain.BulkItemSucceeded:
+			result.Succeeded++
+		case domain.BulkItemFailed:
+			result.Failed++
+		default:
+			item.Status = domain.BulkItemSkipped
+			item.Error = "not applied, another task of the all-or-nothing batch failed"
+			result.Skipped++
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,91 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	domain "taskmanager/Domain"
+	infrastructure "taskmanager/Infrastructure"
+	repositories "taskmanager/Repositories"
+	"time"
+)
+
+type CalendarUsecase interface {
+	GetCalendarFeed(ctx context.Context, userId string) (domain.CalendarFeed, error)
+	CreateCalendarFeed(ctx context.Context, userId string) (domain.CalendarFeed, string, error)
+	RevokeCalendarFeed(ctx context.Context, userId string) error
+	GetCalendarTasks(ctx context.Context, token string) ([]domain.Task, error)
+}
+
+type CalendarUsecaseImpl struct {
+	feedRepository repositories.CalendarFeedRepository
+	taskRepository repositories.TaskRepository
+}
+
+// Constructor for dependency injection
+func NewCalendarUsecase(feedRepo repositories.CalendarFeedRepository, taskRepo repositories.TaskRepository) CalendarUsecase {
+	return &CalendarUsecaseImpl{
+		feedRepository: feedRepo,
+		taskRepository: taskRepo,
+	}
+}
+
+func (c *CalendarUsecaseImpl) GetCalendarFeed(ctx context.Context, userId string) (domain.CalendarFeed, error) {
+	return c.feedRepository.GetFeedByUser(ctx, userId)
+}
+
+// CreateCalendarFeed gives the user a feed with a new secret, the URL of their previous feed stops working.
+// The secret is returned only here, it can't be looked up later.
+func (c *CalendarUsecaseImpl) CreateCalendarFeed(ctx context.Context, userId string) (domain.CalendarFeed, string, error) {
+
+	token, err := infrastructure.GenerateCalendarToken()
+	if err != nil {
+		return domain.CalendarFeed{}, "", err
+	}
+
+	feed := domain.CalendarFeed{
+		UserID:    userId,
+		TokenHash: infrastructure.HashCalendarToken(token),
+		CreatedAt: time.Now().UTC().Truncate(time.Millisecond),
+	}
+	if err := c.feedRepository.SaveFeed(ctx, feed); err != nil {
+		return domain.CalendarFeed{}, "", err
+	}
+
+	return feed, token, nil
+}
+
+func (c *CalendarUsecaseImpl) RevokeCalendarFeed(ctx context.Context, userId string) error {
+	return c.feedRepository.DeleteFeed(ctx, userId)
+}
+
+// GetCalendarTasks returns the tasks assigned to the owner of the feed that have a due date, soonest first.
+// An unknown or revoked secret fails with domain.ErrInvalidToken.
+func (c *CalendarUsecaseImpl) GetCalendarTasks(ctx context.Context, token string) ([]domain.Task, error) {
+
+	if token == "" {
+		return nil, domain.ErrInvalidToken
+	}
+
+	feed, err := c.feedRepository.GetFeedByHash(ctx, infrastructure.HashCalendarToken(token))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	filter := domain.TaskFilter{AssigneeID: feed.UserID, SortBy: domain.TaskSortByDueDate}
+
+	tasks := []domain.Task{}
+	err = c.taskRepository.Stream(ctx, filter, func(task domain.Task) error {
+		if !task.DueDate.IsZero() {
+			tasks = append(tasks, task)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
@@ -0,0 +1,143 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	domain "taskmanager/Domain"
+	repositories "taskmanager/Repositories"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+type CommentUsecase interface {
+	AddComment(ctx context.Context, actor domain.Actor, taskId string, body string) (domain.Comment, error)
+	ListComments(ctx context.Context, taskId string, limit int64, offset int64) (domain.CommentPage, error)
+	EditComment(ctx context.Context, actor domain.Actor, taskId string, commentId string, body string) (domain.Comment, error)
+	DeleteComment(ctx context.Context, actor domain.Actor, taskId string, commentId string) error
+}
+
+type CommentUsecaseImpl struct {
+	commentRepository repositories.CommentRepository
+	taskRepository    repositories.TaskRepository
+}
+
+// Constructor for dependency injection
+func NewCommentUsecase(commentRepo repositories.CommentRepository, taskRepo repositories.TaskRepository) CommentUsecase {
+	return &CommentUsecaseImpl{
+		commentRepository: commentRepo,
+		taskRepository:    taskRepo,
+	}
+}
+
+func (c *CommentUsecaseImpl) AddComment(ctx context.Context, actor domain.Actor, taskId string, body string) (domain.Comment, error) {
+
+	body, err := validateCommentBody(body)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+
+	// comments can only be posted on existing tasks
+	if _, err := c.taskRepository.GetByID(ctx, taskId); err != nil {
+		return domain.Comment{}, err
+	}
+
+	now := time.Now()
+	comment := domain.Comment{
+		ID:        uuid.New().String(),
+		TaskID:    taskId,
+		AuthorID:  actor.UserID,
+		Body:      body,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	return c.commentRepository.Create(ctx, comment)
+}
+
+func (c *CommentUsecaseImpl) ListComments(ctx context.Context, taskId string, limit int64, offset int64) (domain.CommentPage, error) {
+
+	// same paging rules as the task list
+	if limit < 0 || offset < 0 {
+		return domain.CommentPage{}, fmt.Errorf("%w: limit and offset must not be negative", domain.ErrValidation)
+	}
+	if limit == 0 {
+		limit = domain.DefaultTaskPageLimit
+	}
+	if limit > domain.MaxTaskPageLimit {
+		limit = domain.MaxTaskPageLimit
+	}
+
+	if _, err := c.taskRepository.GetByID(ctx, taskId); err != nil {
+		return domain.CommentPage{}, err
+	}
+
+	comments, total, err := c.commentRepository.ListByTask(ctx, taskId, limit, offset)
+	if err != nil {
+		return domain.CommentPage{}, err
+	}
+	if comments == nil {
+		comments = []domain.Comment{}
+	}
+
+	return domain.CommentPage{
+		Comments: comments,
+		Total:    total,
+		Limit:    limit,
+		Offset:   offset,
+	}, nil
+}
+
+func (c *CommentUsecaseImpl) EditComment(ctx context.Context, actor domain.Actor, taskId string, commentId string, body string) (domain.Comment, error) {
+
+	body, err := validateCommentBody(body)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+
+	if err := c.checkCommentOwnership(ctx, actor, taskId, commentId); err != nil {
+		return domain.Comment{}, err
+	}
+
+	return c.commentRepository.Update(ctx, taskId, commentId, body, time.Now())
+}
+
+func (c *CommentUsecaseImpl) DeleteComment(ctx context.Context, actor domain.Actor, taskId string, commentId string) error {
+
+	if err := c.checkCommentOwnership(ctx, actor, taskId, commentId); err != nil {
+		return err
+	}
+
+	return c.commentRepository.Delete(ctx, taskId, commentId)
+}
+
+// checkCommentOwnership only lets the author or an admin change a comment
+func (c *CommentUsecaseImpl) checkCommentOwnership(ctx context.Context, actor domain.Actor, taskId string, commentId string) error {
+
+	comment, err := c.commentRepository.GetByID(ctx, taskId, commentId)
+	if err != nil {
+		return err
+	}
+
+	if actor.Role != domain.RoleAdmin && comment.AuthorID != actor.UserID {
+		return fmt.Errorf("%w: only the author or an admin can change this comment", domain.ErrForbidden)
+	}
+
+	return nil
+}
+
+// validateCommentBody trims the body and checks it isn't empty or too long
+func validateCommentBody(body string) (string, error) {
+
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", fmt.Errorf("%w: comment body is required", domain.ErrValidation)
+	}
+	if utf8.RuneCountInString(body) > domain.MaxCommentLength {
+		return "", fmt.Errorf("%w: comment body must not be longer than %d characters", domain.ErrValidation, domain.MaxCommentLength)
+	}
+
+	return body, nil
+}
@@ -0,0 +1,266 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	domain "taskmanager/Domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// SetTaskParent makes a task a subtask of parentId, an empty parentId makes it a top-level task again.
+// A task can't become a subtask of itself or of one of its own subtasks.
+func (t *TaskUsecaseImpl) SetTaskParent(ctx context.Context, actor domain.Actor, id string, parentId string) (domain.Task, error) {
+
+	if parentId == id {
+		return domain.Task{}, fmt.Errorf("%w: a task can't be its own parent", domain.ErrValidation)
+	}
+
+	return t.updateLinks(ctx, actor, id, func(task domain.Task) (bson.M, error) {
+		if parentId == task.ParentID {
+			return nil, nil
+		}
+		if parentId != "" {
+			if err := t.checkParentCycle(ctx, id, parentId); err != nil {
+				return nil, err
+			}
+		}
+		return bson.M{"parent_id": parentId}, nil
+	})
+}
+
+// AddTaskBlocker records that a task can't be done before blockerId is finished.
+// A link that would make two tasks wait on each other, directly or through other tasks, is rejected.
+func (t *TaskUsecaseImpl) AddTaskBlocker(ctx context.Context, actor domain.Actor, id string, blockerId string) (domain.Task, error) {
+
+	if blockerId == "" {
+		return domain.Task{}, fmt.Errorf("%w: blocker id is required", domain.ErrValidation)
+	}
+	if blockerId == id {
+		return domain.Task{}, fmt.Errorf("%w: a task can't block itself", domain.ErrValidation)
+	}
+
+	return t.updateLinks(ctx, actor, id, func(task domain.Task) (bson.M, error) {
+		if slices.Contains(task.BlockedBy, blockerId) {
+			return nil, nil
+		}
+		if len(task.BlockedBy) >= domain.MaxTaskBlockers {
+			return nil, fmt.Errorf("%w: a task can be blocked by at most %d tasks", domain.ErrValidation, domain.MaxTaskBlockers)
+		}
+		if err := t.checkBlockerCycle(ctx, id, blockerId); err != nil {
+			return nil, err
+		}
+		return bson.M{"blocked_by": append(slices.Clone(task.BlockedBy), blockerId)}, nil
+	})
+}
+
+// RemoveTaskBlocker removes a blocker from a task, a task not blocked by it is returned unchanged
+func (t *TaskUsecaseImpl) RemoveTaskBlocker(ctx context.Context, actor domain.Actor, id string, blockerId string) (domain.Task, error) {
+
+	return t.updateLinks(ctx, actor, id, func(task domain.Task) (bson.M, error) {
+		if !slices.Contains(task.BlockedBy, blockerId) {
+			return nil, nil
+		}
+		return bson.M{"blocked_by": slices.DeleteFunc(slices.Clone(task.BlockedBy), func(b string) bool { return b == blockerId })}, nil
+	})
+}
+
+// RetrieveTaskTree returns a task with its subtasks, up to MaxSubtreeDepth levels below it.
+// Subtasks in the trash are left out together with their own subtasks.
+func (t *TaskUsecaseImpl) RetrieveTaskTree(ctx context.Context, id string) (domain.TaskNode, error) {
+
+	root, err := t.taskRepository.GetByID(ctx, id)
+	if err != nil {
+		return domain.TaskNode{}, err
+	}
+
+	// load the tree one level at a time, the seen set guards against a cycle left by concurrent links
+	children := make(map[string][]domain.Task)
+	seen := map[string]bool{root.ID: true}
+	level := []string{root.ID}
+	for depth := 0; depth < domain.MaxSubtreeDepth && len(level) > 0; depth++ {
+		subtasks, err := t.taskRepository.GetSubtasks(ctx, level)
+		if err != nil {
+			return domain.TaskNode{}, err
+		}
+
+		level = level[:0]
+		for _, subtask := range subtasks {
+			if seen[subtask.ID] {
+				continue
+			}
+			seen[subtask.ID] = true
+			children[subtask.ParentID] = append(children[subtask.ParentID], subtask)
+			level = append(level, subtask.ID)
+		}
+	}
+
+	return taskNode(root, children), nil
+}
+
+// taskNode nests the loaded subtasks below task
+func taskNode(task domain.Task, children map[string][]domain.Task) domain.TaskNode {
+
+	node := domain.TaskNode{Task: task, Subtasks: []domain.TaskNode{}}
+	for _, child := range children[task.ID] {
+		node.Subtasks = append(node.Subtasks, taskNode(child, children))
+	}
+
+	return node
+}
+
+// updateLinks changes how a task is linked to other tasks, change returns nil updates when nothing changes.
+// Links follow the same rule as any other update and are written conditionally on the version read.
+func (t *TaskUsecaseImpl) updateLinks(ctx context.Context, actor domain.Actor, id string, change func(task domain.Task) (bson.M, error)) (domain.Task, error) {
+
+	before, err := t.taskRepository.GetByID(ctx, id)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	if err := checkTaskAccess(actor, before); err != nil {
+		return domain.Task{}, err
+	}
+
+	updates, err := change(before)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if updates == nil {
+		return before, nil
+	}
+
+	task, err := t.taskRepository.Update(ctx, id, updates, before.Version)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	recordChange(ctx, t.auditRepository, t.publisher, actor.UserID, domain.AuditTaskUpdated, domain.AuditTargetTask, id, before, task)
+
+	return task, nil
+}
+
+// checkParentCycle walks up from parentId and fails if it reaches id, which would make id its own ancestor
+func (t *TaskUsecaseImpl) checkParentCycle(ctx context.Context, id string, parentId string) error {
+
+	seen := make(map[string]bool)
+	for current := parentId; current != "" && !seen[current]; {
+		if current == id {
+			return fmt.Errorf("%w: task %q is a subtask of %q, linking them would create a cycle", domain.ErrValidation, parentId, id)
+		}
+		seen[current] = true
+
+		ancestor, err := t.taskRepository.GetByID(ctx, current)
+		if errors.Is(err, domain.ErrNotFound) {
+			if current == parentId {
+				return fmt.Errorf("%w: parent task %q does not exist", domain.ErrValidation, parentId)
+			}
+			// the chain ends at a task in the trash
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		current = ancestor.ParentID
+	}
+
+	return nil
+}
+
+// checkBlockerCycle follows what blockerId is blocked by and fails if it reaches id,
+// the two tasks would then wait on each other forever
+func (t *TaskUsecaseImpl) checkBlockerCycle(ctx context.Context, id string, blockerId string) error {
+
+	blockers, err := t.taskRepository.GetByIDs(ctx, []string{blockerId})
+	if err != nil {
+		return err
+	}
+	if len(blockers) == 0 {
+		return fmt.Errorf("%w: blocking task %q does not exist", domain.ErrValidation, blockerId)
+	}
+
+	seen := map[string]bool{blockerId: true}
+	for len(blockers) > 0 {
+		var next []string
+		for _, blocker := range blockers {
+			for _, b := range blocker.BlockedBy {
+				if b == id {
+					return fmt.Errorf("%w: task %q already waits on %q, linking them would create a cycle", domain.ErrValidation, blockerId, id)
+				}
+				if !seen[b] {
+					seen[b] = true
+					next = append(next, b)
+				}
+			}
+		}
+		if len(next) == 0 {
+			break
+		}
+
+		if blockers, err = t.taskRepository.GetByIDs(ctx, next); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkBlockersFinished fails while any task blocking task is neither done nor cancelled.
+// Blockers moved to the trash no longer block.
+func (t *TaskUsecaseImpl) checkBlockersFinished(ctx context.Context, task domain.Task) error {
+
+	if len(task.BlockedBy) == 0 {
+		return nil
+	}
+
+	blockers, err := t.taskRepository.GetByIDs(ctx, task.BlockedBy)
+	if err != nil {
+		return err
+	}
+
+	var unfinished []string
+	for _, blocker := range blockers {
+		if !blocker.Status.IsFinished() {
+			unfinished = append(unfinished, blocker.ID)
+		}
+	}
+	if len(unfinished) > 0 {
+		slices.Sort(unfinished)
+		return fmt.Errorf("%w: task is blocked by unfinished tasks: %s", domain.ErrValidation, strings.Join(unfinished, ", "))
+	}
+
+	return nil
+}
+
+// checkNewTaskLinks makes sure the parent and the blockers a new task is created with exist
+func (t *TaskUsecaseImpl) checkNewTaskLinks(ctx context.Context, task domain.Task) error {
+
+	if task.ParentID == "" && len(task.BlockedBy) == 0 {
+		return nil
+	}
+
+	ids := slices.Clone(task.BlockedBy)
+	if task.ParentID != "" {
+		ids = append(ids, task.ParentID)
+	}
+
+	existing, err := t.taskRepository.GetByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	found := make(map[string]bool, len(existing))
+	for _, linked := range existing {
+		found[linked.ID] = true
+	}
+
+	for _, linked := range ids {
+		if !found[linked] {
+			return fmt.Errorf("%w: linked task %q does not exist", domain.ErrValidation, linked)
+		}
+	}
+
+	return nil
+}
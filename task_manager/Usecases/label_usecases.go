@@ -0,0 +1,145 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	domain "taskmanager/Domain"
+	repositories "taskmanager/Repositories"
+	"time"
+)
+
+type LabelUsecase interface {
+	ListLabels(ctx context.Context) ([]domain.Label, error)
+	CreateLabel(ctx context.Context, actor domain.Actor, label domain.Label) (domain.Label, error)
+	UpdateLabel(ctx context.Context, actor domain.Actor, name string, changes domain.Label) (domain.Label, error)
+	DeleteLabel(ctx context.Context, actor domain.Actor, name string) (int64, error)
+}
+
+type LabelUsecaseImpl struct {
+	labelRepository repositories.LabelRepository
+	taskRepository  repositories.TaskRepository
+	auditRepository repositories.AuditRepository
+}
+
+// Constructor for dependency injection
+func NewLabelUsecase(labelRepo repositories.LabelRepository, taskRepo repositories.TaskRepository, auditRepo repositories.AuditRepository) LabelUsecase {
+	return &LabelUsecaseImpl{
+		labelRepository: labelRepo,
+		taskRepository:  taskRepo,
+		auditRepository: auditRepo,
+	}
+}
+
+func (l *LabelUsecaseImpl) ListLabels(ctx context.Context) ([]domain.Label, error) {
+	return l.labelRepository.GetAll(ctx)
+}
+
+func (l *LabelUsecaseImpl) CreateLabel(ctx context.Context, actor domain.Actor, label domain.Label) (domain.Label, error) {
+
+	var err error
+	if label.Name, err = domain.NormalizeLabelName(label.Name); err != nil {
+		return domain.Label{}, err
+	}
+	if label.Color, err = domain.NormalizeLabelColor(label.Color); err != nil {
+		return domain.Label{}, err
+	}
+	if err := domain.ValidateLabelDescription(label.Description); err != nil {
+		return domain.Label{}, err
+	}
+	label.CreatedAt = time.Now().UTC().Truncate(time.Millisecond)
+
+	label, err = l.labelRepository.Create(ctx, label)
+	if err != nil {
+		return domain.Label{}, err
+	}
+
+	recordAudit(ctx, l.auditRepository, actor.UserID, domain.AuditLabelCreated, domain.AuditTargetLabel, label.Name, nil, label)
+
+	return label, nil
+}
+
+// UpdateLabel changes the fields set in changes, a new name is renamed on every task using the label
+func (l *LabelUsecaseImpl) UpdateLabel(ctx context.Context, actor domain.Actor, name string, changes domain.Label) (domain.Label, error) {
+
+	name, err := domain.NormalizeLabelName(name)
+	if err != nil {
+		return domain.Label{}, err
+	}
+
+	before, err := l.labelRepository.GetByName(ctx, name)
+	if err != nil {
+		return domain.Label{}, err
+	}
+
+	label := before
+	if changes.Name != "" {
+		if label.Name, err = domain.NormalizeLabelName(changes.Name); err != nil {
+			return domain.Label{}, err
+		}
+	}
+	if changes.Color != "" {
+		if label.Color, err = domain.NormalizeLabelColor(changes.Color); err != nil {
+			return domain.Label{}, err
+		}
+	}
+	if changes.Description != "" {
+		if err := domain.ValidateLabelDescription(changes.Description); err != nil {
+			return domain.Label{}, err
+		}
+		label.Description = changes.Description
+	}
+
+	if label.Name != before.Name {
+		// the tasks are renamed before the definition so a failed rename can be retried,
+		// which is only safe once we know the new name is free
+		_, err := l.labelRepository.GetByName(ctx, label.Name)
+		if err == nil {
+			return domain.Label{}, fmt.Errorf("%w: label %q already exists", domain.ErrAleadyExists, label.Name)
+		}
+		if !errors.Is(err, domain.ErrNotFound) {
+			return domain.Label{}, err
+		}
+
+		if _, err := l.taskRepository.RenameLabel(ctx, before.Name, label.Name); err != nil {
+			return domain.Label{}, err
+		}
+	}
+
+	label, err = l.labelRepository.Update(ctx, before.Name, label)
+	if err != nil {
+		return domain.Label{}, err
+	}
+
+	recordAudit(ctx, l.auditRepository, actor.UserID, domain.AuditLabelUpdated, domain.AuditTargetLabel, before.Name, before, label)
+
+	return label, nil
+}
+
+// DeleteLabel removes a label definition and takes the label off every task, it returns how many tasks had it
+func (l *LabelUsecaseImpl) DeleteLabel(ctx context.Context, actor domain.Actor, name string) (int64, error) {
+
+	name, err := domain.NormalizeLabelName(name)
+	if err != nil {
+		return 0, err
+	}
+
+	label, err := l.labelRepository.GetByName(ctx, name)
+	if err != nil {
+		return 0, err
+	}
+
+	// like a rename, the tasks go first so a failed delete can be retried
+	untagged, err := l.taskRepository.DropLabel(ctx, label.Name)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := l.labelRepository.Delete(ctx, label.Name); err != nil {
+		return untagged, err
+	}
+
+	recordAudit(ctx, l.auditRepository, actor.UserID, domain.AuditLabelDeleted, domain.AuditTargetLabel, label.Name, label, nil)
+
+	return untagged, nil
+}
@@ -0,0 +1,110 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	domain "taskmanager/Domain"
+	repositories "taskmanager/Repositories"
+	"time"
+)
+
+// loginThrottle slows down and then locks out the usernames and client addresses that keep failing to log in
+type loginThrottle struct {
+	repository repositories.LoginAttemptRepository
+	limits     domain.LoginLimits
+}
+
+// loginSubjects are the counters a login attempt is checked against, an unknown address is left out
+func loginSubjects(userName string, clientIP string) []domain.LoginAttempts {
+	subjects := []domain.LoginAttempts{{Kind: domain.LoginAttemptUser, Subject: userName}}
+	if clientIP != "" {
+		subjects = append(subjects, domain.LoginAttempts{Kind: domain.LoginAttemptIP, Subject: clientIP})
+	}
+	return subjects
+}
+
+// loginReservation is the attempt reserved against each counter of a login, until it is decided
+type loginReservation []domain.LoginAttempts
+
+// reserve counts the attempt against the username and the client address before the password is checked.
+// It returns a *domain.LoginThrottledError when either may not try yet, the longest wait wins when both are throttled.
+func (t *loginThrottle) reserve(ctx context.Context, userName string, clientIP string, now time.Time) (loginReservation, error) {
+
+	var reservation loginReservation
+	var throttled *domain.LoginThrottledError
+	for _, subject := range loginSubjects(userName, clientIP) {
+		attempts, err := t.repository.ReserveAttempt(ctx, subject.Kind, subject.Subject, now, t.limits)
+
+		var wait *domain.LoginThrottledError
+		switch {
+		case errors.As(err, &wait):
+			if throttled == nil || wait.RetryAfter > throttled.RetryAfter {
+				throttled = wait
+			}
+		case err != nil:
+			if releaseErr := t.release(ctx, reservation); releaseErr != nil {
+				return nil, releaseErr
+			}
+			return nil, err
+		default:
+			reservation = append(reservation, attempts)
+		}
+	}
+
+	// a throttled attempt isn't counted against the other counter either
+	if throttled != nil {
+		if err := t.release(ctx, reservation); err != nil {
+			return nil, err
+		}
+		return nil, throttled
+	}
+
+	return reservation, nil
+}
+
+// release takes back the reserved attempt of a login that failed for another reason than the credentials
+func (t *loginThrottle) release(ctx context.Context, reservation loginReservation) error {
+
+	for _, attempts := range reservation {
+		err := t.repository.ReleaseAttempt(ctx, attempts.Kind, attempts.Subject)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fail keeps the reserved attempt as a failure, and locks out the counters that reached their limit
+func (t *loginThrottle) fail(ctx context.Context, reservation loginReservation, now time.Time) error {
+
+	for _, attempts := range reservation {
+		_, lockoutAfter := t.limits.Thresholds(attempts.Kind)
+		if lockoutAfter > 0 && attempts.Failures >= lockoutAfter {
+			if err := t.repository.Lock(ctx, attempts.Kind, attempts.Subject, now.Add(t.limits.LockoutDuration)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// succeed forgets the failures of the username. Those of the address are kept, only the reserved
+// attempt is taken back, or one account of its own would let an attacker keep guessing at others.
+func (t *loginThrottle) succeed(ctx context.Context, reservation loginReservation) error {
+
+	for _, attempts := range reservation {
+		var err error
+		if attempts.Kind == domain.LoginAttemptUser {
+			err = t.repository.Reset(ctx, attempts.Kind, attempts.Subject)
+		} else {
+			err = t.repository.ReleaseAttempt(ctx, attempts.Kind, attempts.Subject)
+		}
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package usecases
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log"
+	domain "taskmanager/Domain"
+	"time"
+)
+
+// PreviewTaskOccurrences returns the due dates of the next count occurrences of a recurring task,
+// fewer when its series ends first. A count of 0 previews DefaultRecurrencePreview occurrences.
+func (t *TaskUsecaseImpl) PreviewTaskOccurrences(ctx context.Context, id string, count int) ([]time.Time, error) {
+
+	if count < 0 {
+		return nil, fmt.Errorf("%w: count must not be negative", domain.ErrValidation)
+	}
+	if count == 0 {
+		count = domain.DefaultRecurrencePreview
+	}
+	if count > domain.MaxRecurrencePreview {
+		count = domain.MaxRecurrencePreview
+	}
+
+	task, err := t.taskRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if task.Recurrence == "" {
+		return nil, fmt.Errorf("%w: task does not repeat", domain.ErrValidation)
+	}
+
+	rule, err := domain.ParseRecurrenceRule(task.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+
+	return rule.Occurrences(task.DueDate, max(task.Occurrence, 1), count), nil
+}
+
+// normalizeRecurrence validates the recurrence rule of a task and rewrites it in its canonical form
+func normalizeRecurrence(task *domain.Task) error {
+
+	if task.Recurrence == "" {
+		return nil
+	}
+
+	rule, err := domain.ParseRecurrenceRule(task.Recurrence)
+	if err != nil {
+		return err
+	}
+	task.Recurrence = rule.String()
+
+	return nil
+}
+
+// completedRecurrence returns the rule of a recurring task that changes mark as done, or "" when they don't.
+// Completing an occurrence hands the rule over to the next one, so a task that is reopened and done
+// again doesn't start a second copy of its series.
+func completedRecurrence(before domain.Task, changes domain.Task) string {
+
+	if changes.Status != domain.StatusDone || before.Status == domain.StatusDone {
+		return ""
+	}
+
+	// a rule sent together with the completion replaces the stored one
+	return cmp.Or(changes.Recurrence, before.Recurrence)
+}
+
+// spawnNextOccurrence creates the task for the occurrence after completed, nothing is created once the series ended.
+// The completion has already been stored, so a failure is only logged.
+func (t *TaskUsecaseImpl) spawnNextOccurrence(ctx context.Context, actor domain.Actor, completed domain.Task, recurrence string) {
+
+	rule, err := domain.ParseRecurrenceRule(recurrence)
+	if err != nil {
+		log.Printf("failed to continue the series of task %s: %v", completed.ID, err)
+		return
+	}
+
+	occurrence := max(completed.Occurrence, 1)
+	dueDate, ok := rule.Next(completed.DueDate, occurrence)
+	if !ok {
+		return
+	}
+
+	// the next occurrence keeps what the task is about and who works on it, but not what blocked it
+	next, err := prepareNewTask(domain.Task{
+		Title:       completed.Title,
+		Description: completed.Description,
+		DueDate:     dueDate,
+		Status:      domain.StatusTodo,
+		CreatedBy:   completed.CreatedBy,
+		AssigneeID:  completed.AssigneeID,
+		Labels:      completed.Labels,
+		ParentID:    completed.ParentID,
+		Recurrence:  recurrence,
+	})
+	if err != nil {
+		log.Printf("failed to continue the series of task %s: %v", completed.ID, err)
+		return
+	}
+	next.Occurrence = occurrence + 1
+
+	// the next occurrence is still created when the client has already gone away
+	createCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+
+	next, err = t.taskRepository.Create(createCtx, next)
+	if err != nil {
+		log.Printf("failed to continue the series of task %s: %v", completed.ID, err)
+		return
+	}
+
+	recordChange(ctx, t.auditRepository, t.publisher, actor.UserID, domain.AuditTaskCreated, domain.AuditTargetTask, next.ID, nil, next)
+}
@@ -0,0 +1,50 @@
+package usecases
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// ReminderScheduler periodically sends the reminders of the tasks that are due soon or overdue
+type ReminderScheduler struct {
+	reminderUsecase ReminderUsecase
+	interval        time.Duration
+}
+
+func NewReminderScheduler(ru ReminderUsecase, interval time.Duration) *ReminderScheduler {
+	return &ReminderScheduler{
+		reminderUsecase: ru,
+		interval:        interval,
+	}
+}
+
+// Run sends the due reminders right away and then once per interval, until ctx is cancelled
+func (s *ReminderScheduler) Run(ctx context.Context) {
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.send(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *ReminderScheduler) send(ctx context.Context) {
+
+	// reminders that failed are retried on the next tick
+	sent, err := s.reminderUsecase.SendDueReminders(ctx)
+	if err != nil && ctx.Err() == nil {
+		log.Printf("failed to send due-date reminders: %v", err)
+	}
+
+	if sent > 0 {
+		log.Printf("Sent %d due-date reminders.", sent)
+	}
+}
@@ -0,0 +1,102 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	domain "taskmanager/Domain"
+	infrastructure "taskmanager/Infrastructure"
+	repositories "taskmanager/Repositories"
+	"time"
+)
+
+type ReminderUsecase interface {
+	SendDueReminders(ctx context.Context) (int, error)
+}
+
+type ReminderUsecaseImpl struct {
+	taskRepository     repositories.TaskRepository
+	reminderRepository repositories.ReminderRepository
+	notifier           infrastructure.Notifier
+	leadTime           time.Duration
+}
+
+// Constructor for dependency injection, tasks are reminded of leadTime before they are due
+func NewReminderUsecase(taskRepo repositories.TaskRepository, reminderRepo repositories.ReminderRepository, notifier infrastructure.Notifier, leadTime time.Duration) ReminderUsecase {
+	return &ReminderUsecaseImpl{
+		taskRepository:     taskRepo,
+		reminderRepository: reminderRepo,
+		notifier:           notifier,
+		leadTime:           leadTime,
+	}
+}
+
+// SendDueReminders sends a reminder for every unfinished task that is due within the lead time or overdue,
+// unless it was sent before. A reminder that fails is retried on the next run, the others are still sent.
+func (r *ReminderUsecaseImpl) SendDueReminders(ctx context.Context) (int, error) {
+
+	now := time.Now()
+	filter := domain.TaskFilter{
+		DueFrom: now.Add(-domain.ReminderLookback),
+		DueTo:   now.Add(r.leadTime),
+		SortBy:  domain.TaskSortByDueDate,
+		Limit:   domain.MaxTaskPageLimit,
+	}
+
+	sent := 0
+	var failures []error
+	for {
+		tasks, total, err := r.taskRepository.GetAll(ctx, filter)
+		if err != nil {
+			return sent, err
+		}
+
+		for _, task := range tasks {
+			if status, ok := domain.NormalizeTaskStatus(string(task.Status)); ok && status.IsFinished() {
+				continue
+			}
+
+			kind := domain.ReminderDueSoon
+			if !task.DueDate.After(now) {
+				kind = domain.ReminderOverdue
+			}
+
+			ok, err := r.remind(ctx, domain.NewReminder(kind, task))
+			if ok {
+				sent++
+			}
+			if err != nil {
+				// the remaining reminders can't be sent either once ctx is done
+				if ctx.Err() != nil {
+					return sent, ctx.Err()
+				}
+				failures = append(failures, err)
+			}
+		}
+
+		filter.Offset += int64(len(tasks))
+		if len(tasks) == 0 || filter.Offset >= total {
+			return sent, errors.Join(failures...)
+		}
+	}
+}
+
+// remind sends a reminder that wasn't sent yet and records it, the bool reports whether it was sent now
+func (r *ReminderUsecaseImpl) remind(ctx context.Context, reminder domain.Reminder) (bool, error) {
+
+	sent, err := r.reminderRepository.WasSent(ctx, reminder.Key)
+	if err != nil || sent {
+		return false, err
+	}
+
+	if err := r.notifier.Notify(ctx, reminder); err != nil {
+		return false, fmt.Errorf("failed to send %s reminder for task %s: %w", reminder.Kind, reminder.TaskID, err)
+	}
+
+	// the reminder is out, record it even when the run is being stopped
+	reminder.SentAt = time.Now().UTC().Truncate(time.Millisecond)
+	markCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+
+	return true, r.reminderRepository.MarkSent(markCtx, reminder)
+}
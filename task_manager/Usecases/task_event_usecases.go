@@ -0,0 +1,228 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	domain "taskmanager/Domain"
+	"time"
+)
+
+// how many events a stream may fall behind before it is dropped, the client resumes from the buffer on reconnect
+const taskSubscriptionBacklog = 64
+
+// TaskEventUsecase keeps the latest task events in memory and streams new ones to the subscribers
+type TaskEventUsecase interface {
+	EventPublisher
+	// Subscribe starts a stream, a non-empty lastEventId replays the buffered events after it
+	Subscribe(lastEventId string, filter domain.TaskEventFilter) (*TaskSubscription, error)
+	// Close ends every stream, used on shutdown
+	Close()
+}
+
+// TaskSubscription is one open stream. Missed holds the buffered events after the Last-Event-ID the
+// stream resumed from, Resync is set when some of them are no longer buffered and the client
+// has to reload the tasks. Events is closed when the stream ends.
+type TaskSubscription struct {
+	Missed []domain.TaskEvent
+	Resync bool
+	Events <-chan domain.TaskEvent
+
+	cancel func()
+}
+
+// Close stops the subscription, it is safe to call more than once
+func (s *TaskSubscription) Close() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+}
+
+type taskSubscriber struct {
+	events chan domain.TaskEvent
+	filter domain.TaskEventFilter
+}
+
+type bufferedTaskEvent struct {
+	seq   uint64
+	event domain.TaskEvent
+	// the task before the change, so streams filtering on it see the task leave
+	before *domain.Task
+}
+
+type TaskEventUsecaseImpl struct {
+	mu sync.Mutex
+	// event ids are "<epoch>-<seq>", a new epoch on every start tells resuming clients the buffer is gone
+	epoch       string
+	nextSeq     uint64
+	buffer      []bufferedTaskEvent // ring of the latest events
+	head        int                 // where the next event goes
+	count       int
+	subscribers map[*taskSubscriber]struct{}
+	closed      bool
+}
+
+// Constructor for dependency injection
+func NewTaskEventUsecase(bufferSize int) TaskEventUsecase {
+	if bufferSize <= 0 {
+		bufferSize = domain.DefaultTaskEventBuffer
+	}
+
+	return &TaskEventUsecaseImpl{
+		epoch:       strconv.FormatInt(time.Now().UnixNano(), 36),
+		nextSeq:     1,
+		buffer:      make([]bufferedTaskEvent, bufferSize),
+		subscribers: make(map[*taskSubscriber]struct{}),
+	}
+}
+
+// Publish buffers a task change and sends it to the streams it matches, other changes are ignored.
+// An update reaches a stream when the task matches its filter before or after the change.
+func (t *TaskEventUsecaseImpl) Publish(ctx context.Context, event domain.WebhookEvent, actorId string, before interface{}, after interface{}) {
+
+	entry := bufferedTaskEvent{
+		event: domain.TaskEvent{
+			Event:      event,
+			OccurredAt: time.Now().UTC().Truncate(time.Millisecond),
+			ActorID:    actorId,
+		},
+	}
+
+	beforeTask, hasBefore := before.(domain.Task)
+	afterTask, hasAfter := after.(domain.Task)
+	switch {
+	case hasAfter:
+		entry.event.Task = afterTask
+		if hasBefore {
+			entry.before = &beforeTask
+		}
+	case hasBefore:
+		entry.event.Task = beforeTask
+	default:
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return
+	}
+
+	entry.seq = t.nextSeq
+	entry.event.ID = t.eventID(entry.seq)
+	t.nextSeq++
+
+	t.buffer[t.head] = entry
+	t.head = (t.head + 1) % len(t.buffer)
+	if t.count < len(t.buffer) {
+		t.count++
+	}
+
+	for subscriber := range t.subscribers {
+		if !entry.matches(subscriber.filter) {
+			continue
+		}
+
+		select {
+		case subscriber.events <- entry.event:
+		default:
+			// a stream that can't keep up is dropped rather than holding up the change
+			t.unsubscribe(subscriber)
+		}
+	}
+}
+
+func (t *TaskEventUsecaseImpl) Subscribe(lastEventId string, filter domain.TaskEventFilter) (*TaskSubscription, error) {
+
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return nil, fmt.Errorf("%w: unknown status %q", domain.ErrValidation, filter.Status)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	subscriber := &taskSubscriber{
+		events: make(chan domain.TaskEvent, taskSubscriptionBacklog),
+		filter: filter,
+	}
+	subscription := &TaskSubscription{Events: subscriber.events}
+
+	if t.closed {
+		close(subscriber.events)
+		return subscription, nil
+	}
+
+	if lastEventId != "" {
+		subscription.Missed, subscription.Resync = t.eventsAfter(lastEventId, filter)
+	}
+
+	t.subscribers[subscriber] = struct{}{}
+	subscription.cancel = func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		t.unsubscribe(subscriber)
+	}
+
+	return subscription, nil
+}
+
+func (t *TaskEventUsecaseImpl) Close() {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.closed = true
+	for subscriber := range t.subscribers {
+		t.unsubscribe(subscriber)
+	}
+}
+
+// eventsAfter returns the buffered events after the given id that match the filter,
+// true means events after it were lost and the client has to resync
+func (t *TaskEventUsecaseImpl) eventsAfter(lastEventId string, filter domain.TaskEventFilter) ([]domain.TaskEvent, bool) {
+
+	epoch, seqText, found := strings.Cut(lastEventId, "-")
+	if !found || epoch != t.epoch {
+		return nil, true
+	}
+	seq, err := strconv.ParseUint(seqText, 10, 64)
+	if err != nil || seq >= t.nextSeq {
+		return nil, true
+	}
+
+	// the oldest buffered event has to directly follow the last one the client saw
+	oldest := t.nextSeq - uint64(t.count)
+	if seq+1 < oldest {
+		return nil, true
+	}
+
+	var missed []domain.TaskEvent
+	for i := 0; i < t.count; i++ {
+		entry := t.buffer[(t.head-t.count+i+len(t.buffer))%len(t.buffer)]
+		if entry.seq > seq && entry.matches(filter) {
+			missed = append(missed, entry.event)
+		}
+	}
+
+	return missed, false
+}
+
+// unsubscribe ends a stream, the caller holds the lock
+func (t *TaskEventUsecaseImpl) unsubscribe(subscriber *taskSubscriber) {
+	if _, ok := t.subscribers[subscriber]; !ok {
+		return
+	}
+	delete(t.subscribers, subscriber)
+	close(subscriber.events)
+}
+
+func (t *TaskEventUsecaseImpl) eventID(seq uint64) string {
+	return fmt.Sprintf("%s-%d", t.epoch, seq)
+}
+
+func (e bufferedTaskEvent) matches(filter domain.TaskEventFilter) bool {
+	return filter.Matches(e.event.Task) || (e.before != nil && filter.Matches(*e.before))
+}
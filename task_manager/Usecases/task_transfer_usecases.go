@@ -0,0 +1,460 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	domain "taskmanager/Domain"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// how many tasks an import looks up at once
+const importLookupBatch = 1000
+
+// ExportTasks calls fn with every task matching the filter, sorted like the task list.
+// The limit and offset of the filter are ignored.
+func (t *TaskUsecaseImpl) ExportTasks(ctx context.Context, filter domain.TaskFilter, fn func(task domain.Task) error) error {
+
+	if err := validateTaskSort(&filter); err != nil {
+		return err
+	}
+	if err := validateTaskFilter(&filter); err != nil {
+		return err
+	}
+
+	return t.taskRepository.Stream(ctx, filter, fn)
+}
+
+// ImportTasks creates a task for every row, with Upsert the rows whose id already exists update that task.
+// Rows are checked with the rules of CreateTask and written one at a time in file order, so a row can
+// link to the tasks of earlier rows, and a failing row doesn't stop the others.
+// A dry run checks every row the same way without writing anything.
+func (t *TaskUsecaseImpl) ImportTasks(ctx context.Context, actor domain.Actor, rows []domain.TaskImportRow, options domain.TaskImportOptions) (domain.TaskImportResult, error) {
+
+	if len(rows) == 0 {
+		return domain.TaskImportResult{}, fmt.Errorf("%w: the import is empty", domain.ErrValidation)
+	}
+	if len(rows) > domain.MaxImportTasks {
+		return domain.TaskImportResult{}, fmt.Errorf("%w: at most %d tasks can be imported at once", domain.ErrValidation, domain.MaxImportTasks)
+	}
+
+	imp := &taskImport{
+		usecase: t,
+		actor:   actor,
+		options: options,
+		tasks:   make(map[string]*domain.Task),
+		rows:    make(map[string]int),
+	}
+
+	// load the tasks the rows refer to up front, and the label definitions when some row is labelled
+	var ids []string
+	for _, row := range rows {
+		ids = append(ids, row.Task.ID, row.Task.ParentID)
+		ids = append(ids, row.Task.BlockedBy...)
+		if len(row.Task.Labels) > 0 && imp.defined == nil {
+			var err error
+			if imp.defined, err = t.definedLabels(ctx); err != nil {
+				return domain.TaskImportResult{}, err
+			}
+		}
+	}
+	if err := imp.load(ctx, ids); err != nil {
+		return domain.TaskImportResult{}, err
+	}
+
+	result := domain.TaskImportResult{
+		DryRun:  options.DryRun,
+		Upsert:  options.Upsert,
+		Results: make([]domain.TaskImportRowResult, len(rows)),
+	}
+	for i, row := range rows {
+		item := &result.Results[i]
+		item.Row = row.Row
+		item.ID = row.Task.ID
+
+		task, action, err := imp.importRow(ctx, row)
+		if err != nil {
+			// a failing lookup or write of the storage fails the rest of the import too
+			if !isImportRowError(err) {
+				return domain.TaskImportResult{}, err
+			}
+			item.Status = domain.BulkItemFailed
+			item.Error = err.Error()
+			result.Failed++
+			continue
+		}
+
+		item.ID = task.ID
+		item.Status = domain.BulkItemSucceeded
+		item.Action = action
+		if action == domain.TaskImportCreated {
+			result.Created++
+		} else {
+			result.Updated++
+		}
+	}
+
+	return result, nil
+}
+
+// taskImport is the state of one import, it remembers the tasks looked at so far
+// together with the ones the earlier rows created or changed
+type taskImport struct {
+	usecase *TaskUsecaseImpl
+	actor   domain.Actor
+	options domain.TaskImportOptions
+	defined map[string]bool
+	tasks   map[string]*domain.Task // nil for ids known not to exist
+	rows    map[string]int          // the row each id was imported by
+}
+
+func (imp *taskImport) importRow(ctx context.Context, row domain.TaskImportRow) (domain.Task, domain.TaskImportAction, error) {
+
+	if row.Err != nil {
+		return domain.Task{}, "", fmt.Errorf("%w: %v", domain.ErrValidation, row.Err)
+	}
+
+	id := row.Task.ID
+	if id != "" {
+		if _, err := uuid.Parse(id); err != nil {
+			return domain.Task{}, "", fmt.Errorf("%w: id must be a valid task id", domain.ErrValidation)
+		}
+		if previous, ok := imp.rows[id]; ok {
+			return domain.Task{}, "", fmt.Errorf("%w: task %q was already imported by row %d", domain.ErrValidation, id, previous)
+		}
+	}
+
+	current, err := imp.get(ctx, id)
+	if err != nil {
+		return domain.Task{}, "", err
+	}
+
+	var task domain.Task
+	var action domain.TaskImportAction
+	switch {
+	case current == nil:
+		task, err = imp.create(ctx, row.Task)
+		action = domain.TaskImportCreated
+	case imp.options.Upsert:
+		task, err = imp.update(ctx, *current, row.Task)
+		action = domain.TaskImportUpdated
+	default:
+		err = fmt.Errorf("%w: task %q, import with upsert to update it", domain.ErrAleadyExists, id)
+	}
+	if err != nil {
+		return domain.Task{}, "", err
+	}
+
+	imp.tasks[task.ID] = &task
+	imp.rows[task.ID] = row.Row
+
+	return task, action, nil
+}
+
+// create checks a new task like CreateTask, a task keeps the id it was exported with
+func (imp *taskImport) create(ctx context.Context, task domain.Task) (domain.Task, error) {
+
+	id := task.ID
+	task.CreatedBy = imp.actor.UserID
+
+	task, err := prepareNewTask(task)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if id != "" {
+		task.ID = id
+	}
+
+	if err := checkLabelsDefined(task.Labels, imp.defined); err != nil {
+		return domain.Task{}, err
+	}
+	if err := imp.usecase.checkAssigneeExists(ctx, task.AssigneeID); err != nil {
+		return domain.Task{}, err
+	}
+	if err := imp.checkLinks(ctx, task); err != nil {
+		return domain.Task{}, err
+	}
+
+	// a dry run can only look for a task in the trash taking the id
+	if imp.options.DryRun {
+		if id != "" {
+			if err := imp.checkNotTrashed(ctx, id); err != nil {
+				return domain.Task{}, err
+			}
+		}
+		return task, nil
+	}
+
+	// the storage rejects an id that is taken, even by a concurrent import of the same file
+	created, err := imp.usecase.taskRepository.Create(ctx, task)
+	if err != nil {
+		if errors.Is(err, domain.ErrAleadyExists) {
+			if err := imp.checkNotTrashed(ctx, id); err != nil {
+				return domain.Task{}, err
+			}
+			return domain.Task{}, fmt.Errorf("%w: task %q, import with upsert to update it", domain.ErrAleadyExists, id)
+		}
+		return domain.Task{}, err
+	}
+
+	recordChange(ctx, imp.usecase.auditRepository, imp.usecase.publisher, imp.actor.UserID, domain.AuditTaskCreated, domain.AuditTargetTask, created.ID, nil, created)
+
+	return created, nil
+}
+
+// update replaces the fields of an existing task with the ones of the row, they are checked like a new task.
+// A row without a due date keeps the one of the task. The status moves like in UpdateTask.
+func (imp *taskImport) update(ctx context.Context, current domain.Task, task domain.Task) (domain.Task, error) {
+
+	task.CreatedBy = current.CreatedBy
+	if task.DueDate.IsZero() {
+		task.DueDate = current.DueDate
+	}
+
+	task, err := prepareNewTask(task)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	task.ID = current.ID
+
+	if err := checkLabelsDefined(task.Labels, imp.defined); err != nil {
+		return domain.Task{}, err
+	}
+	if task.AssigneeID != current.AssigneeID {
+		if err := imp.usecase.checkAssigneeExists(ctx, task.AssigneeID); err != nil {
+			return domain.Task{}, err
+		}
+	}
+	if err := imp.checkLinks(ctx, task); err != nil {
+		return domain.Task{}, err
+	}
+	if err := checkStatusTransition(current.Status, task.Status); err != nil {
+		return domain.Task{}, err
+	}
+
+	// completing a recurring task hands its rule over to the next occurrence,
+	// the rule of the row replaces the stored one like the other fields
+	var rule string
+	if task.Status == domain.StatusDone && current.Status != domain.StatusDone {
+		if err := imp.checkBlockersFinished(ctx, task); err != nil {
+			return domain.Task{}, err
+		}
+		rule = task.Recurrence
+	}
+
+	updates := bson.M{}
+	if task.Title != current.Title {
+		updates["title"] = task.Title
+	}
+	if task.Description != current.Description {
+		updates["description"] = task.Description
+	}
+	if !task.DueDate.Equal(current.DueDate) {
+		updates["due_date"] = task.DueDate
+	}
+	if task.Status != current.Status {
+		updates["status"] = task.Status
+	}
+	if task.AssigneeID != current.AssigneeID {
+		updates["assignee_id"] = task.AssigneeID
+	}
+	if !slices.Equal(task.Labels, current.Labels) {
+		updates["labels"] = task.Labels
+	}
+	if task.ParentID != current.ParentID {
+		updates["parent_id"] = task.ParentID
+	}
+	if !slices.Equal(task.BlockedBy, current.BlockedBy) {
+		updates["blocked_by"] = task.BlockedBy
+	}
+	if task.Recurrence != current.Recurrence {
+		updates["recurrence"] = task.Recurrence
+		// a task that starts repeating is the first of its series
+		if task.Recurrence != "" && current.Occurrence == 0 {
+			updates["occurrence"] = 1
+		}
+	}
+	if rule != "" {
+		task.Recurrence = ""
+		updates["recurrence"] = ""
+	}
+
+	if len(updates) == 0 {
+		return current, nil
+	}
+
+	if imp.options.DryRun {
+		// what the update would store, for the rows after this one
+		current.Title, current.Description, current.DueDate = task.Title, task.Description, task.DueDate.UTC().Truncate(time.Millisecond)
+		current.Status, current.AssigneeID, current.Labels = task.Status, task.AssigneeID, task.Labels
+		current.ParentID, current.BlockedBy, current.Recurrence = task.ParentID, task.BlockedBy, task.Recurrence
+		current.Version++
+		return current, nil
+	}
+
+	updated, err := imp.usecase.taskRepository.Update(ctx, current.ID, updates, current.Version)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	recordChange(ctx, imp.usecase.auditRepository, imp.usecase.publisher, imp.actor.UserID, domain.AuditTaskUpdated, domain.AuditTargetTask, current.ID, current, updated)
+	if rule != "" {
+		imp.usecase.spawnNextOccurrence(ctx, imp.actor, updated, rule)
+	}
+
+	return updated, nil
+}
+
+// checkBlockersFinished fails while any task blocking the row is neither done nor cancelled,
+// the blockers are looked at as the earlier rows left them
+func (imp *taskImport) checkBlockersFinished(ctx context.Context, task domain.Task) error {
+
+	if err := imp.load(ctx, task.BlockedBy); err != nil {
+		return err
+	}
+
+	var unfinished []string
+	for _, id := range task.BlockedBy {
+		if blocker := imp.tasks[id]; blocker != nil && !blocker.Status.IsFinished() {
+			unfinished = append(unfinished, id)
+		}
+	}
+	if len(unfinished) > 0 {
+		return fmt.Errorf("%w: task is blocked by unfinished tasks: %s", domain.ErrValidation, strings.Join(unfinished, ", "))
+	}
+
+	return nil
+}
+
+// checkNotTrashed fails when the id is taken by a task in the trash
+func (imp *taskImport) checkNotTrashed(ctx context.Context, id string) error {
+
+	_, err := imp.usecase.taskRepository.GetDeletedByID(ctx, id)
+	if err == nil {
+		return fmt.Errorf("%w: task %q is in the trash, restore it to update it", domain.ErrAleadyExists, id)
+	}
+	if !errors.Is(err, domain.ErrNotFound) {
+		return err
+	}
+
+	return nil
+}
+
+// checkLinks makes sure the parent and the blockers of a row exist, in storage or in an earlier row,
+// and that linking them doesn't make the task wait on or descend from itself
+func (imp *taskImport) checkLinks(ctx context.Context, task domain.Task) error {
+
+	if task.ParentID == "" && len(task.BlockedBy) == 0 {
+		return nil
+	}
+	if task.ParentID == task.ID || slices.Contains(task.BlockedBy, task.ID) {
+		return fmt.Errorf("%w: a task can't be linked to itself", domain.ErrValidation)
+	}
+
+	linked := slices.Clone(task.BlockedBy)
+	if task.ParentID != "" {
+		linked = append(linked, task.ParentID)
+	}
+	if err := imp.load(ctx, linked); err != nil {
+		return err
+	}
+	for _, id := range linked {
+		if imp.tasks[id] == nil {
+			return fmt.Errorf("%w: linked task %q does not exist", domain.ErrValidation, id)
+		}
+	}
+
+	// walk up from the parent, the task must not be one of its ancestors
+	seen := make(map[string]bool)
+	for current := task.ParentID; current != "" && !seen[current]; {
+		if current == task.ID {
+			return fmt.Errorf("%w: task %q is a subtask of %q, linking them would create a cycle", domain.ErrValidation, task.ParentID, task.ID)
+		}
+		seen[current] = true
+
+		ancestor, err := imp.get(ctx, current)
+		if err != nil {
+			return err
+		}
+		if ancestor == nil {
+			break
+		}
+		current = ancestor.ParentID
+	}
+
+	// follow what the blockers wait on, the task must not be one of them
+	seen = make(map[string]bool)
+	level := task.BlockedBy
+	for len(level) > 0 {
+		if err := imp.load(ctx, level); err != nil {
+			return err
+		}
+		var next []string
+		for _, id := range level {
+			blocker := imp.tasks[id]
+			if blocker == nil {
+				continue
+			}
+			for _, b := range blocker.BlockedBy {
+				if b == task.ID {
+					return fmt.Errorf("%w: task %q already waits on %q, linking them would create a cycle", domain.ErrValidation, id, task.ID)
+				}
+				if !seen[b] {
+					seen[b] = true
+					next = append(next, b)
+				}
+			}
+		}
+		level = next
+	}
+
+	return nil
+}
+
+// get returns the live task with the id, nil when there is none
+func (imp *taskImport) get(ctx context.Context, id string) (*domain.Task, error) {
+
+	if id == "" {
+		return nil, nil
+	}
+	if err := imp.load(ctx, []string{id}); err != nil {
+		return nil, err
+	}
+
+	return imp.tasks[id], nil
+}
+
+// load looks up the tasks that weren't looked at yet
+func (imp *taskImport) load(ctx context.Context, ids []string) error {
+
+	var missing []string
+	for _, id := range ids {
+		if _, known := imp.tasks[id]; !known && id != "" {
+			imp.tasks[id] = nil
+			missing = append(missing, id)
+		}
+	}
+
+	for start := 0; start < len(missing); start += importLookupBatch {
+		found, err := imp.usecase.taskRepository.GetByIDs(ctx, missing[start:min(start+importLookupBatch, len(missing))])
+		if err != nil {
+			return err
+		}
+		for i := range found {
+			imp.tasks[found[i].ID] = &found[i]
+		}
+	}
+
+	return nil
+}
+
+// isImportRowError reports whether an error only fails the row it happened on
+func isImportRowError(err error) bool {
+	return errors.Is(err, domain.ErrValidation) || errors.Is(err, domain.ErrAleadyExists) ||
+		errors.Is(err, domain.ErrConflict) || errors.Is(err, domain.ErrNotFound)
+}
@@ -2,7 +2,9 @@ package usecases
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	domain "taskmanager/Domain"
 	repositories "taskmanager/Repositories"
 	"time"
@@ -12,31 +14,129 @@ import (
 )
 
 type TaskUsecase interface {
-	RetrieveAllTasks(ctx context.Context) ([]domain.Task, error)
+	RetrieveAllTasks(ctx context.Context, filter domain.TaskFilter) (domain.TaskPage, error)
+	SearchTasks(ctx context.Context, rawQuery string, limit int64, offset int64) (domain.TaskSearchResult, error)
 	RetrieveTaskByID(ctx context.Context, id string) (domain.Task, error)
 	CreateTask(ctx context.Context, task domain.Task) (domain.Task, error)
-	ModifyTask(ctx context.Context, id string, updatedTask domain.Task) (domain.Task, error)
-	RemoveTask(ctx context.Context, id string) error
+	ModifyTask(ctx context.Context, actor domain.Actor, id string, updatedTask domain.Task) (domain.Task, error)
+	RemoveTask(ctx context.Context, actor domain.Actor, id string, expectedVersion int64) error
+	AssignTask(ctx context.Context, actor domain.Actor, id string, assigneeId string) (domain.Task, error)
+	UnassignTask(ctx context.Context, actor domain.Actor, id string) (domain.Task, error)
+	AddTaskLabels(ctx context.Context, actor domain.Actor, id string, labels []string) (domain.Task, error)
+	RemoveTaskLabel(ctx context.Context, actor domain.Actor, id string, label string) (domain.Task, error)
+	SetTaskParent(ctx context.Context, actor domain.Actor, id string, parentId string) (domain.Task, error)
+	AddTaskBlocker(ctx context.Context, actor domain.Actor, id string, blockerId string) (domain.Task, error)
+	RemoveTaskBlocker(ctx context.Context, actor domain.Actor, id string, blockerId string) (domain.Task, error)
+	RetrieveTaskTree(ctx context.Context, id string) (domain.TaskNode, error)
+	PreviewTaskOccurrences(ctx context.Context, id string, count int) ([]time.Time, error)
+	ListDeletedTasks(ctx context.Context, limit int64, offset int64) (domain.TaskPage, error)
+	RestoreTask(ctx context.Context, actor domain.Actor, id string) (domain.Task, error)
+	PurgeTask(ctx context.Context, actor domain.Actor, id string) error
+	PurgeExpiredTasks(ctx context.Context, retention time.Duration) (int64, error)
+	BulkCreateTasks(ctx context.Context, actor domain.Actor, tasks []domain.Task, atomic bool) (domain.BulkResult, error)
+	BulkUpdateTasks(ctx context.Context, actor domain.Actor, update domain.BulkTaskUpdate) (domain.BulkResult, error)
+	BulkDeleteTasks(ctx context.Context, actor domain.Actor, ids []string, atomic bool) (domain.BulkResult, error)
+	MigrateTaskStatuses(ctx context.Context) (int64, error)
+	ExportTasks(ctx context.Context, filter domain.TaskFilter, fn func(task domain.Task) error) error
+	ImportTasks(ctx context.Context, actor domain.Actor, rows []domain.TaskImportRow, options domain.TaskImportOptions) (domain.TaskImportResult, error)
 }
 
 type TaskUsecaseImpl struct {
-	taskRepository repositories.TaskRepository
+	taskRepository    repositories.TaskRepository
+	userRepository    repositories.UserRepository
+	commentRepository repositories.CommentRepository
+	auditRepository   repositories.AuditRepository
+	labelRepository   repositories.LabelRepository
+	publisher         EventPublisher
 }
 
 // Constructor for dependency injection
-func NewTaskUsecase(repo repositories.TaskRepository) TaskUsecase {
+func NewTaskUsecase(repo repositories.TaskRepository, userRepo repositories.UserRepository, commentRepo repositories.CommentRepository, auditRepo repositories.AuditRepository, labelRepo repositories.LabelRepository, publisher EventPublisher) TaskUsecase {
 	return &TaskUsecaseImpl{
-		taskRepository: repo,
+		taskRepository:    repo,
+		userRepository:    userRepo,
+		commentRepository: commentRepo,
+		auditRepository:   auditRepo,
+		labelRepository:   labelRepo,
+		publisher:         publisher,
 	}
 }
 
-func (t *TaskUsecaseImpl) RetrieveAllTasks(ctx context.Context) ([]domain.Task, error) {
-	tasks, err := t.taskRepository.GetAll(ctx)
+func (t *TaskUsecaseImpl) RetrieveAllTasks(ctx context.Context, filter domain.TaskFilter) (domain.TaskPage, error) {
+
+	// validate the filter and fill in the defaults
+	if filter.Limit < 0 || filter.Offset < 0 {
+		return domain.TaskPage{}, fmt.Errorf("%w: limit and offset must not be negative", domain.ErrValidation)
+	}
+	if filter.Limit == 0 {
+		filter.Limit = domain.DefaultTaskPageLimit
+	}
+	if filter.Limit > domain.MaxTaskPageLimit {
+		filter.Limit = domain.MaxTaskPageLimit
+	}
+
+	if err := validateTaskSort(&filter); err != nil {
+		return domain.TaskPage{}, err
+	}
+
+	if err := validateTaskFilter(&filter); err != nil {
+		return domain.TaskPage{}, err
+	}
+
+	tasks, total, err := t.taskRepository.GetAll(ctx, filter)
+	if err != nil {
+		return domain.TaskPage{}, err
+	}
+	if tasks == nil {
+		tasks = []domain.Task{}
+	}
+
+	return domain.TaskPage{
+		Tasks:  tasks,
+		Total:  total,
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
+	}, nil
+}
+
+func (t *TaskUsecaseImpl) SearchTasks(ctx context.Context, rawQuery string, limit int64, offset int64) (domain.TaskSearchResult, error) {
+
+	query, err := domain.ParseTaskSearchQuery(rawQuery)
+	if err != nil {
+		return domain.TaskSearchResult{}, err
+	}
+
+	// same paging rules as the task list
+	if limit < 0 || offset < 0 {
+		return domain.TaskSearchResult{}, fmt.Errorf("%w: limit and offset must not be negative", domain.ErrValidation)
+	}
+	if limit == 0 {
+		limit = domain.DefaultTaskPageLimit
+	}
+	if limit > domain.MaxTaskPageLimit {
+		limit = domain.MaxTaskPageLimit
+	}
+	query.Limit = limit
+	query.Offset = offset
+
+	hits, total, err := t.taskRepository.Search(ctx, query)
 	if err != nil {
-		return []domain.Task{}, err
+		return domain.TaskSearchResult{}, err
+	}
+	if hits == nil {
+		hits = []domain.TaskSearchHit{}
+	}
+
+	for i := range hits {
+		hits[i].Highlights = query.Highlights(hits[i].Task)
 	}
 
-	return tasks, nil
+	return domain.TaskSearchResult{
+		Hits:   hits,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	}, nil
 }
 
 func (t *TaskUsecaseImpl) RetrieveTaskByID(ctx context.Context, id string) (domain.Task, error) {
@@ -51,31 +151,446 @@ func (t *TaskUsecaseImpl) RetrieveTaskByID(ctx context.Context, id string) (doma
 
 func (t *TaskUsecaseImpl) CreateTask(ctx context.Context, task domain.Task) (domain.Task, error) {
 
+	task, err := prepareNewTask(task)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	if len(task.Labels) > 0 {
+		defined, err := t.definedLabels(ctx)
+		if err != nil {
+			return domain.Task{}, err
+		}
+		if err := checkLabelsDefined(task.Labels, defined); err != nil {
+			return domain.Task{}, err
+		}
+	}
+
+	if err := t.checkAssigneeExists(ctx, task.AssigneeID); err != nil {
+		return domain.Task{}, err
+	}
+
+	if err := t.checkNewTaskLinks(ctx, task); err != nil {
+		return domain.Task{}, err
+	}
+
+	createdTask, err := t.taskRepository.Create(ctx, task)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	// the creator is the admin who sent the request
+	recordChange(ctx, t.auditRepository, t.publisher, createdTask.CreatedBy, domain.AuditTaskCreated, domain.AuditTargetTask, createdTask.ID, nil, createdTask)
+
+	return createdTask, nil
+}
+
+// prepareNewTask validates a task sent for creation and fills in the fields the server owns
+func prepareNewTask(task domain.Task) (domain.Task, error) {
+
 	// validat the user input
 	if task.Title == "" || task.Description == "" || task.Status == "" {
 		return domain.Task{}, fmt.Errorf("%w: title, description and status are required", domain.ErrValidation)
 	}
+	if !task.Status.IsValid() {
+		return domain.Task{}, fmt.Errorf("%w: unknown status %q", domain.ErrValidation, task.Status)
+	}
+	if task.AssigneeID != "" {
+		if _, err := uuid.Parse(task.AssigneeID); err != nil {
+			return domain.Task{}, fmt.Errorf("%w: assignee_id must be a valid user id", domain.ErrValidation)
+		}
+	}
+
+	labels, err := domain.NormalizeLabelNames(task.Labels)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if len(labels) > domain.MaxTaskLabels {
+		return domain.Task{}, fmt.Errorf("%w: a task can have at most %d labels", domain.ErrValidation, domain.MaxTaskLabels)
+	}
+	task.Labels = labels
+
+	// a new task can't be part of a cycle, its links only need to point at existing tasks
+	task.BlockedBy = slices.Compact(slices.Sorted(slices.Values(task.BlockedBy)))
+	if slices.Contains(task.BlockedBy, "") {
+		return domain.Task{}, fmt.Errorf("%w: blocked_by must not contain empty ids", domain.ErrValidation)
+	}
+	if len(task.BlockedBy) > domain.MaxTaskBlockers {
+		return domain.Task{}, fmt.Errorf("%w: a task can be blocked by at most %d tasks", domain.ErrValidation, domain.MaxTaskBlockers)
+	}
+	if len(task.BlockedBy) == 0 {
+		task.BlockedBy = nil
+	}
+
+	// a recurring task starts its series, the rule is stored in its canonical form
+	task.Occurrence = 0
+	if task.Recurrence != "" {
+		rule, err := domain.ParseRecurrenceRule(task.Recurrence)
+		if err != nil {
+			return domain.Task{}, err
+		}
+		task.Recurrence = rule.String()
+		task.Occurrence = 1
+	}
 
 	// assign id
 	newId := uuid.New()
 	task.ID = newId.String()
 
+	// every task starts at version 1, outside the trash
+	task.Version = 1
+	task.DeletedAt = nil
+
 	// assign due date if not assigned(it's optional for the user request)
 	if task.DueDate.IsZero() {
 		task.DueDate = time.Now()
 	}
 
-	createdTask, err := t.taskRepository.Create(ctx, task)
+	return task, nil
+}
+
+func (t *TaskUsecaseImpl) ModifyTask(ctx context.Context, actor domain.Actor, id string, updatedTask domain.Task) (domain.Task, error) {
+
+	if updatedTask.Status != "" && !updatedTask.Status.IsValid() {
+		return domain.Task{}, fmt.Errorf("%w: unknown status %q", domain.ErrValidation, updatedTask.Status)
+	}
+	if err := normalizeRecurrence(&updatedTask); err != nil {
+		return domain.Task{}, err
+	}
+
+	// the current task is needed to check ownership and the status transition,
+	// and is what the audit log records as the state before the change
+	task, err := t.taskRepository.GetByID(ctx, id)
 	if err != nil {
 		return domain.Task{}, err
 	}
 
-	return createdTask, nil
+	if err := checkTaskAccess(actor, task); err != nil {
+		return domain.Task{}, err
+	}
+
+	if err := checkStatusTransition(task.Status, updatedTask.Status); err != nil {
+		return domain.Task{}, err
+	}
+
+	// a task can only be done once everything blocking it is finished
+	if updatedTask.Status == domain.StatusDone && task.Status != domain.StatusDone {
+		if err := t.checkBlockersFinished(ctx, task); err != nil {
+			return domain.Task{}, err
+		}
+	}
+
+	updates := taskChanges(updatedTask)
+
+	// nothing to change, return the task as it is
+	if len(updates) == 0 {
+		if updatedTask.Version > 0 && task.Version != updatedTask.Version {
+			return domain.Task{}, domain.ErrConflict
+		}
+		return task, nil
+	}
+
+	rule := completedRecurrence(task, updatedTask)
+	if rule != "" {
+		updates["recurrence"] = ""
+	}
+
+	// a non-zero version in the request makes the update conditional
+	updatedTask, err = t.taskRepository.Update(ctx, id, updates, updatedTask.Version)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	recordChange(ctx, t.auditRepository, t.publisher, actor.UserID, domain.AuditTaskUpdated, domain.AuditTargetTask, id, task, updatedTask)
+
+	if rule != "" {
+		t.spawnNextOccurrence(ctx, actor, updatedTask, rule)
+	}
+
+	return updatedTask, nil
+}
+
+func (t *TaskUsecaseImpl) RemoveTask(ctx context.Context, actor domain.Actor, id string, expectedVersion int64) error {
+
+	// keep the deleted task for the audit log
+	task, err := t.taskRepository.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// the task only moves to the trash, it can be restored until it is purged
+	err = t.taskRepository.Delete(ctx, id, expectedVersion, time.Now())
+	if err != nil {
+		return err
+	}
+
+	recordChange(ctx, t.auditRepository, t.publisher, actor.UserID, domain.AuditTaskDeleted, domain.AuditTargetTask, id, task, nil)
+
+	return nil
+}
+
+func (t *TaskUsecaseImpl) AssignTask(ctx context.Context, actor domain.Actor, id string, assigneeId string) (domain.Task, error) {
+
+	// the assignee must be a valid user id
+	if _, err := uuid.Parse(assigneeId); err != nil {
+		return domain.Task{}, fmt.Errorf("%w: assignee_id must be a valid user id", domain.ErrValidation)
+	}
+	if err := t.checkAssigneeExists(ctx, assigneeId); err != nil {
+		return domain.Task{}, err
+	}
+
+	return t.updateAssignee(ctx, actor, domain.AuditTaskAssigned, id, assigneeId)
+}
+
+func (t *TaskUsecaseImpl) UnassignTask(ctx context.Context, actor domain.Actor, id string) (domain.Task, error) {
+	return t.updateAssignee(ctx, actor, domain.AuditTaskUnassigned, id, "")
+}
+
+// AddTaskLabels tags a task with defined labels, labels it already has are ignored
+func (t *TaskUsecaseImpl) AddTaskLabels(ctx context.Context, actor domain.Actor, id string, labels []string) (domain.Task, error) {
+
+	labels, err := domain.NormalizeLabelNames(labels)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if len(labels) == 0 {
+		return domain.Task{}, fmt.Errorf("%w: labels must not be empty", domain.ErrValidation)
+	}
+
+	defined, err := t.definedLabels(ctx)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if err := checkLabelsDefined(labels, defined); err != nil {
+		return domain.Task{}, err
+	}
+
+	return t.updateLabels(ctx, actor, id, func(current []string) ([]string, error) {
+		merged, _ := domain.NormalizeLabelNames(append(slices.Clone(current), labels...))
+		if len(merged) > domain.MaxTaskLabels {
+			return nil, fmt.Errorf("%w: a task can have at most %d labels", domain.ErrValidation, domain.MaxTaskLabels)
+		}
+		return merged, nil
+	})
+}
+
+// RemoveTaskLabel takes a label off a task, a task without the label is returned unchanged
+func (t *TaskUsecaseImpl) RemoveTaskLabel(ctx context.Context, actor domain.Actor, id string, label string) (domain.Task, error) {
+
+	label, err := domain.NormalizeLabelName(label)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	return t.updateLabels(ctx, actor, id, func(current []string) ([]string, error) {
+		return slices.DeleteFunc(slices.Clone(current), func(l string) bool { return l == label }), nil
+	})
+}
+
+// updateLabels replaces the labels of a task with the ones change computes from its current labels.
+// The write is conditional on the version read, so concurrent label changes aren't lost.
+func (t *TaskUsecaseImpl) updateLabels(ctx context.Context, actor domain.Actor, id string, change func(current []string) ([]string, error)) (domain.Task, error) {
+
+	before, err := t.taskRepository.GetByID(ctx, id)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	// labels follow the same rule as any other update
+	if err := checkTaskAccess(actor, before); err != nil {
+		return domain.Task{}, err
+	}
+
+	labels, err := change(before.Labels)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if slices.Equal(labels, before.Labels) {
+		return before, nil
+	}
+
+	task, err := t.taskRepository.Update(ctx, id, bson.M{"labels": labels}, before.Version)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	recordChange(ctx, t.auditRepository, t.publisher, actor.UserID, domain.AuditTaskUpdated, domain.AuditTargetTask, id, before, task)
+
+	return task, nil
+}
+
+// definedLabels returns the names of every defined label
+func (t *TaskUsecaseImpl) definedLabels(ctx context.Context) (map[string]bool, error) {
+
+	labels, err := t.labelRepository.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defined := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		defined[label.Name] = true
+	}
+
+	return defined, nil
+}
+
+// checkLabelsDefined makes sure tasks are only tagged with labels an admin has defined
+func checkLabelsDefined(labels []string, defined map[string]bool) error {
+	for _, label := range labels {
+		if !defined[label] {
+			return fmt.Errorf("%w: unknown label %q", domain.ErrValidation, label)
+		}
+	}
+	return nil
+}
+
+func (t *TaskUsecaseImpl) ListDeletedTasks(ctx context.Context, limit int64, offset int64) (domain.TaskPage, error) {
+
+	// same paging rules as the task list
+	if limit < 0 || offset < 0 {
+		return domain.TaskPage{}, fmt.Errorf("%w: limit and offset must not be negative", domain.ErrValidation)
+	}
+	if limit == 0 {
+		limit = domain.DefaultTaskPageLimit
+	}
+	if limit > domain.MaxTaskPageLimit {
+		limit = domain.MaxTaskPageLimit
+	}
+
+	tasks, total, err := t.taskRepository.ListDeleted(ctx, time.Time{}, limit, offset)
+	if err != nil {
+		return domain.TaskPage{}, err
+	}
+	if tasks == nil {
+		tasks = []domain.Task{}
+	}
+
+	return domain.TaskPage{
+		Tasks:  tasks,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	}, nil
+}
+
+func (t *TaskUsecaseImpl) RestoreTask(ctx context.Context, actor domain.Actor, id string) (domain.Task, error) {
+
+	before, err := t.taskRepository.GetDeletedByID(ctx, id)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	task, err := t.taskRepository.Restore(ctx, id)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	recordChange(ctx, t.auditRepository, t.publisher, actor.UserID, domain.AuditTaskRestored, domain.AuditTargetTask, id, before, task)
+
+	return task, nil
+}
+
+// PurgeTask permanently removes a task from the trash
+func (t *TaskUsecaseImpl) PurgeTask(ctx context.Context, actor domain.Actor, id string) error {
+	return t.purge(ctx, actor.UserID, id)
+}
+
+// PurgeExpiredTasks permanently removes the tasks deleted more than retention ago
+func (t *TaskUsecaseImpl) PurgeExpiredTasks(ctx context.Context, retention time.Duration) (int64, error) {
+
+	if retention <= 0 {
+		return 0, fmt.Errorf("%w: retention must be positive", domain.ErrValidation)
+	}
+	cutoff := time.Now().Add(-retention)
+
+	var purged int64
+	for {
+		// purged tasks leave the trash, so the next batch always starts at the beginning
+		tasks, _, err := t.taskRepository.ListDeleted(ctx, cutoff, domain.MaxTaskPageLimit, 0)
+		if err != nil {
+			return purged, err
+		}
+		if len(tasks) == 0 {
+			return purged, nil
+		}
+
+		for _, task := range tasks {
+			err := t.purge(ctx, domain.AuditSystemActor, task.ID)
+			// a task restored in the meantime is no longer in the trash
+			if errors.Is(err, domain.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return purged, err
+			}
+			purged++
+		}
+
+		if len(tasks) < int(domain.MaxTaskPageLimit) {
+			return purged, nil
+		}
+	}
+}
+
+// purge removes a task and its comments from the trash and records it in the audit log
+func (t *TaskUsecaseImpl) purge(ctx context.Context, actorId string, id string) error {
+
+	task, err := t.taskRepository.GetDeletedByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// the comments go first, a purge that fails halfway leaves the task in the trash to be purged again
+	if err := t.commentRepository.DeleteByTask(ctx, id); err != nil {
+		return err
+	}
+
+	if err := t.taskRepository.Purge(ctx, id); err != nil {
+		return err
+	}
+
+	recordChange(ctx, t.auditRepository, t.publisher, actorId, domain.AuditTaskPurged, domain.AuditTargetTask, id, task, nil)
+
+	return nil
+}
+
+// updateAssignee sets the assignee of a task and records the change in the audit log
+func (t *TaskUsecaseImpl) updateAssignee(ctx context.Context, actor domain.Actor, action domain.AuditAction, id string, assigneeId string) (domain.Task, error) {
+
+	before, err := t.taskRepository.GetByID(ctx, id)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	task, err := t.taskRepository.Update(ctx, id, bson.M{"assignee_id": assigneeId}, 0)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	recordChange(ctx, t.auditRepository, t.publisher, actor.UserID, action, domain.AuditTargetTask, id, before, task)
+
+	return task, nil
+}
+
+// checkAssigneeExists makes sure a task is assigned to a registered user, no assignee is fine
+func (t *TaskUsecaseImpl) checkAssigneeExists(ctx context.Context, assigneeId string) error {
+
+	if assigneeId == "" {
+		return nil
+	}
+
+	_, err := t.userRepository.GetUserByID(ctx, assigneeId)
+	if errors.Is(err, domain.ErrNotFound) {
+		return fmt.Errorf("%w: assignee %q does not exist", domain.ErrValidation, assigneeId)
+	}
+
+	return err
 }
 
-func (t *TaskUsecaseImpl) ModifyTask(ctx context.Context, id string, updatedTask domain.Task) (domain.Task, error) {
+// taskChanges builds the update document from the fields of a task update that are set
+func taskChanges(updatedTask domain.Task) bson.M {
 
-	// build the update document
 	updates := bson.M{} // empty unordered map
 
 	// check updated fields
@@ -91,25 +606,117 @@ func (t *TaskUsecaseImpl) ModifyTask(ctx context.Context, id string, updatedTask
 	if updatedTask.Status != "" {
 		updates["status"] = updatedTask.Status
 	}
-
-	if len(updates) == 0 {
-		t.taskRepository.GetByID(ctx, id)
+	if updatedTask.Recurrence != "" {
+		updates["recurrence"] = updatedTask.Recurrence
 	}
 
-	updatedTask, err := t.taskRepository.Update(ctx, id, updates)
-	if err != nil {
-		return domain.Task{}, err
+	return updates
+}
+
+// validateTaskSort checks the sort order of a task filter, tasks are sorted by due date unless it says otherwise
+func validateTaskSort(filter *domain.TaskFilter) error {
+
+	switch filter.SortBy {
+	case "":
+		filter.SortBy = domain.TaskSortByDueDate
+	case domain.TaskSortByDueDate, domain.TaskSortByTitle, domain.TaskSortByStatus:
+	default:
+		return fmt.Errorf("%w: cannot sort by %q", domain.ErrValidation, filter.SortBy)
 	}
 
-	return updatedTask, nil
+	return nil
 }
 
-func (t *TaskUsecaseImpl) RemoveTask(ctx context.Context, id string) error {
+// validateTaskFilter checks the criteria of a task filter and normalizes its label names,
+// paging and sorting are checked by the caller
+func validateTaskFilter(filter *domain.TaskFilter) error {
 
-	err := t.taskRepository.Delete(ctx, id)
-	if err != nil {
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return fmt.Errorf("%w: unknown status %q", domain.ErrValidation, filter.Status)
+	}
+
+	if !filter.DueFrom.IsZero() && !filter.DueTo.IsZero() && filter.DueFrom.After(filter.DueTo) {
+		return fmt.Errorf("%w: due_from must not be after due_to", domain.ErrValidation)
+	}
+
+	var err error
+	if filter.LabelsAny, err = normalizeFilterLabels(filter.LabelsAny); err != nil {
+		return err
+	}
+	if filter.LabelsAll, err = normalizeFilterLabels(filter.LabelsAll); err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// normalizeFilterLabels normalizes the label names of a filter, an empty set stays unset
+func normalizeFilterLabels(labels []string) ([]string, error) {
+	if len(labels) == 0 {
+		return nil, nil
+	}
+	return domain.NormalizeLabelNames(labels)
+}
+
+// checkTaskAccess lets admins change any task and regular users only the tasks assigned to them
+func checkTaskAccess(actor domain.Actor, task domain.Task) error {
+	if actor.Role != domain.RoleAdmin && (task.AssigneeID == "" || task.AssigneeID != actor.UserID) {
+		return fmt.Errorf("%w: task is not assigned to you", domain.ErrForbidden)
+	}
+	return nil
+}
+
+// checkStatusTransition makes sure a task may move from its current status to the requested one
+func checkStatusTransition(current domain.TaskStatus, next domain.TaskStatus) error {
+
+	if next == "" || next == current {
+		return nil
+	}
+
+	// tasks still holding a legacy status are checked as their normalized status,
+	// unrecognized ones may move anywhere so they can be fixed by hand
+	if !current.IsValid() {
+		normalized, ok := domain.NormalizeTaskStatus(string(current))
+		if !ok || normalized == next {
+			return nil
+		}
+		current = normalized
+	}
+
+	if !current.CanTransitionTo(next) {
+		return fmt.Errorf("%w: cannot move task from %q to %q, allowed next states: %s",
+			domain.ErrValidation, current, next, domain.JoinTaskStatuses(current.NextStatuses()))
+	}
+
+	return nil
+}
+
+// MigrateTaskStatuses rewrites stored statuses outside the closed status set.
+// Recognized legacy values are mapped to their new status, anything else becomes "todo".
+func (t *TaskUsecaseImpl) MigrateTaskStatuses(ctx context.Context) (int64, error) {
+
+	statuses, err := t.taskRepository.DistinctStatuses(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var migrated int64
+	for _, status := range statuses {
+		if domain.TaskStatus(status).IsValid() {
+			continue
+		}
+
+		target, ok := domain.NormalizeTaskStatus(status)
+		if !ok {
+			target = domain.StatusTodo
+		}
+
+		count, err := t.taskRepository.ReplaceStatus(ctx, status, target)
+		if err != nil {
+			return migrated, err
+		}
+		migrated += count
+	}
+
+	return migrated, nil
+}
@@ -0,0 +1,55 @@
+package usecases
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// TrashPurger periodically purges the tasks that have been in the trash longer than the retention period
+type TrashPurger struct {
+	taskUsecase TaskUsecase
+	retention   time.Duration
+	interval    time.Duration
+}
+
+func NewTrashPurger(tu TaskUsecase, retention time.Duration, interval time.Duration) *TrashPurger {
+	return &TrashPurger{
+		taskUsecase: tu,
+		retention:   retention,
+		interval:    interval,
+	}
+}
+
+// Run purges the trash right away and then once per interval, until ctx is cancelled
+func (p *TrashPurger) Run(ctx context.Context) {
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.purge(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *TrashPurger) purge(ctx context.Context) {
+
+	// a run that fails is simply retried on the next tick
+	purged, err := p.taskUsecase.PurgeExpiredTasks(ctx, p.retention)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("failed to purge the task trash: %v", err)
+		}
+		return
+	}
+
+	if purged > 0 {
+		log.Printf("Purged %d tasks deleted more than %s ago.", purged, p.retention)
+	}
+}
@@ -4,35 +4,62 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	domain "taskmanager/Domain"
 	infrastructure "taskmanager/Infrastructure"
 	repositories "taskmanager/Repositories"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type UserUsecase interface {
 	RegisterUser(ctx context.Context, userName string, password string) (domain.User, error)
-	AuthenticateUser(ctx context.Context, userName string, password string) (string, error)
-	PromoteUser(ctx context.Context, userId string) (domain.User, error)
+	AuthenticateUser(ctx context.Context, userName string, password string, newPassword string, clientIP string) (domain.TokenPair, error)
+	PromoteUser(ctx context.Context, actor domain.Actor, userId string) (domain.User, error)
+	ChangePassword(ctx context.Context, actor domain.Actor, currentPassword string, newPassword string) (domain.TokenPair, error)
+	ResetPassword(ctx context.Context, actor domain.Actor, userId string, temporaryPassword string) (domain.User, error)
+	RefreshTokens(ctx context.Context, refreshToken string) (domain.TokenPair, error)
+	Logout(ctx context.Context, userId string, refreshToken string, accessTokenId string, accessTokenExpiresAt time.Time) error
+	IsAccessTokenRevoked(ctx context.Context, tokenId string) (bool, error)
+	CheckAccessTokenUser(ctx context.Context, userId string, issuedAt time.Time) error
+	ListLoginLockouts(ctx context.Context) ([]domain.LoginAttempts, error)
+	UnlockLogin(ctx context.Context, actor domain.Actor, kind domain.LoginAttemptKind, subject string) error
 }
 
 type UserUsecaseImpl struct {
-	userRepository repositories.UserRepository
+	userRepository  repositories.UserRepository
+	tokenRepository repositories.TokenRepository
+	auditRepository repositories.AuditRepository
+	publisher       EventPublisher
+	loginThrottle   *loginThrottle
+	passwordPolicy  domain.PasswordPolicy
+	passwordHasher  infrastructure.PasswordHasher
+	jwtKeys         *infrastructure.JWTKeys
+	jwtSettings     infrastructure.JWTSettings
 }
 
-// Constructor for dependency injection
-func NewUserUsecase(repo repositories.UserRepository) UserUsecase {
+// Constructor for dependency injection, failed logins are counted in attemptRepo and throttled by limits,
+// new passwords have to satisfy the policy and are hashed by hasher, access tokens are signed with jwtKeys for the issuer and audience in jwtSettings
+func NewUserUsecase(repo repositories.UserRepository, tokenRepo repositories.TokenRepository, auditRepo repositories.AuditRepository, publisher EventPublisher, attemptRepo repositories.LoginAttemptRepository, limits domain.LoginLimits, policy domain.PasswordPolicy, hasher infrastructure.PasswordHasher, jwtKeys *infrastructure.JWTKeys, jwtSettings infrastructure.JWTSettings) UserUsecase {
 	return &UserUsecaseImpl{
-		userRepository: repo,
+		userRepository:  repo,
+		tokenRepository: tokenRepo,
+		auditRepository: auditRepo,
+		publisher:       publisher,
+		loginThrottle:   &loginThrottle{repository: attemptRepo, limits: limits},
+		passwordPolicy:  policy,
+		passwordHasher:  hasher,
+		jwtKeys:         jwtKeys,
+		jwtSettings:     jwtSettings,
 	}
 }
 
 func (u *UserUsecaseImpl) RegisterUser(ctx context.Context, userName string, password string) (domain.User, error) {
 
-	// validate password length
-	if len(password) < 4 {
-		return domain.User{}, fmt.Errorf("%w:password should be at least 4 characters", domain.ErrValidation)
+	// validate password against the policy
+	if err := infrastructure.CheckPasswordPolicy(u.passwordPolicy, userName, password); err != nil {
+		return domain.User{}, err
 	}
 
 	// check if user name is available
@@ -45,7 +72,7 @@ func (u *UserUsecaseImpl) RegisterUser(ctx context.Context, userName string, pas
 	}
 
 	// hash password
-	hashedPassword, err := infrastructure.HashPassword(password)
+	hashedPassword, err := u.passwordHasher.Hash(password)
 	if err != nil {
 		return domain.User{}, err
 	}
@@ -64,6 +91,7 @@ func (u *UserUsecaseImpl) RegisterUser(ctx context.Context, userName string, pas
 	newUser.ID = newId
 	newUser.UserName = userName
 	newUser.HashedPassword = hashedPassword
+	newUser.PasswordChangedAt = time.Now().UTC().Truncate(time.Millisecond)
 	if isEmpty {
 		newUser.Role = domain.RoleAdmin
 	} else {
@@ -79,30 +107,232 @@ func (u *UserUsecaseImpl) RegisterUser(ctx context.Context, userName string, pas
 	return savedUser, nil
 }
 
-func (u *UserUsecaseImpl) AuthenticateUser(ctx context.Context, userName string, password string) (string, error) {
+// AuthenticateUser logs a user in. The username and the client address that keep failing are
+// slowed down and then locked out, a *domain.LoginThrottledError says how long to wait.
+// A user who has to change their password gets domain.ErrPasswordChangeRequired until they log in with a new one.
+func (u *UserUsecaseImpl) AuthenticateUser(ctx context.Context, userName string, password string, newPassword string, clientIP string) (domain.TokenPair, error) {
 
-	// check if username exists
+	// a throttled attempt isn't even checked, so it can't be used to guess. The attempt is counted
+	// as failed before the password is checked, so parallel guesses can't share one free attempt.
+	now := time.Now()
+	reservation, err := u.loginThrottle.reserve(ctx, userName, clientIP, now)
+	if err != nil {
+		return domain.TokenPair{}, err
+	}
+
+	// check if username exists, guessing at unknown usernames counts as failing too
 	userId, SavedPassword, role, err := u.userRepository.DoesUserExist(ctx, userName)
+	if err == nil {
+		// check if password is correct
+		err = u.passwordHasher.Compare(SavedPassword, password)
+	}
+	if err != nil {
+		var throttleErr error
+		if errors.Is(err, domain.ErrNotFound) || errors.Is(err, domain.ErrValidation) {
+			throttleErr = u.loginThrottle.fail(ctx, reservation, now)
+		} else {
+			throttleErr = u.loginThrottle.release(ctx, reservation)
+		}
+		if throttleErr != nil {
+			return domain.TokenPair{}, throttleErr
+		}
+		return domain.TokenPair{}, err
+	}
+
+	if err := u.loginThrottle.succeed(ctx, reservation); err != nil {
+		return domain.TokenPair{}, err
+	}
+
+	// the password is right, but an admin asked for a new one
+	user, err := u.userRepository.GetUserByID(ctx, userId)
+	if err != nil {
+		return domain.TokenPair{}, err
+	}
+	if user.MustChangePassword {
+		if newPassword == "" {
+			return domain.TokenPair{}, domain.ErrPasswordChangeRequired
+		}
+		if _, err := u.setPassword(ctx, user, password, newPassword); err != nil {
+			return domain.TokenPair{}, err
+		}
+	} else if u.passwordHasher.NeedsRehash(SavedPassword) {
+		u.rehashPassword(ctx, userId, SavedPassword, password)
+	}
+
+	// every login starts a new refresh token family
+	return u.issueTokenPair(ctx, userId, userName, role, uuid.New().String(), uuid.New().String())
+}
+
+func (u *UserUsecaseImpl) RefreshTokens(ctx context.Context, refreshToken string) (domain.TokenPair, error) {
+
+	stored, err := u.tokenRepository.GetRefreshTokenByHash(ctx, infrastructure.HashRefreshToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.TokenPair{}, domain.ErrInvalidToken
+		}
+		return domain.TokenPair{}, err
+	}
+
+	if stored.Revoked {
+		return domain.TokenPair{}, domain.ErrInvalidToken
+	}
+
+	// an already rotated token is being replayed, assume it was stolen and kill the whole family
+	if stored.ReplacedBy != "" {
+		if err := u.tokenRepository.RevokeTokenFamily(ctx, stored.FamilyID); err != nil {
+			return domain.TokenPair{}, err
+		}
+		return domain.TokenPair{}, fmt.Errorf("%w: refresh token reuse detected", domain.ErrInvalidToken)
+	}
+
+	if time.Now().After(stored.ExpiresAt) {
+		return domain.TokenPair{}, domain.ErrInvalidToken
+	}
+
+	// load the user again so role changes are reflected in the new access token
+	user, err := u.userRepository.GetUserByID(ctx, stored.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.TokenPair{}, domain.ErrInvalidToken
+		}
+		return domain.TokenPair{}, err
+	}
+
+	// changing or resetting the password ends the sessions started before
+	if user.MustChangePassword || stored.CreatedAt.Before(user.PasswordChangedAt) {
+		return domain.TokenPair{}, domain.ErrInvalidToken
+	}
+
+	// mark the presented token as used, losing this race is treated as reuse too
+	newTokenId := uuid.New().String()
+	if err := u.tokenRepository.RotateRefreshToken(ctx, stored.ID, newTokenId); err != nil {
+		if errors.Is(err, domain.ErrConflict) {
+			if err := u.tokenRepository.RevokeTokenFamily(ctx, stored.FamilyID); err != nil {
+				return domain.TokenPair{}, err
+			}
+			return domain.TokenPair{}, fmt.Errorf("%w: refresh token reuse detected", domain.ErrInvalidToken)
+		}
+		return domain.TokenPair{}, err
+	}
+
+	return u.issueTokenPair(ctx, user.ID.String(), user.UserName, user.Role, stored.FamilyID, newTokenId)
+}
+
+func (u *UserUsecaseImpl) Logout(ctx context.Context, userId string, refreshToken string, accessTokenId string, accessTokenExpiresAt time.Time) error {
+
+	// revoke the refresh token family, but only if it belongs to the caller
+	stored, err := u.tokenRepository.GetRefreshTokenByHash(ctx, infrastructure.HashRefreshToken(refreshToken))
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return err
+	}
+	if err == nil && stored.UserID == userId {
+		if err := u.tokenRepository.RevokeTokenFamily(ctx, stored.FamilyID); err != nil {
+			return err
+		}
+	}
+
+	// the access token stays revoked until it would have expired anyway
+	return u.tokenRepository.RevokeAccessToken(ctx, accessTokenId, accessTokenExpiresAt)
+}
+
+func (u *UserUsecaseImpl) IsAccessTokenRevoked(ctx context.Context, tokenId string) (bool, error) {
+	return u.tokenRepository.IsAccessTokenRevoked(ctx, tokenId)
+}
+
+// CheckAccessTokenUser fails with domain.ErrInvalidToken for an access token issued before its user
+// last changed their password, and with domain.ErrPasswordChangeRequired while they have to choose a new one
+func (u *UserUsecaseImpl) CheckAccessTokenUser(ctx context.Context, userId string, issuedAt time.Time) error {
+
+	user, err := u.userRepository.GetUserByID(ctx, userId)
 	if err != nil {
-		return "", err
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrInvalidToken
+		}
+		return err
 	}
 
-	// check if password is correct
-	err = infrastructure.ComparePassword(SavedPassword, password)
+	// changing or resetting the password ends the sessions started before, token times are whole seconds
+	if issuedAt.Before(user.PasswordChangedAt.Truncate(time.Second)) {
+		return domain.ErrInvalidToken
+	}
+	if user.MustChangePassword {
+		return domain.ErrPasswordChangeRequired
+	}
+
+	return nil
+}
+
+// ListLoginLockouts returns the usernames and client addresses that are locked out right now
+func (u *UserUsecaseImpl) ListLoginLockouts(ctx context.Context) ([]domain.LoginAttempts, error) {
+	return u.loginThrottle.repository.ListLocked(ctx, time.Now())
+}
+
+// UnlockLogin forgets the failed logins of a username or client address, lifting its lockout
+func (u *UserUsecaseImpl) UnlockLogin(ctx context.Context, actor domain.Actor, kind domain.LoginAttemptKind, subject string) error {
+
+	if !kind.IsValid() {
+		return fmt.Errorf("%w: logins are locked by user or ip", domain.ErrValidation)
+	}
+	if subject == "" {
+		return fmt.Errorf("%w: the username or address to unlock is required", domain.ErrValidation)
+	}
+
+	// keep the counter as it was for the audit log
+	attempts, err := u.loginThrottle.repository.GetAttempts(ctx, kind, subject)
 	if err != nil {
-		return "", err
+		return err
+	}
+
+	if err := u.loginThrottle.repository.Reset(ctx, kind, subject); err != nil {
+		return err
 	}
 
+	recordAudit(ctx, u.auditRepository, actor.UserID, domain.AuditLoginUnlocked, domain.AuditTargetLogin, string(kind)+":"+subject, attempts, nil)
+
+	return nil
+}
+
+// issueTokenPair signs a new access token and stores a new refresh token in the given family
+func (u *UserUsecaseImpl) issueTokenPair(ctx context.Context, userId string, userName string, role domain.UserRole, familyId string, refreshTokenId string) (domain.TokenPair, error) {
+
 	// generate jwt token
-	token, err := infrastructure.GenerateJWT(userId, userName, role)
+	accessToken, err := infrastructure.GenerateJWT(u.jwtKeys, u.jwtSettings, userId, userName, role)
+	if err != nil {
+		return domain.TokenPair{}, err
+	}
+
+	refreshToken, err := infrastructure.GenerateRefreshToken()
+	if err != nil {
+		return domain.TokenPair{}, err
+	}
+
+	now := time.Now()
+	err = u.tokenRepository.SaveRefreshToken(ctx, domain.RefreshToken{
+		ID:        refreshTokenId,
+		FamilyID:  familyId,
+		UserID:    userId,
+		TokenHash: infrastructure.HashRefreshToken(refreshToken),
+		ExpiresAt: now.Add(infrastructure.RefreshTokenTTL),
+		CreatedAt: now,
+	})
 	if err != nil {
-		return "", err
+		return domain.TokenPair{}, err
 	}
 
-	return token, nil
+	return domain.TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(infrastructure.AccessTokenTTL.Seconds()),
+	}, nil
 }
 
-func (u *UserUsecaseImpl) PromoteUser(ctx context.Context, userId string) (domain.User, error) {
+func (u *UserUsecaseImpl) PromoteUser(ctx context.Context, actor domain.Actor, userId string) (domain.User, error) {
+
+	// keep the user as it was for the audit log
+	user, err := u.userRepository.GetUserByID(ctx, userId)
+	if err != nil {
+		return domain.User{}, err
+	}
 
 	// call the promote user function from repository
 	promotedUser, err := u.userRepository.PromoteUser(ctx, userId)
@@ -110,5 +340,118 @@ func (u *UserUsecaseImpl) PromoteUser(ctx context.Context, userId string) (domai
 		return domain.User{}, err
 	}
 
+	recordChange(ctx, u.auditRepository, u.publisher, actor.UserID, domain.AuditUserPromoted, domain.AuditTargetUser, userId, user, promotedUser)
+
 	return promotedUser, nil
 }
+
+// ChangePassword sets a new password for the caller, who has to know the current one.
+// Their other sessions end, the returned tokens start a new one.
+func (u *UserUsecaseImpl) ChangePassword(ctx context.Context, actor domain.Actor, currentPassword string, newPassword string) (domain.TokenPair, error) {
+
+	user, err := u.userRepository.GetUserByID(ctx, actor.UserID)
+	if err != nil {
+		return domain.TokenPair{}, err
+	}
+
+	// a stolen access token mustn't become a way around the login throttle
+	now := time.Now()
+	reservation, err := u.loginThrottle.reserve(ctx, user.UserName, "", now)
+	if err != nil {
+		return domain.TokenPair{}, err
+	}
+	if err := u.passwordHasher.Compare(user.HashedPassword, currentPassword); err != nil {
+		if !errors.Is(err, domain.ErrValidation) {
+			if releaseErr := u.loginThrottle.release(ctx, reservation); releaseErr != nil {
+				return domain.TokenPair{}, releaseErr
+			}
+			return domain.TokenPair{}, err
+		}
+		if failErr := u.loginThrottle.fail(ctx, reservation, now); failErr != nil {
+			return domain.TokenPair{}, failErr
+		}
+		return domain.TokenPair{}, domain.ErrInvalidCredential
+	}
+	if err := u.loginThrottle.succeed(ctx, reservation); err != nil {
+		return domain.TokenPair{}, err
+	}
+
+	updated, err := u.setPassword(ctx, user, currentPassword, newPassword)
+	if err != nil {
+		return domain.TokenPair{}, err
+	}
+
+	return u.issueTokenPair(ctx, updated.ID.String(), updated.UserName, updated.Role, uuid.New().String(), uuid.New().String())
+}
+
+// ResetPassword makes a user choose a new password the next time they log in and ends their sessions.
+// A temporary password replaces the current one, for a user who forgot theirs.
+func (u *UserUsecaseImpl) ResetPassword(ctx context.Context, actor domain.Actor, userId string, temporaryPassword string) (domain.User, error) {
+
+	// keep the user as it was for the audit log
+	user, err := u.userRepository.GetUserByID(ctx, userId)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	hashedPassword := user.HashedPassword
+	if temporaryPassword != "" {
+		if err := infrastructure.CheckPasswordPolicy(u.passwordPolicy, user.UserName, temporaryPassword); err != nil {
+			return domain.User{}, err
+		}
+		hashedPassword, err = u.passwordHasher.Hash(temporaryPassword)
+		if err != nil {
+			return domain.User{}, err
+		}
+	}
+
+	updated, err := u.userRepository.UpdatePassword(ctx, userId, hashedPassword, true, time.Now().UTC().Truncate(time.Millisecond))
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	recordAudit(ctx, u.auditRepository, actor.UserID, domain.AuditUserPasswordReset, domain.AuditTargetUser, userId, user, updated)
+
+	return updated, nil
+}
+
+// setPassword checks the new password of a user against the policy and stores it, ending the sessions started before
+func (u *UserUsecaseImpl) setPassword(ctx context.Context, user domain.User, currentPassword string, newPassword string) (domain.User, error) {
+
+	if err := infrastructure.CheckPasswordPolicy(u.passwordPolicy, user.UserName, newPassword); err != nil {
+		return domain.User{}, err
+	}
+	if newPassword == currentPassword {
+		return domain.User{}, &domain.PasswordPolicyError{Reason: "must differ from the current one"}
+	}
+
+	hashedPassword, err := u.passwordHasher.Hash(newPassword)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	updated, err := u.userRepository.UpdatePassword(ctx, user.ID.String(), hashedPassword, false, time.Now().UTC().Truncate(time.Millisecond))
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	userId := user.ID.String()
+	recordAudit(ctx, u.auditRepository, userId, domain.AuditUserPasswordChanged, domain.AuditTargetUser, userId, user, updated)
+
+	return updated, nil
+}
+
+// rehashPassword replaces a hash made with an outdated algorithm or outdated parameters while the password is at hand.
+// The login doesn't depend on it, a failure is logged and the next login tries again.
+func (u *UserUsecaseImpl) rehashPassword(ctx context.Context, userId string, currentHash string, password string) {
+
+	newHash, err := u.passwordHasher.Hash(password)
+	if err == nil {
+		err = u.userRepository.ReplacePasswordHash(ctx, userId, currentHash, newHash)
+	}
+
+	// a password changed in the meantime already has a current hash
+	if err != nil && !errors.Is(err, domain.ErrConflict) {
+		log.Printf("failed to rehash the password of user %s: %v", userId, err)
+	}
+}
@@ -0,0 +1,229 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"sync"
+	domain "taskmanager/Domain"
+	infrastructure "taskmanager/Infrastructure"
+	repositories "taskmanager/Repositories"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// how many published events and redeliveries can wait for the dispatcher
+const webhookQueueSize = 1024
+
+// deliveries to a disabled webhook fail right away, they can be redelivered once it is active again
+var errWebhookInactive = errors.New("webhook is not active")
+
+// WebhookDispatcher delivers the published events to the subscribed webhooks in the background.
+// Every event gets one delivery per webhook, failed attempts are retried with exponential backoff
+// until the delivery succeeds or runs out of attempts. Each attempt is written to the delivery log.
+type WebhookDispatcher struct {
+	webhookRepository repositories.WebhookRepository
+	sender            infrastructure.WebhookSender
+	maxAttempts       int
+	backoff           time.Duration
+
+	events     chan domain.WebhookPayload
+	deliveries chan domain.WebhookDelivery
+}
+
+// NewWebhookDispatcher creates a dispatcher making at most maxAttempts attempts per delivery,
+// the wait after the first failure is backoff and doubles after every further failure
+func NewWebhookDispatcher(webhookRepo repositories.WebhookRepository, sender infrastructure.WebhookSender, maxAttempts int, backoff time.Duration) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		webhookRepository: webhookRepo,
+		sender:            sender,
+		maxAttempts:       maxAttempts,
+		backoff:           backoff,
+		events:            make(chan domain.WebhookPayload, webhookQueueSize),
+		deliveries:        make(chan domain.WebhookDelivery, webhookQueueSize),
+	}
+}
+
+// Publish queues an event for delivery without waiting, it reports false when the queue is full and the event was dropped
+func (d *WebhookDispatcher) Publish(payload domain.WebhookPayload) bool {
+	select {
+	case d.events <- payload:
+		return true
+	default:
+		log.Printf("webhook queue is full, dropped %s event %s", payload.Event, payload.ID)
+		return false
+	}
+}
+
+// Deliver queues an already logged delivery without waiting, it reports false when the queue is full
+func (d *WebhookDispatcher) Deliver(delivery domain.WebhookDelivery) bool {
+	select {
+	case d.deliveries <- delivery:
+		return true
+	default:
+		return false
+	}
+}
+
+// Run delivers the queued events until ctx is cancelled, then waits for the attempts in flight.
+// Deliveries still pending at shutdown, including those of events that were queued but not sent yet,
+// are resumed by the next Run.
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+
+	var inFlight sync.WaitGroup
+	defer inFlight.Wait()
+
+	start := func(delivery domain.WebhookDelivery) {
+		inFlight.Go(func() { d.deliver(ctx, delivery) })
+	}
+
+	pending, err := d.webhookRepository.GetPendingDeliveries(ctx)
+	if err != nil {
+		log.Printf("failed to load pending webhook deliveries: %v", err)
+	}
+	for _, delivery := range pending {
+		start(delivery)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			d.drain(ctx)
+			return
+
+		case payload := <-d.events:
+			for _, delivery := range d.fanOut(ctx, payload) {
+				start(delivery)
+			}
+
+		case delivery := <-d.deliveries:
+			start(delivery)
+		}
+	}
+}
+
+// drain logs the deliveries of the events still queued at shutdown, the next Run resumes them
+func (d *WebhookDispatcher) drain(ctx context.Context) {
+
+	saveCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+
+	for {
+		select {
+		case payload := <-d.events:
+			d.fanOut(saveCtx, payload)
+		default:
+			return
+		}
+	}
+}
+
+// fanOut logs one pending delivery of the event for every webhook subscribed to it
+func (d *WebhookDispatcher) fanOut(ctx context.Context, payload domain.WebhookPayload) []domain.WebhookDelivery {
+
+	webhooks, err := d.webhookRepository.GetAll(ctx)
+	if err != nil {
+		log.Printf("failed to load webhooks for %s event %s: %v", payload.Event, payload.ID, err)
+		return nil
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		log.Printf("failed to encode %s event %s: %v", payload.Event, payload.ID, err)
+		return nil
+	}
+
+	var deliveries []domain.WebhookDelivery
+	for _, webhook := range webhooks {
+		if !webhook.Subscribes(payload.Event) {
+			continue
+		}
+
+		delivery := domain.WebhookDelivery{
+			ID:        uuid.New().String(),
+			WebhookID: webhook.ID,
+			EventID:   payload.ID,
+			Event:     payload.Event,
+			Payload:   body,
+			Status:    domain.DeliveryPending,
+			CreatedAt: time.Now().UTC().Truncate(time.Millisecond),
+		}
+		if err := d.webhookRepository.SaveDelivery(ctx, delivery); err != nil {
+			log.Printf("failed to log delivery of %s event %s to webhook %s: %v", payload.Event, payload.ID, webhook.ID, err)
+			continue
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries
+}
+
+// deliver makes the remaining attempts of a delivery, it gives up early when ctx is cancelled
+func (d *WebhookDispatcher) deliver(ctx context.Context, delivery domain.WebhookDelivery) {
+
+	for delivery.Status == domain.DeliveryPending {
+
+		webhook, err := d.webhookRepository.GetByID(ctx, delivery.WebhookID)
+		if errors.Is(err, domain.ErrNotFound) {
+			// the webhook was deleted together with its delivery log
+			return
+		}
+
+		// a failed lookup counts as a failed attempt so a broken repository doesn't retry forever
+		var status int
+		if err == nil {
+			if webhook.Active {
+				status, err = d.sender.Send(ctx, webhook, delivery)
+			} else {
+				err = errWebhookInactive
+			}
+		}
+		if ctx.Err() != nil {
+			// interrupted by the shutdown, the attempt is made again on the next start
+			return
+		}
+
+		attemptedAt := time.Now().UTC().Truncate(time.Millisecond)
+		delivery.Attempts++
+		delivery.LastAttemptAt = &attemptedAt
+		delivery.ResponseStatus = status
+		delivery.LastError = ""
+		if err != nil {
+			delivery.LastError = err.Error()
+		}
+		switch {
+		case err == nil:
+			delivery.Status = domain.DeliverySucceeded
+		case delivery.Attempts >= d.maxAttempts || errors.Is(err, errWebhookInactive):
+			delivery.Status = domain.DeliveryFailed
+		}
+
+		if err := d.webhookRepository.SaveDelivery(ctx, delivery); err != nil {
+			log.Printf("failed to log attempt %d of webhook delivery %s: %v", delivery.Attempts, delivery.ID, err)
+		}
+
+		if delivery.Status != domain.DeliveryPending {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(d.retryDelay(delivery.Attempts)):
+		}
+	}
+}
+
+// retryDelay is the wait after the given number of failed attempts, it doubles every time up to MaxWebhookRetryBackoff
+func (d *WebhookDispatcher) retryDelay(attempts int) time.Duration {
+
+	delay := d.backoff
+	for i := 1; i < attempts && delay < domain.MaxWebhookRetryBackoff; i++ {
+		delay *= 2
+	}
+
+	return min(delay, domain.MaxWebhookRetryBackoff)
+}
@@ -0,0 +1,268 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	domain "taskmanager/Domain"
+	infrastructure "taskmanager/Infrastructure"
+	repositories "taskmanager/Repositories"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventPublisher is told about the changes webhooks and event streams can subscribe to,
+// before is nil for creations and after for deletions. Publishing never blocks the change.
+type EventPublisher interface {
+	Publish(ctx context.Context, event domain.WebhookEvent, actorId string, before interface{}, after interface{})
+}
+
+// EventPublishers tells every publisher in it about a change
+type EventPublishers []EventPublisher
+
+func (p EventPublishers) Publish(ctx context.Context, event domain.WebhookEvent, actorId string, before interface{}, after interface{}) {
+	for _, publisher := range p {
+		publisher.Publish(ctx, event, actorId, before, after)
+	}
+}
+
+type WebhookUsecase interface {
+	EventPublisher
+	ListWebhooks(ctx context.Context) ([]domain.Webhook, error)
+	GetWebhook(ctx context.Context, id string) (domain.Webhook, error)
+	CreateWebhook(ctx context.Context, actor domain.Actor, webhook domain.Webhook) (domain.Webhook, error)
+	UpdateWebhook(ctx context.Context, actor domain.Actor, id string, changes domain.WebhookChanges) (domain.Webhook, error)
+	DeleteWebhook(ctx context.Context, actor domain.Actor, id string) error
+	ListDeliveries(ctx context.Context, webhookId string, limit int64, offset int64) (domain.DeliveryPage, error)
+	Redeliver(ctx context.Context, webhookId string, deliveryId string) (domain.WebhookDelivery, error)
+}
+
+type WebhookUsecaseImpl struct {
+	webhookRepository repositories.WebhookRepository
+	auditRepository   repositories.AuditRepository
+	dispatcher        *WebhookDispatcher
+}
+
+// Constructor for dependency injection
+func NewWebhookUsecase(webhookRepo repositories.WebhookRepository, auditRepo repositories.AuditRepository, dispatcher *WebhookDispatcher) WebhookUsecase {
+	return &WebhookUsecaseImpl{
+		webhookRepository: webhookRepo,
+		auditRepository:   auditRepo,
+		dispatcher:        dispatcher,
+	}
+}
+
+// Publish hands the event to the dispatcher, the target after the change, or before it for deletions,
+// is sent as the "data" field of the payload
+func (w *WebhookUsecaseImpl) Publish(ctx context.Context, event domain.WebhookEvent, actorId string, before interface{}, after interface{}) {
+
+	data := after
+	if data == nil {
+		data = before
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		log.Printf("failed to encode %s webhook event: %v", event, err)
+		return
+	}
+
+	w.dispatcher.Publish(domain.WebhookPayload{
+		ID:         uuid.New().String(),
+		Event:      event,
+		OccurredAt: time.Now().UTC().Truncate(time.Millisecond),
+		ActorID:    actorId,
+		Data:       encoded,
+	})
+}
+
+func (w *WebhookUsecaseImpl) ListWebhooks(ctx context.Context) ([]domain.Webhook, error) {
+	return w.webhookRepository.GetAll(ctx)
+}
+
+func (w *WebhookUsecaseImpl) GetWebhook(ctx context.Context, id string) (domain.Webhook, error) {
+	return w.webhookRepository.GetByID(ctx, id)
+}
+
+// CreateWebhook subscribes a URL to events, a random secret is generated when none is given.
+// The returned webhook is the only place the secret can be read back from.
+func (w *WebhookUsecaseImpl) CreateWebhook(ctx context.Context, actor domain.Actor, webhook domain.Webhook) (domain.Webhook, error) {
+
+	if err := domain.ValidateWebhookURL(webhook.URL); err != nil {
+		return domain.Webhook{}, err
+	}
+
+	events, err := domain.NormalizeWebhookEvents(webhook.Events)
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+
+	if webhook.Secret == "" {
+		if webhook.Secret, err = infrastructure.GenerateWebhookSecret(); err != nil {
+			return domain.Webhook{}, err
+		}
+	} else if err := domain.ValidateWebhookSecret(webhook.Secret); err != nil {
+		return domain.Webhook{}, err
+	}
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	webhook.ID = uuid.New().String()
+	webhook.Events = events
+	webhook.CreatedBy = actor.UserID
+	webhook.CreatedAt = now
+	webhook.UpdatedAt = now
+
+	webhook, err = w.webhookRepository.Create(ctx, webhook)
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+
+	recordAudit(ctx, w.auditRepository, actor.UserID, domain.AuditWebhookCreated, domain.AuditTargetWebhook, webhook.ID, nil, webhook)
+
+	return webhook, nil
+}
+
+// UpdateWebhook changes the fields set in changes
+func (w *WebhookUsecaseImpl) UpdateWebhook(ctx context.Context, actor domain.Actor, id string, changes domain.WebhookChanges) (domain.Webhook, error) {
+
+	before, err := w.webhookRepository.GetByID(ctx, id)
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+
+	webhook := before
+	if changes.URL != nil {
+		if err := domain.ValidateWebhookURL(*changes.URL); err != nil {
+			return domain.Webhook{}, err
+		}
+		webhook.URL = *changes.URL
+	}
+	if changes.Events != nil {
+		if webhook.Events, err = domain.NormalizeWebhookEvents(changes.Events); err != nil {
+			return domain.Webhook{}, err
+		}
+	}
+	if changes.Secret != nil {
+		if err := domain.ValidateWebhookSecret(*changes.Secret); err != nil {
+			return domain.Webhook{}, err
+		}
+		webhook.Secret = *changes.Secret
+	}
+	if changes.Active != nil {
+		webhook.Active = *changes.Active
+	}
+	webhook.UpdatedAt = time.Now().UTC().Truncate(time.Millisecond)
+
+	webhook, err = w.webhookRepository.Update(ctx, webhook)
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+
+	recordAudit(ctx, w.auditRepository, actor.UserID, domain.AuditWebhookUpdated, domain.AuditTargetWebhook, id, before, webhook)
+
+	return webhook, nil
+}
+
+// DeleteWebhook removes a webhook and its delivery log, deliveries still being retried are dropped
+func (w *WebhookUsecaseImpl) DeleteWebhook(ctx context.Context, actor domain.Actor, id string) error {
+
+	webhook, err := w.webhookRepository.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := w.webhookRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	recordAudit(ctx, w.auditRepository, actor.UserID, domain.AuditWebhookDeleted, domain.AuditTargetWebhook, id, webhook, nil)
+
+	return nil
+}
+
+func (w *WebhookUsecaseImpl) ListDeliveries(ctx context.Context, webhookId string, limit int64, offset int64) (domain.DeliveryPage, error) {
+
+	if limit < 0 || offset < 0 {
+		return domain.DeliveryPage{}, fmt.Errorf("%w: limit and offset must not be negative", domain.ErrValidation)
+	}
+	if limit == 0 {
+		limit = domain.DefaultDeliveryPageLimit
+	}
+	if limit > domain.MaxDeliveryPageLimit {
+		limit = domain.MaxDeliveryPageLimit
+	}
+
+	// an unknown webhook is reported instead of an empty log
+	if _, err := w.webhookRepository.GetByID(ctx, webhookId); err != nil {
+		return domain.DeliveryPage{}, err
+	}
+
+	deliveries, total, err := w.webhookRepository.ListDeliveries(ctx, webhookId, limit, offset)
+	if err != nil {
+		return domain.DeliveryPage{}, err
+	}
+
+	return domain.DeliveryPage{
+		Deliveries: deliveries,
+		Total:      total,
+		Limit:      limit,
+		Offset:     offset,
+	}, nil
+}
+
+// Redeliver sends the payload of an earlier delivery again as a new delivery, whatever the outcome of the first one
+func (w *WebhookUsecaseImpl) Redeliver(ctx context.Context, webhookId string, deliveryId string) (domain.WebhookDelivery, error) {
+
+	webhook, err := w.webhookRepository.GetByID(ctx, webhookId)
+	if err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+	if !webhook.Active {
+		return domain.WebhookDelivery{}, fmt.Errorf("%w: webhook is not active", domain.ErrConflict)
+	}
+
+	original, err := w.webhookRepository.GetDelivery(ctx, deliveryId)
+	if err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+	if original.WebhookID != webhook.ID {
+		return domain.WebhookDelivery{}, domain.ErrNotFound
+	}
+
+	delivery := domain.WebhookDelivery{
+		ID:           uuid.New().String(),
+		WebhookID:    webhook.ID,
+		EventID:      original.EventID,
+		Event:        original.Event,
+		Payload:      original.Payload,
+		Status:       domain.DeliveryPending,
+		RedeliveryOf: original.ID,
+		CreatedAt:    time.Now().UTC().Truncate(time.Millisecond),
+	}
+
+	if err := w.webhookRepository.SaveDelivery(ctx, delivery); err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+
+	// a delivery that can't be queued now stays pending and is picked up on the next start
+	if !w.dispatcher.Deliver(delivery) {
+		log.Printf("webhook delivery queue is full, delivery %s waits for the next start", delivery.ID)
+	}
+
+	return delivery, nil
+}
+
+// recordChange writes the audit entry of a change and publishes it to the webhooks and event streams
+func recordChange(ctx context.Context, repo repositories.AuditRepository, publisher EventPublisher, actorId string, action domain.AuditAction, targetType string, targetId string, before interface{}, after interface{}) {
+
+	recordAudit(ctx, repo, actorId, action, targetType, targetId, before, after)
+
+	event, ok := domain.WebhookEventForAudit(action)
+	if !ok {
+		return
+	}
+
+	publisher.Publish(ctx, event, actorId, before, after)
+}